@@ -0,0 +1,230 @@
+// Package catalog maintains an in-memory model of a database schema, built by applying the
+// DDL statements of parse trees, e.g. to replay a migration history offline.
+package catalog
+
+import (
+	"sort"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+)
+
+// Catalog is an in-memory model of the schemas, tables, views, types and constraints of a
+// database
+//
+// Catalog implements pg_query.Catalog, so it can be used to resolve the columns of queries.
+type Catalog struct {
+	schemas    map[string]*Schema
+	searchPath []string
+}
+
+// Schema is a schema of the catalog
+type Schema struct {
+	Name   string
+	Tables map[string]*Table
+	Types  map[string]*Type
+}
+
+// TableKind is the kind of relation of a Table
+type TableKind int
+
+const (
+	// KindTable is a regular table
+	KindTable TableKind = iota
+	// KindView is a view
+	KindView
+	// KindMaterializedView is a materialized view
+	KindMaterializedView
+)
+
+// Table is a table or view of the catalog
+type Table struct {
+	Schema      string
+	Name        string
+	Kind        TableKind
+	Columns     []*Column
+	Constraints []*Constraint
+	// Query is the deparsed query of a view or materialized view
+	Query string
+	// uses are the tables and views read by the query of a view or materialized view, and
+	// reads the columns of them it reads
+	uses  []*Table
+	reads []columnUse
+}
+
+// Column is a column of a table or view
+type Column struct {
+	Name string
+	// Type is the deparsed type name, e.g. "int", "varchar(20)" or "text[]"
+	Type    string
+	NotNull bool
+	// Default is the deparsed default expression, or "" if the column has none
+	Default string
+}
+
+// ConstraintType is the kind of a table constraint
+type ConstraintType int
+
+const (
+	// ConstraintPrimaryKey is a PRIMARY KEY constraint
+	ConstraintPrimaryKey ConstraintType = iota
+	// ConstraintUnique is a UNIQUE constraint
+	ConstraintUnique
+	// ConstraintForeignKey is a FOREIGN KEY constraint
+	ConstraintForeignKey
+	// ConstraintCheck is a CHECK constraint
+	ConstraintCheck
+	// ConstraintExclusion is an EXCLUDE constraint
+	ConstraintExclusion
+)
+
+// Constraint is a table constraint
+//
+// Constraints without a name in the DDL are named like PostgreSQL does, e.g. "users_pkey"
+// or "orders_user_id_fkey".
+type Constraint struct {
+	Name    string
+	Type    ConstraintType
+	Columns []string
+	// Expr is the deparsed expression of a CHECK constraint
+	Expr string
+	// RefSchema, RefTable and RefColumns are the columns referenced by a FOREIGN KEY
+	RefSchema  string
+	RefTable   string
+	RefColumns []string
+}
+
+// Type is a user-defined type of the catalog
+type Type struct {
+	Schema string
+	Name   string
+	// Labels are the values of an enum type
+	Labels []string
+}
+
+// New returns a catalog with an empty "public" schema, which is the search path
+func New() *Catalog {
+	return &Catalog{
+		schemas:    map[string]*Schema{"public": newSchema("public")},
+		searchPath: []string{"public"},
+	}
+}
+
+func newSchema(name string) *Schema {
+	return &Schema{Name: name, Tables: map[string]*Table{}, Types: map[string]*Type{}}
+}
+
+// SetSearchPath sets the schemas searched for unqualified names, the first of which
+// receives the objects created with unqualified names
+func (c *Catalog) SetSearchPath(schemas ...string) {
+	c.searchPath = append([]string(nil), schemas...)
+}
+
+// Schema returns the schema with the given name
+func (c *Catalog) Schema(name string) (*Schema, bool) {
+	schema, ok := c.schemas[name]
+	return schema, ok
+}
+
+// Schemas returns the schemas of the catalog sorted by name
+func (c *Catalog) Schemas() []*Schema {
+	schemas := make([]*Schema, 0, len(c.schemas))
+	for _, schema := range c.schemas {
+		schemas = append(schemas, schema)
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].Name < schemas[j].Name
+	})
+	return schemas
+}
+
+// LookupTable returns the table or view with the given name, looking it up in the search
+// path if schema is empty
+func (c *Catalog) LookupTable(schema, name string) (*Table, bool) {
+	for _, s := range c.lookupSchemas(schema) {
+		if table, ok := s.Tables[name]; ok {
+			return table, true
+		}
+	}
+	return nil, false
+}
+
+// LookupType returns the user-defined type with the given name, looking it up in the
+// search path if schema is empty
+func (c *Catalog) LookupType(schema, name string) (*Type, bool) {
+	for _, s := range c.lookupSchemas(schema) {
+		if typ, ok := s.Types[name]; ok {
+			return typ, true
+		}
+	}
+	return nil, false
+}
+
+// Table returns the definition of a table or view for pg_query.ResolveColumns and similar
+func (c *Catalog) Table(schema, name string) (*pg_query.TableDefinition, bool) {
+	table, ok := c.LookupTable(schema, name)
+	if !ok {
+		return nil, false
+	}
+	definition := &pg_query.TableDefinition{Schema: table.Schema, Name: table.Name}
+	for _, column := range table.Columns {
+		definition.Columns = append(definition.Columns, pg_query.TableColumn{Name: column.Name, Type: column.Type})
+	}
+	return definition, true
+}
+
+// lookupSchemas returns the schemas searched for a name with the given schema qualifier
+func (c *Catalog) lookupSchemas(schema string) []*Schema {
+	names := c.searchPath
+	if schema != "" {
+		names = []string{schema}
+	}
+	var schemas []*Schema
+	for _, name := range names {
+		if s, ok := c.schemas[name]; ok {
+			schemas = append(schemas, s)
+		}
+	}
+	return schemas
+}
+
+// SortedTables returns the tables and views of the schema sorted by name
+func (s *Schema) SortedTables() []*Table {
+	tables := make([]*Table, 0, len(s.Tables))
+	for _, table := range s.Tables {
+		tables = append(tables, table)
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Name < tables[j].Name
+	})
+	return tables
+}
+
+// Column returns the column with the given name
+func (t *Table) Column(name string) (*Column, bool) {
+	for _, column := range t.Columns {
+		if column.Name == name {
+			return column, true
+		}
+	}
+	return nil, false
+}
+
+// Constraint returns the constraint with the given name
+func (t *Table) Constraint(name string) (*Constraint, bool) {
+	for _, constraint := range t.Constraints {
+		if constraint.Name == name {
+			return constraint, true
+		}
+	}
+	return nil, false
+}
+
+// PrimaryKey returns the primary key constraint of the table
+func (t *Table) PrimaryKey() (*Constraint, bool) {
+	for _, constraint := range t.Constraints {
+		if constraint.Type == ConstraintPrimaryKey {
+			return constraint, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,303 @@
+//go:build cgo
+// +build cgo
+
+package catalog_test
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+	"github.com/cossacklabs/pg_query_go/v5/catalog"
+)
+
+// describe returns one line per table and type of the catalog
+func describe(c *catalog.Catalog) []string {
+	var lines []string
+	for _, schema := range c.Schemas() {
+		for _, table := range schema.SortedTables() {
+			var parts []string
+			for _, column := range table.Columns {
+				part := column.Name + " " + column.Type
+				if column.NotNull {
+					part += " NOT NULL"
+				}
+				if column.Default != "" {
+					part += " DEFAULT " + column.Default
+				}
+				parts = append(parts, part)
+			}
+			for _, constraint := range table.Constraints {
+				part := fmt.Sprintf("%s %d (%s)", constraint.Name, constraint.Type, strings.Join(constraint.Columns, ", "))
+				if constraint.RefTable != "" {
+					part += fmt.Sprintf(" -> %s.%s (%s)", constraint.RefSchema, constraint.RefTable, strings.Join(constraint.RefColumns, ", "))
+				}
+				if constraint.Expr != "" {
+					part += " " + constraint.Expr
+				}
+				parts = append(parts, part)
+			}
+			lines = append(lines, fmt.Sprintf("%s.%s %d: %s", table.Schema, table.Name, table.Kind, strings.Join(parts, ", ")))
+		}
+		for _, name := range sortedTypeNames(schema) {
+			lines = append(lines, fmt.Sprintf("%s.%s type: %s", schema.Name, name, strings.Join(schema.Types[name].Labels, ", ")))
+		}
+	}
+	return lines
+}
+
+func sortedTypeNames(schema *catalog.Schema) []string {
+	var names []string
+	for name := range schema.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var catalogTests = []struct {
+	input    string
+	expected []string
+}{
+	{
+		"CREATE TABLE users (id serial PRIMARY KEY, email varchar(255) NOT NULL UNIQUE, name text DEFAULT 'x')",
+		[]string{
+			"public.users 0: id int NOT NULL DEFAULT nextval('users_id_seq'::regclass), email varchar(255) NOT NULL, " +
+				"name text DEFAULT 'x', users_pkey 0 (id), users_email_key 1 (email)",
+		},
+	},
+	{
+		"CREATE TABLE users (id int PRIMARY KEY);" +
+			"CREATE TABLE orders (id int, user_id int REFERENCES users, total numeric(10, 2), CHECK (total > 0), PRIMARY KEY (id))",
+		[]string{
+			"public.orders 0: id int NOT NULL, user_id int, total numeric(10, 2), " +
+				"orders_user_id_fkey 2 (user_id) -> public.users (id), orders_total_check 3 (total) total > 0, orders_pkey 0 (id)",
+			"public.users 0: id int NOT NULL, users_pkey 0 (id)",
+		},
+	},
+	{
+		"CREATE TABLE t (a int, b text, c int);" +
+			"ALTER TABLE t ADD COLUMN d timestamptz DEFAULT now(), DROP COLUMN b, ALTER COLUMN a TYPE bigint, ALTER COLUMN c SET NOT NULL;" +
+			"ALTER TABLE t ADD CONSTRAINT t_uniq UNIQUE (a, c);" +
+			"ALTER TABLE t RENAME COLUMN c TO e;" +
+			"ALTER TABLE t RENAME TO s",
+		[]string{"public.s 0: a bigint, e int NOT NULL, d timestamptz DEFAULT now(), t_uniq 1 (a, e)"},
+	},
+	{
+		"CREATE SCHEMA app CREATE TABLE accounts (id int) CREATE VIEW active AS SELECT id FROM accounts;" +
+			"CREATE TYPE app.mood AS ENUM ('sad', 'ok');" +
+			"CREATE TABLE app.people (name text, current_mood app.mood)",
+		[]string{
+			"app.accounts 0: id int",
+			"app.active 1: id int",
+			"app.people 0: name text, current_mood app.mood",
+			"app.mood type: sad, ok",
+		},
+	},
+	{
+		"CREATE TABLE a (id int PRIMARY KEY, x text);" +
+			"CREATE VIEW v (ident, other) AS SELECT a.*, 1::bigint AS n, 1 AS m FROM a",
+		[]string{"public.a 0: id int NOT NULL, x text, a_pkey 0 (id)", "public.v 1: ident int, other text, n bigint, m int"},
+	},
+	{
+		"CREATE TABLE a (id int PRIMARY KEY, x text);" +
+			"CREATE TABLE b (a_id int REFERENCES a);" +
+			"CREATE VIEW v (ident, other) AS SELECT a.*, 1::bigint AS n FROM a;" +
+			"DROP TABLE a CASCADE",
+		[]string{"public.b 0: a_id int"},
+	},
+	{
+		"CREATE TABLE a (id int PRIMARY KEY);" +
+			"CREATE TABLE b (a_id int REFERENCES a);" +
+			"CREATE VIEW v AS SELECT id FROM a;" +
+			"CREATE MATERIALIZED VIEW w AS SELECT v.id FROM b JOIN v ON a_id = id;" +
+			"CREATE OR REPLACE VIEW v AS SELECT id, 1 AS n FROM a;" +
+			"DROP TABLE a CASCADE",
+		[]string{"public.b 0: a_id int"},
+	},
+	{
+		"CREATE TABLE a (id int PRIMARY KEY, x text, y int);" +
+			"CREATE TABLE b (a_id int REFERENCES a);" +
+			"CREATE VIEW v AS SELECT x FROM a;" +
+			"CREATE VIEW w AS SELECT * FROM v;" +
+			"CREATE VIEW u AS SELECT y FROM a;" +
+			"ALTER TABLE a DROP COLUMN x CASCADE, DROP COLUMN id CASCADE",
+		[]string{"public.a 0: y int", "public.b 0: a_id int", "public.u 1: y int"},
+	},
+	{
+		"CREATE TABLE t (a int, b int); CREATE VIEW v AS SELECT a FROM t; ALTER TABLE t DROP COLUMN b",
+		[]string{"public.t 0: a int", "public.v 1: a int"},
+	},
+	{
+		"CREATE SCHEMA s;" +
+			"CREATE TABLE a (id int PRIMARY KEY);" +
+			"CREATE TABLE b (a_id int REFERENCES a);" +
+			"CREATE TYPE mood AS ENUM ('ok');" +
+			"ALTER TABLE a SET SCHEMA s;" +
+			"ALTER TYPE mood SET SCHEMA s;" +
+			"ALTER TABLE IF EXISTS missing SET SCHEMA s",
+		[]string{"public.b 0: a_id int, b_a_id_fkey 2 (a_id) -> s.a (id)", "s.a 0: id int NOT NULL, a_pkey 0 (id)", "s.mood type: ok"},
+	},
+	{
+		"CREATE TYPE mood AS ENUM ('sad', 'ok');" +
+			"ALTER TYPE mood ADD VALUE 'happy';" +
+			"ALTER TYPE mood ADD VALUE 'meh' BEFORE 'ok';" +
+			"ALTER TYPE mood ADD VALUE IF NOT EXISTS 'sad' AFTER 'ok';" +
+			"ALTER TYPE mood ADD VALUE 'angry' AFTER 'sad';" +
+			"ALTER TYPE mood RENAME VALUE 'ok' TO 'fine'",
+		[]string{"public.mood type: sad, angry, meh, fine, happy"},
+	},
+	{
+		"CREATE TABLE t (a int); CREATE INDEX i ON t (a); CREATE SEQUENCE s;" +
+			"ALTER SEQUENCE s OWNER TO x; ALTER INDEX i SET TABLESPACE ts; ALTER TABLE t ALTER COLUMN a SET NOT NULL",
+		[]string{"public.t 0: a int NOT NULL"},
+	},
+	{
+		"CREATE TABLE t (a int); DROP TABLE IF EXISTS missing; CREATE TABLE IF NOT EXISTS t (b int); " +
+			"CREATE MATERIALIZED VIEW m AS SELECT a AS x FROM t; INSERT INTO t VALUES (1)",
+		[]string{"public.m 2: x int", "public.t 0: a int"},
+	},
+}
+
+func TestCatalog(t *testing.T) {
+	for _, test := range catalogTests {
+		c := catalog.New()
+		if err := c.Exec(test.input); err != nil {
+			t.Errorf("Exec(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		actual := describe(c)
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Exec(%s)\nexpected %q\nactual %q\n\n", test.input, test.expected, actual)
+		}
+	}
+}
+
+var catalogErrorTests = []struct {
+	input    string
+	expected string
+}{
+	{"CREATE TABLE t (a int); CREATE TABLE t (b int)", `catalog: relation "t" already exists`},
+	{"ALTER TABLE missing ADD COLUMN a int", `catalog: relation "missing" does not exist`},
+	{"CREATE TABLE t (a int); ALTER TABLE t ADD COLUMN b int, DROP COLUMN c", `catalog: column "c" of relation "t" does not exist`},
+	{"CREATE TABLE t (a int REFERENCES missing (id))", `catalog: relation "missing" does not exist`},
+	{"CREATE TABLE t (a int PRIMARY KEY); CREATE TABLE u (a int REFERENCES t); DROP TABLE t", `catalog: cannot drop table "t" because other objects depend on it`},
+	{"CREATE VIEW v AS SELECT 1; DROP TABLE v", `catalog: "v" is not a table`},
+	{"CREATE TABLE t (a int); CREATE VIEW v AS SELECT a FROM t; DROP TABLE t", `catalog: cannot drop table "t" because other objects depend on it`},
+	{"CREATE TABLE t (a int); CREATE VIEW v AS SELECT a FROM t; CREATE VIEW w AS SELECT * FROM v; DROP VIEW v", `catalog: cannot drop view "v" because other objects depend on it`},
+	{"CREATE SCHEMA s CREATE TABLE other.t (a int)", `catalog: CREATE specifies a schema (other) different from the one being created (s)`},
+	{"CREATE TYPE mood AS ENUM ('sad'); ALTER TYPE mood ADD VALUE 'sad'", `catalog: enum label "sad" already exists`},
+	{"CREATE TYPE mood AS ENUM ('sad'); ALTER TYPE mood ADD VALUE 'ok' AFTER 'happy'", `catalog: "happy" is not an existing enum label`},
+	{"ALTER TYPE missing ADD VALUE 'ok'", `catalog: type "missing" does not exist`},
+	{"CREATE TABLE a (id int PRIMARY KEY); CREATE TABLE b (a_id int REFERENCES a); ALTER TABLE a DROP COLUMN id", `catalog: cannot drop column "id" of table "a" because other objects depend on it`},
+	{
+		"CREATE TABLE t (a int, b int); CREATE VIEW v AS SELECT s.a FROM (SELECT * FROM t) s; ALTER TABLE t RENAME COLUMN b TO c; ALTER TABLE t DROP COLUMN c",
+		`catalog: cannot drop column "c" of table "t" because other objects depend on it`,
+	},
+	{"CREATE TABLE t (a int); ALTER TABLE t SET SCHEMA missing", `catalog: schema "missing" does not exist`},
+	{"CREATE SCHEMA s; CREATE TABLE t (a int); CREATE TABLE s.t (b int); ALTER TABLE t SET SCHEMA s", `catalog: relation "t" already exists in schema "s"`},
+	{"CREATE TABLE other.t (a int)", `catalog: schema "other" does not exist`},
+}
+
+func TestCatalogErrors(t *testing.T) {
+	for _, test := range catalogErrorTests {
+		c := catalog.New()
+		err := c.Exec(test.input)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("Exec(%s)\nexpected %s\nactual %v\n\n", test.input, test.expected, err)
+		}
+	}
+}
+
+func TestCatalogAlterIsAtomic(t *testing.T) {
+	c := catalog.New()
+	if err := c.Exec("CREATE TABLE t (a int)"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Exec("ALTER TABLE t ADD COLUMN b int, ADD COLUMN a int"); err == nil {
+		t.Fatalf("Exec(ALTER TABLE)\nexpected error\nactual nil\n\n")
+	}
+	table, _ := c.LookupTable("", "t")
+	if len(table.Columns) != 1 {
+		t.Errorf("Exec(ALTER TABLE)\nexpected 1 column after failed ALTER\nactual %d\n\n", len(table.Columns))
+	}
+}
+
+var catalogAtomicTests = []struct {
+	setup    string
+	input    string
+	expected []string
+}{
+	{
+		"CREATE TABLE a (id int); CREATE TABLE b (id int)",
+		"DROP TABLE a, b, missing",
+		[]string{"public.a 0: id int", "public.b 0: id int"},
+	},
+	{
+		"CREATE TABLE a (id int PRIMARY KEY); CREATE TABLE b (a_id int REFERENCES a); CREATE TABLE c (id int)",
+		"DROP TABLE c, a",
+		[]string{"public.a 0: id int NOT NULL, a_pkey 0 (id)", "public.b 0: a_id int, b_a_id_fkey 2 (a_id) -> public.a (id)", "public.c 0: id int"},
+	},
+	{
+		"CREATE TABLE a (x int, y int); CREATE VIEW v AS SELECT x FROM a",
+		"ALTER TABLE a DROP COLUMN x CASCADE, DROP COLUMN missing",
+		[]string{"public.a 0: x int, y int", "public.v 1: x int"},
+	},
+	{
+		"CREATE TYPE a AS ENUM ('x')",
+		"DROP TYPE a, missing",
+		[]string{"public.a type: x"},
+	},
+	{
+		"CREATE TABLE t (a int)",
+		"CREATE SCHEMA s CREATE TABLE u (a int) CREATE TABLE t (b int) CREATE TABLE t (c int)",
+		[]string{"public.t 0: a int"},
+	},
+}
+
+// TestCatalogAtomic checks that failing statements leave the catalog unchanged
+func TestCatalogAtomic(t *testing.T) {
+	for _, test := range catalogAtomicTests {
+		c := catalog.New()
+		if err := c.Exec(test.setup); err != nil {
+			t.Fatalf("Exec(%s)\nerror %s\n\n", test.setup, err)
+		}
+		if err := c.Exec(test.input); err == nil {
+			t.Errorf("Exec(%s)\nexpected error but none returned\n\n", test.input)
+			continue
+		}
+
+		actual := describe(c)
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Exec(%s)\nexpected %q\nactual %q\n\n", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestCatalogResolveColumns(t *testing.T) {
+	c := catalog.New()
+	if err := c.Exec("CREATE TABLE users (id int, email text); CREATE VIEW emails AS SELECT email FROM users"); err != nil {
+		t.Fatal(err)
+	}
+
+	input := "SELECT id, e.email FROM users u, emails e"
+	tree, err := pg_query.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actual []string
+	for _, binding := range pg_query.ResolveColumns(tree, c) {
+		if binding.Err != nil {
+			t.Fatalf("ResolveColumns(%s)\nerror %s\n\n", input, binding.Err)
+		}
+		actual = append(actual, binding.Origin.Table+"."+binding.Origin.Column)
+	}
+	expected := []string{"users.id", "emails.email"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("ResolveColumns(%s)\nexpected %q\nactual %q\n\n", input, expected, actual)
+	}
+}
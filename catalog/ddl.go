@@ -0,0 +1,1175 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Apply applies the statements of a parse tree to the catalog, stopping at the first
+// statement that fails
+func (c *Catalog) Apply(tree *pg_query.ParseResult) error {
+	for _, stmt := range tree.GetStmts() {
+		if err := c.ApplyStmt(stmt.GetStmt()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyStmt applies a statement to the catalog
+//
+// CREATE TABLE, CREATE TABLE AS, CREATE [MATERIALIZED] VIEW, CREATE SCHEMA, CREATE TYPE
+// ... AS ENUM, ALTER TABLE, ALTER TYPE ... ADD VALUE, ALTER ... RENAME, ALTER ... SET
+// SCHEMA and DROP change the catalog, other statements are ignored. Statements PostgreSQL would reject, like creating a table that already
+// exists or altering one that doesn't, return an error and leave the catalog unchanged.
+func (c *Catalog) ApplyStmt(stmt *pg_query.Node) error {
+	switch n := stmt.GetNode().(type) {
+	case *pg_query.Node_CreateStmt:
+		return c.createTable(n.CreateStmt)
+	case *pg_query.Node_CreateTableAsStmt:
+		return c.createTableAs(n.CreateTableAsStmt)
+	case *pg_query.Node_ViewStmt:
+		return c.createView(n.ViewStmt)
+	case *pg_query.Node_CreateSchemaStmt:
+		return c.createSchema(n.CreateSchemaStmt)
+	case *pg_query.Node_CreateEnumStmt:
+		return c.createEnum(n.CreateEnumStmt)
+	case *pg_query.Node_AlterEnumStmt:
+		return c.alterEnum(n.AlterEnumStmt)
+	case *pg_query.Node_AlterTableStmt:
+		return c.alterTable(n.AlterTableStmt)
+	case *pg_query.Node_RenameStmt:
+		return c.rename(n.RenameStmt)
+	case *pg_query.Node_AlterObjectSchemaStmt:
+		return c.alterObjectSchema(n.AlterObjectSchemaStmt)
+	case *pg_query.Node_DropStmt:
+		return c.drop(n.DropStmt)
+	}
+	return nil
+}
+
+// creationSchema returns the schema receiving an object created with the given qualifier
+func (c *Catalog) creationSchema(name string) (*Schema, error) {
+	if name == "" {
+		for _, s := range c.searchPath {
+			if schema, ok := c.schemas[s]; ok {
+				return schema, nil
+			}
+		}
+		return nil, fmt.Errorf("catalog: no schema has been selected to create in")
+	}
+	schema, ok := c.schemas[name]
+	if !ok {
+		return nil, fmt.Errorf("catalog: schema %q does not exist", name)
+	}
+	return schema, nil
+}
+
+// relation returns the table of a RangeVar, or an error if it doesn't exist
+func (c *Catalog) relation(rangeVar *pg_query.RangeVar) (*Table, error) {
+	table, ok := c.LookupTable(rangeVar.GetSchemaname(), rangeVar.GetRelname())
+	if !ok {
+		return nil, fmt.Errorf("catalog: relation %q does not exist", qualifiedName(rangeVar.GetSchemaname(), rangeVar.GetRelname()))
+	}
+	return table, nil
+}
+
+// newRelation checks that a relation can be created and returns its schema, or nil if it
+// already exists and ifNotExists is set
+func (c *Catalog) newRelation(rangeVar *pg_query.RangeVar, ifNotExists bool) (*Schema, error) {
+	schema, err := c.creationSchema(rangeVar.GetSchemaname())
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := schema.Tables[rangeVar.GetRelname()]; exists {
+		if ifNotExists {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("catalog: relation %q already exists", rangeVar.GetRelname())
+	}
+	return schema, nil
+}
+
+func (c *Catalog) createTable(stmt *pg_query.CreateStmt) error {
+	schema, err := c.newRelation(stmt.Relation, stmt.IfNotExists)
+	if schema == nil {
+		return err
+	}
+
+	table := &Table{Schema: schema.Name, Name: stmt.Relation.Relname, Kind: KindTable}
+	inherited := map[string]bool{}
+	for _, node := range stmt.InhRelations {
+		parent, err := c.relation(node.GetRangeVar())
+		if err != nil {
+			return err
+		}
+		for _, column := range parent.Columns {
+			if _, exists := table.Column(column.Name); !exists {
+				copied := *column
+				table.Columns = append(table.Columns, &copied)
+				inherited[column.Name] = true
+			}
+		}
+	}
+
+	var constraints []*pg_query.Constraint
+	for _, elt := range stmt.TableElts {
+		switch e := elt.GetNode().(type) {
+		case *pg_query.Node_ColumnDef:
+			if err := c.addColumn(table, e.ColumnDef, inherited[e.ColumnDef.Colname]); err != nil {
+				return err
+			}
+		case *pg_query.Node_Constraint:
+			constraints = append(constraints, e.Constraint)
+		case *pg_query.Node_TableLikeClause:
+			if err := c.likeClause(table, e.TableLikeClause); err != nil {
+				return err
+			}
+		}
+	}
+	for _, constraint := range append(constraints, nodeConstraints(stmt.Constraints)...) {
+		if err := c.addConstraint(table, constraint, nil); err != nil {
+			return err
+		}
+	}
+
+	schema.Tables[table.Name] = table
+	return nil
+}
+
+func nodeConstraints(nodes []*pg_query.Node) []*pg_query.Constraint {
+	var constraints []*pg_query.Constraint
+	for _, node := range nodes {
+		if constraint := node.GetConstraint(); constraint != nil {
+			constraints = append(constraints, constraint)
+		}
+	}
+	return constraints
+}
+
+// serialTypes maps the serial pseudo-types to the types of their columns
+var serialTypes = map[string]string{
+	"smallserial": "smallint",
+	"serial2":     "smallint",
+	"serial":      "int",
+	"serial4":     "int",
+	"bigserial":   "bigint",
+	"serial8":     "bigint",
+}
+
+// addColumn adds a column with its constraints to a table, or merges the constraints into
+// an inherited column of the same name
+func (c *Catalog) addColumn(table *Table, def *pg_query.ColumnDef, merge bool) error {
+	column, exists := table.Column(def.Colname)
+	if exists && !merge {
+		return fmt.Errorf("catalog: column %q of relation %q already exists", def.Colname, table.Name)
+	}
+
+	typ, err := typeName(def.TypeName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		column = &Column{Name: def.Colname}
+		table.Columns = append(table.Columns, column)
+	}
+	column.Type = typ
+	column.NotNull = column.NotNull || def.IsNotNull
+	if serial, ok := serialTypes[typ]; ok {
+		column.Type = serial
+		column.NotNull = true
+		column.Default = fmt.Sprintf("nextval('%s_%s_seq'::regclass)", table.Name, column.Name)
+	}
+	if def.RawDefault != nil {
+		if column.Default, err = pg_query.DeparseNode(def.RawDefault); err != nil {
+			return err
+		}
+	}
+
+	for _, node := range def.Constraints {
+		constraint := node.GetConstraint()
+		switch constraint.GetContype() {
+		case pg_query.ConstrType_CONSTR_NOTNULL, pg_query.ConstrType_CONSTR_IDENTITY:
+			column.NotNull = true
+		case pg_query.ConstrType_CONSTR_NULL:
+			column.NotNull = false
+		case pg_query.ConstrType_CONSTR_DEFAULT:
+			if column.Default, err = pg_query.DeparseNode(constraint.RawExpr); err != nil {
+				return err
+			}
+		default:
+			if err := c.addConstraint(table, constraint, []string{column.Name}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CREATE TABLE ... (LIKE ...) options, see TableLikeOption in parsenodes.h
+const (
+	likeConstraints = 1 << 2
+	likeDefaults    = 1 << 3
+)
+
+func (c *Catalog) likeClause(table *Table, like *pg_query.TableLikeClause) error {
+	source, err := c.relation(like.Relation)
+	if err != nil {
+		return err
+	}
+	for _, column := range source.Columns {
+		if _, exists := table.Column(column.Name); exists {
+			return fmt.Errorf("catalog: column %q specified more than once", column.Name)
+		}
+		copied := *column
+		if like.Options&likeDefaults == 0 {
+			copied.Default = ""
+		}
+		table.Columns = append(table.Columns, &copied)
+	}
+	if like.Options&likeConstraints != 0 {
+		for _, constraint := range source.Constraints {
+			if constraint.Type == ConstraintCheck {
+				copied := *constraint
+				table.Constraints = append(table.Constraints, &copied)
+			}
+		}
+	}
+	return nil
+}
+
+// addConstraint adds a table constraint, or the constraint of a column if columns is set
+func (c *Catalog) addConstraint(table *Table, constraint *pg_query.Constraint, columns []string) error {
+	con := &Constraint{Name: constraint.Conname, Columns: columns}
+	switch constraint.Contype {
+	case pg_query.ConstrType_CONSTR_PRIMARY:
+		con.Type = ConstraintPrimaryKey
+		if _, exists := table.PrimaryKey(); exists {
+			return fmt.Errorf("catalog: multiple primary keys for table %q are not allowed", table.Name)
+		}
+	case pg_query.ConstrType_CONSTR_UNIQUE:
+		con.Type = ConstraintUnique
+	case pg_query.ConstrType_CONSTR_FOREIGN:
+		con.Type = ConstraintForeignKey
+	case pg_query.ConstrType_CONSTR_CHECK:
+		con.Type = ConstraintCheck
+	case pg_query.ConstrType_CONSTR_EXCLUSION:
+		con.Type = ConstraintExclusion
+	default:
+		return nil
+	}
+
+	if con.Columns == nil {
+		switch con.Type {
+		case ConstraintPrimaryKey, ConstraintUnique:
+			con.Columns = strVals(constraint.Keys)
+		case ConstraintForeignKey:
+			con.Columns = strVals(constraint.FkAttrs)
+		case ConstraintExclusion:
+			for _, exclusion := range constraint.Exclusions {
+				items := exclusion.GetList().GetItems()
+				if len(items) > 0 {
+					con.Columns = append(con.Columns, items[0].GetIndexElem().GetName())
+				}
+			}
+		case ConstraintCheck:
+			con.Columns = referencedColumns(constraint.RawExpr)
+		}
+	}
+	if con.Type != ConstraintCheck {
+		for _, name := range con.Columns {
+			column, exists := table.Column(name)
+			if !exists {
+				return fmt.Errorf("catalog: column %q named in key does not exist", name)
+			}
+			if con.Type == ConstraintPrimaryKey {
+				column.NotNull = true
+			}
+		}
+	}
+
+	switch con.Type {
+	case ConstraintCheck:
+		expr, err := pg_query.DeparseNode(constraint.RawExpr)
+		if err != nil {
+			return err
+		}
+		con.Expr = expr
+	case ConstraintForeignKey:
+		if err := c.references(table, con, constraint); err != nil {
+			return err
+		}
+	}
+
+	if con.Name == "" {
+		con.Name = constraintName(table, con)
+	} else if _, exists := table.Constraint(con.Name); exists {
+		return fmt.Errorf("catalog: constraint %q for relation %q already exists", con.Name, table.Name)
+	}
+	table.Constraints = append(table.Constraints, con)
+	return nil
+}
+
+// references sets the referenced columns of a foreign key, which default to the primary
+// key of the referenced table
+func (c *Catalog) references(table *Table, con *Constraint, constraint *pg_query.Constraint) error {
+	pktable := constraint.Pktable
+	ref := table
+	if pktable.GetRelname() != table.Name || (pktable.GetSchemaname() != "" && pktable.GetSchemaname() != table.Schema) {
+		var err error
+		if ref, err = c.relation(pktable); err != nil {
+			return err
+		}
+	}
+
+	con.RefSchema, con.RefTable = ref.Schema, ref.Name
+	con.RefColumns = strVals(constraint.PkAttrs)
+	if len(con.RefColumns) == 0 {
+		pk, ok := ref.PrimaryKey()
+		if !ok {
+			return fmt.Errorf("catalog: there is no primary key for referenced table %q", ref.Name)
+		}
+		con.RefColumns = append([]string(nil), pk.Columns...)
+	}
+	for _, name := range con.RefColumns {
+		if _, exists := ref.Column(name); !exists {
+			return fmt.Errorf("catalog: column %q referenced in foreign key constraint does not exist", name)
+		}
+	}
+	return nil
+}
+
+// referencedColumns returns the distinct column names referenced by an expression
+func referencedColumns(expr *pg_query.Node) []string {
+	var columns []string
+	seen := map[string]bool{}
+	_ = pg_query.Walk(func(node *pg_query.Node) (bool, error) {
+		if fields := node.GetColumnRef().GetFields(); len(fields) > 0 {
+			name := fields[len(fields)-1].GetString_().GetSval()
+			if name != "" && !seen[name] {
+				seen[name] = true
+				columns = append(columns, name)
+			}
+		}
+		return true, nil
+	}, expr)
+	return columns
+}
+
+// constraintName returns the name PostgreSQL gives to an unnamed constraint, e.g.
+// "users_pkey", "users_email_key" or "orders_user_id_fkey"
+func constraintName(table *Table, con *Constraint) string {
+	var label string
+	columns := con.Columns
+	switch con.Type {
+	case ConstraintPrimaryKey:
+		label, columns = "pkey", nil
+	case ConstraintUnique:
+		label = "key"
+	case ConstraintForeignKey:
+		label = "fkey"
+	case ConstraintCheck:
+		label = "check"
+		if len(columns) != 1 {
+			columns = nil
+		}
+	case ConstraintExclusion:
+		label = "excl"
+	}
+
+	base := strings.Join(append(append([]string{table.Name}, columns...), label), "_")
+	name := base
+	for i := 1; ; i++ {
+		if _, exists := table.Constraint(name); !exists {
+			return name
+		}
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+}
+
+func (c *Catalog) createTableAs(stmt *pg_query.CreateTableAsStmt) error {
+	kind := KindTable
+	if stmt.Objtype == pg_query.ObjectType_OBJECT_MATVIEW {
+		kind = KindMaterializedView
+	}
+	return c.createQueryRelation(stmt.GetInto().GetRel(), kind, stmt.Query, stmt.GetInto().GetColNames(), stmt.IfNotExists, false)
+}
+
+func (c *Catalog) createView(stmt *pg_query.ViewStmt) error {
+	return c.createQueryRelation(stmt.View, KindView, stmt.Query, stmt.Aliases, false, stmt.Replace)
+}
+
+// createQueryRelation creates a view or a table whose columns are the output columns of a query
+func (c *Catalog) createQueryRelation(rangeVar *pg_query.RangeVar, kind TableKind, query *pg_query.Node, aliases []*pg_query.Node, ifNotExists, replace bool) error {
+	schema, err := c.creationSchema(rangeVar.GetSchemaname())
+	if err != nil {
+		return err
+	}
+	if existing, exists := schema.Tables[rangeVar.GetRelname()]; exists {
+		switch {
+		case replace && existing.Kind == kind:
+		case ifNotExists:
+			return nil
+		default:
+			return fmt.Errorf("catalog: relation %q already exists", rangeVar.GetRelname())
+		}
+	}
+
+	table := &Table{Schema: schema.Name, Name: rangeVar.GetRelname(), Kind: kind}
+	if kind != KindTable {
+		if table.Query, err = pg_query.DeparseNode(query); err != nil {
+			return err
+		}
+	}
+	if table.Columns, err = c.queryColumns(query); err != nil {
+		return err
+	}
+	for i, alias := range strVals(aliases) {
+		if i < len(table.Columns) {
+			table.Columns[i].Name = alias
+		}
+	}
+	if kind != KindTable {
+		table.uses = c.queryRelations(query)
+		table.reads = c.readColumns(query, table.uses)
+	}
+
+	// A replaced view keeps its identity, as the views using it still do
+	if existing, exists := schema.Tables[table.Name]; exists {
+		*existing = *table
+		return nil
+	}
+	schema.Tables[table.Name] = table
+	return nil
+}
+
+// queryRelations returns the tables and views of the catalog read by a query
+func (c *Catalog) queryRelations(query *pg_query.Node) []*Table {
+	var tables []*Table
+	tree := &pg_query.ParseResult{Stmts: []*pg_query.RawStmt{{Stmt: query}}}
+	for _, ref := range pg_query.ExtractTables(tree) {
+		if table, ok := c.LookupTable(ref.Schema, ref.Name); ok {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+// columnUse is a column of a table or view read by the query of a view
+type columnUse struct {
+	table  *Table
+	column string
+}
+
+// readColumns returns the columns read by a query from the given tables and views
+//
+// Stars are expanded first, as the query reads all the columns they cover. If they can't
+// be, or if the query has a whole-row reference, it's assumed to read all the columns.
+func (c *Catalog) readColumns(query *pg_query.Node, uses []*Table) []columnUse {
+	all := func() []columnUse {
+		var reads []columnUse
+		for _, table := range uses {
+			for _, column := range table.Columns {
+				reads = append(reads, columnUse{table: table, column: column.Name})
+			}
+		}
+		return reads
+	}
+
+	tree := &pg_query.ParseResult{Stmts: []*pg_query.RawStmt{{Stmt: proto.Clone(query).(*pg_query.Node)}}}
+	if _, err := pg_query.ExpandStars(tree, c); err != nil {
+		return all()
+	}
+	var reads []columnUse
+	for _, binding := range pg_query.ResolveColumns(tree, c) {
+		if binding.Err == nil && binding.Column == "" {
+			return all()
+		}
+		if origin := binding.Origin; origin != nil {
+			if table, ok := c.LookupTable(origin.Schema, origin.Table); ok {
+				reads = append(reads, columnUse{table: table, column: origin.Column})
+			}
+		}
+	}
+	return reads
+}
+
+// queryColumns returns the output columns of a query
+func (c *Catalog) queryColumns(query *pg_query.Node) ([]*Column, error) {
+	results, err := pg_query.OutputColumns(query, c)
+	if err != nil {
+		return nil, err
+	}
+	var columns []*Column
+	for _, result := range results {
+		columns = append(columns, &Column{Name: result.Name, Type: result.Type})
+	}
+	return columns, nil
+}
+
+func (c *Catalog) createSchema(stmt *pg_query.CreateSchemaStmt) error {
+	name := stmt.Schemaname
+	if name == "" {
+		name = stmt.GetAuthrole().GetRolename()
+	}
+	if _, exists := c.schemas[name]; exists {
+		if stmt.IfNotExists {
+			return nil
+		}
+		return fmt.Errorf("catalog: schema %q already exists", name)
+	}
+	c.schemas[name] = newSchema(name)
+
+	// Objects created by the schema elements go into the new schema
+	searchPath := c.searchPath
+	c.searchPath = append([]string{name}, searchPath...)
+	defer func() {
+		c.searchPath = searchPath
+	}()
+	for _, elt := range stmt.SchemaElts {
+		if err := c.schemaElement(name, elt); err != nil {
+			// The elements only create objects in the new schema, so dropping it undoes them
+			delete(c.schemas, name)
+			return err
+		}
+	}
+	return nil
+}
+
+// schemaElement applies an element of CREATE SCHEMA, which must not name another schema
+func (c *Catalog) schemaElement(schema string, elt *pg_query.Node) error {
+	var rangeVar *pg_query.RangeVar
+	switch e := elt.GetNode().(type) {
+	case *pg_query.Node_CreateStmt:
+		rangeVar = e.CreateStmt.Relation
+	case *pg_query.Node_ViewStmt:
+		rangeVar = e.ViewStmt.View
+	}
+	if name := rangeVar.GetSchemaname(); name != "" && name != schema {
+		return fmt.Errorf("catalog: CREATE specifies a schema (%s) different from the one being created (%s)", name, schema)
+	}
+	return c.ApplyStmt(elt)
+}
+
+func (c *Catalog) createEnum(stmt *pg_query.CreateEnumStmt) error {
+	schemaName, name := splitName(strVals(stmt.TypeName))
+	schema, err := c.creationSchema(schemaName)
+	if err != nil {
+		return err
+	}
+	if _, exists := schema.Types[name]; exists {
+		return fmt.Errorf("catalog: type %q already exists", name)
+	}
+	schema.Types[name] = &Type{Schema: schema.Name, Name: name, Labels: strVals(stmt.Vals)}
+	return nil
+}
+
+// alterEnum applies ALTER TYPE ... ADD VALUE and ALTER TYPE ... RENAME VALUE
+func (c *Catalog) alterEnum(stmt *pg_query.AlterEnumStmt) error {
+	schemaName, name := splitName(strVals(stmt.TypeName))
+	typ, ok := c.LookupType(schemaName, name)
+	if !ok {
+		return fmt.Errorf("catalog: type %q does not exist", qualifiedName(schemaName, name))
+	}
+	if contains(typ.Labels, stmt.NewVal) {
+		if stmt.SkipIfNewValExists {
+			return nil
+		}
+		return fmt.Errorf("catalog: enum label %q already exists", stmt.NewVal)
+	}
+
+	if stmt.OldVal != "" {
+		i := indexOf(typ.Labels, stmt.OldVal)
+		if i < 0 {
+			return fmt.Errorf("catalog: %q is not an existing enum label", stmt.OldVal)
+		}
+		typ.Labels[i] = stmt.NewVal
+		return nil
+	}
+
+	i := len(typ.Labels)
+	if stmt.NewValNeighbor != "" {
+		if i = indexOf(typ.Labels, stmt.NewValNeighbor); i < 0 {
+			return fmt.Errorf("catalog: %q is not an existing enum label", stmt.NewValNeighbor)
+		}
+		if stmt.NewValIsAfter {
+			i++
+		}
+	}
+	typ.Labels = append(typ.Labels[:i], append([]string{stmt.NewVal}, typ.Labels[i:]...)...)
+	return nil
+}
+
+func (c *Catalog) alterTable(stmt *pg_query.AlterTableStmt) error {
+	// Indexes, sequences and the other objects the catalog doesn't model are ignored
+	if _, ok := relationKinds[stmt.Objtype]; !ok {
+		return nil
+	}
+	table, err := c.relation(stmt.Relation)
+	if err != nil {
+		if stmt.MissingOk {
+			return nil
+		}
+		return err
+	}
+
+	// Commands are applied to a copy, so a failing statement leaves the table unchanged, and
+	// the objects depending on dropped columns are removed at the end
+	altered := table.clone()
+	var droppedColumns []string
+	var views []*Table
+	for _, node := range stmt.Cmds {
+		cmd := node.GetAlterTableCmd()
+		if _, exists := altered.Column(cmd.GetName()); exists && cmd.GetSubtype() == pg_query.AlterTableType_AT_DropColumn {
+			dependents, err := c.columnDependents(table, cmd.Name, cmd.Behavior == pg_query.DropBehavior_DROP_CASCADE)
+			if err != nil {
+				return err
+			}
+			droppedColumns = append(droppedColumns, cmd.Name)
+			views = append(views, dependents...)
+		}
+		if err := c.alterTableCmd(altered, cmd); err != nil {
+			return err
+		}
+	}
+	*table = *altered
+
+	for _, schema := range c.schemas {
+		for _, t := range schema.Tables {
+			var constraints []*Constraint
+			for _, constraint := range t.Constraints {
+				if !referencesColumn(constraint, table, droppedColumns) {
+					constraints = append(constraints, constraint)
+				}
+			}
+			t.Constraints = constraints
+		}
+	}
+	return c.dropTables(views, true)
+}
+
+// columnDependents returns the views reading a column of a table, or an error if there are
+// any or if foreign keys reference the column, unless cascade is set
+func (c *Catalog) columnDependents(table *Table, column string, cascade bool) ([]*Table, error) {
+	var views []*Table
+	referenced := false
+	for _, schema := range c.schemas {
+		for _, t := range schema.Tables {
+			for _, read := range t.reads {
+				if read.table == table && read.column == column {
+					views = append(views, t)
+					break
+				}
+			}
+			for _, constraint := range t.Constraints {
+				if referencesColumn(constraint, table, []string{column}) {
+					referenced = true
+				}
+			}
+		}
+	}
+	if (referenced || len(views) > 0) && !cascade {
+		return nil, fmt.Errorf("catalog: cannot drop column %q of table %q because other objects depend on it", column, table.Name)
+	}
+	return views, nil
+}
+
+// referencesColumn returns whether a constraint is a foreign key referencing one of the
+// given columns of a table
+func referencesColumn(constraint *Constraint, table *Table, columns []string) bool {
+	if constraint.Type != ConstraintForeignKey || constraint.RefSchema != table.Schema || constraint.RefTable != table.Name {
+		return false
+	}
+	for _, column := range columns {
+		if contains(constraint.RefColumns, column) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Table) clone() *Table {
+	clone := *t
+	clone.Columns = make([]*Column, len(t.Columns))
+	for i, column := range t.Columns {
+		copied := *column
+		clone.Columns[i] = &copied
+	}
+	clone.Constraints = make([]*Constraint, len(t.Constraints))
+	for i, constraint := range t.Constraints {
+		copied := *constraint
+		clone.Constraints[i] = &copied
+	}
+	return &clone
+}
+
+func (c *Catalog) alterTableCmd(table *Table, cmd *pg_query.AlterTableCmd) error {
+	if cmd.Subtype == pg_query.AlterTableType_AT_AddColumn {
+		def := cmd.Def.GetColumnDef()
+		if _, exists := table.Column(def.GetColname()); exists && cmd.MissingOk {
+			return nil
+		}
+		return c.addColumn(table, def, false)
+	}
+	if cmd.Subtype == pg_query.AlterTableType_AT_AddConstraint {
+		return c.addConstraint(table, cmd.Def.GetConstraint(), nil)
+	}
+	if cmd.Subtype == pg_query.AlterTableType_AT_DropConstraint {
+		for i, constraint := range table.Constraints {
+			if constraint.Name == cmd.Name {
+				table.Constraints = append(table.Constraints[:i], table.Constraints[i+1:]...)
+				return nil
+			}
+		}
+		if cmd.MissingOk {
+			return nil
+		}
+		return fmt.Errorf("catalog: constraint %q of relation %q does not exist", cmd.Name, table.Name)
+	}
+
+	var column *Column
+	switch cmd.Subtype {
+	case pg_query.AlterTableType_AT_DropColumn, pg_query.AlterTableType_AT_AlterColumnType, pg_query.AlterTableType_AT_ColumnDefault,
+		pg_query.AlterTableType_AT_SetNotNull, pg_query.AlterTableType_AT_DropNotNull:
+		var exists bool
+		if column, exists = table.Column(cmd.Name); !exists {
+			if cmd.MissingOk {
+				return nil
+			}
+			return fmt.Errorf("catalog: column %q of relation %q does not exist", cmd.Name, table.Name)
+		}
+	default:
+		return nil
+	}
+
+	var err error
+	switch cmd.Subtype {
+	case pg_query.AlterTableType_AT_DropColumn:
+		table.dropColumn(column.Name)
+	case pg_query.AlterTableType_AT_AlterColumnType:
+		column.Type, err = typeName(cmd.Def.GetColumnDef().GetTypeName())
+	case pg_query.AlterTableType_AT_ColumnDefault:
+		column.Default = ""
+		if cmd.Def != nil {
+			column.Default, err = pg_query.DeparseNode(cmd.Def)
+		}
+	case pg_query.AlterTableType_AT_SetNotNull:
+		column.NotNull = true
+	case pg_query.AlterTableType_AT_DropNotNull:
+		column.NotNull = false
+	}
+	return err
+}
+
+// dropColumn removes a column and the constraints involving it
+func (t *Table) dropColumn(name string) {
+	var columns []*Column
+	for _, column := range t.Columns {
+		if column.Name != name {
+			columns = append(columns, column)
+		}
+	}
+	t.Columns = columns
+
+	var constraints []*Constraint
+	for _, constraint := range t.Constraints {
+		if !contains(constraint.Columns, name) {
+			constraints = append(constraints, constraint)
+		}
+	}
+	t.Constraints = constraints
+}
+
+func (c *Catalog) rename(stmt *pg_query.RenameStmt) error {
+	switch stmt.RenameType {
+	case pg_query.ObjectType_OBJECT_SCHEMA:
+		return c.renameSchema(stmt.Subname, stmt.Newname)
+	case pg_query.ObjectType_OBJECT_TYPE:
+		schemaName, name := splitName(strVals(stmt.Object.GetList().GetItems()))
+		typ, ok := c.LookupType(schemaName, name)
+		if !ok {
+			return fmt.Errorf("catalog: type %q does not exist", qualifiedName(schemaName, name))
+		}
+		schema := c.schemas[typ.Schema]
+		if _, exists := schema.Types[stmt.Newname]; exists {
+			return fmt.Errorf("catalog: type %q already exists", stmt.Newname)
+		}
+		delete(schema.Types, typ.Name)
+		typ.Name = stmt.Newname
+		schema.Types[typ.Name] = typ
+		return nil
+	case pg_query.ObjectType_OBJECT_TABLE, pg_query.ObjectType_OBJECT_VIEW, pg_query.ObjectType_OBJECT_MATVIEW,
+		pg_query.ObjectType_OBJECT_FOREIGN_TABLE, pg_query.ObjectType_OBJECT_COLUMN, pg_query.ObjectType_OBJECT_TABCONSTRAINT:
+	default:
+		return nil
+	}
+
+	table, err := c.relation(stmt.Relation)
+	if err != nil {
+		if stmt.MissingOk {
+			return nil
+		}
+		return err
+	}
+
+	switch stmt.RenameType {
+	case pg_query.ObjectType_OBJECT_COLUMN:
+		column, exists := table.Column(stmt.Subname)
+		if !exists {
+			return fmt.Errorf("catalog: column %q does not exist", stmt.Subname)
+		}
+		if _, exists := table.Column(stmt.Newname); exists {
+			return fmt.Errorf("catalog: column %q of relation %q already exists", stmt.Newname, table.Name)
+		}
+		column.Name = stmt.Newname
+		for _, constraint := range table.Constraints {
+			replace(constraint.Columns, stmt.Subname, stmt.Newname)
+		}
+		c.eachReference(table, func(constraint *Constraint) {
+			replace(constraint.RefColumns, stmt.Subname, stmt.Newname)
+		})
+		for _, schema := range c.schemas {
+			for _, view := range schema.Tables {
+				for i, read := range view.reads {
+					if read.table == table && read.column == stmt.Subname {
+						view.reads[i].column = stmt.Newname
+					}
+				}
+			}
+		}
+	case pg_query.ObjectType_OBJECT_TABCONSTRAINT:
+		constraint, exists := table.Constraint(stmt.Subname)
+		if !exists {
+			return fmt.Errorf("catalog: constraint %q for table %q does not exist", stmt.Subname, table.Name)
+		}
+		if _, exists := table.Constraint(stmt.Newname); exists {
+			return fmt.Errorf("catalog: constraint %q for relation %q already exists", stmt.Newname, table.Name)
+		}
+		constraint.Name = stmt.Newname
+	default:
+		schema := c.schemas[table.Schema]
+		if _, exists := schema.Tables[stmt.Newname]; exists {
+			return fmt.Errorf("catalog: relation %q already exists", stmt.Newname)
+		}
+		c.eachReference(table, func(constraint *Constraint) {
+			constraint.RefTable = stmt.Newname
+		})
+		delete(schema.Tables, table.Name)
+		table.Name = stmt.Newname
+		schema.Tables[table.Name] = table
+	}
+	return nil
+}
+
+func (c *Catalog) renameSchema(name, newName string) error {
+	schema, ok := c.schemas[name]
+	if !ok {
+		return fmt.Errorf("catalog: schema %q does not exist", name)
+	}
+	if _, exists := c.schemas[newName]; exists {
+		return fmt.Errorf("catalog: schema %q already exists", newName)
+	}
+	for _, s := range c.schemas {
+		for _, table := range s.Tables {
+			for _, constraint := range table.Constraints {
+				if constraint.Type == ConstraintForeignKey && constraint.RefSchema == name {
+					constraint.RefSchema = newName
+				}
+			}
+		}
+	}
+	for _, table := range schema.Tables {
+		table.Schema = newName
+	}
+	for _, typ := range schema.Types {
+		typ.Schema = newName
+	}
+	delete(c.schemas, name)
+	schema.Name = newName
+	c.schemas[newName] = schema
+	return nil
+}
+
+// alterObjectSchema moves a table, view or type into another schema
+func (c *Catalog) alterObjectSchema(stmt *pg_query.AlterObjectSchemaStmt) error {
+	if _, ok := relationKinds[stmt.ObjectType]; !ok && stmt.ObjectType != pg_query.ObjectType_OBJECT_TYPE {
+		return nil
+	}
+	schema, ok := c.schemas[stmt.Newschema]
+	if !ok {
+		return fmt.Errorf("catalog: schema %q does not exist", stmt.Newschema)
+	}
+
+	if stmt.ObjectType == pg_query.ObjectType_OBJECT_TYPE {
+		schemaName, name := splitName(strVals(stmt.Object.GetList().GetItems()))
+		typ, ok := c.LookupType(schemaName, name)
+		if !ok {
+			return fmt.Errorf("catalog: type %q does not exist", qualifiedName(schemaName, name))
+		}
+		if typ.Schema == schema.Name {
+			return nil
+		}
+		if _, exists := schema.Types[typ.Name]; exists {
+			return fmt.Errorf("catalog: type %q already exists in schema %q", typ.Name, schema.Name)
+		}
+		delete(c.schemas[typ.Schema].Types, typ.Name)
+		typ.Schema = schema.Name
+		schema.Types[typ.Name] = typ
+		return nil
+	}
+
+	table, err := c.relation(stmt.Relation)
+	if err != nil {
+		if stmt.MissingOk {
+			return nil
+		}
+		return err
+	}
+	if table.Schema == schema.Name {
+		return nil
+	}
+	if _, exists := schema.Tables[table.Name]; exists {
+		return fmt.Errorf("catalog: relation %q already exists in schema %q", table.Name, schema.Name)
+	}
+	c.eachReference(table, func(constraint *Constraint) {
+		constraint.RefSchema = schema.Name
+	})
+	delete(c.schemas[table.Schema].Tables, table.Name)
+	table.Schema = schema.Name
+	schema.Tables[table.Name] = table
+	return nil
+}
+
+// eachReference calls fn for every foreign key referencing the table
+func (c *Catalog) eachReference(table *Table, fn func(constraint *Constraint)) {
+	for _, schema := range c.schemas {
+		for _, t := range schema.Tables {
+			for _, constraint := range t.Constraints {
+				if constraint.Type == ConstraintForeignKey && constraint.RefSchema == table.Schema && constraint.RefTable == table.Name {
+					fn(constraint)
+				}
+			}
+		}
+	}
+}
+
+// relationKinds maps the object types of relations to their kinds of tables
+var relationKinds = map[pg_query.ObjectType]TableKind{
+	pg_query.ObjectType_OBJECT_TABLE:         KindTable,
+	pg_query.ObjectType_OBJECT_FOREIGN_TABLE: KindTable,
+	pg_query.ObjectType_OBJECT_VIEW:          KindView,
+	pg_query.ObjectType_OBJECT_MATVIEW:       KindMaterializedView,
+}
+
+var kindNames = map[TableKind]string{
+	KindTable:            "table",
+	KindView:             "view",
+	KindMaterializedView: "materialized view",
+}
+
+// drop applies a DROP statement, looking up all of its objects before dropping any, so a
+// failing statement drops nothing
+func (c *Catalog) drop(stmt *pg_query.DropStmt) error {
+	cascade := stmt.Behavior == pg_query.DropBehavior_DROP_CASCADE
+	switch stmt.RemoveType {
+	case pg_query.ObjectType_OBJECT_SCHEMA:
+		return c.dropSchemas(strVals(stmt.Objects), stmt.MissingOk, cascade)
+	case pg_query.ObjectType_OBJECT_TYPE:
+		return c.dropTypes(stmt.Objects, stmt.MissingOk)
+	}
+	kind, ok := relationKinds[stmt.RemoveType]
+	if !ok {
+		return nil
+	}
+
+	var tables []*Table
+	for _, object := range stmt.Objects {
+		schemaName, name := splitName(strVals(object.GetList().GetItems()))
+		table, ok := c.LookupTable(schemaName, name)
+		if !ok {
+			if stmt.MissingOk {
+				continue
+			}
+			return fmt.Errorf("catalog: %s %q does not exist", kindNames[kind], qualifiedName(schemaName, name))
+		}
+		if table.Kind != kind {
+			return fmt.Errorf("catalog: %q is not a %s", name, kindNames[kind])
+		}
+		tables = append(tables, table)
+	}
+	return c.dropTables(tables, cascade)
+}
+
+func (c *Catalog) dropTypes(objects []*pg_query.Node, missingOk bool) error {
+	var types []*Type
+	for _, object := range objects {
+		schemaName, name := splitName(strVals(object.GetTypeName().GetNames()))
+		typ, ok := c.LookupType(schemaName, name)
+		if !ok {
+			if missingOk {
+				continue
+			}
+			return fmt.Errorf("catalog: type %q does not exist", qualifiedName(schemaName, name))
+		}
+		types = append(types, typ)
+	}
+	for _, typ := range types {
+		delete(c.schemas[typ.Schema].Types, typ.Name)
+	}
+	return nil
+}
+
+// dropTables drops tables and views with the objects depending on them: the foreign keys
+// referencing them are removed and the views using them are dropped, which requires cascade
+func (c *Catalog) dropTables(tables []*Table, cascade bool) error {
+	dropped := map[*Table]bool{}
+	for _, table := range tables {
+		dropped[table] = true
+	}
+	dependentsError := func(table *Table) error {
+		return fmt.Errorf("catalog: cannot drop %s %q because other objects depend on it", kindNames[table.Kind], table.Name)
+	}
+
+	// Views using dropped relations are dropped too, until there are no more
+	for more := true; more; {
+		more = false
+		for _, schema := range c.schemas {
+			for _, view := range schema.Tables {
+				if dropped[view] {
+					continue
+				}
+				for _, used := range view.uses {
+					if dropped[used] {
+						if !cascade {
+							return dependentsError(used)
+						}
+						dropped[view], more = true, true
+						break
+					}
+				}
+			}
+		}
+	}
+
+	referenced := func(constraint *Constraint) *Table {
+		if constraint.Type != ConstraintForeignKey {
+			return nil
+		}
+		for table := range dropped {
+			if constraint.RefSchema == table.Schema && constraint.RefTable == table.Name {
+				return table
+			}
+		}
+		return nil
+	}
+	for _, schema := range c.schemas {
+		for _, table := range schema.Tables {
+			if dropped[table] {
+				continue
+			}
+			for _, constraint := range table.Constraints {
+				if ref := referenced(constraint); ref != nil && !cascade {
+					return dependentsError(ref)
+				}
+			}
+		}
+	}
+
+	for _, schema := range c.schemas {
+		for _, table := range schema.Tables {
+			var constraints []*Constraint
+			for _, constraint := range table.Constraints {
+				if dropped[table] || referenced(constraint) == nil {
+					constraints = append(constraints, constraint)
+				}
+			}
+			table.Constraints = constraints
+		}
+	}
+	for table := range dropped {
+		delete(c.schemas[table.Schema].Tables, table.Name)
+	}
+	return nil
+}
+
+func (c *Catalog) dropSchemas(names []string, missingOk, cascade bool) error {
+	var schemas []*Schema
+	var tables []*Table
+	for _, name := range names {
+		schema, ok := c.schemas[name]
+		if !ok {
+			if missingOk {
+				continue
+			}
+			return fmt.Errorf("catalog: schema %q does not exist", name)
+		}
+		if !cascade && (len(schema.Tables) > 0 || len(schema.Types) > 0) {
+			return fmt.Errorf("catalog: cannot drop schema %q because other objects depend on it", name)
+		}
+		schemas = append(schemas, schema)
+		tables = append(tables, schema.SortedTables()...)
+	}
+	if err := c.dropTables(tables, cascade); err != nil {
+		return err
+	}
+	for _, schema := range schemas {
+		delete(c.schemas, schema.Name)
+	}
+	return nil
+}
+
+func typeName(typeName *pg_query.TypeName) (string, error) {
+	return pg_query.DeparseNode(&pg_query.Node{Node: &pg_query.Node_TypeName{TypeName: typeName}})
+}
+
+func strVals(nodes []*pg_query.Node) []string {
+	var values []string
+	for _, node := range nodes {
+		values = append(values, node.GetString_().GetSval())
+	}
+	return values
+}
+
+// splitName returns the schema and object name of a possibly qualified name
+func splitName(names []string) (schema, name string) {
+	if len(names) == 0 {
+		return "", ""
+	}
+	if len(names) > 1 {
+		schema = names[len(names)-2]
+	}
+	return schema, names[len(names)-1]
+}
+
+func qualifiedName(schema, name string) string {
+	if schema == "" {
+		return name
+	}
+	return schema + "." + name
+}
+
+func contains(values []string, value string) bool {
+	return indexOf(values, value) >= 0
+}
+
+// indexOf returns the index of the first occurrence of a value in a slice, or -1
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// replace replaces the occurrences of a value in a slice
+func replace(values []string, old, new string) {
+	for i, v := range values {
+		if v == old {
+			values[i] = new
+		}
+	}
+}
@@ -0,0 +1,17 @@
+//go:build cgo
+// +build cgo
+
+package catalog
+
+import (
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+)
+
+// Exec parses the given SQL, e.g. a migration script, and applies its statements to the catalog
+func (c *Catalog) Exec(sql string) error {
+	tree, err := pg_query.Parse(sql)
+	if err != nil {
+		return err
+	}
+	return c.Apply(tree)
+}
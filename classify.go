@@ -0,0 +1,604 @@
+package pg_query
+
+import "strings"
+
+// StatementCategory is the kind of a SQL statement
+type StatementCategory int
+
+const (
+	// CategoryDQL is a query reading data: SELECT, VALUES, TABLE and COPY ... TO
+	CategoryDQL StatementCategory = iota
+	// CategoryDML is a statement modifying data: INSERT, UPDATE, DELETE, MERGE, TRUNCATE and
+	// COPY ... FROM
+	CategoryDML
+	// CategoryDDL is a statement defining or changing database objects, e.g. CREATE TABLE,
+	// ALTER INDEX, DROP VIEW, COMMENT or SELECT INTO
+	CategoryDDL
+	// CategoryDCL is a statement managing roles and privileges, e.g. GRANT or CREATE ROLE
+	CategoryDCL
+	// CategoryTCL is a statement controlling transactions: BEGIN, COMMIT, ROLLBACK,
+	// SAVEPOINT, SET TRANSACTION, SET CONSTRAINTS and LOCK
+	CategoryTCL
+	// CategoryUtility is any other statement, e.g. EXPLAIN, SET, SHOW, PREPARE, VACUUM or
+	// LISTEN
+	CategoryUtility
+)
+
+func (c StatementCategory) String() string {
+	switch c {
+	case CategoryDQL:
+		return "DQL"
+	case CategoryDML:
+		return "DML"
+	case CategoryDDL:
+		return "DDL"
+	case CategoryDCL:
+		return "DCL"
+	case CategoryTCL:
+		return "TCL"
+	case CategoryUtility:
+		return "utility"
+	}
+	return "unknown"
+}
+
+// StatementInfo describes what a statement does
+type StatementInfo struct {
+	Category StatementCategory
+	// ReadOnly is set for statements PostgreSQL allows in a read-only transaction, except
+	// CALL, DO and EXECUTE, which run statements unknown to the parse tree. Functions called
+	// by a query are assumed not to write.
+	ReadOnly bool
+	// RequiresTransactionBlock is set for statements that fail outside of a transaction
+	// block, e.g. SAVEPOINT, LOCK or DECLARE without WITH HOLD
+	RequiresTransactionBlock bool
+	// PreventsTransactionBlock is set for statements that can't run inside a transaction
+	// block, e.g. VACUUM, CREATE DATABASE or CREATE INDEX CONCURRENTLY
+	PreventsTransactionBlock bool
+	// CommandTag is the command tag PostgreSQL reports for the statement, without the row
+	// count, e.g. "INSERT" or "CREATE TABLE"
+	CommandTag string
+}
+
+// Classify returns the category, command tag and transaction requirements of a statement
+func Classify(stmt *RawStmt) StatementInfo {
+	return classifyNode(stmt.GetStmt())
+}
+
+func classifyNode(node *Node) StatementInfo {
+	switch n := node.GetNode().(type) {
+	case *Node_SelectStmt:
+		info := StatementInfo{Category: CategoryDQL, ReadOnly: isReadOnlyQuery(node), CommandTag: "SELECT"}
+		if n.SelectStmt.IntoClause != nil {
+			info.Category = CategoryDDL
+		}
+		return info
+	case *Node_InsertStmt:
+		return StatementInfo{Category: CategoryDML, CommandTag: "INSERT"}
+	case *Node_UpdateStmt:
+		return StatementInfo{Category: CategoryDML, CommandTag: "UPDATE"}
+	case *Node_DeleteStmt:
+		return StatementInfo{Category: CategoryDML, CommandTag: "DELETE"}
+	case *Node_MergeStmt:
+		return StatementInfo{Category: CategoryDML, CommandTag: "MERGE"}
+	case *Node_CopyStmt:
+		if n.CopyStmt.IsFrom {
+			return StatementInfo{Category: CategoryDML, CommandTag: "COPY"}
+		}
+		return StatementInfo{Category: CategoryDQL, ReadOnly: isReadOnlyQuery(n.CopyStmt.Query), CommandTag: "COPY"}
+	case *Node_TruncateStmt:
+		return StatementInfo{Category: CategoryDML, CommandTag: "TRUNCATE TABLE"}
+
+	case *Node_TransactionStmt:
+		return transactionStmtInfo(n.TransactionStmt)
+	case *Node_ConstraintsSetStmt:
+		return StatementInfo{Category: CategoryTCL, ReadOnly: true, CommandTag: "SET CONSTRAINTS"}
+	case *Node_LockStmt:
+		return StatementInfo{Category: CategoryTCL, ReadOnly: true, RequiresTransactionBlock: true, CommandTag: "LOCK TABLE"}
+	case *Node_VariableSetStmt:
+		info := StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "SET"}
+		switch n.VariableSetStmt.Kind {
+		case VariableSetKind_VAR_RESET, VariableSetKind_VAR_RESET_ALL:
+			info.CommandTag = "RESET"
+		}
+		// SET TRANSACTION, SET TRANSACTION SNAPSHOT, SET SESSION CHARACTERISTICS AS TRANSACTION
+		if name := n.VariableSetStmt.Name; strings.HasPrefix(name, "TRANSACTION") || name == "SESSION CHARACTERISTICS" {
+			info.Category = CategoryTCL
+		}
+		return info
+
+	case *Node_GrantStmt:
+		if n.GrantStmt.IsGrant {
+			return StatementInfo{Category: CategoryDCL, CommandTag: "GRANT"}
+		}
+		return StatementInfo{Category: CategoryDCL, CommandTag: "REVOKE"}
+	case *Node_GrantRoleStmt:
+		if n.GrantRoleStmt.IsGrant {
+			return StatementInfo{Category: CategoryDCL, CommandTag: "GRANT ROLE"}
+		}
+		return StatementInfo{Category: CategoryDCL, CommandTag: "REVOKE ROLE"}
+	case *Node_AlterDefaultPrivilegesStmt:
+		return StatementInfo{Category: CategoryDCL, CommandTag: "ALTER DEFAULT PRIVILEGES"}
+	case *Node_CreateRoleStmt:
+		return StatementInfo{Category: CategoryDCL, CommandTag: "CREATE ROLE"}
+	case *Node_AlterRoleStmt, *Node_AlterRoleSetStmt:
+		return StatementInfo{Category: CategoryDCL, CommandTag: "ALTER ROLE"}
+	case *Node_DropRoleStmt:
+		return StatementInfo{Category: CategoryDCL, CommandTag: "DROP ROLE"}
+	case *Node_DropOwnedStmt:
+		return StatementInfo{Category: CategoryDCL, CommandTag: "DROP OWNED"}
+	case *Node_ReassignOwnedStmt:
+		return StatementInfo{Category: CategoryDCL, CommandTag: "REASSIGN OWNED"}
+
+	case *Node_ExplainStmt:
+		info := StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "EXPLAIN"}
+		for _, option := range n.ExplainStmt.Options {
+			if option.GetDefElem().GetDefname() == "analyze" && defElemBool(option.GetDefElem()) {
+				info.ReadOnly = classifyNode(n.ExplainStmt.Query).ReadOnly
+			}
+		}
+		return info
+	case *Node_DeclareCursorStmt:
+		return StatementInfo{
+			Category:                 CategoryUtility,
+			ReadOnly:                 isReadOnlyQuery(n.DeclareCursorStmt.Query),
+			RequiresTransactionBlock: n.DeclareCursorStmt.Options&cursorOptHold == 0,
+			CommandTag:               "DECLARE CURSOR",
+		}
+	case *Node_ClosePortalStmt:
+		if n.ClosePortalStmt.Portalname == "" {
+			return StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "CLOSE CURSOR ALL"}
+		}
+		return StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "CLOSE CURSOR"}
+	case *Node_FetchStmt:
+		if n.FetchStmt.Ismove {
+			return StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "MOVE"}
+		}
+		return StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "FETCH"}
+	case *Node_DeallocateStmt:
+		if n.DeallocateStmt.Name == "" {
+			return StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "DEALLOCATE ALL"}
+		}
+		return StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "DEALLOCATE"}
+	case *Node_DiscardStmt:
+		info := StatementInfo{Category: CategoryUtility, ReadOnly: true}
+		switch n.DiscardStmt.Target {
+		case DiscardMode_DISCARD_ALL:
+			info.CommandTag, info.PreventsTransactionBlock = "DISCARD ALL", true
+		case DiscardMode_DISCARD_PLANS:
+			info.CommandTag = "DISCARD PLANS"
+		case DiscardMode_DISCARD_SEQUENCES:
+			info.CommandTag = "DISCARD SEQUENCES"
+		case DiscardMode_DISCARD_TEMP:
+			info.CommandTag = "DISCARD TEMP"
+		}
+		return info
+	case *Node_VacuumStmt:
+		if n.VacuumStmt.IsVacuumcmd {
+			return StatementInfo{Category: CategoryUtility, ReadOnly: true, PreventsTransactionBlock: true, CommandTag: "VACUUM"}
+		}
+		return StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "ANALYZE"}
+	case *Node_ClusterStmt:
+		return StatementInfo{
+			Category:                 CategoryUtility,
+			ReadOnly:                 true,
+			PreventsTransactionBlock: n.ClusterStmt.Relation == nil,
+			CommandTag:               "CLUSTER",
+		}
+	case *Node_ReindexStmt:
+		info := StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "REINDEX"}
+		switch n.ReindexStmt.Kind {
+		case ReindexObjectType_REINDEX_OBJECT_SCHEMA, ReindexObjectType_REINDEX_OBJECT_SYSTEM, ReindexObjectType_REINDEX_OBJECT_DATABASE:
+			info.PreventsTransactionBlock = true
+		}
+		for _, param := range n.ReindexStmt.Params {
+			if param.GetDefElem().GetDefname() == "concurrently" && defElemBool(param.GetDefElem()) {
+				info.PreventsTransactionBlock = true
+			}
+		}
+		return info
+	case *Node_VariableShowStmt:
+		return StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "SHOW"}
+	case *Node_PrepareStmt:
+		return StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "PREPARE"}
+	case *Node_ExecuteStmt:
+		return StatementInfo{Category: CategoryUtility, CommandTag: "EXECUTE"}
+	case *Node_DoStmt:
+		return StatementInfo{Category: CategoryUtility, CommandTag: "DO"}
+	case *Node_CallStmt:
+		return StatementInfo{Category: CategoryUtility, CommandTag: "CALL"}
+	case *Node_ListenStmt:
+		return StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "LISTEN"}
+	case *Node_UnlistenStmt:
+		return StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "UNLISTEN"}
+	case *Node_NotifyStmt:
+		return StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "NOTIFY"}
+	case *Node_LoadStmt:
+		return StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "LOAD"}
+	case *Node_CheckPointStmt:
+		return StatementInfo{Category: CategoryUtility, ReadOnly: true, CommandTag: "CHECKPOINT"}
+	}
+
+	info := StatementInfo{Category: CategoryDDL, CommandTag: ddlCommandTag(node)}
+	switch n := node.GetNode().(type) {
+	case *Node_CreatedbStmt, *Node_DropdbStmt, *Node_CreateTableSpaceStmt, *Node_DropTableSpaceStmt, *Node_AlterSystemStmt:
+		info.PreventsTransactionBlock = true
+	case *Node_AlterDatabaseStmt:
+		for _, option := range n.AlterDatabaseStmt.Options {
+			if option.GetDefElem().GetDefname() == "tablespace" {
+				info.PreventsTransactionBlock = true
+			}
+		}
+	case *Node_IndexStmt:
+		info.PreventsTransactionBlock = n.IndexStmt.Concurrent
+	case *Node_DropStmt:
+		info.PreventsTransactionBlock = n.DropStmt.Concurrent
+	case *Node_AlterTableStmt:
+		for _, cmd := range n.AlterTableStmt.Cmds {
+			if cmd.GetAlterTableCmd().GetSubtype() == AlterTableType_AT_DetachPartition && cmd.GetAlterTableCmd().GetDef().GetPartitionCmd().GetConcurrent() {
+				info.PreventsTransactionBlock = true
+			}
+		}
+	case *Node_CreateSubscriptionStmt:
+		info.PreventsTransactionBlock = true
+		for _, option := range n.CreateSubscriptionStmt.Options {
+			if option.GetDefElem().GetDefname() == "create_slot" && !defElemBool(option.GetDefElem()) {
+				info.PreventsTransactionBlock = false
+			}
+		}
+	case *Node_AlterSubscriptionStmt:
+		// REFRESH PUBLICATION, and SET, ADD or DROP PUBLICATION unless WITH (refresh = false)
+		switch n.AlterSubscriptionStmt.Kind {
+		case AlterSubscriptionType_ALTER_SUBSCRIPTION_REFRESH:
+			info.PreventsTransactionBlock = true
+		case AlterSubscriptionType_ALTER_SUBSCRIPTION_SET_PUBLICATION, AlterSubscriptionType_ALTER_SUBSCRIPTION_ADD_PUBLICATION,
+			AlterSubscriptionType_ALTER_SUBSCRIPTION_DROP_PUBLICATION:
+			info.PreventsTransactionBlock = true
+			for _, option := range n.AlterSubscriptionStmt.Options {
+				if option.GetDefElem().GetDefname() == "refresh" && !defElemBool(option.GetDefElem()) {
+					info.PreventsTransactionBlock = false
+				}
+			}
+		}
+	case *Node_RenameStmt:
+		// ALTER ROLE ... RENAME, like the other forms of ALTER ROLE
+		if n.RenameStmt.RenameType == ObjectType_OBJECT_ROLE {
+			info.Category = CategoryDCL
+		}
+	}
+	if info.CommandTag == "???" {
+		info.Category = CategoryUtility
+	}
+	return info
+}
+
+// isReadOnlyQuery returns whether a query neither modifies data nor locks rows, including
+// in its CTEs and subqueries
+func isReadOnlyQuery(node *Node) bool {
+	readOnly := true
+	_ = Walk(func(child *Node) (bool, error) {
+		switch n := child.Node.(type) {
+		case *Node_InsertStmt, *Node_UpdateStmt, *Node_DeleteStmt, *Node_MergeStmt:
+			readOnly = false
+		case *Node_SelectStmt:
+			if n.SelectStmt.IntoClause != nil || len(n.SelectStmt.LockingClause) > 0 {
+				readOnly = false
+			}
+		}
+		return readOnly, nil
+	}, node)
+	return readOnly
+}
+
+// defElemBool returns the value of a boolean option, like defGetBoolean in define.c
+func defElemBool(defElem *DefElem) bool {
+	arg := defElem.GetArg()
+	switch arg.GetNode().(type) {
+	case nil:
+		return true
+	case *Node_Integer:
+		return intVal(arg) != 0
+	case *Node_Boolean:
+		return boolVal(arg)
+	}
+	switch strings.ToLower(strVal(arg)) {
+	case "false", "off", "0", "no":
+		return false
+	}
+	return true
+}
+
+func transactionStmtInfo(stmt *TransactionStmt) StatementInfo {
+	info := StatementInfo{Category: CategoryTCL, ReadOnly: true}
+	switch stmt.Kind {
+	case TransactionStmtKind_TRANS_STMT_BEGIN:
+		info.CommandTag = "BEGIN"
+	case TransactionStmtKind_TRANS_STMT_START:
+		info.CommandTag = "START TRANSACTION"
+	case TransactionStmtKind_TRANS_STMT_COMMIT:
+		info.CommandTag = "COMMIT"
+	case TransactionStmtKind_TRANS_STMT_ROLLBACK:
+		info.CommandTag = "ROLLBACK"
+	case TransactionStmtKind_TRANS_STMT_SAVEPOINT:
+		info.CommandTag, info.RequiresTransactionBlock = "SAVEPOINT", true
+	case TransactionStmtKind_TRANS_STMT_RELEASE:
+		info.CommandTag, info.RequiresTransactionBlock = "RELEASE", true
+	case TransactionStmtKind_TRANS_STMT_ROLLBACK_TO:
+		info.CommandTag, info.RequiresTransactionBlock = "ROLLBACK", true
+	case TransactionStmtKind_TRANS_STMT_PREPARE:
+		info.CommandTag = "PREPARE TRANSACTION"
+	case TransactionStmtKind_TRANS_STMT_COMMIT_PREPARED:
+		info.CommandTag, info.PreventsTransactionBlock = "COMMIT PREPARED", true
+	case TransactionStmtKind_TRANS_STMT_ROLLBACK_PREPARED:
+		info.CommandTag, info.PreventsTransactionBlock = "ROLLBACK PREPARED", true
+	default:
+		info.CommandTag = "???"
+	}
+	return info
+}
+
+// ddlCommandTag returns the command tag of a DDL statement, like CreateCommandTag in
+// utility.c, or "???" if the node isn't one
+func ddlCommandTag(node *Node) string {
+	switch n := node.GetNode().(type) {
+	case *Node_CreateStmt:
+		return "CREATE TABLE"
+	case *Node_CreateTableAsStmt:
+		if n.CreateTableAsStmt.Objtype == ObjectType_OBJECT_MATVIEW {
+			return "CREATE MATERIALIZED VIEW"
+		}
+		if n.CreateTableAsStmt.IsSelectInto {
+			return "SELECT INTO"
+		}
+		return "CREATE TABLE AS"
+	case *Node_ViewStmt:
+		return "CREATE VIEW"
+	case *Node_RefreshMatViewStmt:
+		return "REFRESH MATERIALIZED VIEW"
+	case *Node_IndexStmt:
+		return "CREATE INDEX"
+	case *Node_CreateSchemaStmt:
+		return "CREATE SCHEMA"
+	case *Node_CreateSeqStmt:
+		return "CREATE SEQUENCE"
+	case *Node_AlterSeqStmt:
+		return "ALTER SEQUENCE"
+	case *Node_CreateDomainStmt:
+		return "CREATE DOMAIN"
+	case *Node_AlterDomainStmt:
+		return "ALTER DOMAIN"
+	case *Node_CompositeTypeStmt, *Node_CreateEnumStmt, *Node_CreateRangeStmt:
+		return "CREATE TYPE"
+	case *Node_AlterEnumStmt, *Node_AlterTypeStmt:
+		return "ALTER TYPE"
+	case *Node_DefineStmt:
+		return "CREATE " + objectTypeTag(n.DefineStmt.Kind)
+	case *Node_CreateFunctionStmt:
+		if n.CreateFunctionStmt.IsProcedure {
+			return "CREATE PROCEDURE"
+		}
+		return "CREATE FUNCTION"
+	case *Node_AlterFunctionStmt:
+		switch n.AlterFunctionStmt.Objtype {
+		case ObjectType_OBJECT_PROCEDURE, ObjectType_OBJECT_ROUTINE:
+			return "ALTER " + objectTypeTag(n.AlterFunctionStmt.Objtype)
+		}
+		return "ALTER FUNCTION"
+	case *Node_CreateTrigStmt:
+		return "CREATE TRIGGER"
+	case *Node_CreateEventTrigStmt:
+		return "CREATE EVENT TRIGGER"
+	case *Node_AlterEventTrigStmt:
+		return "ALTER EVENT TRIGGER"
+	case *Node_RuleStmt:
+		return "CREATE RULE"
+	case *Node_CreatePolicyStmt:
+		return "CREATE POLICY"
+	case *Node_AlterPolicyStmt:
+		return "ALTER POLICY"
+	case *Node_CreateStatsStmt:
+		return "CREATE STATISTICS"
+	case *Node_AlterStatsStmt:
+		return "ALTER STATISTICS"
+	case *Node_CreateExtensionStmt:
+		return "CREATE EXTENSION"
+	case *Node_AlterExtensionStmt, *Node_AlterExtensionContentsStmt:
+		return "ALTER EXTENSION"
+	case *Node_CreatePlangStmt:
+		return "CREATE LANGUAGE"
+	case *Node_CreateAmStmt:
+		return "CREATE ACCESS METHOD"
+	case *Node_CreateOpClassStmt:
+		return "CREATE OPERATOR CLASS"
+	case *Node_CreateOpFamilyStmt:
+		return "CREATE OPERATOR FAMILY"
+	case *Node_AlterOpFamilyStmt:
+		return "ALTER OPERATOR FAMILY"
+	case *Node_AlterOperatorStmt:
+		return "ALTER OPERATOR"
+	case *Node_CreateCastStmt:
+		return "CREATE CAST"
+	case *Node_CreateConversionStmt:
+		return "CREATE CONVERSION"
+	case *Node_CreateTransformStmt:
+		return "CREATE TRANSFORM"
+	case *Node_AlterCollationStmt:
+		return "ALTER COLLATION"
+	case *Node_AlterTsdictionaryStmt:
+		return "ALTER TEXT SEARCH DICTIONARY"
+	case *Node_AlterTsconfigurationStmt:
+		return "ALTER TEXT SEARCH CONFIGURATION"
+	case *Node_CreateFdwStmt:
+		return "CREATE FOREIGN DATA WRAPPER"
+	case *Node_AlterFdwStmt:
+		return "ALTER FOREIGN DATA WRAPPER"
+	case *Node_CreateForeignServerStmt:
+		return "CREATE SERVER"
+	case *Node_AlterForeignServerStmt:
+		return "ALTER SERVER"
+	case *Node_CreateForeignTableStmt:
+		return "CREATE FOREIGN TABLE"
+	case *Node_CreateUserMappingStmt:
+		return "CREATE USER MAPPING"
+	case *Node_AlterUserMappingStmt:
+		return "ALTER USER MAPPING"
+	case *Node_DropUserMappingStmt:
+		return "DROP USER MAPPING"
+	case *Node_ImportForeignSchemaStmt:
+		return "IMPORT FOREIGN SCHEMA"
+	case *Node_CreatePublicationStmt:
+		return "CREATE PUBLICATION"
+	case *Node_AlterPublicationStmt:
+		return "ALTER PUBLICATION"
+	case *Node_CreateSubscriptionStmt:
+		return "CREATE SUBSCRIPTION"
+	case *Node_AlterSubscriptionStmt:
+		return "ALTER SUBSCRIPTION"
+	case *Node_DropSubscriptionStmt:
+		return "DROP SUBSCRIPTION"
+	case *Node_CreatedbStmt:
+		return "CREATE DATABASE"
+	case *Node_AlterDatabaseStmt, *Node_AlterDatabaseSetStmt, *Node_AlterDatabaseRefreshCollStmt:
+		return "ALTER DATABASE"
+	case *Node_DropdbStmt:
+		return "DROP DATABASE"
+	case *Node_CreateTableSpaceStmt:
+		return "CREATE TABLESPACE"
+	case *Node_AlterTableSpaceOptionsStmt:
+		return "ALTER TABLESPACE"
+	case *Node_DropTableSpaceStmt:
+		return "DROP TABLESPACE"
+	case *Node_AlterSystemStmt:
+		return "ALTER SYSTEM"
+	case *Node_CommentStmt:
+		return "COMMENT"
+	case *Node_SecLabelStmt:
+		return "SECURITY LABEL"
+
+	case *Node_AlterTableStmt:
+		return alterCommandTag(n.AlterTableStmt.Objtype)
+	case *Node_AlterTableMoveAllStmt:
+		return alterCommandTag(n.AlterTableMoveAllStmt.Objtype)
+	case *Node_RenameStmt:
+		// The tag of RENAME COLUMN names the kind of its relation
+		if n.RenameStmt.RenameType == ObjectType_OBJECT_COLUMN {
+			return alterCommandTag(n.RenameStmt.RelationType)
+		}
+		return alterCommandTag(n.RenameStmt.RenameType)
+	case *Node_AlterObjectSchemaStmt:
+		return alterCommandTag(n.AlterObjectSchemaStmt.ObjectType)
+	case *Node_AlterOwnerStmt:
+		return alterCommandTag(n.AlterOwnerStmt.ObjectType)
+	case *Node_AlterObjectDependsStmt:
+		return alterCommandTag(n.AlterObjectDependsStmt.ObjectType)
+	case *Node_DropStmt:
+		if name := objectTypeTag(n.DropStmt.RemoveType); name != "???" {
+			return "DROP " + name
+		}
+	}
+	return "???"
+}
+
+// alterCommandTag returns the command tag of an ALTER statement on an object type, like
+// AlterObjectTypeCommandTag in utility.c
+func alterCommandTag(objectType ObjectType) string {
+	switch objectType {
+	case ObjectType_OBJECT_COLUMN, ObjectType_OBJECT_TABCONSTRAINT:
+		return "ALTER TABLE"
+	case ObjectType_OBJECT_ATTRIBUTE:
+		return "ALTER TYPE"
+	case ObjectType_OBJECT_DOMCONSTRAINT:
+		return "ALTER DOMAIN"
+	}
+	if name := objectTypeTag(objectType); name != "???" {
+		return "ALTER " + name
+	}
+	return "???"
+}
+
+// objectTypeTag returns the name of an object type in command tags, e.g. "MATERIALIZED VIEW"
+func objectTypeTag(objectType ObjectType) string {
+	switch objectType {
+	case ObjectType_OBJECT_ACCESS_METHOD:
+		return "ACCESS METHOD"
+	case ObjectType_OBJECT_AGGREGATE:
+		return "AGGREGATE"
+	case ObjectType_OBJECT_CAST:
+		return "CAST"
+	case ObjectType_OBJECT_COLLATION:
+		return "COLLATION"
+	case ObjectType_OBJECT_CONVERSION:
+		return "CONVERSION"
+	case ObjectType_OBJECT_DATABASE:
+		return "DATABASE"
+	case ObjectType_OBJECT_DOMAIN:
+		return "DOMAIN"
+	case ObjectType_OBJECT_EVENT_TRIGGER:
+		return "EVENT TRIGGER"
+	case ObjectType_OBJECT_EXTENSION:
+		return "EXTENSION"
+	case ObjectType_OBJECT_FDW:
+		return "FOREIGN DATA WRAPPER"
+	case ObjectType_OBJECT_FOREIGN_SERVER:
+		return "SERVER"
+	case ObjectType_OBJECT_FOREIGN_TABLE:
+		return "FOREIGN TABLE"
+	case ObjectType_OBJECT_FUNCTION:
+		return "FUNCTION"
+	case ObjectType_OBJECT_INDEX:
+		return "INDEX"
+	case ObjectType_OBJECT_LANGUAGE:
+		return "LANGUAGE"
+	case ObjectType_OBJECT_LARGEOBJECT:
+		return "LARGE OBJECT"
+	case ObjectType_OBJECT_MATVIEW:
+		return "MATERIALIZED VIEW"
+	case ObjectType_OBJECT_OPCLASS:
+		return "OPERATOR CLASS"
+	case ObjectType_OBJECT_OPERATOR:
+		return "OPERATOR"
+	case ObjectType_OBJECT_OPFAMILY:
+		return "OPERATOR FAMILY"
+	case ObjectType_OBJECT_POLICY:
+		return "POLICY"
+	case ObjectType_OBJECT_PROCEDURE:
+		return "PROCEDURE"
+	case ObjectType_OBJECT_PUBLICATION:
+		return "PUBLICATION"
+	case ObjectType_OBJECT_ROLE:
+		return "ROLE"
+	case ObjectType_OBJECT_ROUTINE:
+		return "ROUTINE"
+	case ObjectType_OBJECT_RULE:
+		return "RULE"
+	case ObjectType_OBJECT_SCHEMA:
+		return "SCHEMA"
+	case ObjectType_OBJECT_SEQUENCE:
+		return "SEQUENCE"
+	case ObjectType_OBJECT_STATISTIC_EXT:
+		return "STATISTICS"
+	case ObjectType_OBJECT_SUBSCRIPTION:
+		return "SUBSCRIPTION"
+	case ObjectType_OBJECT_TABLE:
+		return "TABLE"
+	case ObjectType_OBJECT_TABLESPACE:
+		return "TABLESPACE"
+	case ObjectType_OBJECT_TRANSFORM:
+		return "TRANSFORM"
+	case ObjectType_OBJECT_TRIGGER:
+		return "TRIGGER"
+	case ObjectType_OBJECT_TSCONFIGURATION:
+		return "TEXT SEARCH CONFIGURATION"
+	case ObjectType_OBJECT_TSDICTIONARY:
+		return "TEXT SEARCH DICTIONARY"
+	case ObjectType_OBJECT_TSPARSER:
+		return "TEXT SEARCH PARSER"
+	case ObjectType_OBJECT_TSTEMPLATE:
+		return "TEXT SEARCH TEMPLATE"
+	case ObjectType_OBJECT_TYPE:
+		return "TYPE"
+	case ObjectType_OBJECT_VIEW:
+		return "VIEW"
+	}
+	return "???"
+}
@@ -0,0 +1,91 @@
+//go:build cgo
+// +build cgo
+
+package pg_query_test
+
+import (
+	"testing"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+)
+
+var classifyTests = []struct {
+	input    string
+	expected pg_query.StatementInfo
+}{
+	{"SELECT * FROM users", pg_query.StatementInfo{Category: pg_query.CategoryDQL, ReadOnly: true, CommandTag: "SELECT"}},
+	{"VALUES (1)", pg_query.StatementInfo{Category: pg_query.CategoryDQL, ReadOnly: true, CommandTag: "SELECT"}},
+	{"SELECT * FROM users FOR UPDATE", pg_query.StatementInfo{Category: pg_query.CategoryDQL, CommandTag: "SELECT"}},
+	{"WITH d AS (DELETE FROM users RETURNING id) SELECT * FROM d", pg_query.StatementInfo{Category: pg_query.CategoryDQL, CommandTag: "SELECT"}},
+	{"SELECT * INTO copy FROM users", pg_query.StatementInfo{Category: pg_query.CategoryDDL, CommandTag: "SELECT"}},
+	{"INSERT INTO users VALUES (1)", pg_query.StatementInfo{Category: pg_query.CategoryDML, CommandTag: "INSERT"}},
+	{"UPDATE users SET name = 'a'", pg_query.StatementInfo{Category: pg_query.CategoryDML, CommandTag: "UPDATE"}},
+	{"DELETE FROM users", pg_query.StatementInfo{Category: pg_query.CategoryDML, CommandTag: "DELETE"}},
+	{"MERGE INTO users u USING orders o ON u.id = o.user_id WHEN MATCHED THEN DELETE", pg_query.StatementInfo{Category: pg_query.CategoryDML, CommandTag: "MERGE"}},
+	{"TRUNCATE users", pg_query.StatementInfo{Category: pg_query.CategoryDML, CommandTag: "TRUNCATE TABLE"}},
+	{"COPY users FROM STDIN", pg_query.StatementInfo{Category: pg_query.CategoryDML, CommandTag: "COPY"}},
+	{"COPY (SELECT * FROM users) TO STDOUT", pg_query.StatementInfo{Category: pg_query.CategoryDQL, ReadOnly: true, CommandTag: "COPY"}},
+	{"CREATE TABLE t (a int)", pg_query.StatementInfo{Category: pg_query.CategoryDDL, CommandTag: "CREATE TABLE"}},
+	{"CREATE TABLE t AS SELECT 1", pg_query.StatementInfo{Category: pg_query.CategoryDDL, CommandTag: "CREATE TABLE AS"}},
+	{"CREATE MATERIALIZED VIEW v AS SELECT 1", pg_query.StatementInfo{Category: pg_query.CategoryDDL, CommandTag: "CREATE MATERIALIZED VIEW"}},
+	{"CREATE INDEX CONCURRENTLY i ON t (a)", pg_query.StatementInfo{Category: pg_query.CategoryDDL, PreventsTransactionBlock: true, CommandTag: "CREATE INDEX"}},
+	{"ALTER TABLE t ADD COLUMN b int", pg_query.StatementInfo{Category: pg_query.CategoryDDL, CommandTag: "ALTER TABLE"}},
+	{"ALTER MATERIALIZED VIEW v RENAME COLUMN a TO b", pg_query.StatementInfo{Category: pg_query.CategoryDDL, CommandTag: "ALTER MATERIALIZED VIEW"}},
+	{"ALTER FUNCTION f() OWNER TO bob", pg_query.StatementInfo{Category: pg_query.CategoryDDL, CommandTag: "ALTER FUNCTION"}},
+	{"ALTER TABLE p DETACH PARTITION c CONCURRENTLY", pg_query.StatementInfo{Category: pg_query.CategoryDDL, PreventsTransactionBlock: true, CommandTag: "ALTER TABLE"}},
+	{"DROP VIEW IF EXISTS v", pg_query.StatementInfo{Category: pg_query.CategoryDDL, CommandTag: "DROP VIEW"}},
+	{"DROP INDEX CONCURRENTLY i", pg_query.StatementInfo{Category: pg_query.CategoryDDL, PreventsTransactionBlock: true, CommandTag: "DROP INDEX"}},
+	{"CREATE TYPE mood AS ENUM ('sad', 'ok')", pg_query.StatementInfo{Category: pg_query.CategoryDDL, CommandTag: "CREATE TYPE"}},
+	{"CREATE TEXT SEARCH DICTIONARY d (TEMPLATE = simple)", pg_query.StatementInfo{Category: pg_query.CategoryDDL, CommandTag: "CREATE TEXT SEARCH DICTIONARY"}},
+	{"CREATE PROCEDURE p() LANGUAGE sql AS 'SELECT 1'", pg_query.StatementInfo{Category: pg_query.CategoryDDL, CommandTag: "CREATE PROCEDURE"}},
+	{"COMMENT ON TABLE t IS 'x'", pg_query.StatementInfo{Category: pg_query.CategoryDDL, CommandTag: "COMMENT"}},
+	{"CREATE DATABASE d", pg_query.StatementInfo{Category: pg_query.CategoryDDL, PreventsTransactionBlock: true, CommandTag: "CREATE DATABASE"}},
+	{"ALTER SYSTEM SET work_mem = '64MB'", pg_query.StatementInfo{Category: pg_query.CategoryDDL, PreventsTransactionBlock: true, CommandTag: "ALTER SYSTEM"}},
+	{"GRANT SELECT ON users TO bob", pg_query.StatementInfo{Category: pg_query.CategoryDCL, CommandTag: "GRANT"}},
+	{"REVOKE admin FROM bob", pg_query.StatementInfo{Category: pg_query.CategoryDCL, CommandTag: "REVOKE ROLE"}},
+	{"CREATE USER bob", pg_query.StatementInfo{Category: pg_query.CategoryDCL, CommandTag: "CREATE ROLE"}},
+	{"BEGIN", pg_query.StatementInfo{Category: pg_query.CategoryTCL, ReadOnly: true, CommandTag: "BEGIN"}},
+	{"START TRANSACTION READ ONLY", pg_query.StatementInfo{Category: pg_query.CategoryTCL, ReadOnly: true, CommandTag: "START TRANSACTION"}},
+	{"ROLLBACK TO SAVEPOINT s", pg_query.StatementInfo{Category: pg_query.CategoryTCL, ReadOnly: true, RequiresTransactionBlock: true, CommandTag: "ROLLBACK"}},
+	{"COMMIT PREPARED 'x'", pg_query.StatementInfo{Category: pg_query.CategoryTCL, ReadOnly: true, PreventsTransactionBlock: true, CommandTag: "COMMIT PREPARED"}},
+	{"SET TRANSACTION ISOLATION LEVEL SERIALIZABLE", pg_query.StatementInfo{Category: pg_query.CategoryTCL, ReadOnly: true, CommandTag: "SET"}},
+	{"LOCK TABLE users IN SHARE MODE", pg_query.StatementInfo{Category: pg_query.CategoryTCL, ReadOnly: true, RequiresTransactionBlock: true, CommandTag: "LOCK TABLE"}},
+	{"SET search_path = public", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, CommandTag: "SET"}},
+	{"RESET ALL", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, CommandTag: "RESET"}},
+	{"SHOW work_mem", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, CommandTag: "SHOW"}},
+	{"EXPLAIN DELETE FROM users", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, CommandTag: "EXPLAIN"}},
+	{"EXPLAIN ANALYZE DELETE FROM users", pg_query.StatementInfo{Category: pg_query.CategoryUtility, CommandTag: "EXPLAIN"}},
+	{"EXPLAIN (ANALYZE false) DELETE FROM users", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, CommandTag: "EXPLAIN"}},
+	{"DECLARE c CURSOR FOR SELECT 1", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, RequiresTransactionBlock: true, CommandTag: "DECLARE CURSOR"}},
+	{"DECLARE c CURSOR WITH HOLD FOR SELECT 1", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, CommandTag: "DECLARE CURSOR"}},
+	{"MOVE NEXT IN c", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, CommandTag: "MOVE"}},
+	{"CLOSE ALL", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, CommandTag: "CLOSE CURSOR ALL"}},
+	{"DEALLOCATE ALL", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, CommandTag: "DEALLOCATE ALL"}},
+	{"DISCARD ALL", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, PreventsTransactionBlock: true, CommandTag: "DISCARD ALL"}},
+	{"VACUUM users", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, PreventsTransactionBlock: true, CommandTag: "VACUUM"}},
+	{"ANALYZE users", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, CommandTag: "ANALYZE"}},
+	{"REINDEX TABLE CONCURRENTLY users", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, PreventsTransactionBlock: true, CommandTag: "REINDEX"}},
+	{"EXECUTE q(1)", pg_query.StatementInfo{Category: pg_query.CategoryUtility, CommandTag: "EXECUTE"}},
+	{"CALL p()", pg_query.StatementInfo{Category: pg_query.CategoryUtility, CommandTag: "CALL"}},
+	{"CHECKPOINT", pg_query.StatementInfo{Category: pg_query.CategoryUtility, ReadOnly: true, CommandTag: "CHECKPOINT"}},
+	{"ALTER SUBSCRIPTION s REFRESH PUBLICATION", pg_query.StatementInfo{Category: pg_query.CategoryDDL, PreventsTransactionBlock: true, CommandTag: "ALTER SUBSCRIPTION"}},
+	{"ALTER SUBSCRIPTION s SET PUBLICATION p", pg_query.StatementInfo{Category: pg_query.CategoryDDL, PreventsTransactionBlock: true, CommandTag: "ALTER SUBSCRIPTION"}},
+	{"ALTER SUBSCRIPTION s ADD PUBLICATION p WITH (refresh = false)", pg_query.StatementInfo{Category: pg_query.CategoryDDL, CommandTag: "ALTER SUBSCRIPTION"}},
+	{"ALTER SUBSCRIPTION s DISABLE", pg_query.StatementInfo{Category: pg_query.CategoryDDL, CommandTag: "ALTER SUBSCRIPTION"}},
+	{"ALTER ROLE r RENAME TO s", pg_query.StatementInfo{Category: pg_query.CategoryDCL, CommandTag: "ALTER ROLE"}},
+	{"ALTER GROUP g RENAME TO h", pg_query.StatementInfo{Category: pg_query.CategoryDCL, CommandTag: "ALTER ROLE"}},
+}
+
+func TestClassify(t *testing.T) {
+	for _, test := range classifyTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+		actual := pg_query.Classify(tree.Stmts[0])
+		if actual != test.expected {
+			t.Errorf("Classify(%s)\nexpected %+v\nactual %+v\n\n", test.input, test.expected, actual)
+		}
+	}
+}
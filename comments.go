@@ -0,0 +1,151 @@
+package pg_query
+
+import (
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Comment is an SQL comment of a query parsed with ParseWithComments
+type Comment struct {
+	// Text is the comment including its delimiters, e.g. "/*+ SeqScan(t) */" or "-- note"
+	Text string
+	// Location is the byte offset of the comment in the parsed input
+	Location int32
+	// Stmt is the index of the statement the comment belongs to
+	Stmt int
+	// Node is the node the comment is placed in front of (or, when Trailing is set, after),
+	// or nil if the comment is placed in front of or after the whole statement
+	Node *Node
+	// Trailing is set for comments that follow their node, or the last token of their
+	// statement if Node is nil
+	Trailing bool
+}
+
+// canCarryComment returns whether the deparser emits the comments attached to the node
+func canCarryComment(node *Node) bool {
+	switch node.GetNode().(type) {
+	case *Node_ResTarget, *Node_RangeVar, *Node_RangeTableSample, *Node_RangeFunction,
+		*Node_RangeTableFunc, *Node_RangeSubselect, *Node_JoinExpr:
+		return true
+	}
+	return isExpr(node)
+}
+
+// nodeLocation returns the location field of the node, or -1 if it has none
+func nodeLocation(node *Node) int32 {
+	if node.GetNode() == nil {
+		return -1
+	}
+	msg := node.ProtoReflect()
+	field := msg.WhichOneof(msg.Descriptor().Oneofs().ByName("node"))
+	if field == nil {
+		return -1
+	}
+	inner := msg.Get(field).Message()
+	location := inner.Descriptor().Fields().ByName("location")
+	if location == nil || location.Kind() != protoreflect.Int32Kind {
+		return -1
+	}
+	return int32(inner.Get(location).Int())
+}
+
+// DeparseWithComments - Deparses a parse tree into SQL, re-emitting the comments returned by ParseWithComments
+//
+// Comments are written in front of the node they are attached to. Comments whose node
+// isn't part of the deparsed statement anymore (e.g. after a Rewrite) are written after
+// the statement, so no comment is lost.
+func DeparseWithComments(tree *ParseResult, comments []*Comment) (output string, err error) {
+	d := &deparser{
+		comments: map[*Node][]*Comment{},
+		emitted:  map[*Comment]bool{},
+	}
+	defer d.recover(&err)
+
+	comments = append([]*Comment(nil), comments...)
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].Location < comments[j].Location
+	})
+	stmtComments := map[int][]*Comment{}
+	for _, comment := range comments {
+		if comment.Node != nil {
+			d.comments[comment.Node] = append(d.comments[comment.Node], comment)
+		}
+		stmtComments[comment.Stmt] = append(stmtComments[comment.Stmt], comment)
+	}
+
+	for i, stmt := range tree.GetStmts() {
+		for _, comment := range stmtComments[i] {
+			if comment.Node == nil && !comment.Trailing {
+				d.comment(comment)
+			}
+		}
+		d.rawStmt(stmt)
+		for _, comment := range stmtComments[i] {
+			if !d.emitted[comment] {
+				d.trailingComment(comment)
+			}
+		}
+		d.removeTrailingSpace()
+		if i < len(tree.Stmts)-1 {
+			d.write("; ")
+		}
+	}
+
+	// Comments of statements that have been removed from the tree
+	for _, comment := range comments {
+		if !d.emitted[comment] {
+			if len(d.buf) > 0 && d.buf[len(d.buf)-1] != '\n' {
+				d.trailingComment(comment)
+			} else {
+				d.comment(comment)
+			}
+		}
+	}
+	return strings.TrimSuffix(strings.TrimRight(d.String(), " "), "\n"), nil
+}
+
+// nodeComments appends the comments placed in front of the node
+func (d *deparser) nodeComments(node *Node) {
+	for _, comment := range d.comments[node] {
+		if !comment.Trailing {
+			d.comment(comment)
+		}
+	}
+}
+
+// nodeTrailingComments appends the comments placed after the node
+func (d *deparser) nodeTrailingComments(node *Node) {
+	for _, comment := range d.comments[node] {
+		if comment.Trailing {
+			d.trailingComment(comment)
+		}
+	}
+}
+
+// trailingComment appends a comment separated by a space from the preceding output
+//
+// After a "--" comment, which ends with a line break, the space that usually follows is
+// left out, see lineComment.
+func (d *deparser) trailingComment(comment *Comment) {
+	d.removeTrailingSpace()
+	d.writeByte(' ')
+	d.write(comment.Text)
+	if strings.HasPrefix(comment.Text, "--") {
+		d.writeByte('\n')
+		d.lineComment = true
+	}
+	d.emitted[comment] = true
+}
+
+// comment appends a comment followed by a space, or by a line break for "--" comments
+func (d *deparser) comment(comment *Comment) {
+	d.write(comment.Text)
+	if strings.HasPrefix(comment.Text, "--") {
+		d.writeByte('\n')
+	} else {
+		d.writeByte(' ')
+	}
+	d.emitted[comment] = true
+}
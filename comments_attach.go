@@ -0,0 +1,149 @@
+//go:build cgo
+// +build cgo
+
+package pg_query
+
+import "sort"
+
+// attachComments assigns the comment tokens of a scanned input to the statements and
+// nodes of its parse tree
+//
+// A comment belongs to the statement it appears in, or the last statement if it comes
+// after all of them. Within the statement, a comment directly following an expression,
+// target list entry or table reference is placed after the outermost such node ending
+// there. Otherwise it is placed in front of the outermost such node starting after it,
+// unless that would move it across a keyword. Comments in front of the first node of a
+// statement precede the statement, all other comments follow it.
+func attachComments(input string, tree *ParseResult, scan *ScanResult) []*Comment {
+	tokens := &sourceTokens{source: input}
+	for _, token := range scan.GetTokens() {
+		if !isCommentToken(token) {
+			tokens.tokens = append(tokens.tokens, token)
+		}
+	}
+
+	var comments []*Comment
+	var targets *commentTargets
+	stmts := tree.GetStmts()
+	stmt := 0
+	// next is the index of the first token in tokens after the current comment
+	next := 0
+	for _, token := range scan.GetTokens() {
+		if !isCommentToken(token) {
+			next++
+			continue
+		}
+
+		comment := &Comment{
+			Text:     input[token.Start:token.End],
+			Location: token.Start,
+		}
+		comments = append(comments, comment)
+		if len(stmts) == 0 {
+			continue
+		}
+
+		for stmt+1 < len(stmts) && stmts[stmt+1].StmtLocation <= token.Start {
+			stmt++
+		}
+		comment.Stmt = stmt
+		if targets == nil || targets.stmt != stmts[stmt] {
+			targets = newCommentTargets(tokens, stmts[stmt])
+		}
+		targets.attach(comment, next)
+	}
+	return comments
+}
+
+func isCommentToken(token *ScanToken) bool {
+	return token.Token == Token_SQL_COMMENT || token.Token == Token_C_COMMENT
+}
+
+// commentTargets holds the nodes of a statement that comments can be attached to
+type commentTargets struct {
+	tokens *sourceTokens
+	stmt   *RawStmt
+	// first and end are the index of the first token of the statement and the index after its last
+	first, end int
+	// nodes are the nodes that can carry a comment, with outer nodes before their children
+	nodes []*Node
+	// sorted are the nodes ordered by location, with outer nodes first at the same location
+	sorted    []*Node
+	locations []int32
+	// ends maps the end offsets of the nodes to the outermost node ending there, see endingAt
+	ends map[int]*Node
+}
+
+func newCommentTargets(tokens *sourceTokens, stmt *RawStmt) *commentTargets {
+	c := &commentTargets{tokens: tokens, stmt: stmt}
+	c.first = tokens.at(int(stmt.StmtLocation))
+	c.end = len(tokens.tokens)
+	if stmt.StmtLen > 0 {
+		c.end = tokens.at(int(stmt.StmtLocation + stmt.StmtLen))
+	}
+
+	_ = Walk(func(node *Node) (bool, error) {
+		if canCarryComment(node) && nodeLocation(node) >= 0 {
+			c.nodes = append(c.nodes, node)
+		}
+		return true, nil
+	}, stmt.Stmt)
+	c.sorted = append([]*Node(nil), c.nodes...)
+	sort.SliceStable(c.sorted, func(i, j int) bool {
+		return nodeLocation(c.sorted[i]) < nodeLocation(c.sorted[j])
+	})
+	c.locations = make([]int32, len(c.sorted))
+	for i, node := range c.sorted {
+		c.locations[i] = nodeLocation(node)
+	}
+	return c
+}
+
+// attach places a comment followed by the token with index next
+func (c *commentTargets) attach(comment *Comment, next int) {
+	switch {
+	case next >= c.end:
+		comment.Trailing = true
+		return
+	case next <= c.first:
+		return
+	}
+
+	if node := c.endingAt(int(c.tokens.tokens[next-1].End)); node != nil {
+		comment.Node, comment.Trailing = node, true
+		return
+	}
+
+	i := sort.Search(len(c.locations), func(i int) bool {
+		return c.locations[i] >= c.tokens.tokens[next].Start
+	})
+	if i < len(c.locations) && !c.keywordBefore(next, c.locations[i]) {
+		comment.Node = c.sorted[i]
+		return
+	}
+	comment.Trailing = len(c.locations) == 0 || c.locations[0] < comment.Location
+}
+
+// endingAt returns the outermost node whose span ends at the given offset, or nil
+func (c *commentTargets) endingAt(offset int) *Node {
+	if c.ends == nil {
+		c.ends = map[int]*Node{}
+		for _, node := range c.nodes {
+			if _, end := c.tokens.span(node); end >= 0 && c.ends[end] == nil {
+				c.ends[end] = node
+			}
+		}
+	}
+	return c.ends[offset]
+}
+
+// keywordBefore returns whether there is a keyword between the token with index next and
+// the given location
+func (c *commentTargets) keywordBefore(next int, location int32) bool {
+	for i := next; i < c.end && c.tokens.tokens[i].Start < location; i++ {
+		if c.tokens.tokens[i].KeywordKind != KeywordKind_NO_KEYWORD {
+			return true
+		}
+	}
+	return false
+}
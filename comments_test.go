@@ -0,0 +1,104 @@
+//go:build cgo
+// +build cgo
+
+package pg_query_test
+
+import (
+	"testing"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+)
+
+var commentTests = []struct {
+	input    string
+	expected string
+}{
+	{
+		"SELECT /*+ IndexScan(t) */ * FROM t WHERE a = 1",
+		"SELECT /*+ IndexScan(t) */ * FROM t WHERE a = 1",
+	},
+	{
+		"SELECT * FROM t WHERE a = 1 /*controller='index',framework='django'*/",
+		"SELECT * FROM t WHERE a = 1 /*controller='index',framework='django'*/",
+	},
+	{
+		"-- leading\nselect a, -- second\n b from /* t */ t where /* w */ x = 1",
+		"-- leading\nSELECT a, -- second\nb FROM /* t */ t WHERE /* w */ x = 1",
+	},
+	{
+		"SELECT 1; /* second */ SELECT 2; -- end",
+		"SELECT 1; /* second */ SELECT 2 -- end",
+	},
+	{
+		"SELECT a FROM t ORDER BY /* o */ b LIMIT /* l */ 5",
+		"SELECT a FROM t ORDER BY /* o */ b LIMIT /* l */ 5",
+	},
+	{
+		// Comments in front of nodes that don't carry comments move behind the statement
+		"CREATE TABLE x (a int /* a */, b text)",
+		"CREATE TABLE x (a int, b text) /* a */",
+	},
+	{
+		"-- no statement",
+		"-- no statement",
+	},
+	{
+		// Hints after the statement keyword stay in front of the first node
+		"INSERT /*+ Rows(t #10) */ INTO t (a) VALUES (1) -- end",
+		"/*+ Rows(t #10) */ INSERT INTO t (a) VALUES (1) -- end",
+	},
+	{
+		"SELECT a /* c */ FROM t",
+		"SELECT a /* c */ FROM t",
+	},
+	{
+		"SELECT a AS x -- x\n, f(b) /* f */\nFROM t u /* u */ WHERE a /* a */ = 1",
+		"SELECT a AS x -- x\n, f(b) /* f */ FROM t u /* u */ WHERE a /* a */ = 1",
+	},
+	{
+		"SELECT a FROM t WHERE /* w */ (b = 1 OR c = 2)",
+		"SELECT a FROM t WHERE /* w */ b = 1 OR c = 2",
+	},
+}
+
+func TestDeparseWithComments(t *testing.T) {
+	for _, test := range commentTests {
+		tree, comments, err := pg_query.ParseWithComments(test.input)
+		if err != nil {
+			t.Errorf("ParseWithComments(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		actual, err := pg_query.DeparseWithComments(tree, comments)
+		if err != nil {
+			t.Errorf("DeparseWithComments(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		if actual != test.expected {
+			t.Errorf("DeparseWithComments(%s)\nexpected %s\nactual %s\n\n", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestParseWithComments(t *testing.T) {
+	input := "/* a */ SELECT x /* b */ FROM t /* c */"
+	tree, comments, err := pg_query.ParseWithComments(input)
+	if err != nil {
+		t.Fatalf("ParseWithComments(%s)\nerror %s\n\n", input, err)
+	}
+	if len(comments) != 3 {
+		t.Fatalf("ParseWithComments(%s)\nexpected 3 comments\nactual %d\n\n", input, len(comments))
+	}
+
+	if c := comments[0]; c.Text != "/* a */" || c.Location != 0 || c.Node != nil || c.Trailing {
+		t.Errorf("ParseWithComments(%s)\nexpected leading comment /* a */\nactual %+v\n\n", input, c)
+	}
+	resTarget := tree.Stmts[0].Stmt.GetSelectStmt().TargetList[0]
+	if c := comments[1]; c.Text != "/* b */" || c.Location != 17 || c.Node != resTarget || !c.Trailing {
+		t.Errorf("ParseWithComments(%s)\nexpected /* b */ following the target list entry\nactual %+v\n\n", input, c)
+	}
+	if c := comments[2]; c.Text != "/* c */" || c.Node != nil || !c.Trailing {
+		t.Errorf("ParseWithComments(%s)\nexpected trailing comment /* c */\nactual %+v\n\n", input, c)
+	}
+}
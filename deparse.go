@@ -0,0 +1,1341 @@
+package pg_query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//go:generate go run ./internal/keywordgen -output keywords.go
+
+// Deparse - Deparses a given Go parse tree into a SQL statement
+//
+// The parse tree is rendered in Go, producing the same output as the deparser of libpg_query
+// (parser/postgres_deparse.c), so this neither requires cgo nor a protobuf round trip.
+func Deparse(tree *ParseResult) (output string, err error) {
+	d := &deparser{}
+	defer d.recover(&err)
+
+	for i, stmt := range tree.GetStmts() {
+		d.rawStmt(stmt)
+		if i < len(tree.Stmts)-1 {
+			d.write("; ")
+		}
+	}
+	return d.String(), nil
+}
+
+// DeparseNode - Deparses a single node of a parse tree into SQL
+//
+// Besides statements this supports expressions, target list entries (ResTarget, rendered
+// as in a SELECT list), table references such as RangeVar and JoinExpr, type names and
+// clauses like SortBy, WindowDef, WithClause or OnConflictClause. A List is deparsed as
+// a comma-separated expression list.
+func DeparseNode(node *Node) (output string, err error) {
+	d := &deparser{}
+	defer d.recover(&err)
+
+	d.node(node)
+	return d.String(), nil
+}
+
+// deparseContext describes the parent of a node, where that influences its output
+type deparseContext int
+
+const (
+	deparseContextNone deparseContext = iota
+	// Parent node type (and sometimes field)
+	deparseContextInsertRelation
+	deparseContextInsertOnConflict
+	deparseContextUpdate
+	deparseContextReturning
+	deparseContextAExpr
+	deparseContextXMLAttributes
+	deparseContextXMLNamespaces
+	deparseContextCreateType
+	deparseContextAlterType
+	deparseContextSetStatement
+	deparseContextFuncExpr
+	// Identifier vs constant context
+	deparseContextIdentifier
+	deparseContextConstant
+)
+
+// deparseError is raised (through panic) when a node can't be deparsed
+type deparseError struct {
+	msg string
+}
+
+func (e *deparseError) Error() string {
+	return "deparse: " + e.msg
+}
+
+// deparser renders parse tree nodes to SQL. Its methods are named after the
+// corresponding functions (and grammar rules) of parser/postgres_deparse.c.
+type deparser struct {
+	buf []byte
+	// format enables line breaks and indentation, see Format
+	format *FormatOptions
+	// indent is the current indentation level of formatted output
+	indent int
+	// lineStart is the start of the line that the output has to fit on when formatting, or -1,
+	// see singleLine
+	lineStart int
+	// comments are written in front of the nodes they are attached to, see DeparseWithComments
+	comments map[*Node][]*Comment
+	emitted  map[*Comment]bool
+	// lineComment is set after a trailing "--" comment, to leave out the space written next
+	lineComment bool
+}
+
+func (d *deparser) String() string {
+	return string(d.buf)
+}
+
+func (d *deparser) write(s string) {
+	if d.lineComment {
+		d.lineComment = false
+		s = strings.TrimPrefix(s, " ")
+	}
+	d.buf = append(d.buf, s...)
+	if d.format != nil {
+		d.checkLine()
+	}
+}
+
+func (d *deparser) writeByte(c byte) {
+	if d.lineComment {
+		d.lineComment = false
+		if c == ' ' {
+			return
+		}
+	}
+	d.buf = append(d.buf, c)
+	if d.format != nil {
+		d.checkLine()
+	}
+}
+
+func (d *deparser) writef(format string, args ...interface{}) {
+	d.buf = append(d.buf, fmt.Sprintf(format, args...)...)
+	if d.format != nil {
+		d.checkLine()
+	}
+}
+
+func (d *deparser) removeTrailingSpace() {
+	if len(d.buf) >= 1 && d.buf[len(d.buf)-1] == ' ' {
+		d.buf = d.buf[:len(d.buf)-1]
+	}
+}
+
+func (d *deparser) fail(format string, args ...interface{}) {
+	panic(&deparseError{msg: fmt.Sprintf(format, args...)})
+}
+
+// recover turns a deparseError raised while deparsing into the returned error
+func (d *deparser) recover(err *error) {
+	if r := recover(); r != nil {
+		e, ok := r.(*deparseError)
+		if !ok {
+			panic(r)
+		}
+		*err = e
+	}
+}
+
+// defElem returns the DefElem of an option list item
+func (d *deparser) defElem(node *Node) *DefElem {
+	defElem := node.GetDefElem()
+	if defElem == nil {
+		d.fail("unexpected node in option list: %s", nodeName(node))
+	}
+	return defElem
+}
+
+// nodeName returns the name of the node type, for use in error messages
+func nodeName(node *Node) string {
+	if node == nil || node.Node == nil {
+		return "<nil>"
+	}
+	m := node.ProtoReflect()
+	return string(m.WhichOneof(m.Descriptor().Oneofs().ByName("node")).Message().Name())
+}
+
+func strVal(node *Node) string {
+	return node.GetString_().GetSval()
+}
+
+func intVal(node *Node) int32 {
+	return node.GetInteger().GetIval()
+}
+
+func boolVal(node *Node) bool {
+	return node.GetBoolean().GetBoolval()
+}
+
+// quoteIdentifier quotes an identifier if needed, like quote_identifier in ruleutils.c
+func quoteIdentifier(ident string) string {
+	safe := ident != "" && (ident[0] >= 'a' && ident[0] <= 'z' || ident[0] == '_')
+	quotes := 0
+	for i := 0; i < len(ident); i++ {
+		ch := ident[i]
+		if ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9' || ch == '_' {
+			continue
+		}
+		safe = false
+		if ch == '"' {
+			quotes++
+		}
+	}
+	if safe {
+		if kind, ok := keywords[ident]; ok && kind != KeywordKind_UNRESERVED_KEYWORD {
+			safe = false
+		}
+	}
+	if safe {
+		return ident
+	}
+	if quotes == 0 {
+		return `"` + ident + `"`
+	}
+	return `"` + strings.Replace(ident, `"`, `""`, -1) + `"`
+}
+
+// isReservedKeyword checks whether the value is a reserved keyword, to determine escaping for output
+//
+// Note that since the parser lowercases all keywords, this does *not* match when the
+// value is not all-lowercase and a reserved keyword.
+func isReservedKeyword(val string) bool {
+	for i := 0; i < len(val); i++ {
+		ch := val[i]
+		if !(ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9' || ch == '_') {
+			return false
+		}
+	}
+	return keywords[val] == KeywordKind_RESERVED_KEYWORD
+}
+
+// isOp returns whether the given value consists only of operator characters
+func isOp(val string) bool {
+	for i := 0; i < len(val); i++ {
+		if !strings.ContainsRune("~!@#^&|`?+-*/%<>=", rune(val[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// stringLiteral appends a SQL string literal, using E'...' escape syntax if the value contains backslashes
+func (d *deparser) stringLiteral(val string) {
+	if strings.Contains(val, `\`) {
+		d.writeByte('E')
+	}
+	d.writeByte('\'')
+	for i := 0; i < len(val); i++ {
+		ch := val[i]
+		if ch == '\'' || ch == '\\' {
+			d.writeByte(ch)
+		}
+		d.writeByte(ch)
+	}
+	d.writeByte('\'')
+}
+
+// "any_name" in gram.y
+func (d *deparser) anyName(parts []*Node) {
+	for i, part := range parts {
+		d.write(quoteIdentifier(strVal(part)))
+		if i < len(parts)-1 {
+			d.writeByte('.')
+		}
+	}
+}
+
+func (d *deparser) anyNameSkipFirst(parts []*Node) {
+	if len(parts) > 0 {
+		d.anyName(parts[1:])
+	}
+}
+
+func (d *deparser) anyNameSkipLast(parts []*Node) {
+	if len(parts) > 0 {
+		d.anyName(parts[:len(parts)-1])
+	}
+}
+
+// "func_expr" in gram.y
+func (d *deparser) funcExpr(node *Node) {
+	switch n := node.Node.(type) {
+	case *Node_FuncCall:
+		d.funcCall(n.FuncCall)
+	case *Node_SqlvalueFunction:
+		d.sqlValueFunction(n.SqlvalueFunction)
+	case *Node_MinMaxExpr:
+		d.minMaxExpr(n.MinMaxExpr)
+	case *Node_CoalesceExpr:
+		d.coalesceExpr(n.CoalesceExpr)
+	case *Node_XmlExpr:
+		d.xmlExpr(n.XmlExpr)
+	case *Node_XmlSerialize:
+		d.xmlSerialize(n.XmlSerialize)
+	case *Node_JsonObjectAgg:
+		d.jsonObjectAgg(n.JsonObjectAgg)
+	case *Node_JsonArrayAgg:
+		d.jsonArrayAgg(n.JsonArrayAgg)
+	case *Node_JsonObjectConstructor:
+		d.jsonObjectConstructor(n.JsonObjectConstructor)
+	case *Node_JsonArrayConstructor:
+		d.jsonArrayConstructor(n.JsonArrayConstructor)
+	case *Node_JsonArrayQueryConstructor:
+		d.jsonArrayQueryConstructor(n.JsonArrayQueryConstructor)
+	default:
+		d.fail("unpermitted node type in func_expr: %s", nodeName(node))
+	}
+}
+
+// isFuncExpr returns whether the node is handled by funcExpr
+func isFuncExpr(node *Node) bool {
+	switch node.Node.(type) {
+	case *Node_FuncCall, *Node_SqlvalueFunction, *Node_MinMaxExpr, *Node_CoalesceExpr,
+		*Node_XmlExpr, *Node_XmlSerialize, *Node_JsonObjectAgg, *Node_JsonArrayAgg,
+		*Node_JsonObjectConstructor, *Node_JsonArrayConstructor, *Node_JsonArrayQueryConstructor:
+		return true
+	}
+	return false
+}
+
+// isExpr returns whether the node is handled by expr
+func isExpr(node *Node) bool {
+	switch node.Node.(type) {
+	case *Node_ColumnRef, *Node_AConst, *Node_ParamRef, *Node_AIndirection, *Node_CaseExpr,
+		*Node_SubLink, *Node_AArrayExpr, *Node_RowExpr, *Node_GroupingFunc, *Node_TypeCast,
+		*Node_CollateClause, *Node_AExpr, *Node_BoolExpr, *Node_NullTest, *Node_BooleanTest,
+		*Node_JsonIsPredicate, *Node_SetToDefault:
+		return true
+	}
+	return isFuncExpr(node)
+}
+
+// node deparses any node supported by DeparseNode
+func (d *deparser) node(node *Node) {
+	if node.GetNode() == nil {
+		d.fail("empty node")
+	}
+
+	if isExpr(node) {
+		d.expr(node)
+		return
+	}
+
+	switch n := node.Node.(type) {
+	case *Node_List:
+		d.exprList(n.List.Items)
+	case *Node_ResTarget:
+		d.targetList([]*Node{node})
+	case *Node_RangeVar, *Node_RangeTableSample, *Node_RangeFunction, *Node_RangeTableFunc,
+		*Node_RangeSubselect, *Node_JoinExpr:
+		d.tableRef(node)
+	case *Node_TypeName:
+		d.typeName(n.TypeName)
+	case *Node_Alias:
+		d.alias(n.Alias)
+	case *Node_SortBy:
+		d.sortBy(n.SortBy)
+	case *Node_WindowDef:
+		d.windowDef(n.WindowDef)
+	case *Node_GroupingSet:
+		d.groupingSet(n.GroupingSet)
+	case *Node_WithClause:
+		d.withClause(n.WithClause)
+	case *Node_CommonTableExpr:
+		d.commonTableExpr(n.CommonTableExpr)
+	case *Node_IntoClause:
+		d.intoClause(n.IntoClause)
+	case *Node_OnConflictClause:
+		d.onConflictClause(n.OnConflictClause)
+	case *Node_LockingClause:
+		d.lockingClause(n.LockingClause)
+	case *Node_ColumnDef:
+		d.columnDef(n.ColumnDef)
+	case *Node_Constraint:
+		d.constraint(n.Constraint)
+	case *Node_IndexElem:
+		d.indexElem(n.IndexElem)
+	case *Node_RoleSpec:
+		d.roleSpec(n.RoleSpec)
+	case *Node_AccessPriv:
+		d.accessPriv(n.AccessPriv)
+	case *Node_FunctionParameter:
+		d.functionParameter(n.FunctionParameter)
+	case *Node_PartitionSpec:
+		d.partitionSpec(n.PartitionSpec)
+	case *Node_PartitionBoundSpec:
+		d.partitionBoundSpec(n.PartitionBoundSpec)
+	case *Node_RawStmt:
+		d.rawStmt(n.RawStmt)
+	default:
+		d.stmt(node)
+	}
+}
+
+// "a_expr" in gram.y
+func (d *deparser) expr(node *Node) {
+	if node == nil {
+		return
+	}
+	d.nodeComments(node)
+	switch n := node.Node.(type) {
+	case *Node_ColumnRef, *Node_AConst, *Node_ParamRef, *Node_AIndirection, *Node_CaseExpr,
+		*Node_SubLink, *Node_AArrayExpr, *Node_RowExpr, *Node_GroupingFunc:
+		d.cExpr(node)
+	case *Node_TypeCast:
+		d.typeCast(n.TypeCast, deparseContextNone)
+	case *Node_CollateClause:
+		d.collateClause(n.CollateClause)
+	case *Node_AExpr:
+		d.aExpr(n.AExpr, deparseContextNone)
+	case *Node_BoolExpr:
+		d.boolExpr(n.BoolExpr)
+	case *Node_NullTest:
+		d.nullTest(n.NullTest)
+	case *Node_BooleanTest:
+		d.booleanTest(n.BooleanTest)
+	case *Node_JsonIsPredicate:
+		d.jsonIsPredicate(n.JsonIsPredicate)
+	case *Node_SetToDefault:
+		d.write("DEFAULT")
+	default:
+		if !isFuncExpr(node) {
+			// Note that this is also the fallthrough for bExpr and cExpr
+			d.fail("unpermitted node type in a_expr/b_expr/c_expr: %s", nodeName(node))
+		}
+		d.funcExpr(node)
+	}
+	d.nodeTrailingComments(node)
+}
+
+// "b_expr" in gram.y
+func (d *deparser) bExpr(node *Node) {
+	switch n := node.Node.(type) {
+	case *Node_XmlExpr:
+		d.xmlExpr(n.XmlExpr)
+		return
+	case *Node_AExpr:
+		// Other kinds are handled by "c_expr", with parens added around them
+		if k := n.AExpr.Kind; k == A_Expr_Kind_AEXPR_OP || k == A_Expr_Kind_AEXPR_DISTINCT || k == A_Expr_Kind_AEXPR_NOT_DISTINCT {
+			d.aExpr(n.AExpr, deparseContextNone)
+			return
+		}
+	case *Node_BoolExpr:
+		if n.BoolExpr.Boolop == BoolExprType_NOT_EXPR {
+			d.boolExpr(n.BoolExpr)
+			return
+		}
+	}
+	d.cExpr(node)
+}
+
+// "c_expr" in gram.y
+func (d *deparser) cExpr(node *Node) {
+	switch n := node.Node.(type) {
+	case *Node_ColumnRef:
+		d.columnRef(n.ColumnRef)
+	case *Node_AConst:
+		d.aConst(n.AConst)
+	case *Node_ParamRef:
+		d.paramRef(n.ParamRef)
+	case *Node_AIndirection:
+		d.aIndirection(n.AIndirection)
+	case *Node_CaseExpr:
+		d.caseExpr(n.CaseExpr)
+	case *Node_SubLink:
+		d.subLink(n.SubLink)
+	case *Node_AArrayExpr:
+		d.aArrayExpr(n.AArrayExpr)
+	case *Node_RowExpr:
+		d.rowExpr(n.RowExpr)
+	case *Node_GroupingFunc:
+		d.groupingFunc(n.GroupingFunc)
+	default:
+		if isFuncExpr(node) {
+			d.funcExpr(node)
+			return
+		}
+		d.writeByte('(')
+		d.expr(node)
+		d.writeByte(')')
+	}
+}
+
+// "expr_list" in gram.y
+func (d *deparser) exprList(exprs []*Node) {
+	for i, expr := range exprs {
+		d.expr(expr)
+		if i < len(exprs)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "ColId", "name", "database_name", "access_method" and "index_name" in gram.y
+func (d *deparser) colID(s string) {
+	d.write(quoteIdentifier(s))
+}
+
+// "ColLabel", "attr_name"
+//
+// Note this is kept separate from ColId in case we ever want to be more
+// specific on how to handle keywords here
+func (d *deparser) colLabel(s string) {
+	d.write(quoteIdentifier(s))
+}
+
+// "SignedIconst" and "Iconst" in gram.y
+func (d *deparser) signedIconst(node *Node) {
+	d.write(strconv.Itoa(int(intVal(node))))
+}
+
+// "indirection" and "opt_indirection" in gram.y
+func (d *deparser) optIndirection(indirection []*Node, n int) {
+	for i := n; i < len(indirection); i++ {
+		switch item := indirection[i].Node.(type) {
+		case *Node_String_:
+			d.writeByte('.')
+			d.colLabel(item.String_.Sval)
+		case *Node_AStar:
+			d.write(".*")
+		case *Node_AIndices:
+			d.aIndices(item.AIndices)
+		default:
+			d.fail("unpermitted node type in indirection: %s", nodeName(indirection[i]))
+		}
+	}
+}
+
+// "role_list" in gram.y
+func (d *deparser) roleList(roles []*Node) {
+	for i, role := range roles {
+		d.roleSpec(role.GetRoleSpec())
+		if i < len(roles)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "NumericOnly" in gram.y
+func (d *deparser) numericOnly(value *Node) {
+	switch v := value.GetNode().(type) {
+	case *Node_Integer:
+		d.write(strconv.Itoa(int(v.Integer.Ival)))
+	case *Node_Float:
+		d.write(v.Float.Fval)
+	default:
+		d.fail("unpermitted node type in NumericOnly: %s", nodeName(value))
+	}
+}
+
+// "NumericOnly_list" in gram.y
+func (d *deparser) numericOnlyList(l []*Node) {
+	for i, item := range l {
+		d.numericOnly(item)
+		if i < len(l)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "SeqOptElem" in gram.y
+func (d *deparser) seqOptElem(defElem *DefElem) {
+	switch {
+	case defElem.Defname == "as":
+		d.write("AS ")
+		d.typeName(defElem.Arg.GetTypeName())
+	case defElem.Defname == "cache":
+		d.write("CACHE ")
+		d.numericOnly(defElem.Arg)
+	case defElem.Defname == "cycle" && boolVal(defElem.Arg):
+		d.write("CYCLE")
+	case defElem.Defname == "cycle":
+		d.write("NO CYCLE")
+	case defElem.Defname == "increment":
+		d.write("INCREMENT ")
+		d.numericOnly(defElem.Arg)
+	case defElem.Defname == "maxvalue" && defElem.Arg != nil:
+		d.write("MAXVALUE ")
+		d.numericOnly(defElem.Arg)
+	case defElem.Defname == "maxvalue":
+		d.write("NO MAXVALUE")
+	case defElem.Defname == "minvalue" && defElem.Arg != nil:
+		d.write("MINVALUE ")
+		d.numericOnly(defElem.Arg)
+	case defElem.Defname == "minvalue":
+		d.write("NO MINVALUE")
+	case defElem.Defname == "owned_by":
+		d.write("OWNED BY ")
+		d.anyName(defElem.Arg.GetList().GetItems())
+	case defElem.Defname == "sequence_name":
+		d.write("SEQUENCE NAME ")
+		d.anyName(defElem.Arg.GetList().GetItems())
+	case defElem.Defname == "start":
+		d.write("START ")
+		d.numericOnly(defElem.Arg)
+	case defElem.Defname == "restart" && defElem.Arg == nil:
+		d.write("RESTART")
+	case defElem.Defname == "restart":
+		d.write("RESTART ")
+		d.numericOnly(defElem.Arg)
+	default:
+		d.fail("unsupported sequence option: %s", defElem.Defname)
+	}
+}
+
+// "SeqOptList" in gram.y
+func (d *deparser) seqOptList(options []*Node) {
+	for _, option := range options {
+		d.seqOptElem(d.defElem(option))
+		d.writeByte(' ')
+	}
+}
+
+// "OptSeqOptList" in gram.y
+func (d *deparser) optSeqOptList(options []*Node) {
+	if len(options) > 0 {
+		d.seqOptList(options)
+	}
+}
+
+// "OptParenthesizedSeqOptList" in gram.y
+func (d *deparser) optParenthesizedSeqOptList(options []*Node) {
+	if len(options) > 0 {
+		d.writeByte('(')
+		d.seqOptList(options)
+		d.writeByte(')')
+	}
+}
+
+// "opt_drop_behavior" in gram.y
+func (d *deparser) optDropBehavior(behavior DropBehavior) {
+	if behavior == DropBehavior_DROP_CASCADE {
+		d.write("CASCADE ")
+	}
+}
+
+// "any_operator" in gram.y
+func (d *deparser) anyOperator(op []*Node) {
+	switch len(op) {
+	case 2:
+		d.write(quoteIdentifier(strVal(op[0])))
+		d.writeByte('.')
+		d.write(strVal(op[1]))
+	case 1:
+		d.write(strVal(op[0]))
+	default:
+		d.fail("invalid operator name with %d parts", len(op))
+	}
+}
+
+// "qual_Op" and "qual_all_Op" in gram.y
+func (d *deparser) qualOp(op []*Node) {
+	if len(op) == 1 && isOp(strVal(op[0])) {
+		d.write(strVal(op[0]))
+	} else {
+		d.write("OPERATOR(")
+		d.anyOperator(op)
+		d.write(")")
+	}
+}
+
+// "subquery_Op" in gram.y
+func (d *deparser) subqueryOp(op []*Node) {
+	if len(op) == 1 {
+		switch name := strVal(op[0]); {
+		case name == "~~":
+			d.write("LIKE")
+			return
+		case name == "!~~":
+			d.write("NOT LIKE")
+			return
+		case name == "~~*":
+			d.write("ILIKE")
+			return
+		case name == "!~~*":
+			d.write("NOT ILIKE")
+			return
+		case isOp(name):
+			d.write(name)
+			return
+		}
+	}
+	d.write("OPERATOR(")
+	d.anyOperator(op)
+	d.write(")")
+}
+
+// Not present directly in gram.y (usually matched by ColLabel)
+func (d *deparser) genericDefElemName(in string) {
+	d.write(strings.ToUpper(in))
+}
+
+// "def_arg" and "operator_def_arg" in gram.y
+func (d *deparser) defArg(arg *Node, isOperatorDefArg bool) {
+	switch a := arg.Node.(type) {
+	case *Node_TypeName: // func_type
+		d.typeName(a.TypeName)
+	case *Node_List: // qual_all_Op
+		l := a.List.Items
+		// Schema qualified operator
+		if len(l) == 2 {
+			d.write("OPERATOR(")
+			d.anyOperator(l)
+			d.writeByte(')')
+		} else if len(l) == 1 {
+			d.write(strVal(l[0]))
+		}
+	case *Node_Float, *Node_Integer: // NumericOnly
+		d.value(arg, deparseContextNone)
+	case *Node_String_:
+		s := a.String_.Sval
+		if !isOperatorDefArg && s == "none" { // NONE
+			d.write("NONE")
+		} else if isReservedKeyword(s) { // reserved_keyword
+			d.write(s)
+		} else { // Sconst
+			d.stringLiteral(s)
+		}
+	default:
+		d.fail("unpermitted node type in def_arg: %s", nodeName(arg))
+	}
+}
+
+// "definition" in gram.y
+func (d *deparser) definition(options []*Node) {
+	d.writeByte('(')
+	for i, option := range options {
+		defElem := d.defElem(option)
+		d.write(quoteIdentifier(defElem.Defname))
+		if defElem.Arg != nil {
+			d.write(" = ")
+			d.defArg(defElem.Arg, false)
+		}
+		if i < len(options)-1 {
+			d.write(", ")
+		}
+	}
+	d.writeByte(')')
+}
+
+// "opt_definition" in gram.y
+//
+// Note this method adds a trailing space if a value is output
+func (d *deparser) optDefinition(options []*Node) {
+	if len(options) > 0 {
+		d.write("WITH ")
+		d.definition(options)
+	}
+}
+
+// "create_generic_options" in gram.y
+func (d *deparser) createGenericOptions(options []*Node) {
+	if len(options) == 0 {
+		return
+	}
+	d.write("OPTIONS (")
+	for i, option := range options {
+		defElem := d.defElem(option)
+		d.write(quoteIdentifier(defElem.Defname))
+		d.writeByte(' ')
+		d.stringLiteral(strVal(defElem.Arg))
+		if i < len(options)-1 {
+			d.write(", ")
+		}
+	}
+	d.write(")")
+}
+
+// "common_func_opt_item" in gram.y
+func (d *deparser) commonFuncOptItem(defElem *DefElem) {
+	switch {
+	case defElem.Defname == "strict" && boolVal(defElem.Arg):
+		d.write("RETURNS NULL ON NULL INPUT")
+	case defElem.Defname == "strict":
+		d.write("CALLED ON NULL INPUT")
+	case defElem.Defname == "volatility" && strVal(defElem.Arg) == "immutable":
+		d.write("IMMUTABLE")
+	case defElem.Defname == "volatility" && strVal(defElem.Arg) == "stable":
+		d.write("STABLE")
+	case defElem.Defname == "volatility" && strVal(defElem.Arg) == "volatile":
+		d.write("VOLATILE")
+	case defElem.Defname == "security" && boolVal(defElem.Arg):
+		d.write("SECURITY DEFINER")
+	case defElem.Defname == "security":
+		d.write("SECURITY INVOKER")
+	case defElem.Defname == "leakproof" && boolVal(defElem.Arg):
+		d.write("LEAKPROOF")
+	case defElem.Defname == "leakproof":
+		d.write("NOT LEAKPROOF")
+	case defElem.Defname == "cost":
+		d.write("COST ")
+		d.value(defElem.Arg, deparseContextNone)
+	case defElem.Defname == "rows":
+		d.write("ROWS ")
+		d.value(defElem.Arg, deparseContextNone)
+	case defElem.Defname == "support":
+		d.write("SUPPORT ")
+		d.anyName(defElem.Arg.GetList().GetItems())
+	case defElem.Defname == "set" && defElem.Arg.GetVariableSetStmt() != nil: // FunctionSetResetClause
+		d.variableSetStmt(defElem.Arg.GetVariableSetStmt())
+	case defElem.Defname == "parallel":
+		d.write("PARALLEL ")
+		d.write(quoteIdentifier(strVal(defElem.Arg)))
+	default:
+		d.fail("unsupported function option: %s", defElem.Defname)
+	}
+}
+
+// namedatalen is the maximum identifier length (including the terminating NUL byte)
+const namedatalen = 64
+
+// "NonReservedWord_or_Sconst" in gram.y
+//
+// Note since both identifiers and string constants are allowed here, we
+// currently always return an identifier, except:
+//
+// 1) when the string is empty (since an empty identifier can't be scanned)
+// 2) when the value is equal or larger than NAMEDATALEN (64+ characters)
+func (d *deparser) nonReservedWordOrSconst(val string) {
+	if len(val) == 0 {
+		d.write("''")
+	} else if len(val) >= namedatalen {
+		d.stringLiteral(val)
+	} else {
+		d.write(quoteIdentifier(val))
+	}
+}
+
+// "func_as" in gram.y
+func (d *deparser) funcAs(l []*Node) {
+	for i, item := range l {
+		s := strVal(item)
+		if !strings.Contains(s, "$$") {
+			d.write("$$")
+			d.write(s)
+			d.write("$$")
+		} else {
+			d.stringLiteral(s)
+		}
+		if i < len(l)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "createfunc_opt_item" in gram.y
+func (d *deparser) createFuncOptItem(defElem *DefElem) {
+	switch defElem.Defname {
+	case "as":
+		d.write("AS ")
+		d.funcAs(defElem.Arg.GetList().GetItems())
+	case "language":
+		d.write("LANGUAGE ")
+		d.nonReservedWordOrSconst(strVal(defElem.Arg))
+	case "transform":
+		l := defElem.Arg.GetList().GetItems()
+		d.write("TRANSFORM ")
+		for i, item := range l {
+			d.write("FOR TYPE ")
+			d.typeName(item.GetTypeName())
+			if i < len(l)-1 {
+				d.write(", ")
+			}
+		}
+	case "window":
+		d.write("WINDOW")
+	default:
+		d.commonFuncOptItem(defElem)
+	}
+}
+
+// "alter_generic_options" in gram.y
+func (d *deparser) alterGenericOptions(options []*Node) {
+	d.write("OPTIONS (")
+	for i, option := range options {
+		defElem := d.defElem(option)
+		switch defElem.Defaction {
+		case DefElemAction_DEFELEM_UNSPEC:
+			d.write(quoteIdentifier(defElem.Defname))
+			d.writeByte(' ')
+			d.stringLiteral(strVal(defElem.Arg))
+		case DefElemAction_DEFELEM_SET:
+			d.write("SET ")
+			d.write(quoteIdentifier(defElem.Defname))
+			d.writeByte(' ')
+			d.stringLiteral(strVal(defElem.Arg))
+		case DefElemAction_DEFELEM_ADD:
+			d.write("ADD ")
+			d.write(quoteIdentifier(defElem.Defname))
+			d.writeByte(' ')
+			d.stringLiteral(strVal(defElem.Arg))
+		case DefElemAction_DEFELEM_DROP:
+			d.write("DROP ")
+			d.write(quoteIdentifier(defElem.Defname))
+		}
+		if i < len(options)-1 {
+			d.write(", ")
+		}
+	}
+	d.write(") ")
+}
+
+// "func_name" in gram.y
+func (d *deparser) funcName(funcName []*Node) {
+	d.anyName(funcName)
+}
+
+// "function_with_argtypes" in gram.y
+func (d *deparser) functionWithArgtypes(objectWithArgs *ObjectWithArgs) {
+	if objectWithArgs == nil {
+		d.fail("missing ObjectWithArgs")
+	}
+	d.funcName(objectWithArgs.Objname)
+	if !objectWithArgs.ArgsUnspecified {
+		d.writeByte('(')
+		d.objectArgs(objectWithArgs)
+		d.writeByte(')')
+	}
+}
+
+// objectArgs appends the argument types of a function, preferring the full parameter definitions
+func (d *deparser) objectArgs(objectWithArgs *ObjectWithArgs) {
+	objargs := objectWithArgs.Objargs
+	if len(objectWithArgs.Objfuncargs) > 0 {
+		objargs = objectWithArgs.Objfuncargs
+	}
+	for i, arg := range objargs {
+		if param := arg.GetFunctionParameter(); param != nil {
+			d.functionParameter(param)
+		} else {
+			d.typeName(arg.GetTypeName())
+		}
+		if i < len(objargs)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "function_with_argtypes_list" in gram.y
+func (d *deparser) functionWithArgtypesList(l []*Node) {
+	for i, item := range l {
+		d.functionWithArgtypes(item.GetObjectWithArgs())
+		if i < len(l)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "operator_with_argtypes" in gram.y
+func (d *deparser) operatorWithArgtypes(objectWithArgs *ObjectWithArgs) {
+	d.anyOperator(objectWithArgs.Objname)
+	if len(objectWithArgs.Objargs) != 2 {
+		d.fail("operator with %d argument types", len(objectWithArgs.Objargs))
+	}
+	d.writeByte('(')
+	if left := objectWithArgs.Objargs[0]; left.GetNode() == nil {
+		d.write("NONE")
+	} else {
+		d.typeName(left.GetTypeName())
+	}
+	d.write(", ")
+	if right := objectWithArgs.Objargs[1]; right.GetNode() == nil {
+		d.write("NONE")
+	} else {
+		d.typeName(right.GetTypeName())
+	}
+	d.writeByte(')')
+}
+
+// "aggr_args" in gram.y
+func (d *deparser) aggrArgs(aggrArgs []*Node) {
+	if len(aggrArgs) != 2 {
+		d.fail("aggregate arguments with %d elements", len(aggrArgs))
+	}
+	args := aggrArgs[0].GetList().GetItems()
+	orderByPos := int(intVal(aggrArgs[1]))
+
+	d.writeByte('(')
+	if len(args) == 0 {
+		d.writeByte('*')
+	} else {
+		for i, arg := range args {
+			if i == orderByPos {
+				if i > 0 {
+					d.writeByte(' ')
+				}
+				d.write("ORDER BY ")
+			} else if i > 0 {
+				d.write(", ")
+			}
+			d.functionParameter(arg.GetFunctionParameter())
+		}
+
+		// Repeat the last direct arg as a ordered arg to handle the
+		// simplification done by makeOrderedSetArgs in gram.y
+		if orderByPos == len(args) {
+			d.write(" ORDER BY ")
+			d.functionParameter(args[len(args)-1].GetFunctionParameter())
+		}
+	}
+	d.writeByte(')')
+}
+
+// "aggregate_with_argtypes" in gram.y
+func (d *deparser) aggregateWithArgtypes(objectWithArgs *ObjectWithArgs) {
+	d.funcName(objectWithArgs.Objname)
+	d.writeByte('(')
+	if len(objectWithArgs.Objargs) == 0 && len(objectWithArgs.Objfuncargs) == 0 {
+		d.writeByte('*')
+	} else {
+		d.objectArgs(objectWithArgs)
+	}
+	d.writeByte(')')
+}
+
+// "columnList" in gram.y
+func (d *deparser) columnList(columns []*Node) {
+	for i, column := range columns {
+		d.write(quoteIdentifier(strVal(column)))
+		if i < len(columns)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "OptTemp" in gram.y
+//
+// Note this method adds a trailing space if a value is output
+func (d *deparser) optTemp(relpersistence string) {
+	switch relpersistence {
+	case "p":
+		// Default
+	case "u":
+		d.write("UNLOGGED ")
+	case "t":
+		d.write("TEMPORARY ")
+	default:
+		d.fail("unsupported relpersistence: %q", relpersistence)
+	}
+}
+
+// "relation_expr_list" in gram.y
+func (d *deparser) relationExprList(relationExprs []*Node) {
+	for i, rel := range relationExprs {
+		d.rangeVar(rel.GetRangeVar(), deparseContextNone)
+		if i < len(relationExprs)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "handler_name" in gram.y
+func (d *deparser) handlerName(handlerName []*Node) {
+	d.anyName(handlerName)
+}
+
+// "fdw_options" in gram.y
+func (d *deparser) fdwOptions(fdwOptions []*Node) {
+	for i, option := range fdwOptions {
+		defElem := d.defElem(option)
+		switch {
+		case defElem.Defname == "handler" && defElem.Arg != nil:
+			d.write("HANDLER ")
+			d.handlerName(defElem.Arg.GetList().GetItems())
+		case defElem.Defname == "handler":
+			d.write("NO HANDLER ")
+		case defElem.Defname == "validator" && defElem.Arg != nil:
+			d.write("VALIDATOR ")
+			d.handlerName(defElem.Arg.GetList().GetItems())
+		case defElem.Defname == "validator":
+			d.write("NO VALIDATOR ")
+		default:
+			d.fail("unsupported foreign data wrapper option: %s", defElem.Defname)
+		}
+		if i < len(fdwOptions)-1 {
+			d.writeByte(' ')
+		}
+	}
+}
+
+// "type_list" in gram.y
+func (d *deparser) typeList(typeList []*Node) {
+	for i, item := range typeList {
+		d.typeName(item.GetTypeName())
+		if i < len(typeList)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "opt_boolean_or_string" in gram.y
+func (d *deparser) optBooleanOrString(s string) {
+	switch s {
+	case "":
+		// No value set
+	case "true":
+		d.write("TRUE")
+	case "false":
+		d.write("FALSE")
+	case "on":
+		d.write("ON")
+	case "off":
+		d.write("OFF")
+	default:
+		d.nonReservedWordOrSconst(s)
+	}
+}
+
+func (d *deparser) optBoolean(node *Node) {
+	if node == nil {
+		return
+	}
+	switch n := node.Node.(type) {
+	case *Node_String_:
+		d.write(" " + n.String_.Sval)
+	case *Node_Integer:
+		d.writef(" %d", n.Integer.Ival)
+	case *Node_Boolean:
+		if n.Boolean.Boolval {
+			d.write(" TRUE")
+		} else {
+			d.write(" FALSE")
+		}
+	default:
+		d.fail("unpermitted node type in boolean option: %s", nodeName(node))
+	}
+}
+
+func optBooleanValue(node *Node) bool {
+	if node == nil {
+		return true
+	}
+	switch n := node.Node.(type) {
+	case *Node_String_:
+		// Longest valid string is "off"
+		lower := n.String_.Sval
+		if len(lower) > 3 {
+			lower = lower[:3]
+		}
+		// No sane way to handle other values
+		return lower == "on"
+	case *Node_Integer:
+		return n.Integer.Ival != 0
+	case *Node_Boolean:
+		return n.Boolean.Boolval
+	}
+	return false
+}
+
+// "var_name"
+//
+// Note this is kept separate from ColId in case we want to improve the
+// output of namespaced variable names
+func (d *deparser) varName(s string) {
+	d.colID(s)
+}
+
+// "var_list"
+func (d *deparser) varList(l []*Node) {
+	for i, item := range l {
+		switch n := item.Node.(type) {
+		case *Node_ParamRef:
+			d.paramRef(n.ParamRef)
+		case *Node_AConst:
+			switch v := n.AConst.Val.(type) {
+			case *A_Const_Ival:
+				d.writef("%d", v.Ival.Ival)
+			case *A_Const_Fval:
+				d.write(v.Fval.Fval)
+			case *A_Const_Sval:
+				d.optBooleanOrString(v.Sval.Sval)
+			default:
+				d.fail("unsupported constant in var_list")
+			}
+		case *Node_TypeCast:
+			d.typeCast(n.TypeCast, deparseContextSetStatement)
+		default:
+			d.fail("unpermitted node type in var_list: %s", nodeName(item))
+		}
+		if i < len(l)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// aConstInt returns the integer value of an A_Const node
+func aConstInt(node *Node) int32 {
+	return node.GetAConst().GetIval().GetIval()
+}
+
+// "transaction_mode_list" in gram.y
+func (d *deparser) transactionModeList(l []*Node) {
+	for i, item := range l {
+		defElem := d.defElem(item)
+		switch {
+		case defElem.Defname == "transaction_isolation":
+			d.write("ISOLATION LEVEL ")
+			switch s := defElem.Arg.GetAConst().GetSval().GetSval(); s {
+			case "read uncommitted":
+				d.write("READ UNCOMMITTED")
+			case "read committed":
+				d.write("READ COMMITTED")
+			case "repeatable read":
+				d.write("REPEATABLE READ")
+			case "serializable":
+				d.write("SERIALIZABLE")
+			default:
+				d.fail("unsupported isolation level: %s", s)
+			}
+		case defElem.Defname == "transaction_read_only" && aConstInt(defElem.Arg) == 1:
+			d.write("READ ONLY")
+		case defElem.Defname == "transaction_read_only" && aConstInt(defElem.Arg) == 0:
+			d.write("READ WRITE")
+		case defElem.Defname == "transaction_deferrable" && aConstInt(defElem.Arg) == 1:
+			d.write("DEFERRABLE")
+		case defElem.Defname == "transaction_deferrable" && aConstInt(defElem.Arg) == 0:
+			d.write("NOT DEFERRABLE")
+		default:
+			d.fail("unsupported transaction mode: %s", defElem.Defname)
+		}
+		if i < len(l)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "alter_identity_column_option_list" in gram.y
+func (d *deparser) alterIdentityColumnOptionList(l []*Node) {
+	for i, item := range l {
+		defElem := d.defElem(item)
+		switch {
+		case defElem.Defname == "restart" && defElem.Arg == nil:
+			d.write("RESTART")
+		case defElem.Defname == "restart":
+			d.write("RESTART ")
+			d.numericOnly(defElem.Arg)
+		case defElem.Defname == "generated":
+			d.write("SET GENERATED ")
+			// The identity kind is stored as the integer value of the char
+			switch string(rune(intVal(defElem.Arg))) {
+			case attributeIdentityAlways:
+				d.write("ALWAYS")
+			case attributeIdentityByDefault:
+				d.write("BY DEFAULT")
+			default:
+				d.fail("unsupported identity kind: %d", intVal(defElem.Arg))
+			}
+		default:
+			d.write("SET ")
+			d.seqOptElem(defElem)
+		}
+		if i < len(l)-1 {
+			d.writeByte(' ')
+		}
+	}
+}
+
+// "reloptions" in gram.y
+func (d *deparser) relOptions(l []*Node) {
+	d.writeByte('(')
+	for i, item := range l {
+		defElem := d.defElem(item)
+		if defElem.Defnamespace != "" {
+			d.write(quoteIdentifier(defElem.Defnamespace))
+			d.writeByte('.')
+		}
+		if defElem.Defname != "" {
+			d.write(quoteIdentifier(defElem.Defname))
+		}
+		if defElem.Defname != "" && defElem.Arg != nil {
+			d.writeByte('=')
+		}
+		if defElem.Arg != nil {
+			d.defArg(defElem.Arg, false)
+		}
+		if i < len(l)-1 {
+			d.write(", ")
+		}
+	}
+	d.writeByte(')')
+}
+
+// "OptWith" and "opt_reloptions" in gram.y
+//
+// Note this method adds a trailing space if a value is output
+func (d *deparser) optWith(l []*Node) {
+	if len(l) > 0 {
+		d.write("WITH ")
+		d.relOptions(l)
+		d.writeByte(' ')
+	}
+}
+
+// "target_list" and "opt_target_list" in gram.y
+func (d *deparser) targetList(l []*Node) {
+	d.list(len(l), func(i int) {
+		resTarget := l[i].GetResTarget()
+		if resTarget.GetVal() == nil {
+			d.fail("error in targetList: ResTarget without val")
+		}
+		d.nodeComments(l[i])
+		d.expr(resTarget.Val)
+		if resTarget.Name != "" {
+			d.write(" AS ")
+			d.write(quoteIdentifier(resTarget.Name))
+		}
+		d.nodeTrailingComments(l[i])
+	})
+}
+
+// "insert_column_list" in gram.y
+func (d *deparser) insertColumnList(l []*Node) {
+	for i, item := range l {
+		resTarget := item.GetResTarget()
+		d.write(quoteIdentifier(resTarget.GetName()))
+		d.optIndirection(resTarget.GetIndirection(), 0)
+		if i < len(l)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "xml_attribute_list" in gram.y
+func (d *deparser) xmlAttributeList(l []*Node) {
+	for i, item := range l {
+		resTarget := item.GetResTarget()
+		d.expr(resTarget.GetVal())
+		if resTarget.GetName() != "" {
+			d.write(" AS ")
+			d.write(quoteIdentifier(resTarget.Name))
+		}
+		if i < len(l)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "xml_namespace_list" in gram.y
+func (d *deparser) xmlNamespaceList(l []*Node) {
+	for i, item := range l {
+		resTarget := item.GetResTarget()
+		if resTarget.GetName() == "" {
+			d.write("DEFAULT ")
+		}
+		d.expr(resTarget.GetVal())
+		if resTarget.GetName() != "" {
+			d.write(" AS ")
+			d.write(quoteIdentifier(resTarget.Name))
+		}
+		if i < len(l)-1 {
+			d.write(", ")
+		}
+	}
+}
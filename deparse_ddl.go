@@ -0,0 +1,4535 @@
+package pg_query
+
+import (
+	"math"
+	"strings"
+)
+
+func (d *deparser) columnDef(columnDef *ColumnDef) {
+	if columnDef.Colname != "" {
+		d.write(quoteIdentifier(columnDef.Colname))
+		d.writeByte(' ')
+	}
+
+	if columnDef.TypeName != nil {
+		d.typeName(columnDef.TypeName)
+		d.writeByte(' ')
+	}
+
+	if columnDef.StorageName != "" {
+		d.write("STORAGE ")
+		d.write(columnDef.StorageName)
+		d.writeByte(' ')
+	}
+
+	if columnDef.RawDefault != nil {
+		d.write("USING ")
+		d.expr(columnDef.RawDefault)
+		d.writeByte(' ')
+	}
+
+	if columnDef.Compression != "" {
+		d.write("COMPRESSION ")
+		d.write(columnDef.Compression)
+		d.writeByte(' ')
+	}
+
+	if len(columnDef.Fdwoptions) > 0 {
+		d.createGenericOptions(columnDef.Fdwoptions)
+		d.writeByte(' ')
+	}
+
+	for _, constraint := range columnDef.Constraints {
+		d.constraint(constraint.GetConstraint())
+		d.writeByte(' ')
+	}
+
+	if columnDef.CollClause != nil {
+		d.collateClause(columnDef.CollClause)
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) createCastStmt(stmt *CreateCastStmt) {
+	d.write("CREATE CAST (")
+	d.typeName(stmt.Sourcetype)
+	d.write(" AS ")
+	d.typeName(stmt.Targettype)
+	d.write(") ")
+
+	if stmt.Func != nil {
+		d.write("WITH FUNCTION ")
+		d.functionWithArgtypes(stmt.Func)
+		d.writeByte(' ')
+	} else if stmt.Inout {
+		d.write("WITH INOUT ")
+	} else {
+		d.write("WITHOUT FUNCTION ")
+	}
+
+	switch stmt.Context {
+	case CoercionContext_COERCION_IMPLICIT:
+		d.write("AS IMPLICIT")
+	case CoercionContext_COERCION_ASSIGNMENT:
+		d.write("AS ASSIGNMENT")
+	case CoercionContext_COERCION_PLPGSQL:
+		// Not present in raw parser output
+		d.fail("unexpected coercion context in CREATE CAST: %s", stmt.Context)
+	}
+}
+
+func (d *deparser) createOpClassStmt(stmt *CreateOpClassStmt) {
+	d.write("CREATE OPERATOR CLASS ")
+
+	d.anyName(stmt.Opclassname)
+	d.writeByte(' ')
+
+	if stmt.IsDefault {
+		d.write("DEFAULT ")
+	}
+
+	d.write("FOR TYPE ")
+	d.typeName(stmt.Datatype)
+	d.writeByte(' ')
+
+	d.write("USING ")
+	d.write(quoteIdentifier(stmt.Amname))
+	d.writeByte(' ')
+
+	if len(stmt.Opfamilyname) > 0 {
+		d.write("FAMILY ")
+		d.anyName(stmt.Opfamilyname)
+		d.writeByte(' ')
+	}
+
+	d.write("AS ")
+	d.opclassItemList(stmt.Items)
+}
+
+func (d *deparser) createOpFamilyStmt(stmt *CreateOpFamilyStmt) {
+	d.write("CREATE OPERATOR FAMILY ")
+
+	d.anyName(stmt.Opfamilyname)
+	d.writeByte(' ')
+
+	d.write("USING ")
+	d.write(quoteIdentifier(stmt.Amname))
+}
+
+// Item types of CreateOpClassItem, from nodes/parsenodes.h
+const (
+	opclassItemOperator    = 1
+	opclassItemFunction    = 2
+	opclassItemStoragetype = 3
+)
+
+func (d *deparser) createOpClassItem(item *CreateOpClassItem) {
+	switch item.Itemtype {
+	case opclassItemOperator:
+		d.write("OPERATOR ")
+		d.writef("%d ", item.Number)
+
+		if item.Name != nil {
+			if len(item.Name.Objargs) > 0 {
+				d.operatorWithArgtypes(item.Name)
+			} else {
+				d.anyOperator(item.Name.Objname)
+			}
+			d.writeByte(' ')
+		}
+
+		if len(item.OrderFamily) > 0 {
+			d.write("FOR ORDER BY ")
+			d.anyName(item.OrderFamily)
+		}
+
+		if len(item.ClassArgs) > 0 {
+			d.writeByte('(')
+			d.typeList(item.ClassArgs)
+			d.writeByte(')')
+		}
+		d.removeTrailingSpace()
+	case opclassItemFunction:
+		d.write("FUNCTION ")
+		d.writef("%d ", item.Number)
+		if len(item.ClassArgs) > 0 {
+			d.writeByte('(')
+			d.typeList(item.ClassArgs)
+			d.write(") ")
+		}
+		if item.Name != nil {
+			d.functionWithArgtypes(item.Name)
+		}
+		d.removeTrailingSpace()
+	case opclassItemStoragetype:
+		d.write("STORAGE ")
+		d.typeName(item.Storedtype)
+	default:
+		d.fail("unknown operator class item type: %d", item.Itemtype)
+	}
+}
+
+// Bits of TableLikeClause.Options, from nodes/parsenodes.h
+const (
+	createTableLikeComments    = 1 << 0
+	createTableLikeCompression = 1 << 1
+	createTableLikeConstraints = 1 << 2
+	createTableLikeDefaults    = 1 << 3
+	createTableLikeGenerated   = 1 << 4
+	createTableLikeIdentity    = 1 << 5
+	createTableLikeIndexes     = 1 << 6
+	createTableLikeStatistics  = 1 << 7
+	createTableLikeStorage     = 1 << 8
+	createTableLikeAll         = 0x7FFFFFFF
+)
+
+func (d *deparser) tableLikeClause(tableLikeClause *TableLikeClause) {
+	d.write("LIKE ")
+	d.rangeVar(tableLikeClause.Relation, deparseContextNone)
+	d.writeByte(' ')
+
+	options := tableLikeClause.Options
+	if options == createTableLikeAll {
+		d.write("INCLUDING ALL ")
+	} else {
+		if options&createTableLikeComments != 0 {
+			d.write("INCLUDING COMMENTS ")
+		}
+		if options&createTableLikeCompression != 0 {
+			d.write("INCLUDING COMPRESSION ")
+		}
+		if options&createTableLikeConstraints != 0 {
+			d.write("INCLUDING CONSTRAINTS ")
+		}
+		if options&createTableLikeDefaults != 0 {
+			d.write("INCLUDING DEFAULTS ")
+		}
+		if options&createTableLikeIdentity != 0 {
+			d.write("INCLUDING IDENTITY ")
+		}
+		if options&createTableLikeGenerated != 0 {
+			d.write("INCLUDING GENERATED ")
+		}
+		if options&createTableLikeIndexes != 0 {
+			d.write("INCLUDING INDEXES ")
+		}
+		if options&createTableLikeStatistics != 0 {
+			d.write("INCLUDING STATISTICS ")
+		}
+		if options&createTableLikeStorage != 0 {
+			d.write("INCLUDING STORAGE ")
+		}
+	}
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) createDomainStmt(stmt *CreateDomainStmt) {
+	if stmt.TypeName == nil {
+		d.fail("missing type name in CREATE DOMAIN")
+	}
+
+	d.write("CREATE DOMAIN ")
+	d.anyName(stmt.Domainname)
+	d.write(" AS ")
+
+	d.typeName(stmt.TypeName)
+	d.writeByte(' ')
+
+	if stmt.CollClause != nil {
+		d.collateClause(stmt.CollClause)
+		d.writeByte(' ')
+	}
+
+	for _, constraint := range stmt.Constraints {
+		d.constraint(constraint.GetConstraint())
+		d.writeByte(' ')
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) createExtensionStmt(stmt *CreateExtensionStmt) {
+	d.write("CREATE EXTENSION ")
+
+	if stmt.IfNotExists {
+		d.write("IF NOT EXISTS ")
+	}
+
+	d.colID(stmt.Extname)
+	d.writeByte(' ')
+
+	for _, option := range stmt.Options {
+		defElem := d.defElem(option)
+		switch defElem.Defname {
+		case "schema":
+			d.write("SCHEMA ")
+			d.colID(strVal(defElem.Arg))
+		case "new_version":
+			d.write("VERSION ")
+			d.nonReservedWordOrSconst(strVal(defElem.Arg))
+		case "cascade":
+			d.write("CASCADE")
+		default:
+			d.fail("unknown CREATE EXTENSION option: %s", defElem.Defname)
+		}
+		d.writeByte(' ')
+	}
+
+	d.removeTrailingSpace()
+}
+
+// Values of the char fields of Constraint, from nodes/parsenodes.h
+const (
+	attributeIdentityAlways    = "a"
+	attributeIdentityByDefault = "d"
+
+	fkConstrMatchFull    = "f"
+	fkConstrMatchPartial = "p"
+
+	fkConstrActionRestrict   = "r"
+	fkConstrActionCascade    = "c"
+	fkConstrActionSetNull    = "n"
+	fkConstrActionSetDefault = "d"
+)
+
+// defaultIndexType is the access method used when an index constraint doesn't specify one
+const defaultIndexType = "btree"
+
+func (d *deparser) constraint(constraint *Constraint) {
+	if constraint == nil {
+		d.fail("missing Constraint")
+	}
+	if constraint.Conname != "" {
+		d.write("CONSTRAINT ")
+		d.write(quoteIdentifier(constraint.Conname))
+		d.writeByte(' ')
+	}
+
+	switch constraint.Contype {
+	case ConstrType_CONSTR_NULL:
+		d.write("NULL ")
+	case ConstrType_CONSTR_NOTNULL:
+		d.write("NOT NULL ")
+	case ConstrType_CONSTR_DEFAULT:
+		d.write("DEFAULT ")
+		d.bExpr(constraint.RawExpr)
+	case ConstrType_CONSTR_IDENTITY:
+		d.write("GENERATED ")
+		switch constraint.GeneratedWhen {
+		case attributeIdentityAlways:
+			d.write("ALWAYS ")
+		case attributeIdentityByDefault:
+			d.write("BY DEFAULT ")
+		default:
+			d.fail("unknown identity generation: %q", constraint.GeneratedWhen)
+		}
+		d.write("AS IDENTITY ")
+		d.optParenthesizedSeqOptList(constraint.Options)
+	case ConstrType_CONSTR_GENERATED:
+		if constraint.GeneratedWhen != attributeIdentityAlways {
+			d.fail("unknown column generation: %q", constraint.GeneratedWhen)
+		}
+		d.write("GENERATED ALWAYS AS (")
+		d.expr(constraint.RawExpr)
+		d.write(") STORED ")
+	case ConstrType_CONSTR_CHECK:
+		d.write("CHECK (")
+		d.expr(constraint.RawExpr)
+		d.write(") ")
+	case ConstrType_CONSTR_PRIMARY:
+		d.write("PRIMARY KEY ")
+	case ConstrType_CONSTR_UNIQUE:
+		d.write("UNIQUE ")
+		if constraint.NullsNotDistinct {
+			d.write("NULLS NOT DISTINCT ")
+		}
+	case ConstrType_CONSTR_EXCLUSION:
+		d.write("EXCLUDE ")
+		if constraint.AccessMethod != defaultIndexType {
+			d.write("USING ")
+			d.write(quoteIdentifier(constraint.AccessMethod))
+			d.writeByte(' ')
+		}
+		d.writeByte('(')
+		for i, item := range constraint.Exclusions {
+			exclusion := item.GetList().GetItems()
+			if len(exclusion) != 2 {
+				d.fail("malformed exclusion constraint element")
+			}
+			d.indexElem(exclusion[0].GetIndexElem())
+			d.write(" WITH ")
+			d.anyOperator(exclusion[1].GetList().GetItems())
+			if i < len(constraint.Exclusions)-1 {
+				d.write(", ")
+			}
+		}
+		d.write(") ")
+		if constraint.WhereClause != nil {
+			d.write("WHERE (")
+			d.expr(constraint.WhereClause)
+			d.write(") ")
+		}
+	case ConstrType_CONSTR_FOREIGN:
+		if len(constraint.FkAttrs) > 0 {
+			d.write("FOREIGN KEY ")
+		}
+	case ConstrType_CONSTR_ATTR_DEFERRABLE:
+		d.write("DEFERRABLE ")
+	case ConstrType_CONSTR_ATTR_NOT_DEFERRABLE:
+		d.write("NOT DEFERRABLE ")
+	case ConstrType_CONSTR_ATTR_DEFERRED:
+		d.write("INITIALLY DEFERRED ")
+	case ConstrType_CONSTR_ATTR_IMMEDIATE:
+		d.write("INITIALLY IMMEDIATE ")
+	}
+
+	if len(constraint.Keys) > 0 {
+		d.writeByte('(')
+		d.columnList(constraint.Keys)
+		d.write(") ")
+	}
+
+	if len(constraint.FkAttrs) > 0 {
+		d.writeByte('(')
+		d.columnList(constraint.FkAttrs)
+		d.write(") ")
+	}
+
+	if constraint.Pktable != nil {
+		d.write("REFERENCES ")
+		d.rangeVar(constraint.Pktable, deparseContextNone)
+		d.writeByte(' ')
+		if len(constraint.PkAttrs) > 0 {
+			d.writeByte('(')
+			d.columnList(constraint.PkAttrs)
+			d.write(") ")
+		}
+	}
+
+	switch constraint.FkMatchtype {
+	case fkConstrMatchFull:
+		d.write("MATCH FULL ")
+	case fkConstrMatchPartial:
+		// Not implemented in Postgres
+		d.fail("MATCH PARTIAL is not supported")
+	}
+
+	switch constraint.FkUpdAction {
+	case fkConstrActionRestrict:
+		d.write("ON UPDATE RESTRICT ")
+	case fkConstrActionCascade:
+		d.write("ON UPDATE CASCADE ")
+	case fkConstrActionSetNull:
+		d.write("ON UPDATE SET NULL ")
+	case fkConstrActionSetDefault:
+		d.write("ON UPDATE SET DEFAULT ")
+	}
+
+	switch constraint.FkDelAction {
+	case fkConstrActionRestrict:
+		d.write("ON DELETE RESTRICT ")
+	case fkConstrActionCascade:
+		d.write("ON DELETE CASCADE ")
+	case fkConstrActionSetNull, fkConstrActionSetDefault:
+		d.write("ON DELETE SET ")
+		if constraint.FkDelAction == fkConstrActionSetDefault {
+			d.write("DEFAULT ")
+		} else {
+			d.write("NULL ")
+		}
+		if len(constraint.FkDelSetCols) > 0 {
+			d.writeByte('(')
+			for i, col := range constraint.FkDelSetCols {
+				d.write(strVal(col))
+				if i < len(constraint.FkDelSetCols)-1 {
+					d.write(", ")
+				}
+			}
+			d.writeByte(')')
+		}
+	}
+
+	if len(constraint.Including) > 0 {
+		d.write("INCLUDE (")
+		d.columnList(constraint.Including)
+		d.write(") ")
+	}
+
+	switch constraint.Contype {
+	case ConstrType_CONSTR_PRIMARY, ConstrType_CONSTR_UNIQUE, ConstrType_CONSTR_EXCLUSION:
+		d.optWith(constraint.Options)
+	}
+
+	if constraint.Indexname != "" {
+		d.writef("USING INDEX %s ", quoteIdentifier(constraint.Indexname))
+	}
+
+	if constraint.Indexspace != "" {
+		d.writef("USING INDEX TABLESPACE %s ", quoteIdentifier(constraint.Indexspace))
+	}
+
+	if constraint.Deferrable {
+		d.write("DEFERRABLE ")
+	}
+
+	if constraint.Initdeferred {
+		d.write("INITIALLY DEFERRED ")
+	}
+
+	if constraint.IsNoInherit {
+		d.write("NO INHERIT ")
+	}
+
+	if constraint.SkipValidation {
+		d.write("NOT VALID ")
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) returnStmt(stmt *ReturnStmt) {
+	d.write("RETURN ")
+	d.expr(stmt.Returnval)
+}
+
+func (d *deparser) createFunctionStmt(stmt *CreateFunctionStmt) {
+	tableFunc := false
+
+	d.write("CREATE ")
+	if stmt.Replace {
+		d.write("OR REPLACE ")
+	}
+	if stmt.IsProcedure {
+		d.write("PROCEDURE ")
+	} else {
+		d.write("FUNCTION ")
+	}
+
+	d.funcName(stmt.Funcname)
+
+	d.writeByte('(')
+	for i, item := range stmt.Parameters {
+		param := d.functionParameterItem(item)
+		if param.Mode != FunctionParameterMode_FUNC_PARAM_TABLE {
+			d.functionParameter(param)
+			if i < len(stmt.Parameters)-1 && stmt.Parameters[i+1].GetFunctionParameter().GetMode() != FunctionParameterMode_FUNC_PARAM_TABLE {
+				d.write(", ")
+			}
+		} else {
+			tableFunc = true
+		}
+	}
+	d.write(") ")
+
+	if tableFunc {
+		d.write("RETURNS TABLE (")
+		for i, item := range stmt.Parameters {
+			param := d.functionParameterItem(item)
+			if param.Mode == FunctionParameterMode_FUNC_PARAM_TABLE {
+				d.functionParameter(param)
+				if i < len(stmt.Parameters)-1 {
+					d.write(", ")
+				}
+			}
+		}
+		d.write(") ")
+	} else if stmt.ReturnType != nil {
+		d.write("RETURNS ")
+		d.typeName(stmt.ReturnType)
+		d.writeByte(' ')
+	}
+
+	for _, option := range stmt.Options {
+		d.createFuncOptItem(d.defElem(option))
+		d.writeByte(' ')
+	}
+
+	if stmt.SqlBody != nil {
+		// RETURN or BEGIN ... END
+		if returnStmt := stmt.SqlBody.GetReturnStmt(); returnStmt != nil {
+			d.returnStmt(returnStmt)
+		} else {
+			d.write("BEGIN ATOMIC ")
+			if items := stmt.SqlBody.GetList().GetItems(); len(items) > 0 && items[0].GetNode() != nil {
+				for _, bodyStmt := range items[0].GetList().GetItems() {
+					if returnStmt := bodyStmt.GetReturnStmt(); returnStmt != nil {
+						d.returnStmt(returnStmt)
+					} else {
+						d.stmt(bodyStmt)
+					}
+					d.write("; ")
+				}
+			}
+			d.write("END ")
+		}
+	}
+
+	d.removeTrailingSpace()
+}
+
+// functionParameterItem returns the FunctionParameter of a parameter list item
+func (d *deparser) functionParameterItem(node *Node) *FunctionParameter {
+	param := node.GetFunctionParameter()
+	if param == nil {
+		d.fail("unexpected node in function parameters: %s", nodeName(node))
+	}
+	return param
+}
+
+func (d *deparser) functionParameter(param *FunctionParameter) {
+	switch param.Mode {
+	case FunctionParameterMode_FUNC_PARAM_IN: // input only
+		d.write("IN ")
+	case FunctionParameterMode_FUNC_PARAM_OUT: // output only
+		d.write("OUT ")
+	case FunctionParameterMode_FUNC_PARAM_INOUT: // both
+		d.write("INOUT ")
+	case FunctionParameterMode_FUNC_PARAM_VARIADIC: // variadic (always input)
+		d.write("VARIADIC ")
+	case FunctionParameterMode_FUNC_PARAM_TABLE: // table function output column
+		// No special annotation, the caller is expected to correctly put
+		// this into the RETURNS part of the CREATE FUNCTION statement
+	case FunctionParameterMode_FUNC_PARAM_DEFAULT:
+		// Default
+	default:
+		d.fail("unknown function parameter mode: %s", param.Mode)
+	}
+
+	if param.Name != "" {
+		d.write(param.Name)
+		d.writeByte(' ')
+	}
+
+	d.typeName(param.ArgType)
+	d.writeByte(' ')
+
+	if param.Defexpr != nil {
+		d.write("= ")
+		d.expr(param.Defexpr)
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) createSchemaStmt(stmt *CreateSchemaStmt) {
+	d.write("CREATE SCHEMA ")
+
+	if stmt.IfNotExists {
+		d.write("IF NOT EXISTS ")
+	}
+
+	if stmt.Schemaname != "" {
+		d.colID(stmt.Schemaname)
+		d.writeByte(' ')
+	}
+
+	if stmt.Authrole != nil {
+		d.write("AUTHORIZATION ")
+		d.roleSpec(stmt.Authrole)
+		d.writeByte(' ')
+	}
+
+	for i, elt := range stmt.SchemaElts {
+		d.schemaStmt(elt)
+		if i < len(stmt.SchemaElts)-1 {
+			d.writeByte(' ')
+		}
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) alterRoleSetStmt(stmt *AlterRoleSetStmt) {
+	d.write("ALTER ROLE ")
+
+	if stmt.Role == nil {
+		d.write("ALL")
+	} else {
+		d.roleSpec(stmt.Role)
+	}
+
+	d.writeByte(' ')
+
+	if stmt.Database != "" {
+		d.write("IN DATABASE ")
+		d.write(quoteIdentifier(stmt.Database))
+		d.writeByte(' ')
+	}
+
+	d.variableSetStmt(stmt.Setstmt)
+}
+
+func (d *deparser) createConversionStmt(stmt *CreateConversionStmt) {
+	d.write("CREATE ")
+	if stmt.Def {
+		d.write("DEFAULT ")
+	}
+
+	d.write("CONVERSION ")
+	d.anyName(stmt.ConversionName)
+	d.writeByte(' ')
+
+	d.write("FOR ")
+	d.stringLiteral(stmt.ForEncodingName)
+	d.write(" TO ")
+	d.stringLiteral(stmt.ToEncodingName)
+
+	d.write("FROM ")
+	d.anyName(stmt.FuncName)
+}
+
+func (d *deparser) roleSpec(roleSpec *RoleSpec) {
+	switch roleSpec.GetRoletype() {
+	case RoleSpecType_ROLESPEC_CSTRING:
+		if roleSpec.Rolename == "" {
+			d.fail("missing role name")
+		}
+		d.write(quoteIdentifier(roleSpec.Rolename))
+	case RoleSpecType_ROLESPEC_CURRENT_ROLE:
+		d.write("CURRENT_ROLE")
+	case RoleSpecType_ROLESPEC_CURRENT_USER:
+		d.write("CURRENT_USER")
+	case RoleSpecType_ROLESPEC_SESSION_USER:
+		d.write("SESSION_USER")
+	case RoleSpecType_ROLESPEC_PUBLIC:
+		d.write("public")
+	}
+}
+
+// "part_elem" in gram.y
+func (d *deparser) partitionElem(elem *PartitionElem) {
+	if elem.Name != "" {
+		d.colID(elem.Name)
+		d.writeByte(' ')
+	} else if elem.Expr != nil {
+		d.writeByte('(')
+		d.expr(elem.Expr)
+		d.write(") ")
+	}
+
+	d.optCollate(elem.Collation)
+	d.anyName(elem.Opclass)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) partitionSpec(spec *PartitionSpec) {
+	d.write("PARTITION BY ")
+
+	switch spec.Strategy {
+	case PartitionStrategy_PARTITION_STRATEGY_LIST:
+		d.write("LIST")
+	case PartitionStrategy_PARTITION_STRATEGY_HASH:
+		d.write("HASH")
+	case PartitionStrategy_PARTITION_STRATEGY_RANGE:
+		d.write("RANGE")
+	}
+
+	d.writeByte('(')
+	for i, param := range spec.PartParams {
+		d.partitionElem(param.GetPartitionElem())
+		if i < len(spec.PartParams)-1 {
+			d.write(", ")
+		}
+	}
+	d.writeByte(')')
+}
+
+// Values of PartitionBoundSpec.Strategy, from nodes/parsenodes.h
+const (
+	partitionStrategyList  = "l"
+	partitionStrategyRange = "r"
+	partitionStrategyHash  = "h"
+)
+
+func (d *deparser) partitionBoundSpec(spec *PartitionBoundSpec) {
+	if spec.IsDefault {
+		d.write("DEFAULT")
+		return
+	}
+
+	d.write("FOR VALUES ")
+
+	switch spec.Strategy {
+	case partitionStrategyHash:
+		d.writef("WITH (MODULUS %d, REMAINDER %d)", spec.Modulus, spec.Remainder)
+	case partitionStrategyList:
+		d.write("IN (")
+		d.exprList(spec.Listdatums)
+		d.writeByte(')')
+	case partitionStrategyRange:
+		d.write("FROM (")
+		d.exprList(spec.Lowerdatums)
+		d.write(") TO (")
+		d.exprList(spec.Upperdatums)
+		d.writeByte(')')
+	default:
+		d.fail("unknown partition strategy: %q", spec.Strategy)
+	}
+}
+
+func (d *deparser) partitionCmd(cmd *PartitionCmd) {
+	d.rangeVar(cmd.Name, deparseContextNone)
+
+	if cmd.Bound != nil {
+		d.writeByte(' ')
+		d.partitionBoundSpec(cmd.Bound)
+	}
+	if cmd.Concurrent {
+		d.write(" CONCURRENTLY ")
+	}
+}
+
+// "TableElement" in gram.y
+func (d *deparser) tableElement(node *Node) {
+	switch n := node.GetNode().(type) {
+	case *Node_ColumnDef:
+		d.columnDef(n.ColumnDef)
+	case *Node_TableLikeClause:
+		d.tableLikeClause(n.TableLikeClause)
+	case *Node_Constraint:
+		d.constraint(n.Constraint)
+	default:
+		d.fail("unpermitted node type in TableElement: %s", nodeName(node))
+	}
+}
+
+func (d *deparser) createStmt(stmt *CreateStmt, isForeignTable bool) {
+	d.write("CREATE ")
+
+	if isForeignTable {
+		d.write("FOREIGN ")
+	}
+
+	if stmt.Relation == nil {
+		d.fail("missing relation")
+	}
+	d.optTemp(stmt.Relation.GetRelpersistence())
+
+	d.write("TABLE ")
+
+	if stmt.IfNotExists {
+		d.write("IF NOT EXISTS ")
+	}
+
+	d.rangeVar(stmt.Relation, deparseContextNone)
+	d.writeByte(' ')
+
+	if stmt.OfTypename != nil {
+		d.write("OF ")
+		d.typeName(stmt.OfTypename)
+		d.writeByte(' ')
+	}
+
+	if stmt.Partbound != nil {
+		if len(stmt.InhRelations) != 1 {
+			d.fail("partition must have exactly one parent")
+		}
+		d.write("PARTITION OF ")
+		d.rangeVar(stmt.InhRelations[0].GetRangeVar(), deparseContextNone)
+		d.writeByte(' ')
+	}
+
+	if len(stmt.TableElts) > 0 {
+		// In raw parse output tableElts contains both columns and constraints
+		// (and the constraints field is empty)
+		d.writeByte('(')
+		for i, elt := range stmt.TableElts {
+			d.tableElement(elt)
+			if i < len(stmt.TableElts)-1 {
+				d.write(", ")
+			}
+		}
+		d.write(") ")
+	} else if stmt.Partbound == nil && stmt.OfTypename == nil {
+		d.write("() ")
+	}
+
+	if stmt.Partbound != nil {
+		d.partitionBoundSpec(stmt.Partbound)
+		d.writeByte(' ')
+	} else {
+		d.optInherit(stmt.InhRelations)
+	}
+
+	if stmt.Partspec != nil {
+		d.partitionSpec(stmt.Partspec)
+		d.writeByte(' ')
+	}
+
+	if stmt.AccessMethod != "" {
+		d.write("USING ")
+		d.write(quoteIdentifier(stmt.AccessMethod))
+	}
+
+	d.optWith(stmt.Options)
+
+	switch stmt.Oncommit {
+	case OnCommitAction_ONCOMMIT_PRESERVE_ROWS:
+		d.write("ON COMMIT PRESERVE ROWS ")
+	case OnCommitAction_ONCOMMIT_DELETE_ROWS:
+		d.write("ON COMMIT DELETE ROWS ")
+	case OnCommitAction_ONCOMMIT_DROP:
+		d.write("ON COMMIT DROP ")
+	}
+
+	if stmt.Tablespacename != "" {
+		d.write("TABLESPACE ")
+		d.write(quoteIdentifier(stmt.Tablespacename))
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) createFdwStmt(stmt *CreateFdwStmt) {
+	d.write("CREATE FOREIGN DATA WRAPPER ")
+	d.write(quoteIdentifier(stmt.Fdwname))
+	d.writeByte(' ')
+
+	if len(stmt.FuncOptions) > 0 {
+		d.fdwOptions(stmt.FuncOptions)
+		d.writeByte(' ')
+	}
+
+	d.createGenericOptions(stmt.Options)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) alterFdwStmt(stmt *AlterFdwStmt) {
+	d.write("ALTER FOREIGN DATA WRAPPER ")
+	d.write(quoteIdentifier(stmt.Fdwname))
+	d.writeByte(' ')
+
+	if len(stmt.FuncOptions) > 0 {
+		d.fdwOptions(stmt.FuncOptions)
+		d.writeByte(' ')
+	}
+
+	if len(stmt.Options) > 0 {
+		d.alterGenericOptions(stmt.Options)
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) createForeignServerStmt(stmt *CreateForeignServerStmt) {
+	d.write("CREATE SERVER ")
+	if stmt.IfNotExists {
+		d.write("IF NOT EXISTS ")
+	}
+	d.write(quoteIdentifier(stmt.Servername))
+	d.writeByte(' ')
+
+	if stmt.Servertype != "" {
+		d.write("TYPE ")
+		d.stringLiteral(stmt.Servertype)
+		d.writeByte(' ')
+	}
+
+	if stmt.Version != "" {
+		d.write("VERSION ")
+		d.stringLiteral(stmt.Version)
+		d.writeByte(' ')
+	}
+
+	d.write("FOREIGN DATA WRAPPER ")
+	d.write(quoteIdentifier(stmt.Fdwname))
+	d.writeByte(' ')
+
+	d.createGenericOptions(stmt.Options)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) alterForeignServerStmt(stmt *AlterForeignServerStmt) {
+	d.write("ALTER SERVER ")
+
+	d.write(quoteIdentifier(stmt.Servername))
+	d.writeByte(' ')
+
+	if stmt.HasVersion {
+		d.write("VERSION ")
+		if stmt.Version != "" {
+			d.stringLiteral(stmt.Version)
+		} else {
+			d.write("NULL")
+		}
+		d.writeByte(' ')
+	}
+
+	if len(stmt.Options) > 0 {
+		d.alterGenericOptions(stmt.Options)
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) createUserMappingStmt(stmt *CreateUserMappingStmt) {
+	d.write("CREATE USER MAPPING ")
+	if stmt.IfNotExists {
+		d.write("IF NOT EXISTS ")
+	}
+
+	d.write("FOR ")
+	d.roleSpec(stmt.User)
+	d.writeByte(' ')
+
+	d.write("SERVER ")
+	d.write(quoteIdentifier(stmt.Servername))
+	d.writeByte(' ')
+
+	d.createGenericOptions(stmt.Options)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) createdbStmt(stmt *CreatedbStmt) {
+	d.write("CREATE DATABASE ")
+	d.colID(stmt.Dbname)
+	d.writeByte(' ')
+	d.createdbOptList(stmt.Options)
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) alterUserMappingStmt(stmt *AlterUserMappingStmt) {
+	d.write("ALTER USER MAPPING FOR ")
+	d.roleSpec(stmt.User)
+	d.writeByte(' ')
+
+	d.write("SERVER ")
+	d.write(quoteIdentifier(stmt.Servername))
+	d.writeByte(' ')
+
+	d.alterGenericOptions(stmt.Options)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) dropUserMappingStmt(stmt *DropUserMappingStmt) {
+	d.write("DROP USER MAPPING ")
+
+	if stmt.MissingOk {
+		d.write("IF EXISTS ")
+	}
+
+	d.write("FOR ")
+	d.roleSpec(stmt.User)
+	d.writeByte(' ')
+
+	d.write("SERVER ")
+	d.write(quoteIdentifier(stmt.Servername))
+}
+
+func (d *deparser) secLabelStmt(stmt *SecLabelStmt) {
+	d.write("SECURITY LABEL ")
+
+	if stmt.Provider != "" {
+		d.write("FOR ")
+		d.write(quoteIdentifier(stmt.Provider))
+		d.writeByte(' ')
+	}
+
+	d.write("ON ")
+
+	switch stmt.Objtype {
+	case ObjectType_OBJECT_COLUMN:
+		d.write("COLUMN ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_FOREIGN_TABLE:
+		d.write("FOREIGN TABLE ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_SEQUENCE:
+		d.write("SEQUENCE ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_TABLE:
+		d.write("TABLE ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_VIEW:
+		d.write("VIEW ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_MATVIEW:
+		d.write("MATERIALIZED VIEW ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_DATABASE:
+		d.write("DATABASE ")
+		d.write(quoteIdentifier(strVal(stmt.Object)))
+	case ObjectType_OBJECT_EVENT_TRIGGER:
+		d.write("EVENT TRIGGER ")
+		d.write(quoteIdentifier(strVal(stmt.Object)))
+	case ObjectType_OBJECT_LANGUAGE:
+		d.write("LANGUAGE ")
+		d.write(quoteIdentifier(strVal(stmt.Object)))
+	case ObjectType_OBJECT_PUBLICATION:
+		d.write("PUBLICATION ")
+		d.write(quoteIdentifier(strVal(stmt.Object)))
+	case ObjectType_OBJECT_ROLE:
+		d.write("ROLE ")
+		d.write(quoteIdentifier(strVal(stmt.Object)))
+	case ObjectType_OBJECT_SCHEMA:
+		d.write("SCHEMA ")
+		d.write(quoteIdentifier(strVal(stmt.Object)))
+	case ObjectType_OBJECT_SUBSCRIPTION:
+		d.write("SUBSCRIPTION ")
+		d.write(quoteIdentifier(strVal(stmt.Object)))
+	case ObjectType_OBJECT_TABLESPACE:
+		d.write("TABLESPACE ")
+		d.write(quoteIdentifier(strVal(stmt.Object)))
+	case ObjectType_OBJECT_TYPE:
+		d.write("TYPE ")
+		d.typeName(stmt.Object.GetTypeName())
+	case ObjectType_OBJECT_DOMAIN:
+		d.write("DOMAIN ")
+		d.typeName(stmt.Object.GetTypeName())
+	case ObjectType_OBJECT_AGGREGATE:
+		d.write("AGGREGATE ")
+		d.aggregateWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_FUNCTION:
+		d.write("FUNCTION ")
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_LARGEOBJECT:
+		d.write("LARGE OBJECT ")
+		d.value(stmt.Object, deparseContextConstant)
+	case ObjectType_OBJECT_PROCEDURE:
+		d.write("PROCEDURE ")
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_ROUTINE:
+		d.write("ROUTINE ")
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+	default:
+		// Not supported in the parser
+		d.fail("unsupported object type in SECURITY LABEL: %s", stmt.Objtype)
+	}
+
+	d.write(" IS ")
+
+	if stmt.Label != "" {
+		d.stringLiteral(stmt.Label)
+	} else {
+		d.write("NULL")
+	}
+}
+
+func (d *deparser) createForeignTableStmt(stmt *CreateForeignTableStmt) {
+	d.createStmt(stmt.BaseStmt, true)
+
+	d.write(" SERVER ")
+	d.write(quoteIdentifier(stmt.Servername))
+	d.writeByte(' ')
+
+	if len(stmt.Options) > 0 {
+		d.alterGenericOptions(stmt.Options)
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) importForeignSchemaStmt(stmt *ImportForeignSchemaStmt) {
+	d.write("IMPORT FOREIGN SCHEMA ")
+
+	d.write(stmt.RemoteSchema)
+	d.writeByte(' ')
+
+	switch stmt.ListType {
+	case ImportForeignSchemaType_FDW_IMPORT_SCHEMA_LIMIT_TO:
+		d.write("LIMIT TO (")
+		d.relationExprList(stmt.TableList)
+		d.write(") ")
+	case ImportForeignSchemaType_FDW_IMPORT_SCHEMA_EXCEPT:
+		d.write("EXCEPT (")
+		d.relationExprList(stmt.TableList)
+		d.write(") ")
+	}
+
+	d.write("FROM SERVER ")
+	d.write(quoteIdentifier(stmt.ServerName))
+	d.writeByte(' ')
+
+	d.write("INTO ")
+	d.write(quoteIdentifier(stmt.LocalSchema))
+	d.writeByte(' ')
+
+	d.createGenericOptions(stmt.Options)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) createTableAsStmt(stmt *CreateTableAsStmt) {
+	d.write("CREATE ")
+
+	if stmt.Into.GetRel() == nil {
+		d.fail("missing relation")
+	}
+	d.optTemp(stmt.Into.GetRel().GetRelpersistence())
+
+	switch stmt.Objtype {
+	case ObjectType_OBJECT_TABLE:
+		d.write("TABLE ")
+	case ObjectType_OBJECT_MATVIEW:
+		d.write("MATERIALIZED VIEW ")
+	default:
+		// Not supported here
+		d.fail("unsupported object type in CREATE TABLE AS: %s", stmt.Objtype)
+	}
+
+	if stmt.IfNotExists {
+		d.write("IF NOT EXISTS ")
+	}
+
+	d.intoClause(stmt.Into)
+	d.writeByte(' ')
+
+	d.write("AS ")
+	if executeStmt := stmt.Query.GetExecuteStmt(); executeStmt != nil {
+		d.executeStmt(executeStmt)
+	} else {
+		d.selectStmt(stmt.Query.GetSelectStmt())
+	}
+	d.writeByte(' ')
+
+	if stmt.Into.GetSkipData() {
+		d.write("WITH NO DATA ")
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) viewStmt(stmt *ViewStmt) {
+	d.write("CREATE ")
+
+	if stmt.Replace {
+		d.write("OR REPLACE ")
+	}
+
+	if stmt.View == nil {
+		d.fail("missing relation")
+	}
+	d.optTemp(stmt.View.GetRelpersistence())
+
+	d.write("VIEW ")
+	d.rangeVar(stmt.View, deparseContextNone)
+	d.writeByte(' ')
+
+	if len(stmt.Aliases) > 0 {
+		d.writeByte('(')
+		d.columnList(stmt.Aliases)
+		d.write(") ")
+	}
+
+	d.optWith(stmt.Options)
+
+	d.write("AS ")
+	d.selectStmt(stmt.Query.GetSelectStmt())
+	d.writeByte(' ')
+
+	switch stmt.WithCheckOption {
+	case ViewCheckOption_LOCAL_CHECK_OPTION:
+		d.write("WITH LOCAL CHECK OPTION ")
+	case ViewCheckOption_CASCADED_CHECK_OPTION:
+		d.write("WITH CHECK OPTION ")
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) dropStmt(stmt *DropStmt) {
+	d.write("DROP ")
+
+	switch stmt.RemoveType {
+	case ObjectType_OBJECT_ACCESS_METHOD:
+		d.write("ACCESS METHOD ")
+	case ObjectType_OBJECT_AGGREGATE:
+		d.write("AGGREGATE ")
+	case ObjectType_OBJECT_CAST:
+		d.write("CAST ")
+	case ObjectType_OBJECT_COLLATION:
+		d.write("COLLATION ")
+	case ObjectType_OBJECT_CONVERSION:
+		d.write("CONVERSION ")
+	case ObjectType_OBJECT_DOMAIN:
+		d.write("DOMAIN ")
+	case ObjectType_OBJECT_EVENT_TRIGGER:
+		d.write("EVENT TRIGGER ")
+	case ObjectType_OBJECT_EXTENSION:
+		d.write("EXTENSION ")
+	case ObjectType_OBJECT_FDW:
+		d.write("FOREIGN DATA WRAPPER ")
+	case ObjectType_OBJECT_FOREIGN_SERVER:
+		d.write("SERVER ")
+	case ObjectType_OBJECT_FOREIGN_TABLE:
+		d.write("FOREIGN TABLE ")
+	case ObjectType_OBJECT_FUNCTION:
+		d.write("FUNCTION ")
+	case ObjectType_OBJECT_INDEX:
+		d.write("INDEX ")
+	case ObjectType_OBJECT_LANGUAGE:
+		d.write("LANGUAGE ")
+	case ObjectType_OBJECT_MATVIEW:
+		d.write("MATERIALIZED VIEW ")
+	case ObjectType_OBJECT_OPCLASS:
+		d.write("OPERATOR CLASS ")
+	case ObjectType_OBJECT_OPERATOR:
+		d.write("OPERATOR ")
+	case ObjectType_OBJECT_OPFAMILY:
+		d.write("OPERATOR FAMILY ")
+	case ObjectType_OBJECT_POLICY:
+		d.write("POLICY ")
+	case ObjectType_OBJECT_PROCEDURE:
+		d.write("PROCEDURE ")
+	case ObjectType_OBJECT_PUBLICATION:
+		d.write("PUBLICATION ")
+	case ObjectType_OBJECT_ROUTINE:
+		d.write("ROUTINE ")
+	case ObjectType_OBJECT_RULE:
+		d.write("RULE ")
+	case ObjectType_OBJECT_SCHEMA:
+		d.write("SCHEMA ")
+	case ObjectType_OBJECT_SEQUENCE:
+		d.write("SEQUENCE ")
+	case ObjectType_OBJECT_STATISTIC_EXT:
+		d.write("STATISTICS ")
+	case ObjectType_OBJECT_TABLE:
+		d.write("TABLE ")
+	case ObjectType_OBJECT_TRANSFORM:
+		d.write("TRANSFORM ")
+	case ObjectType_OBJECT_TRIGGER:
+		d.write("TRIGGER ")
+	case ObjectType_OBJECT_TSCONFIGURATION:
+		d.write("TEXT SEARCH CONFIGURATION ")
+	case ObjectType_OBJECT_TSDICTIONARY:
+		d.write("TEXT SEARCH DICTIONARY ")
+	case ObjectType_OBJECT_TSPARSER:
+		d.write("TEXT SEARCH PARSER ")
+	case ObjectType_OBJECT_TSTEMPLATE:
+		d.write("TEXT SEARCH TEMPLATE ")
+	case ObjectType_OBJECT_TYPE:
+		d.write("TYPE ")
+	case ObjectType_OBJECT_VIEW:
+		d.write("VIEW ")
+	default:
+		// Other object types are not supported here in the parser
+		d.fail("unsupported object type in DROP: %s", stmt.RemoveType)
+	}
+
+	if stmt.Concurrent {
+		d.write("CONCURRENTLY ")
+	}
+
+	if stmt.MissingOk {
+		d.write("IF EXISTS ")
+	}
+
+	switch stmt.RemoveType {
+	// drop_type_any_name
+	case ObjectType_OBJECT_TABLE, ObjectType_OBJECT_SEQUENCE, ObjectType_OBJECT_VIEW,
+		ObjectType_OBJECT_MATVIEW, ObjectType_OBJECT_INDEX, ObjectType_OBJECT_FOREIGN_TABLE,
+		ObjectType_OBJECT_COLLATION, ObjectType_OBJECT_CONVERSION, ObjectType_OBJECT_STATISTIC_EXT,
+		ObjectType_OBJECT_TSPARSER, ObjectType_OBJECT_TSDICTIONARY, ObjectType_OBJECT_TSTEMPLATE,
+		ObjectType_OBJECT_TSCONFIGURATION:
+		d.anyNameList(stmt.Objects)
+		d.writeByte(' ')
+	// drop_type_name
+	case ObjectType_OBJECT_ACCESS_METHOD, ObjectType_OBJECT_EVENT_TRIGGER, ObjectType_OBJECT_EXTENSION,
+		ObjectType_OBJECT_FDW, ObjectType_OBJECT_PUBLICATION, ObjectType_OBJECT_SCHEMA,
+		ObjectType_OBJECT_FOREIGN_SERVER:
+		d.nameList(stmt.Objects)
+		d.writeByte(' ')
+	// drop_type_name_on_any_name
+	case ObjectType_OBJECT_POLICY, ObjectType_OBJECT_RULE, ObjectType_OBJECT_TRIGGER:
+		l := d.singleObject(stmt.Objects)
+		d.colID(strVal(l[len(l)-1]))
+		d.write(" ON ")
+		d.anyNameSkipLast(l)
+		d.writeByte(' ')
+	case ObjectType_OBJECT_CAST:
+		l := d.singleObject(stmt.Objects)
+		if len(l) != 2 {
+			d.fail("malformed DROP CAST object")
+		}
+		d.writeByte('(')
+		d.typeName(l[0].GetTypeName())
+		d.write(" AS ")
+		d.typeName(l[1].GetTypeName())
+		d.writeByte(')')
+		d.writeByte(' ')
+	case ObjectType_OBJECT_OPFAMILY, ObjectType_OBJECT_OPCLASS:
+		l := d.singleObject(stmt.Objects)
+		d.anyNameSkipFirst(l)
+		d.write(" USING ")
+		d.colID(strVal(l[0]))
+		d.writeByte(' ')
+	case ObjectType_OBJECT_TRANSFORM:
+		l := d.singleObject(stmt.Objects)
+		d.write("FOR ")
+		d.typeName(l[0].GetTypeName())
+		d.write(" LANGUAGE ")
+		d.colID(strVal(l[1]))
+		d.writeByte(' ')
+	case ObjectType_OBJECT_LANGUAGE:
+		d.nameList(stmt.Objects)
+		d.writeByte(' ')
+	case ObjectType_OBJECT_TYPE, ObjectType_OBJECT_DOMAIN:
+		for i, object := range stmt.Objects {
+			d.typeName(object.GetTypeName())
+			if i < len(stmt.Objects)-1 {
+				d.write(", ")
+			}
+		}
+		d.writeByte(' ')
+	case ObjectType_OBJECT_AGGREGATE:
+		for i, object := range stmt.Objects {
+			d.aggregateWithArgtypes(object.GetObjectWithArgs())
+			if i < len(stmt.Objects)-1 {
+				d.write(", ")
+			}
+		}
+		d.writeByte(' ')
+	case ObjectType_OBJECT_FUNCTION, ObjectType_OBJECT_PROCEDURE, ObjectType_OBJECT_ROUTINE:
+		for i, object := range stmt.Objects {
+			d.functionWithArgtypes(object.GetObjectWithArgs())
+			if i < len(stmt.Objects)-1 {
+				d.write(", ")
+			}
+		}
+		d.writeByte(' ')
+	case ObjectType_OBJECT_OPERATOR:
+		for i, object := range stmt.Objects {
+			d.operatorWithArgtypes(object.GetObjectWithArgs())
+			if i < len(stmt.Objects)-1 {
+				d.write(", ")
+			}
+		}
+		d.writeByte(' ')
+	}
+
+	d.optDropBehavior(stmt.Behavior)
+
+	d.removeTrailingSpace()
+}
+
+// singleObject returns the name list of the only object in the given list
+func (d *deparser) singleObject(objects []*Node) []*Node {
+	if len(objects) != 1 {
+		d.fail("expected exactly one object, got %d", len(objects))
+	}
+	l := objects[0].GetList().GetItems()
+	if len(l) == 0 {
+		d.fail("empty object name")
+	}
+	return l
+}
+
+func (d *deparser) dropTableSpaceStmt(stmt *DropTableSpaceStmt) {
+	d.write("DROP TABLESPACE ")
+
+	if stmt.MissingOk {
+		d.write("IF EXISTS ")
+	}
+
+	d.write(stmt.Tablespacename)
+}
+
+func (d *deparser) alterObjectDependsStmt(stmt *AlterObjectDependsStmt) {
+	d.write("ALTER ")
+
+	switch stmt.ObjectType {
+	case ObjectType_OBJECT_FUNCTION:
+		d.write("FUNCTION ")
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_PROCEDURE:
+		d.write("PROCEDURE ")
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_ROUTINE:
+		d.write("ROUTINE ")
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_TRIGGER:
+		d.write("TRIGGER ")
+		d.colID(strVal(d.singleObject([]*Node{stmt.Object})[0]))
+		d.write(" ON ")
+		d.rangeVar(stmt.Relation, deparseContextNone)
+	case ObjectType_OBJECT_MATVIEW:
+		d.write("MATERIALIZED VIEW ")
+		d.rangeVar(stmt.Relation, deparseContextNone)
+	case ObjectType_OBJECT_INDEX:
+		d.write("INDEX ")
+		d.rangeVar(stmt.Relation, deparseContextNone)
+	default:
+		// No other object types supported here
+		d.fail("unsupported object type in ALTER ... DEPENDS ON EXTENSION: %s", stmt.ObjectType)
+	}
+	d.writeByte(' ')
+
+	if stmt.Remove {
+		d.write("NO ")
+	}
+
+	d.writef("DEPENDS ON EXTENSION %s", stmt.Extname.GetSval())
+}
+
+func (d *deparser) alterObjectSchemaStmt(stmt *AlterObjectSchemaStmt) {
+	d.write("ALTER ")
+
+	switch stmt.ObjectType {
+	case ObjectType_OBJECT_AGGREGATE:
+		d.write("AGGREGATE ")
+		d.aggregateWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_COLLATION:
+		d.write("COLLATION ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_CONVERSION:
+		d.write("CONVERSION ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_DOMAIN:
+		d.write("DOMAIN ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_EXTENSION:
+		d.write("EXTENSION ")
+		d.write(quoteIdentifier(strVal(stmt.Object)))
+	case ObjectType_OBJECT_FUNCTION:
+		d.write("FUNCTION ")
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_OPERATOR:
+		d.write("OPERATOR ")
+		d.operatorWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_OPCLASS:
+		l := stmt.Object.GetList().GetItems()
+		d.write("OPERATOR CLASS ")
+		d.anyNameSkipFirst(l)
+		d.write(" USING ")
+		d.write(quoteIdentifier(strVal(l[0])))
+	case ObjectType_OBJECT_OPFAMILY:
+		l := stmt.Object.GetList().GetItems()
+		d.write("OPERATOR FAMILY ")
+		d.anyNameSkipFirst(l)
+		d.write(" USING ")
+		d.write(quoteIdentifier(strVal(l[0])))
+	case ObjectType_OBJECT_PROCEDURE:
+		d.write("PROCEDURE ")
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_ROUTINE:
+		d.write("ROUTINE ")
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_TABLE:
+		d.write("TABLE ")
+		if stmt.MissingOk {
+			d.write("IF EXISTS ")
+		}
+		d.rangeVar(stmt.Relation, deparseContextNone)
+	case ObjectType_OBJECT_STATISTIC_EXT:
+		d.write("STATISTICS ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_TSPARSER:
+		d.write("TEXT SEARCH PARSER ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_TSDICTIONARY:
+		d.write("TEXT SEARCH DICTIONARY ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_TSTEMPLATE:
+		d.write("TEXT SEARCH TEMPLATE ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_TSCONFIGURATION:
+		d.write("TEXT SEARCH CONFIGURATION ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_SEQUENCE:
+		d.write("SEQUENCE ")
+		if stmt.MissingOk {
+			d.write("IF EXISTS ")
+		}
+		d.rangeVar(stmt.Relation, deparseContextNone)
+	case ObjectType_OBJECT_VIEW:
+		d.write("VIEW ")
+		if stmt.MissingOk {
+			d.write("IF EXISTS ")
+		}
+		d.rangeVar(stmt.Relation, deparseContextNone)
+	case ObjectType_OBJECT_MATVIEW:
+		d.write("MATERIALIZED VIEW ")
+		if stmt.MissingOk {
+			d.write("IF EXISTS ")
+		}
+		d.rangeVar(stmt.Relation, deparseContextNone)
+	case ObjectType_OBJECT_FOREIGN_TABLE:
+		d.write("FOREIGN TABLE ")
+		if stmt.MissingOk {
+			d.write("IF EXISTS ")
+		}
+		d.rangeVar(stmt.Relation, deparseContextNone)
+	case ObjectType_OBJECT_TYPE:
+		d.write("TYPE ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	default:
+		d.fail("unsupported object type in ALTER ... SET SCHEMA: %s", stmt.ObjectType)
+	}
+
+	d.write(" SET SCHEMA ")
+	d.write(quoteIdentifier(stmt.Newschema))
+}
+
+func (d *deparser) alterTableCmd(cmd *AlterTableCmd, context deparseContext) {
+	options := ""
+	trailingMissingOk := false
+
+	switch cmd.Subtype {
+	case AlterTableType_AT_AddColumn: // add column
+		if context == deparseContextAlterType {
+			d.write("ADD ATTRIBUTE ")
+		} else {
+			d.write("ADD COLUMN ")
+		}
+	case AlterTableType_AT_ColumnDefault: // alter column default
+		d.write("ALTER COLUMN ")
+		if cmd.Def != nil {
+			options = "SET DEFAULT"
+		} else {
+			options = "DROP DEFAULT"
+		}
+	case AlterTableType_AT_DropNotNull: // alter column drop not null
+		d.write("ALTER COLUMN ")
+		options = "DROP NOT NULL"
+	case AlterTableType_AT_SetNotNull: // alter column set not null
+		d.write("ALTER COLUMN ")
+		options = "SET NOT NULL"
+	case AlterTableType_AT_DropExpression: // alter column drop expression
+		d.write("ALTER COLUMN ")
+		options = "DROP EXPRESSION"
+		trailingMissingOk = true
+	case AlterTableType_AT_SetStatistics: // alter column set statistics
+		d.write("ALTER COLUMN ")
+		options = "SET STATISTICS"
+	case AlterTableType_AT_SetOptions: // alter column set ( options )
+		d.write("ALTER COLUMN ")
+		options = "SET"
+	case AlterTableType_AT_ResetOptions: // alter column reset ( options )
+		d.write("ALTER COLUMN ")
+		options = "RESET"
+	case AlterTableType_AT_SetStorage: // alter column set storage
+		d.write("ALTER COLUMN ")
+		options = "SET STORAGE"
+	case AlterTableType_AT_SetCompression: // alter column set compression
+		d.write("ALTER COLUMN ")
+		options = "SET COMPRESSION"
+	case AlterTableType_AT_DropColumn: // drop column
+		if context == deparseContextAlterType {
+			d.write("DROP ATTRIBUTE ")
+		} else {
+			d.write("DROP ")
+		}
+	case AlterTableType_AT_AddIndex: // add index
+		d.write("ADD INDEX ")
+	case AlterTableType_AT_AddConstraint: // add constraint
+		d.write("ADD ")
+	case AlterTableType_AT_AlterConstraint: // alter constraint
+		d.write("ALTER ") // CONSTRAINT keyword gets added by the Constraint itself (when deparsing def)
+	case AlterTableType_AT_ValidateConstraint: // validate constraint
+		d.write("VALIDATE CONSTRAINT ")
+	case AlterTableType_AT_DropConstraint: // drop constraint
+		d.write("DROP CONSTRAINT ")
+	case AlterTableType_AT_AlterColumnType: // alter column type
+		if context == deparseContextAlterType {
+			d.write("ALTER ATTRIBUTE ")
+		} else {
+			d.write("ALTER COLUMN ")
+		}
+		options = "TYPE"
+	case AlterTableType_AT_AlterColumnGenericOptions: // alter column OPTIONS (...)
+		d.write("ALTER COLUMN ")
+		// Handled via special case in def handling
+	case AlterTableType_AT_ChangeOwner: // change owner
+		d.write("OWNER TO ")
+		d.roleSpec(cmd.Newowner)
+	case AlterTableType_AT_ClusterOn: // CLUSTER ON
+		d.write("CLUSTER ON ")
+	case AlterTableType_AT_DropCluster: // SET WITHOUT CLUSTER
+		d.write("SET WITHOUT CLUSTER ")
+	case AlterTableType_AT_SetLogged: // SET LOGGED
+		d.write("SET LOGGED ")
+	case AlterTableType_AT_SetUnLogged: // SET UNLOGGED
+		d.write("SET UNLOGGED ")
+	case AlterTableType_AT_DropOids: // SET WITHOUT OIDS
+		d.write("SET WITHOUT OIDS ")
+	case AlterTableType_AT_SetTableSpace: // SET TABLESPACE
+		d.write("SET TABLESPACE ")
+	case AlterTableType_AT_SetRelOptions: // SET (...) -- AM specific parameters
+		d.write("SET ")
+	case AlterTableType_AT_SetAccessMethod:
+		d.write("SET ACCESS METHOD ")
+	case AlterTableType_AT_ResetRelOptions: // RESET (...) -- AM specific parameters
+		d.write("RESET ")
+	case AlterTableType_AT_EnableTrig: // ENABLE TRIGGER name
+		d.write("ENABLE TRIGGER ")
+	case AlterTableType_AT_EnableAlwaysTrig: // ENABLE ALWAYS TRIGGER name
+		d.write("ENABLE ALWAYS TRIGGER ")
+	case AlterTableType_AT_EnableReplicaTrig: // ENABLE REPLICA TRIGGER name
+		d.write("ENABLE REPLICA TRIGGER ")
+	case AlterTableType_AT_DisableTrig: // DISABLE TRIGGER name
+		d.write("DISABLE TRIGGER ")
+	case AlterTableType_AT_EnableTrigAll: // ENABLE TRIGGER ALL
+		d.write("ENABLE TRIGGER ALL ")
+	case AlterTableType_AT_DisableTrigAll: // DISABLE TRIGGER ALL
+		d.write("DISABLE TRIGGER ALL ")
+	case AlterTableType_AT_EnableTrigUser: // ENABLE TRIGGER USER
+		d.write("ENABLE TRIGGER USER ")
+	case AlterTableType_AT_DisableTrigUser: // DISABLE TRIGGER USER
+		d.write("DISABLE TRIGGER USER ")
+	case AlterTableType_AT_EnableRule: // ENABLE RULE name
+		d.write("ENABLE RULE ")
+	case AlterTableType_AT_EnableAlwaysRule: // ENABLE ALWAYS RULE name
+		d.write("ENABLE ALWAYS RULE ")
+	case AlterTableType_AT_EnableReplicaRule: // ENABLE REPLICA RULE name
+		d.write("ENABLE REPLICA RULE ")
+	case AlterTableType_AT_DisableRule: // DISABLE RULE name
+		d.write("DISABLE RULE ")
+	case AlterTableType_AT_AddInherit: // INHERIT parent
+		d.write("INHERIT ")
+	case AlterTableType_AT_DropInherit: // NO INHERIT parent
+		d.write("NO INHERIT ")
+	case AlterTableType_AT_AddOf: // OF <type_name>
+		d.write("OF ")
+	case AlterTableType_AT_DropOf: // NOT OF
+		d.write("NOT OF ")
+	case AlterTableType_AT_ReplicaIdentity: // REPLICA IDENTITY
+		d.write("REPLICA IDENTITY ")
+	case AlterTableType_AT_EnableRowSecurity: // ENABLE ROW SECURITY
+		d.write("ENABLE ROW LEVEL SECURITY ")
+	case AlterTableType_AT_DisableRowSecurity: // DISABLE ROW SECURITY
+		d.write("DISABLE ROW LEVEL SECURITY ")
+	case AlterTableType_AT_ForceRowSecurity: // FORCE ROW SECURITY
+		d.write("FORCE ROW LEVEL SECURITY ")
+	case AlterTableType_AT_NoForceRowSecurity: // NO FORCE ROW SECURITY
+		d.write("NO FORCE ROW LEVEL SECURITY ")
+	case AlterTableType_AT_GenericOptions: // OPTIONS (...)
+		// Handled in def field handling
+	case AlterTableType_AT_AttachPartition: // ATTACH PARTITION
+		d.write("ATTACH PARTITION ")
+	case AlterTableType_AT_DetachPartition: // DETACH PARTITION
+		d.write("DETACH PARTITION ")
+	case AlterTableType_AT_DetachPartitionFinalize: // DETACH PARTITION FINALIZE
+		d.write("DETACH PARTITION ")
+	case AlterTableType_AT_AddIdentity: // ADD IDENTITY
+		d.write("ALTER ")
+		options = "ADD"
+		// Other details are output via the constraint node (in def field)
+	case AlterTableType_AT_SetIdentity: // SET identity column options
+		d.write("ALTER ")
+	case AlterTableType_AT_DropIdentity: // DROP IDENTITY
+		d.write("ALTER COLUMN ")
+		options = "DROP IDENTITY"
+		trailingMissingOk = true
+	default:
+		// Not present in raw parser output (internal to commands/tablecmds.c)
+		d.fail("unsupported ALTER TABLE subtype: %s", cmd.Subtype)
+	}
+
+	if cmd.MissingOk && !trailingMissingOk {
+		if cmd.Subtype == AlterTableType_AT_AddColumn {
+			d.write("IF NOT EXISTS ")
+		} else {
+			d.write("IF EXISTS ")
+		}
+	}
+
+	if cmd.Name != "" {
+		d.write(quoteIdentifier(cmd.Name))
+		d.writeByte(' ')
+	}
+
+	if cmd.Num > 0 {
+		d.writef("%d ", cmd.Num)
+	}
+
+	if options != "" {
+		d.write(options)
+		d.writeByte(' ')
+	}
+
+	if cmd.MissingOk && trailingMissingOk {
+		d.write("IF EXISTS ")
+	}
+
+	switch cmd.Subtype {
+	case AlterTableType_AT_AttachPartition, AlterTableType_AT_DetachPartition:
+		d.partitionCmd(cmd.Def.GetPartitionCmd())
+		d.writeByte(' ')
+	case AlterTableType_AT_DetachPartitionFinalize:
+		d.partitionCmd(cmd.Def.GetPartitionCmd())
+		d.write("FINALIZE ")
+	case AlterTableType_AT_AddColumn, AlterTableType_AT_AlterColumnType:
+		d.columnDef(cmd.Def.GetColumnDef())
+		d.writeByte(' ')
+	case AlterTableType_AT_ColumnDefault:
+		if cmd.Def != nil {
+			d.expr(cmd.Def)
+			d.writeByte(' ')
+		}
+	case AlterTableType_AT_SetStatistics:
+		d.signedIconst(cmd.Def)
+		d.writeByte(' ')
+	case AlterTableType_AT_SetOptions, AlterTableType_AT_ResetOptions,
+		AlterTableType_AT_SetRelOptions, AlterTableType_AT_ResetRelOptions:
+		d.relOptions(cmd.Def.GetList().GetItems())
+		d.writeByte(' ')
+	case AlterTableType_AT_SetStorage:
+		d.colID(strVal(cmd.Def))
+		d.writeByte(' ')
+	case AlterTableType_AT_SetCompression:
+		if strVal(cmd.Def) == "default" {
+			d.write("DEFAULT")
+		} else {
+			d.colID(strVal(cmd.Def))
+		}
+		d.writeByte(' ')
+	case AlterTableType_AT_AddIdentity, AlterTableType_AT_AddConstraint, AlterTableType_AT_AlterConstraint:
+		d.constraint(cmd.Def.GetConstraint())
+		d.writeByte(' ')
+	case AlterTableType_AT_SetIdentity:
+		d.alterIdentityColumnOptionList(cmd.Def.GetList().GetItems())
+		d.writeByte(' ')
+	case AlterTableType_AT_AlterColumnGenericOptions, AlterTableType_AT_GenericOptions:
+		d.alterGenericOptions(cmd.Def.GetList().GetItems())
+		d.writeByte(' ')
+	case AlterTableType_AT_AddInherit, AlterTableType_AT_DropInherit:
+		d.rangeVar(cmd.Def.GetRangeVar(), deparseContextNone)
+		d.writeByte(' ')
+	case AlterTableType_AT_AddOf:
+		d.typeName(cmd.Def.GetTypeName())
+		d.writeByte(' ')
+	case AlterTableType_AT_ReplicaIdentity:
+		d.replicaIdentityStmt(cmd.Def.GetReplicaIdentityStmt())
+		d.writeByte(' ')
+	default:
+		if cmd.Def != nil {
+			d.fail("unexpected definition for ALTER TABLE subtype %s", cmd.Subtype)
+		}
+	}
+
+	d.optDropBehavior(cmd.Behavior)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) alterTableObjType(objtype ObjectType) deparseContext {
+	switch objtype {
+	case ObjectType_OBJECT_TABLE:
+		d.write("TABLE ")
+	case ObjectType_OBJECT_FOREIGN_TABLE:
+		d.write("FOREIGN TABLE ")
+	case ObjectType_OBJECT_INDEX:
+		d.write("INDEX ")
+	case ObjectType_OBJECT_SEQUENCE:
+		d.write("SEQUENCE ")
+	case ObjectType_OBJECT_VIEW:
+		d.write("VIEW ")
+	case ObjectType_OBJECT_MATVIEW:
+		d.write("MATERIALIZED VIEW ")
+	case ObjectType_OBJECT_TYPE:
+		d.write("TYPE ")
+		return deparseContextAlterType
+	default:
+		d.fail("unsupported object type in ALTER TABLE: %s", objtype)
+	}
+
+	return deparseContextNone
+}
+
+func (d *deparser) alterTableMoveAllStmt(stmt *AlterTableMoveAllStmt) {
+	d.write("ALTER ")
+	d.alterTableObjType(stmt.Objtype)
+
+	d.write("ALL IN TABLESPACE ")
+	d.write(stmt.OrigTablespacename)
+	d.writeByte(' ')
+
+	if len(stmt.Roles) > 0 {
+		d.write("OWNED BY ")
+		d.roleList(stmt.Roles)
+		d.writeByte(' ')
+	}
+
+	d.write("SET TABLESPACE ")
+	d.write(stmt.NewTablespacename)
+	d.writeByte(' ')
+
+	if stmt.Nowait {
+		d.write("NOWAIT")
+	}
+}
+
+func (d *deparser) alterTableStmt(stmt *AlterTableStmt) {
+	d.write("ALTER ")
+	context := d.alterTableObjType(stmt.Objtype)
+
+	if stmt.MissingOk {
+		d.write("IF EXISTS ")
+	}
+
+	d.rangeVar(stmt.Relation, context)
+	d.writeByte(' ')
+
+	for i, cmd := range stmt.Cmds {
+		d.alterTableCmd(cmd.GetAlterTableCmd(), context)
+		if i < len(stmt.Cmds)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+func (d *deparser) alterTableSpaceOptionsStmt(stmt *AlterTableSpaceOptionsStmt) {
+	d.write("ALTER TABLESPACE ")
+	d.colID(stmt.Tablespacename)
+	d.writeByte(' ')
+
+	if stmt.IsReset {
+		d.write("RESET ")
+	} else {
+		d.write("SET ")
+	}
+
+	d.relOptions(stmt.Options)
+}
+
+func (d *deparser) alterDomainStmt(stmt *AlterDomainStmt) {
+	d.write("ALTER DOMAIN ")
+	d.anyName(stmt.TypeName)
+	d.writeByte(' ')
+
+	switch stmt.Subtype {
+	case "T":
+		if stmt.Def != nil {
+			d.write("SET DEFAULT ")
+			d.expr(stmt.Def)
+		} else {
+			d.write("DROP DEFAULT")
+		}
+	case "N":
+		d.write("DROP NOT NULL")
+	case "O":
+		d.write("SET NOT NULL")
+	case "C":
+		d.write("ADD ")
+		d.constraint(stmt.Def.GetConstraint())
+	case "X":
+		d.write("DROP CONSTRAINT ")
+		if stmt.MissingOk {
+			d.write("IF EXISTS ")
+		}
+		d.write(quoteIdentifier(stmt.Name))
+		if stmt.Behavior == DropBehavior_DROP_CASCADE {
+			d.write(" CASCADE")
+		}
+	case "V":
+		d.write("VALIDATE CONSTRAINT ")
+		d.write(quoteIdentifier(stmt.Name))
+	default:
+		// No other subtypes supported by the parser
+		d.fail("unsupported ALTER DOMAIN subtype: %q", stmt.Subtype)
+	}
+}
+
+func (d *deparser) renameStmt(stmt *RenameStmt) {
+	d.write("ALTER ")
+
+	switch stmt.RenameType {
+	case ObjectType_OBJECT_AGGREGATE:
+		d.write("AGGREGATE ")
+	case ObjectType_OBJECT_COLLATION:
+		d.write("COLLATION ")
+	case ObjectType_OBJECT_CONVERSION:
+		d.write("CONVERSION ")
+	case ObjectType_OBJECT_DATABASE:
+		d.write("DATABASE ")
+	case ObjectType_OBJECT_DOMAIN, ObjectType_OBJECT_DOMCONSTRAINT:
+		d.write("DOMAIN ")
+	case ObjectType_OBJECT_FDW:
+		d.write("FOREIGN DATA WRAPPER ")
+	case ObjectType_OBJECT_FUNCTION:
+		d.write("FUNCTION ")
+	case ObjectType_OBJECT_ROLE:
+		d.write("ROLE ")
+	case ObjectType_OBJECT_LANGUAGE:
+		d.write("LANGUAGE ")
+	case ObjectType_OBJECT_OPCLASS:
+		d.write("OPERATOR CLASS ")
+	case ObjectType_OBJECT_OPFAMILY:
+		d.write("OPERATOR FAMILY ")
+	case ObjectType_OBJECT_POLICY:
+		d.write("POLICY ")
+	case ObjectType_OBJECT_PROCEDURE:
+		d.write("PROCEDURE ")
+	case ObjectType_OBJECT_PUBLICATION:
+		d.write("PUBLICATION ")
+	case ObjectType_OBJECT_ROUTINE:
+		d.write("ROUTINE ")
+	case ObjectType_OBJECT_SCHEMA:
+		d.write("SCHEMA ")
+	case ObjectType_OBJECT_FOREIGN_SERVER:
+		d.write("SERVER ")
+	case ObjectType_OBJECT_SUBSCRIPTION:
+		d.write("SUBSCRIPTION ")
+	case ObjectType_OBJECT_TABLE, ObjectType_OBJECT_TABCONSTRAINT:
+		d.write("TABLE ")
+	case ObjectType_OBJECT_COLUMN:
+		switch stmt.RelationType {
+		case ObjectType_OBJECT_TABLE:
+			d.write("TABLE ")
+		case ObjectType_OBJECT_FOREIGN_TABLE:
+			d.write("FOREIGN TABLE ")
+		case ObjectType_OBJECT_VIEW:
+			d.write("VIEW ")
+		case ObjectType_OBJECT_MATVIEW:
+			d.write("MATERIALIZED VIEW ")
+		default:
+			d.fail("unsupported relation type in RENAME COLUMN: %s", stmt.RelationType)
+		}
+	case ObjectType_OBJECT_SEQUENCE:
+		d.write("SEQUENCE ")
+	case ObjectType_OBJECT_VIEW:
+		d.write("VIEW ")
+	case ObjectType_OBJECT_MATVIEW:
+		d.write("MATERIALIZED VIEW ")
+	case ObjectType_OBJECT_INDEX:
+		d.write("INDEX ")
+	case ObjectType_OBJECT_FOREIGN_TABLE:
+		d.write("FOREIGN TABLE ")
+	case ObjectType_OBJECT_RULE:
+		d.write("RULE ")
+	case ObjectType_OBJECT_TRIGGER:
+		d.write("TRIGGER ")
+	case ObjectType_OBJECT_EVENT_TRIGGER:
+		d.write("EVENT TRIGGER ")
+	case ObjectType_OBJECT_TABLESPACE:
+		d.write("TABLESPACE ")
+	case ObjectType_OBJECT_STATISTIC_EXT:
+		d.write("STATISTICS ")
+	case ObjectType_OBJECT_TSPARSER:
+		d.write("TEXT SEARCH PARSER ")
+	case ObjectType_OBJECT_TSDICTIONARY:
+		d.write("TEXT SEARCH DICTIONARY ")
+	case ObjectType_OBJECT_TSTEMPLATE:
+		d.write("TEXT SEARCH TEMPLATE ")
+	case ObjectType_OBJECT_TSCONFIGURATION:
+		d.write("TEXT SEARCH CONFIGURATION ")
+	case ObjectType_OBJECT_TYPE, ObjectType_OBJECT_ATTRIBUTE:
+		d.write("TYPE ")
+	default:
+		d.fail("unsupported object type in RENAME: %s", stmt.RenameType)
+	}
+
+	if stmt.MissingOk {
+		d.write("IF EXISTS ")
+	}
+
+	switch stmt.RenameType {
+	case ObjectType_OBJECT_AGGREGATE:
+		d.aggregateWithArgtypes(stmt.Object.GetObjectWithArgs())
+		d.write(" RENAME ")
+	case ObjectType_OBJECT_DOMCONSTRAINT:
+		d.anyName(stmt.Object.GetList().GetItems())
+		d.write(" RENAME CONSTRAINT ")
+		d.write(quoteIdentifier(stmt.Subname))
+		d.writeByte(' ')
+	case ObjectType_OBJECT_OPCLASS, ObjectType_OBJECT_OPFAMILY:
+		l := stmt.Object.GetList().GetItems()
+		d.anyNameSkipFirst(l)
+		d.write(" USING ")
+		d.write(quoteIdentifier(strVal(l[0])))
+		d.write(" RENAME ")
+	case ObjectType_OBJECT_POLICY:
+		d.write(quoteIdentifier(stmt.Subname))
+		d.write(" ON ")
+		d.rangeVar(stmt.Relation, deparseContextNone)
+		d.write(" RENAME ")
+	case ObjectType_OBJECT_FUNCTION, ObjectType_OBJECT_PROCEDURE, ObjectType_OBJECT_ROUTINE:
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+		d.write(" RENAME ")
+	case ObjectType_OBJECT_SUBSCRIPTION:
+		d.colID(strVal(stmt.Object))
+		d.write(" RENAME ")
+	case ObjectType_OBJECT_TABLE, ObjectType_OBJECT_SEQUENCE, ObjectType_OBJECT_VIEW,
+		ObjectType_OBJECT_MATVIEW, ObjectType_OBJECT_INDEX, ObjectType_OBJECT_FOREIGN_TABLE:
+		d.rangeVar(stmt.Relation, deparseContextNone)
+		d.write(" RENAME ")
+	case ObjectType_OBJECT_COLUMN:
+		d.rangeVar(stmt.Relation, deparseContextNone)
+		d.write(" RENAME COLUMN ")
+		d.write(quoteIdentifier(stmt.Subname))
+		d.writeByte(' ')
+	case ObjectType_OBJECT_TABCONSTRAINT:
+		d.rangeVar(stmt.Relation, deparseContextNone)
+		d.write(" RENAME CONSTRAINT ")
+		d.write(quoteIdentifier(stmt.Subname))
+		d.writeByte(' ')
+	case ObjectType_OBJECT_RULE, ObjectType_OBJECT_TRIGGER:
+		d.write(quoteIdentifier(stmt.Subname))
+		d.write(" ON ")
+		d.rangeVar(stmt.Relation, deparseContextNone)
+		d.write(" RENAME ")
+	case ObjectType_OBJECT_FDW, ObjectType_OBJECT_LANGUAGE, ObjectType_OBJECT_PUBLICATION,
+		ObjectType_OBJECT_FOREIGN_SERVER, ObjectType_OBJECT_EVENT_TRIGGER:
+		d.write(quoteIdentifier(strVal(stmt.Object)))
+		d.write(" RENAME ")
+	case ObjectType_OBJECT_DATABASE, ObjectType_OBJECT_ROLE, ObjectType_OBJECT_SCHEMA,
+		ObjectType_OBJECT_TABLESPACE:
+		d.write(quoteIdentifier(stmt.Subname))
+		d.write(" RENAME ")
+	case ObjectType_OBJECT_COLLATION, ObjectType_OBJECT_CONVERSION, ObjectType_OBJECT_DOMAIN,
+		ObjectType_OBJECT_STATISTIC_EXT, ObjectType_OBJECT_TSPARSER, ObjectType_OBJECT_TSDICTIONARY,
+		ObjectType_OBJECT_TSTEMPLATE, ObjectType_OBJECT_TSCONFIGURATION, ObjectType_OBJECT_TYPE:
+		d.anyName(stmt.Object.GetList().GetItems())
+		d.write(" RENAME ")
+	case ObjectType_OBJECT_ATTRIBUTE:
+		d.rangeVar(stmt.Relation, deparseContextAlterType)
+		d.write(" RENAME ATTRIBUTE ")
+		d.write(quoteIdentifier(stmt.Subname))
+		d.writeByte(' ')
+	}
+
+	d.write("TO ")
+	d.write(quoteIdentifier(stmt.Newname))
+	d.writeByte(' ')
+
+	d.optDropBehavior(stmt.Behavior)
+
+	d.removeTrailingSpace()
+}
+
+// Values of ReplicaIdentityStmt.IdentityType, from catalog/pg_class.h
+const (
+	replicaIdentityDefault = "d"
+	replicaIdentityNothing = "n"
+	replicaIdentityFull    = "f"
+	replicaIdentityIndex   = "i"
+)
+
+func (d *deparser) replicaIdentityStmt(stmt *ReplicaIdentityStmt) {
+	switch stmt.IdentityType {
+	case replicaIdentityNothing:
+		d.write("NOTHING ")
+	case replicaIdentityFull:
+		d.write("FULL ")
+	case replicaIdentityDefault:
+		d.write("DEFAULT ")
+	case replicaIdentityIndex:
+		if stmt.Name == "" {
+			d.fail("missing index name in REPLICA IDENTITY USING INDEX")
+		}
+		d.write("USING INDEX ")
+		d.write(quoteIdentifier(stmt.Name))
+	}
+}
+
+func (d *deparser) transactionStmt(stmt *TransactionStmt) {
+	switch stmt.Kind {
+	case TransactionStmtKind_TRANS_STMT_BEGIN:
+		d.write("BEGIN ")
+		d.transactionModeList(stmt.Options)
+	case TransactionStmtKind_TRANS_STMT_START:
+		d.write("START TRANSACTION ")
+		d.transactionModeList(stmt.Options)
+	case TransactionStmtKind_TRANS_STMT_COMMIT:
+		d.write("COMMIT ")
+		if stmt.Chain {
+			d.write("AND CHAIN ")
+		}
+	case TransactionStmtKind_TRANS_STMT_ROLLBACK:
+		d.write("ROLLBACK ")
+		if stmt.Chain {
+			d.write("AND CHAIN ")
+		}
+	case TransactionStmtKind_TRANS_STMT_SAVEPOINT:
+		d.write("SAVEPOINT ")
+		d.write(quoteIdentifier(stmt.SavepointName))
+	case TransactionStmtKind_TRANS_STMT_RELEASE:
+		d.write("RELEASE ")
+		d.write(quoteIdentifier(stmt.SavepointName))
+	case TransactionStmtKind_TRANS_STMT_ROLLBACK_TO:
+		d.write("ROLLBACK ")
+		d.write("TO SAVEPOINT ")
+		d.write(quoteIdentifier(stmt.SavepointName))
+	case TransactionStmtKind_TRANS_STMT_PREPARE:
+		d.write("PREPARE TRANSACTION ")
+		d.stringLiteral(stmt.Gid)
+	case TransactionStmtKind_TRANS_STMT_COMMIT_PREPARED:
+		d.write("COMMIT PREPARED ")
+		d.stringLiteral(stmt.Gid)
+	case TransactionStmtKind_TRANS_STMT_ROLLBACK_PREPARED:
+		d.write("ROLLBACK PREPARED ")
+		d.stringLiteral(stmt.Gid)
+	}
+
+	d.removeTrailingSpace()
+}
+
+// isSetTimeZoneInterval determines if we hit SET TIME ZONE INTERVAL, that has
+// special syntax not supported for other SET statements
+func isSetTimeZoneInterval(stmt *VariableSetStmt) bool {
+	if stmt.Name != "timezone" || len(stmt.Args) != 1 || stmt.Args[0].GetTypeCast() == nil {
+		return false
+	}
+
+	names := stmt.Args[0].GetTypeCast().GetTypeName().GetNames()
+	return len(names) == 2 && strVal(names[0]) == "pg_catalog" && strVal(names[1]) == "interval"
+}
+
+func (d *deparser) variableSetStmt(stmt *VariableSetStmt) {
+	switch stmt.GetKind() {
+	case VariableSetKind_VAR_SET_VALUE: // SET var = value
+		d.write("SET ")
+		if stmt.IsLocal {
+			d.write("LOCAL ")
+		}
+		if isSetTimeZoneInterval(stmt) {
+			d.write("TIME ZONE ")
+			d.varList(stmt.Args)
+		} else {
+			d.varName(stmt.Name)
+			d.write(" TO ")
+			d.varList(stmt.Args)
+		}
+	case VariableSetKind_VAR_SET_DEFAULT: // SET var TO DEFAULT
+		d.write("SET ")
+		if stmt.IsLocal {
+			d.write("LOCAL ")
+		}
+		d.varName(stmt.Name)
+		d.write(" TO DEFAULT")
+	case VariableSetKind_VAR_SET_CURRENT: // SET var FROM CURRENT
+		d.write("SET ")
+		if stmt.IsLocal {
+			d.write("LOCAL ")
+		}
+		d.varName(stmt.Name)
+		d.write(" FROM CURRENT")
+	case VariableSetKind_VAR_SET_MULTI: // special case for SET TRANSACTION ...
+		d.write("SET ")
+		if stmt.IsLocal {
+			d.write("LOCAL ")
+		}
+		switch stmt.Name {
+		case "TRANSACTION":
+			d.write("TRANSACTION ")
+			d.transactionModeList(stmt.Args)
+		case "SESSION CHARACTERISTICS":
+			d.write("SESSION CHARACTERISTICS AS TRANSACTION ")
+			d.transactionModeList(stmt.Args)
+		case "TRANSACTION SNAPSHOT":
+			d.write("TRANSACTION SNAPSHOT ")
+			d.stringLiteral(stmt.Args[0].GetAConst().GetSval().GetSval())
+		default:
+			d.fail("unsupported SET statement: %s", stmt.Name)
+		}
+	case VariableSetKind_VAR_RESET: // RESET var
+		d.write("RESET ")
+		d.varName(stmt.Name)
+	case VariableSetKind_VAR_RESET_ALL: // RESET ALL
+		d.write("RESET ALL")
+	}
+}
+
+func (d *deparser) dropdbStmt(stmt *DropdbStmt) {
+	d.write("DROP DATABASE ")
+	if stmt.MissingOk {
+		d.write("IF EXISTS ")
+	}
+
+	d.write(quoteIdentifier(stmt.Dbname))
+	d.writeByte(' ')
+
+	if len(stmt.Options) > 0 {
+		d.writeByte('(')
+		for i, option := range stmt.Options {
+			defElem := d.defElem(option)
+			if defElem.Defname == "force" {
+				d.write("FORCE")
+			} else {
+				// Currently there are no other supported values
+				d.fail("unsupported DROP DATABASE option: %s", defElem.Defname)
+			}
+			if i < len(stmt.Options)-1 {
+				d.write(", ")
+			}
+		}
+		d.writeByte(')')
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) vacuumStmt(stmt *VacuumStmt) {
+	if stmt.IsVacuumcmd {
+		d.write("VACUUM ")
+	} else {
+		d.write("ANALYZE ")
+	}
+
+	d.utilityOptionList(stmt.Options)
+
+	for i, item := range stmt.Rels {
+		rel := item.GetVacuumRelation()
+		if rel == nil {
+			d.fail("unpermitted node type in VACUUM relation list: %s", nodeName(item))
+		}
+
+		d.rangeVar(rel.Relation, deparseContextNone)
+		if len(rel.VaCols) > 0 {
+			d.writeByte('(')
+			for j, col := range rel.VaCols {
+				d.write(quoteIdentifier(strVal(col)))
+				if j < len(rel.VaCols)-1 {
+					d.write(", ")
+				}
+			}
+			d.writeByte(')')
+		}
+
+		if i < len(stmt.Rels)-1 {
+			d.write(", ")
+		}
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) loadStmt(stmt *LoadStmt) {
+	d.write("LOAD ")
+	d.stringLiteral(stmt.Filename)
+}
+
+// Lock modes, from storage/lockdefs.h
+const (
+	accessShareLock          = 1
+	rowShareLock             = 2
+	rowExclusiveLock         = 3
+	shareUpdateExclusiveLock = 4
+	shareLock                = 5
+	shareRowExclusiveLock    = 6
+	exclusiveLock            = 7
+	accessExclusiveLock      = 8
+)
+
+func (d *deparser) lockStmt(stmt *LockStmt) {
+	d.write("LOCK TABLE ")
+
+	d.relationExprList(stmt.Relations)
+	d.writeByte(' ')
+
+	if stmt.Mode != accessExclusiveLock {
+		d.write("IN ")
+		switch stmt.Mode {
+		case accessShareLock:
+			d.write("ACCESS SHARE ")
+		case rowShareLock:
+			d.write("ROW SHARE ")
+		case rowExclusiveLock:
+			d.write("ROW EXCLUSIVE ")
+		case shareUpdateExclusiveLock:
+			d.write("SHARE UPDATE EXCLUSIVE ")
+		case shareLock:
+			d.write("SHARE ")
+		case shareRowExclusiveLock:
+			d.write("SHARE ROW EXCLUSIVE ")
+		case exclusiveLock:
+			d.write("EXCLUSIVE ")
+		default:
+			d.fail("unknown lock mode: %d", stmt.Mode)
+		}
+		d.write("MODE ")
+	}
+
+	if stmt.Nowait {
+		d.write("NOWAIT ")
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) constraintsSetStmt(stmt *ConstraintsSetStmt) {
+	d.write("SET CONSTRAINTS ")
+
+	if len(stmt.Constraints) > 0 {
+		d.qualifiedNameList(stmt.Constraints)
+		d.writeByte(' ')
+	} else {
+		d.write("ALL ")
+	}
+
+	if stmt.Deferred {
+		d.write("DEFERRED")
+	} else {
+		d.write("IMMEDIATE")
+	}
+}
+
+func (d *deparser) explainStmt(stmt *ExplainStmt) {
+	d.write("EXPLAIN ")
+
+	d.utilityOptionList(stmt.Options)
+
+	d.explainableStmt(stmt.Query)
+}
+
+// copyOldFormatOption returns whether the COPY option can be written in the pre-9.0 syntax
+func copyOldFormatOption(defElem *DefElem) bool {
+	switch defElem.Defname {
+	case "freeze":
+		return optBooleanValue(defElem.Arg)
+	case "header":
+		return defElem.Arg != nil && optBooleanValue(defElem.Arg)
+	case "format":
+		return strVal(defElem.Arg) == "csv"
+	case "force_quote":
+		return defElem.Arg.GetList() != nil
+	}
+	return false
+}
+
+func (d *deparser) copyStmt(stmt *CopyStmt) {
+	d.write("COPY ")
+
+	if stmt.Relation != nil {
+		d.rangeVar(stmt.Relation, deparseContextNone)
+		if len(stmt.Attlist) > 0 {
+			d.writeByte('(')
+			d.columnList(stmt.Attlist)
+			d.writeByte(')')
+		}
+		d.writeByte(' ')
+	}
+
+	if stmt.Query != nil {
+		d.writeByte('(')
+		d.preparableStmt(stmt.Query)
+		d.write(") ")
+	}
+
+	if stmt.IsFrom {
+		d.write("FROM ")
+	} else {
+		d.write("TO ")
+	}
+
+	if stmt.IsProgram {
+		d.write("PROGRAM ")
+	}
+
+	if stmt.Filename != "" {
+		d.stringLiteral(stmt.Filename)
+		d.writeByte(' ')
+	} else if stmt.IsFrom {
+		d.write("STDIN ")
+	} else {
+		d.write("STDOUT ")
+	}
+
+	if len(stmt.Options) > 0 {
+		// In some cases, equivalent expressions may have slightly different parse trees for COPY
+		// statements. For example the following two statements result in different (but equivalent) parse
+		// trees:
+		//
+		//   - COPY foo FROM STDIN CSV FREEZE
+		//   - COPY foo FROM STDIN WITH (FORMAT CSV, FREEZE)
+		//
+		// In order to make sure we deparse to the "correct" version, we always try to deparse to the older
+		// compact syntax first.
+		oldFmt := true
+		for _, option := range stmt.Options {
+			if !copyOldFormatOption(d.defElem(option)) {
+				oldFmt = false
+				break
+			}
+		}
+
+		if oldFmt {
+			for _, option := range stmt.Options {
+				defElem := d.defElem(option)
+				switch defElem.Defname {
+				case "freeze":
+					d.write("FREEZE ")
+				case "header":
+					d.write("HEADER ")
+				case "format":
+					d.write("CSV ")
+				case "force_quote":
+					d.write("FORCE QUOTE ")
+					d.columnList(defElem.Arg.GetList().GetItems())
+				}
+			}
+		} else {
+			d.write("WITH (")
+			for i, option := range stmt.Options {
+				defElem := d.defElem(option)
+				switch defElem.Defname {
+				case "format":
+					d.write("FORMAT ")
+					switch format := strVal(defElem.Arg); format {
+					case "binary":
+						d.write("BINARY")
+					case "csv":
+						d.write("CSV")
+					case "text":
+						d.write("TEXT")
+					default:
+						d.fail("unknown COPY format: %s", format)
+					}
+				case "freeze":
+					d.write("FREEZE")
+					d.optBoolean(defElem.Arg)
+				case "delimiter":
+					d.write("DELIMITER ")
+					d.stringLiteral(strVal(defElem.Arg))
+				case "null":
+					d.write("NULL ")
+					d.stringLiteral(strVal(defElem.Arg))
+				case "header":
+					d.write("HEADER")
+					d.optBoolean(defElem.Arg)
+				case "quote":
+					d.write("QUOTE ")
+					d.stringLiteral(strVal(defElem.Arg))
+				case "escape":
+					d.write("ESCAPE ")
+					d.stringLiteral(strVal(defElem.Arg))
+				case "force_quote":
+					d.write("FORCE_QUOTE ")
+					switch arg := defElem.Arg.GetNode().(type) {
+					case *Node_AStar:
+						d.writeByte('*')
+					case *Node_List:
+						d.writeByte('(')
+						d.columnList(arg.List.Items)
+						d.writeByte(')')
+					default:
+						d.fail("unpermitted node type in FORCE_QUOTE: %s", nodeName(defElem.Arg))
+					}
+				case "force_not_null":
+					d.write("FORCE_NOT_NULL (")
+					d.columnList(defElem.Arg.GetList().GetItems())
+					d.writeByte(')')
+				case "force_null":
+					d.write("FORCE_NULL (")
+					d.columnList(defElem.Arg.GetList().GetItems())
+					d.writeByte(')')
+				case "encoding":
+					d.write("ENCODING ")
+					d.stringLiteral(strVal(defElem.Arg))
+				default:
+					d.write(quoteIdentifier(defElem.Defname))
+					if defElem.Arg != nil {
+						d.writeByte(' ')
+					}
+
+					switch arg := defElem.Arg.GetNode().(type) {
+					case *Node_String_:
+						d.optBooleanOrString(arg.String_.Sval)
+					case *Node_Integer, *Node_Float:
+						d.numericOnly(defElem.Arg)
+					case *Node_AStar:
+						d.writeByte('*')
+					case *Node_List:
+						d.writeByte('(')
+						for j, item := range arg.List.Items {
+							d.optBooleanOrString(strVal(item))
+							if j < len(arg.List.Items)-1 {
+								d.write(", ")
+							}
+						}
+						d.writeByte(')')
+					}
+				}
+
+				if i < len(stmt.Options)-1 {
+					d.write(", ")
+				}
+			}
+			d.write(") ")
+		}
+	}
+
+	d.whereClause(stmt.WhereClause)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) doStmt(stmt *DoStmt) {
+	d.write("DO ")
+
+	for _, arg := range stmt.Args {
+		defElem := d.defElem(arg)
+		switch defElem.Defname {
+		case "language":
+			d.write("LANGUAGE ")
+			d.write(quoteIdentifier(strVal(defElem.Arg)))
+			d.writeByte(' ')
+		case "as":
+			val := strVal(defElem.Arg)
+			delim := "$$"
+			if strings.Contains(val, "$$") {
+				delim = "$outer$"
+			}
+			d.write(delim)
+			d.write(val)
+			d.write(delim)
+			d.writeByte(' ')
+		}
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) discardStmt(stmt *DiscardStmt) {
+	d.write("DISCARD ")
+	switch stmt.Target {
+	case DiscardMode_DISCARD_ALL:
+		d.write("ALL")
+	case DiscardMode_DISCARD_PLANS:
+		d.write("PLANS")
+	case DiscardMode_DISCARD_SEQUENCES:
+		d.write("SEQUENCES")
+	case DiscardMode_DISCARD_TEMP:
+		d.write("TEMP")
+	}
+}
+
+func (d *deparser) defineStmt(stmt *DefineStmt) {
+	d.write("CREATE ")
+
+	if stmt.Replace {
+		d.write("OR REPLACE ")
+	}
+
+	switch stmt.Kind {
+	case ObjectType_OBJECT_AGGREGATE:
+		d.write("AGGREGATE ")
+	case ObjectType_OBJECT_OPERATOR:
+		d.write("OPERATOR ")
+	case ObjectType_OBJECT_TYPE:
+		d.write("TYPE ")
+	case ObjectType_OBJECT_TSPARSER:
+		d.write("TEXT SEARCH PARSER ")
+	case ObjectType_OBJECT_TSDICTIONARY:
+		d.write("TEXT SEARCH DICTIONARY ")
+	case ObjectType_OBJECT_TSTEMPLATE:
+		d.write("TEXT SEARCH TEMPLATE ")
+	case ObjectType_OBJECT_TSCONFIGURATION:
+		d.write("TEXT SEARCH CONFIGURATION ")
+	case ObjectType_OBJECT_COLLATION:
+		d.write("COLLATION ")
+	default:
+		// This shouldn't happen
+		d.fail("unsupported object type in DefineStmt: %s", stmt.Kind)
+	}
+
+	if stmt.IfNotExists {
+		d.write("IF NOT EXISTS ")
+	}
+
+	switch stmt.Kind {
+	case ObjectType_OBJECT_AGGREGATE:
+		d.funcName(stmt.Defnames)
+	case ObjectType_OBJECT_OPERATOR:
+		d.anyOperator(stmt.Defnames)
+	default:
+		d.anyName(stmt.Defnames)
+	}
+	d.writeByte(' ')
+
+	if !stmt.Oldstyle && stmt.Kind == ObjectType_OBJECT_AGGREGATE {
+		d.aggrArgs(stmt.Args)
+		d.writeByte(' ')
+	}
+
+	if stmt.Kind == ObjectType_OBJECT_COLLATION && len(stmt.Definition) == 1 &&
+		stmt.Definition[0].GetDefElem().GetDefname() == "from" {
+		d.write("FROM ")
+		d.anyName(stmt.Definition[0].GetDefElem().GetArg().GetList().GetItems())
+	} else if len(stmt.Definition) > 0 {
+		d.definition(stmt.Definition)
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) compositeTypeStmt(stmt *CompositeTypeStmt) {
+	d.write("CREATE TYPE ")
+	d.rangeVar(stmt.Typevar, deparseContextCreateType)
+
+	d.write(" AS (")
+	for i, col := range stmt.Coldeflist {
+		d.columnDef(col.GetColumnDef())
+		if i < len(stmt.Coldeflist)-1 {
+			d.write(", ")
+		}
+	}
+	d.writeByte(')')
+}
+
+func (d *deparser) createEnumStmt(stmt *CreateEnumStmt) {
+	d.write("CREATE TYPE ")
+
+	d.anyName(stmt.TypeName)
+	d.write(" AS ENUM (")
+	for i, val := range stmt.Vals {
+		d.stringLiteral(strVal(val))
+		if i < len(stmt.Vals)-1 {
+			d.write(", ")
+		}
+	}
+	d.writeByte(')')
+}
+
+func (d *deparser) createRangeStmt(stmt *CreateRangeStmt) {
+	d.write("CREATE TYPE ")
+	d.anyName(stmt.TypeName)
+	d.write(" AS RANGE ")
+	d.definition(stmt.Params)
+}
+
+func (d *deparser) alterEnumStmt(stmt *AlterEnumStmt) {
+	d.write("ALTER TYPE ")
+	d.anyName(stmt.TypeName)
+	d.writeByte(' ')
+
+	if stmt.OldVal == "" {
+		d.write("ADD VALUE ")
+		if stmt.SkipIfNewValExists {
+			d.write("IF NOT EXISTS ")
+		}
+
+		d.stringLiteral(stmt.NewVal)
+		d.writeByte(' ')
+
+		if stmt.NewValNeighbor != "" {
+			if stmt.NewValIsAfter {
+				d.write("AFTER ")
+			} else {
+				d.write("BEFORE ")
+			}
+			d.stringLiteral(stmt.NewValNeighbor)
+		}
+	} else {
+		d.write("RENAME VALUE ")
+		d.stringLiteral(stmt.OldVal)
+		d.write(" TO ")
+		d.stringLiteral(stmt.NewVal)
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) alterExtensionStmt(stmt *AlterExtensionStmt) {
+	d.write("ALTER EXTENSION ")
+	d.colID(stmt.Extname)
+	d.write(" UPDATE ")
+	for _, option := range stmt.Options {
+		defElem := d.defElem(option)
+		if defElem.Defname == "new_version" {
+			d.write("TO ")
+			d.nonReservedWordOrSconst(strVal(defElem.Arg))
+		} else {
+			d.fail("unknown ALTER EXTENSION option: %s", defElem.Defname)
+		}
+		d.writeByte(' ')
+	}
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) alterExtensionContentsStmt(stmt *AlterExtensionContentsStmt) {
+	d.write("ALTER EXTENSION ")
+	d.colID(stmt.Extname)
+	d.writeByte(' ')
+
+	switch stmt.Action {
+	case 1:
+		d.write("ADD ")
+	case -1:
+		d.write("DROP ")
+	default:
+		d.fail("unknown ALTER EXTENSION action: %d", stmt.Action)
+	}
+
+	switch stmt.Objtype {
+	case ObjectType_OBJECT_ACCESS_METHOD:
+		d.write("ACCESS METHOD ")
+	case ObjectType_OBJECT_AGGREGATE:
+		d.write("AGGREGATE ")
+	case ObjectType_OBJECT_CAST:
+		d.write("CAST ")
+	case ObjectType_OBJECT_COLLATION:
+		d.write("COLLATION ")
+	case ObjectType_OBJECT_CONVERSION:
+		d.write("CONVERSION ")
+	case ObjectType_OBJECT_DOMAIN:
+		d.write("DOMAIN ")
+	case ObjectType_OBJECT_FUNCTION:
+		d.write("FUNCTION ")
+	case ObjectType_OBJECT_LANGUAGE:
+		d.write("LANGUAGE ")
+	case ObjectType_OBJECT_OPERATOR:
+		d.write("OPERATOR ")
+	case ObjectType_OBJECT_OPCLASS:
+		d.write("OPERATOR CLASS ")
+	case ObjectType_OBJECT_OPFAMILY:
+		d.write("OPERATOR FAMILY ")
+	case ObjectType_OBJECT_PROCEDURE:
+		d.write("PROCEDURE ")
+	case ObjectType_OBJECT_ROUTINE:
+		d.write("ROUTINE ")
+	case ObjectType_OBJECT_SCHEMA:
+		d.write("SCHEMA ")
+	case ObjectType_OBJECT_EVENT_TRIGGER:
+		d.write("EVENT TRIGGER ")
+	case ObjectType_OBJECT_TABLE:
+		d.write("TABLE ")
+	case ObjectType_OBJECT_TSPARSER:
+		d.write("TEXT SEARCH PARSER ")
+	case ObjectType_OBJECT_TSDICTIONARY:
+		d.write("TEXT SEARCH DICTIONARY ")
+	case ObjectType_OBJECT_TSTEMPLATE:
+		d.write("TEXT SEARCH TEMPLATE ")
+	case ObjectType_OBJECT_TSCONFIGURATION:
+		d.write("TEXT SEARCH CONFIGURATION ")
+	case ObjectType_OBJECT_SEQUENCE:
+		d.write("SEQUENCE ")
+	case ObjectType_OBJECT_VIEW:
+		d.write("VIEW ")
+	case ObjectType_OBJECT_MATVIEW:
+		d.write("MATERIALIZED VIEW ")
+	case ObjectType_OBJECT_FOREIGN_TABLE:
+		d.write("FOREIGN TABLE ")
+	case ObjectType_OBJECT_FDW:
+		d.write("FOREIGN DATA WRAPPER ")
+	case ObjectType_OBJECT_FOREIGN_SERVER:
+		d.write("SERVER ")
+	case ObjectType_OBJECT_TRANSFORM:
+		d.write("TRANSFORM ")
+	case ObjectType_OBJECT_TYPE:
+		d.write("TYPE ")
+	default:
+		// No other object types are supported here in the parser
+		d.fail("unsupported object type in ALTER EXTENSION: %s", stmt.Objtype)
+	}
+
+	switch stmt.Objtype {
+	// any_name
+	case ObjectType_OBJECT_COLLATION, ObjectType_OBJECT_CONVERSION, ObjectType_OBJECT_TABLE,
+		ObjectType_OBJECT_TSPARSER, ObjectType_OBJECT_TSDICTIONARY, ObjectType_OBJECT_TSTEMPLATE,
+		ObjectType_OBJECT_TSCONFIGURATION, ObjectType_OBJECT_SEQUENCE, ObjectType_OBJECT_VIEW,
+		ObjectType_OBJECT_MATVIEW, ObjectType_OBJECT_FOREIGN_TABLE:
+		d.anyName(stmt.Object.GetList().GetItems())
+	// name
+	case ObjectType_OBJECT_ACCESS_METHOD, ObjectType_OBJECT_LANGUAGE, ObjectType_OBJECT_SCHEMA,
+		ObjectType_OBJECT_EVENT_TRIGGER, ObjectType_OBJECT_FDW, ObjectType_OBJECT_FOREIGN_SERVER:
+		d.colID(strVal(stmt.Object))
+	case ObjectType_OBJECT_AGGREGATE:
+		d.aggregateWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_CAST:
+		l := stmt.Object.GetList().GetItems()
+		if len(l) != 2 {
+			d.fail("malformed cast in ALTER EXTENSION")
+		}
+		d.writeByte('(')
+		d.typeName(l[0].GetTypeName())
+		d.write(" AS ")
+		d.typeName(l[1].GetTypeName())
+		d.writeByte(')')
+	case ObjectType_OBJECT_DOMAIN, ObjectType_OBJECT_TYPE:
+		d.typeName(stmt.Object.GetTypeName())
+	case ObjectType_OBJECT_FUNCTION, ObjectType_OBJECT_PROCEDURE, ObjectType_OBJECT_ROUTINE:
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_OPERATOR:
+		d.operatorWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_OPFAMILY, ObjectType_OBJECT_OPCLASS:
+		l := stmt.Object.GetList().GetItems()
+		d.anyNameSkipFirst(l)
+		d.write(" USING ")
+		d.colID(strVal(l[0]))
+	case ObjectType_OBJECT_TRANSFORM:
+		l := stmt.Object.GetList().GetItems()
+		d.write("FOR ")
+		d.typeName(l[0].GetTypeName())
+		d.write(" LANGUAGE ")
+		d.colID(strVal(l[1]))
+	}
+}
+
+func (d *deparser) accessPriv(accessPriv *AccessPriv) {
+	if accessPriv.PrivName != "" {
+		switch accessPriv.PrivName {
+		case "select", "references", "create":
+			d.write(accessPriv.PrivName)
+		default:
+			d.write(quoteIdentifier(accessPriv.PrivName))
+		}
+	} else {
+		d.write("ALL")
+	}
+	d.writeByte(' ')
+
+	if len(accessPriv.Cols) > 0 {
+		d.writeByte('(')
+		d.columnList(accessPriv.Cols)
+		d.writeByte(')')
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) grantStmt(stmt *GrantStmt) {
+	if stmt.IsGrant {
+		d.write("GRANT ")
+	} else {
+		d.write("REVOKE ")
+	}
+
+	if !stmt.IsGrant && stmt.GrantOption {
+		d.write("GRANT OPTION FOR ")
+	}
+
+	if len(stmt.Privileges) > 0 {
+		for i, priv := range stmt.Privileges {
+			d.accessPriv(priv.GetAccessPriv())
+			if i < len(stmt.Privileges)-1 {
+				d.write(", ")
+			}
+		}
+		d.writeByte(' ')
+	} else {
+		d.write("ALL ")
+	}
+
+	d.write("ON ")
+
+	d.privilegeTarget(stmt.Targtype, stmt.Objtype, stmt.Objects)
+	d.writeByte(' ')
+
+	if stmt.IsGrant {
+		d.write("TO ")
+	} else {
+		d.write("FROM ")
+	}
+
+	for i, grantee := range stmt.Grantees {
+		d.roleSpec(grantee.GetRoleSpec())
+		if i < len(stmt.Grantees)-1 {
+			d.writeByte(',')
+		}
+		d.writeByte(' ')
+	}
+
+	if stmt.IsGrant && stmt.GrantOption {
+		d.write("WITH GRANT OPTION ")
+	}
+
+	d.optDropBehavior(stmt.Behavior)
+
+	if stmt.Grantor != nil {
+		d.write("GRANTED BY ")
+		d.roleSpec(stmt.Grantor)
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) grantRoleStmt(stmt *GrantRoleStmt) {
+	if stmt.IsGrant {
+		d.write("GRANT ")
+	} else {
+		d.write("REVOKE ")
+	}
+
+	if !stmt.IsGrant && len(stmt.Opt) > 0 {
+		switch stmt.Opt[0].GetDefElem().GetDefname() {
+		case "admin":
+			d.write("ADMIN ")
+		case "inherit":
+			d.write("INHERIT ")
+		case "set":
+			d.write("SET ")
+		}
+
+		d.write("OPTION FOR ")
+	}
+
+	for i, role := range stmt.GrantedRoles {
+		d.accessPriv(role.GetAccessPriv())
+		if i < len(stmt.GrantedRoles)-1 {
+			d.writeByte(',')
+		}
+		d.writeByte(' ')
+	}
+
+	if stmt.IsGrant {
+		d.write("TO ")
+	} else {
+		d.write("FROM ")
+	}
+
+	d.roleList(stmt.GranteeRoles)
+	d.writeByte(' ')
+
+	if stmt.IsGrant {
+		if len(stmt.Opt) > 0 {
+			d.write("WITH ")
+		}
+
+		for i, opt := range stmt.Opt {
+			defElem := d.defElem(opt)
+			switch defElem.Defname {
+			case "admin", "inherit", "set":
+				d.write(strings.ToUpper(defElem.Defname))
+				if boolVal(defElem.Arg) {
+					d.write(" OPTION")
+				} else {
+					d.write(" FALSE")
+				}
+			}
+
+			if i < len(stmt.Opt)-1 {
+				d.writeByte(',')
+			}
+
+			d.writeByte(' ')
+		}
+	}
+
+	if stmt.Grantor != nil {
+		d.write("GRANTED BY ")
+		d.roleSpec(stmt.Grantor)
+	}
+
+	if stmt.Behavior == DropBehavior_DROP_CASCADE {
+		d.write("CASCADE ")
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) dropRoleStmt(stmt *DropRoleStmt) {
+	d.write("DROP ROLE ")
+
+	if stmt.MissingOk {
+		d.write("IF EXISTS ")
+	}
+
+	d.roleList(stmt.Roles)
+}
+
+func (d *deparser) indexStmt(stmt *IndexStmt) {
+	d.write("CREATE ")
+
+	if stmt.Unique {
+		d.write("UNIQUE ")
+	}
+
+	d.write("INDEX ")
+
+	if stmt.Concurrent {
+		d.write("CONCURRENTLY ")
+	}
+
+	if stmt.IfNotExists {
+		d.write("IF NOT EXISTS ")
+	}
+
+	if stmt.Idxname != "" {
+		d.write(quoteIdentifier(stmt.Idxname))
+		d.writeByte(' ')
+	}
+
+	d.write("ON ")
+	d.rangeVar(stmt.Relation, deparseContextNone)
+	d.writeByte(' ')
+
+	if stmt.AccessMethod != "" {
+		d.write("USING ")
+		d.write(quoteIdentifier(stmt.AccessMethod))
+		d.writeByte(' ')
+	}
+
+	d.writeByte('(')
+	for i, param := range stmt.IndexParams {
+		d.indexElem(param.GetIndexElem())
+		if i < len(stmt.IndexParams)-1 {
+			d.write(", ")
+		}
+	}
+	d.write(") ")
+
+	if len(stmt.IndexIncludingParams) > 0 {
+		d.write("INCLUDE (")
+		for i, param := range stmt.IndexIncludingParams {
+			d.indexElem(param.GetIndexElem())
+			if i < len(stmt.IndexIncludingParams)-1 {
+				d.write(", ")
+			}
+		}
+		d.write(") ")
+	}
+
+	if stmt.NullsNotDistinct {
+		d.write("NULLS NOT DISTINCT ")
+	}
+
+	d.optWith(stmt.Options)
+
+	if stmt.TableSpace != "" {
+		d.write("TABLESPACE ")
+		d.write(quoteIdentifier(stmt.TableSpace))
+		d.writeByte(' ')
+	}
+
+	d.whereClause(stmt.WhereClause)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) alterOpFamilyStmt(stmt *AlterOpFamilyStmt) {
+	d.write("ALTER OPERATOR FAMILY ")
+	d.anyName(stmt.Opfamilyname)
+	d.writeByte(' ')
+
+	d.write("USING ")
+	d.write(quoteIdentifier(stmt.Amname))
+	d.writeByte(' ')
+
+	if stmt.IsDrop {
+		d.write("DROP ")
+	} else {
+		d.write("ADD ")
+	}
+
+	d.opclassItemList(stmt.Items)
+}
+
+func (d *deparser) prepareStmt(stmt *PrepareStmt) {
+	d.write("PREPARE ")
+	d.colID(stmt.Name)
+	if len(stmt.Argtypes) > 0 {
+		d.writeByte('(')
+		d.typeList(stmt.Argtypes)
+		d.writeByte(')')
+	}
+	d.write(" AS ")
+	d.preparableStmt(stmt.Query)
+}
+
+func (d *deparser) executeStmt(stmt *ExecuteStmt) {
+	d.write("EXECUTE ")
+	d.write(quoteIdentifier(stmt.Name))
+	if len(stmt.Params) > 0 {
+		d.writeByte('(')
+		d.exprList(stmt.Params)
+		d.writeByte(')')
+	}
+}
+
+func (d *deparser) deallocateStmt(stmt *DeallocateStmt) {
+	d.write("DEALLOCATE ")
+	if stmt.Name != "" {
+		d.write(quoteIdentifier(stmt.Name))
+	} else {
+		d.write("ALL")
+	}
+}
+
+// roleFlags maps the boolean role options to their keywords when enabled and disabled
+var roleFlags = map[string][2]string{
+	"superuser":     {"SUPERUSER", "NOSUPERUSER"},
+	"createrole":    {"CREATEROLE", "NOCREATEROLE"},
+	"isreplication": {"REPLICATION", "NOREPLICATION"},
+	"createdb":      {"CREATEDB", "NOCREATEDB"},
+	"canlogin":      {"LOGIN", "NOLOGIN"},
+	"bypassrls":     {"BYPASSRLS", "NOBYPASSRLS"},
+	"inherit":       {"INHERIT", "NOINHERIT"},
+}
+
+// "AlterOptRoleElem" in gram.y
+func (d *deparser) alterRoleElem(defElem *DefElem) {
+	switch defElem.Defname {
+	case "password":
+		d.write("PASSWORD ")
+		switch arg := defElem.Arg.GetNode().(type) {
+		case nil:
+			d.write("NULL")
+		case *Node_ParamRef:
+			d.paramRef(arg.ParamRef)
+		case *Node_String_:
+			d.stringLiteral(arg.String_.Sval)
+		default:
+			d.fail("unpermitted node type in PASSWORD: %s", nodeName(defElem.Arg))
+		}
+	case "connectionlimit":
+		d.writef("CONNECTION LIMIT %d", intVal(defElem.Arg))
+	case "validUntil":
+		d.write("VALID UNTIL ")
+		d.stringLiteral(strVal(defElem.Arg))
+	default:
+		flag, ok := roleFlags[defElem.Defname]
+		if !ok {
+			d.fail("unknown role option: %s", defElem.Defname)
+		}
+		if boolVal(defElem.Arg) {
+			d.write(flag[0])
+		} else {
+			d.write(flag[1])
+		}
+	}
+}
+
+// "CreateOptRoleElem" in gram.y
+func (d *deparser) createRoleElem(defElem *DefElem) {
+	switch defElem.Defname {
+	case "sysid":
+		d.writef("SYSID %d", intVal(defElem.Arg))
+	case "adminmembers":
+		d.write("ADMIN ")
+		d.roleList(defElem.Arg.GetList().GetItems())
+	case "rolemembers":
+		d.write("ROLE ")
+		d.roleList(defElem.Arg.GetList().GetItems())
+	case "addroleto":
+		d.write("IN ROLE ")
+		d.roleList(defElem.Arg.GetList().GetItems())
+	default:
+		d.alterRoleElem(defElem)
+	}
+}
+
+func (d *deparser) createPLangStmt(stmt *CreatePLangStmt) {
+	d.write("CREATE ")
+
+	if stmt.Replace {
+		d.write("OR REPLACE ")
+	}
+
+	if stmt.Pltrusted {
+		d.write("TRUSTED ")
+	}
+
+	d.write("LANGUAGE ")
+	d.nonReservedWordOrSconst(stmt.Plname)
+	d.writeByte(' ')
+
+	d.write("HANDLER ")
+	d.handlerName(stmt.Plhandler)
+	d.writeByte(' ')
+
+	if len(stmt.Plinline) > 0 {
+		d.write("INLINE ")
+		d.handlerName(stmt.Plinline)
+		d.writeByte(' ')
+	}
+
+	if len(stmt.Plvalidator) > 0 {
+		d.write("VALIDATOR ")
+		d.handlerName(stmt.Plvalidator)
+		d.writeByte(' ')
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) createRoleStmt(stmt *CreateRoleStmt) {
+	d.write("CREATE ")
+
+	switch stmt.StmtType {
+	case RoleStmtType_ROLESTMT_ROLE:
+		d.write("ROLE ")
+	case RoleStmtType_ROLESTMT_USER:
+		d.write("USER ")
+	case RoleStmtType_ROLESTMT_GROUP:
+		d.write("GROUP ")
+	}
+
+	d.write(quoteIdentifier(stmt.Role))
+	d.writeByte(' ')
+
+	if len(stmt.Options) > 0 {
+		d.write("WITH ")
+		for _, option := range stmt.Options {
+			d.createRoleElem(d.defElem(option))
+			d.writeByte(' ')
+		}
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) alterRoleStmt(stmt *AlterRoleStmt) {
+	d.write("ALTER ")
+
+	if len(stmt.Options) == 1 && stmt.Options[0].GetDefElem().GetDefname() == "rolemembers" {
+		d.write("GROUP ")
+		d.roleSpec(stmt.Role)
+		d.writeByte(' ')
+
+		switch stmt.Action {
+		case 1:
+			d.write("ADD USER ")
+		case -1:
+			d.write("DROP USER ")
+		default:
+			d.fail("unexpected ALTER GROUP action: %d", stmt.Action)
+		}
+
+		d.roleList(stmt.Options[0].GetDefElem().GetArg().GetList().GetItems())
+	} else {
+		d.write("ROLE ")
+		d.roleSpec(stmt.Role)
+		d.writeByte(' ')
+
+		d.write("WITH ")
+		for _, option := range stmt.Options {
+			d.alterRoleElem(d.defElem(option))
+			d.writeByte(' ')
+		}
+	}
+
+	d.removeTrailingSpace()
+}
+
+// Cursor options, see CURSOR_OPT_* in parsenodes.h
+const (
+	cursorOptBinary      = 0x0001
+	cursorOptScroll      = 0x0002
+	cursorOptNoScroll    = 0x0004
+	cursorOptInsensitive = 0x0008
+	cursorOptHold        = 0x0020
+)
+
+func (d *deparser) declareCursorStmt(stmt *DeclareCursorStmt) {
+	d.write("DECLARE ")
+	d.write(quoteIdentifier(stmt.Portalname))
+	d.writeByte(' ')
+
+	if stmt.Options&cursorOptBinary != 0 {
+		d.write("BINARY ")
+	}
+
+	if stmt.Options&cursorOptScroll != 0 {
+		d.write("SCROLL ")
+	}
+
+	if stmt.Options&cursorOptNoScroll != 0 {
+		d.write("NO SCROLL ")
+	}
+
+	if stmt.Options&cursorOptInsensitive != 0 {
+		d.write("INSENSITIVE ")
+	}
+
+	d.write("CURSOR ")
+
+	if stmt.Options&cursorOptHold != 0 {
+		d.write("WITH HOLD ")
+	}
+
+	d.write("FOR ")
+
+	d.selectStmt(stmt.Query.GetSelectStmt())
+}
+
+// fetchAll is the HowMany value of FETCH ALL (LONG_MAX)
+const fetchAll = math.MaxInt64
+
+func (d *deparser) fetchStmt(stmt *FetchStmt) {
+	if stmt.Ismove {
+		d.write("MOVE ")
+	} else {
+		d.write("FETCH ")
+	}
+
+	switch stmt.Direction {
+	case FetchDirection_FETCH_FORWARD:
+		if stmt.HowMany == 1 {
+			// Default
+		} else if stmt.HowMany == fetchAll {
+			d.write("ALL ")
+		} else {
+			d.writef("FORWARD %d ", stmt.HowMany)
+		}
+	case FetchDirection_FETCH_BACKWARD:
+		if stmt.HowMany == 1 {
+			d.write("PRIOR ")
+		} else if stmt.HowMany == fetchAll {
+			d.write("BACKWARD ALL ")
+		} else {
+			d.writef("BACKWARD %d ", stmt.HowMany)
+		}
+	case FetchDirection_FETCH_ABSOLUTE:
+		if stmt.HowMany == 1 {
+			d.write("FIRST ")
+		} else if stmt.HowMany == -1 {
+			d.write("LAST ")
+		} else {
+			d.writef("ABSOLUTE %d ", stmt.HowMany)
+		}
+	case FetchDirection_FETCH_RELATIVE:
+		d.writef("RELATIVE %d ", stmt.HowMany)
+	}
+
+	d.write(stmt.Portalname)
+}
+
+func (d *deparser) alterDefaultPrivilegesStmt(stmt *AlterDefaultPrivilegesStmt) {
+	d.write("ALTER DEFAULT PRIVILEGES ")
+
+	for _, option := range stmt.Options {
+		defElem := d.defElem(option)
+		switch defElem.GetDefname() {
+		case "schemas":
+			d.write("IN SCHEMA ")
+			d.nameList(defElem.Arg.GetList().GetItems())
+			d.writeByte(' ')
+		case "roles":
+			d.write("FOR ROLE ")
+			d.roleList(defElem.Arg.GetList().GetItems())
+			d.writeByte(' ')
+		default:
+			d.fail("unsupported ALTER DEFAULT PRIVILEGES option: %s", defElem.GetDefname())
+		}
+	}
+
+	d.grantStmt(stmt.Action)
+}
+
+func (d *deparser) reindexStmt(stmt *ReindexStmt) {
+	d.write("REINDEX ")
+
+	d.utilityOptionList(stmt.Params)
+
+	switch stmt.Kind {
+	case ReindexObjectType_REINDEX_OBJECT_INDEX:
+		d.write("INDEX ")
+	case ReindexObjectType_REINDEX_OBJECT_TABLE:
+		d.write("TABLE ")
+	case ReindexObjectType_REINDEX_OBJECT_SCHEMA:
+		d.write("SCHEMA ")
+	case ReindexObjectType_REINDEX_OBJECT_SYSTEM:
+		d.write("SYSTEM ")
+	case ReindexObjectType_REINDEX_OBJECT_DATABASE:
+		d.write("DATABASE ")
+	}
+
+	if stmt.Relation != nil {
+		d.rangeVar(stmt.Relation, deparseContextNone)
+	} else if stmt.Name != "" {
+		d.write(quoteIdentifier(stmt.Name))
+	}
+}
+
+func (d *deparser) ruleStmt(stmt *RuleStmt) {
+	d.write("CREATE ")
+
+	if stmt.Replace {
+		d.write("OR REPLACE ")
+	}
+
+	d.write("RULE ")
+	d.write(quoteIdentifier(stmt.Rulename))
+	d.write(" AS ON ")
+
+	switch stmt.Event {
+	case CmdType_CMD_SELECT:
+		d.write("SELECT ")
+	case CmdType_CMD_UPDATE:
+		d.write("UPDATE ")
+	case CmdType_CMD_INSERT:
+		d.write("INSERT ")
+	case CmdType_CMD_DELETE:
+		d.write("DELETE ")
+	case CmdType_CMD_MERGE:
+		d.write("MERGE ")
+	default:
+		d.fail("unsupported rule event: %s", stmt.Event)
+	}
+
+	d.write("TO ")
+	d.rangeVar(stmt.Relation, deparseContextNone)
+	d.writeByte(' ')
+
+	d.whereClause(stmt.WhereClause)
+
+	d.write("DO ")
+
+	if stmt.Instead {
+		d.write("INSTEAD ")
+	}
+
+	switch len(stmt.Actions) {
+	case 0:
+		d.write("NOTHING")
+	case 1:
+		d.ruleActionStmt(stmt.Actions[0])
+	default:
+		d.writeByte('(')
+		for i, action := range stmt.Actions {
+			d.ruleActionStmt(action)
+			if i < len(stmt.Actions)-1 {
+				d.write("; ")
+			}
+		}
+		d.writeByte(')')
+	}
+}
+
+func (d *deparser) notifyStmt(stmt *NotifyStmt) {
+	d.write("NOTIFY ")
+	d.write(quoteIdentifier(stmt.Conditionname))
+
+	if stmt.Payload != "" {
+		d.write(", ")
+		d.stringLiteral(stmt.Payload)
+	}
+}
+
+func (d *deparser) listenStmt(stmt *ListenStmt) {
+	d.write("LISTEN ")
+	d.write(quoteIdentifier(stmt.Conditionname))
+}
+
+func (d *deparser) unlistenStmt(stmt *UnlistenStmt) {
+	d.write("UNLISTEN ")
+	if stmt.Conditionname == "" {
+		d.write("*")
+	} else {
+		d.write(quoteIdentifier(stmt.Conditionname))
+	}
+}
+
+func (d *deparser) createSeqStmt(stmt *CreateSeqStmt) {
+	d.write("CREATE ")
+
+	if stmt.Sequence == nil {
+		d.fail("missing relation")
+	}
+	d.optTemp(stmt.Sequence.GetRelpersistence())
+
+	d.write("SEQUENCE ")
+
+	if stmt.IfNotExists {
+		d.write("IF NOT EXISTS ")
+	}
+
+	d.rangeVar(stmt.Sequence, deparseContextNone)
+	d.writeByte(' ')
+
+	d.optSeqOptList(stmt.Options)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) alterFunctionStmt(stmt *AlterFunctionStmt) {
+	d.write("ALTER ")
+
+	switch stmt.Objtype {
+	case ObjectType_OBJECT_FUNCTION:
+		d.write("FUNCTION ")
+	case ObjectType_OBJECT_PROCEDURE:
+		d.write("PROCEDURE ")
+	case ObjectType_OBJECT_ROUTINE:
+		d.write("ROUTINE ")
+	default:
+		d.fail("unsupported ALTER FUNCTION object type: %s", stmt.Objtype)
+	}
+
+	d.functionWithArgtypes(stmt.Func)
+	d.writeByte(' ')
+
+	for i, action := range stmt.Actions {
+		d.commonFuncOptItem(d.defElem(action))
+		if i < len(stmt.Actions)-1 {
+			d.writeByte(' ')
+		}
+	}
+}
+
+func (d *deparser) truncateStmt(stmt *TruncateStmt) {
+	d.write("TRUNCATE ")
+
+	d.relationExprList(stmt.Relations)
+	d.writeByte(' ')
+
+	if stmt.RestartSeqs {
+		d.write("RESTART IDENTITY ")
+	}
+
+	d.optDropBehavior(stmt.Behavior)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) createEventTrigStmt(stmt *CreateEventTrigStmt) {
+	d.write("CREATE EVENT TRIGGER ")
+	d.write(quoteIdentifier(stmt.Trigname))
+	d.writeByte(' ')
+
+	d.write("ON ")
+	d.write(quoteIdentifier(stmt.Eventname))
+	d.writeByte(' ')
+
+	if len(stmt.Whenclause) > 0 {
+		d.write("WHEN ")
+
+		for i, clause := range stmt.Whenclause {
+			defElem := d.defElem(clause)
+			d.write(quoteIdentifier(defElem.Defname))
+			d.write(" IN (")
+			items := defElem.Arg.GetList().GetItems()
+			for j, item := range items {
+				d.stringLiteral(strVal(item))
+				if j < len(items)-1 {
+					d.write(", ")
+				}
+			}
+			d.writeByte(')')
+			if i < len(stmt.Whenclause)-1 {
+				d.write(" AND ")
+			}
+		}
+
+		d.writeByte(' ')
+	}
+
+	d.write("EXECUTE FUNCTION ")
+	d.funcName(stmt.Funcname)
+	d.write("()")
+}
+
+// Trigger firing modes, see TRIGGER_FIRES_* in trigger.h
+const (
+	triggerFiresOnOrigin  = "O"
+	triggerFiresAlways    = "A"
+	triggerFiresOnReplica = "R"
+	triggerDisabled       = "D"
+)
+
+func (d *deparser) alterEventTrigStmt(stmt *AlterEventTrigStmt) {
+	d.write("ALTER EVENT TRIGGER ")
+	d.write(quoteIdentifier(stmt.Trigname))
+	d.writeByte(' ')
+
+	switch stmt.Tgenabled {
+	case triggerFiresOnOrigin:
+		d.write("ENABLE")
+	case triggerFiresOnReplica:
+		d.write("ENABLE REPLICA")
+	case triggerFiresAlways:
+		d.write("ENABLE ALWAYS")
+	case triggerDisabled:
+		d.write("DISABLE")
+	}
+}
+
+func (d *deparser) refreshMatViewStmt(stmt *RefreshMatViewStmt) {
+	d.write("REFRESH MATERIALIZED VIEW ")
+
+	if stmt.Concurrent {
+		d.write("CONCURRENTLY ")
+	}
+
+	d.rangeVar(stmt.Relation, deparseContextNone)
+	d.writeByte(' ')
+
+	if stmt.SkipData {
+		d.write("WITH NO DATA ")
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) createPolicyStmt(stmt *CreatePolicyStmt) {
+	d.write("CREATE POLICY ")
+	d.colID(stmt.PolicyName)
+	d.write(" ON ")
+	d.rangeVar(stmt.Table, deparseContextNone)
+	d.writeByte(' ')
+
+	if !stmt.Permissive {
+		d.write("AS RESTRICTIVE ")
+	}
+
+	switch stmt.CmdName {
+	case "all":
+		// Default
+	case "select":
+		d.write("FOR SELECT ")
+	case "insert":
+		d.write("FOR INSERT ")
+	case "update":
+		d.write("FOR UPDATE ")
+	case "delete":
+		d.write("FOR DELETE ")
+	default:
+		d.fail("unsupported policy command: %s", stmt.CmdName)
+	}
+
+	d.write("TO ")
+	d.roleList(stmt.Roles)
+	d.writeByte(' ')
+
+	if stmt.Qual != nil {
+		d.write("USING (")
+		d.expr(stmt.Qual)
+		d.write(") ")
+	}
+
+	if stmt.WithCheck != nil {
+		d.write("WITH CHECK (")
+		d.expr(stmt.WithCheck)
+		d.write(") ")
+	}
+}
+
+func (d *deparser) alterPolicyStmt(stmt *AlterPolicyStmt) {
+	d.write("ALTER POLICY ")
+	d.write(quoteIdentifier(stmt.PolicyName))
+	d.write(" ON ")
+	d.rangeVar(stmt.Table, deparseContextNone)
+	d.writeByte(' ')
+
+	if len(stmt.Roles) > 0 {
+		d.write("TO ")
+		d.roleList(stmt.Roles)
+		d.writeByte(' ')
+	}
+
+	if stmt.Qual != nil {
+		d.write("USING (")
+		d.expr(stmt.Qual)
+		d.write(") ")
+	}
+
+	if stmt.WithCheck != nil {
+		d.write("WITH CHECK (")
+		d.expr(stmt.WithCheck)
+		d.write(") ")
+	}
+}
+
+func (d *deparser) createTableSpaceStmt(stmt *CreateTableSpaceStmt) {
+	d.write("CREATE TABLESPACE ")
+	d.colID(stmt.Tablespacename)
+	d.writeByte(' ')
+
+	if stmt.Owner != nil {
+		d.write("OWNER ")
+		d.roleSpec(stmt.Owner)
+		d.writeByte(' ')
+	}
+
+	d.write("LOCATION ")
+
+	if stmt.Location != "" {
+		d.stringLiteral(stmt.Location)
+	} else {
+		d.write("''")
+	}
+
+	d.writeByte(' ')
+
+	d.optWith(stmt.Options)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) createTransformStmt(stmt *CreateTransformStmt) {
+	d.write("CREATE ")
+	if stmt.Replace {
+		d.write("OR REPLACE ")
+	}
+
+	d.write("TRANSFORM FOR ")
+	d.typeName(stmt.TypeName)
+	d.writeByte(' ')
+
+	d.write("LANGUAGE ")
+	d.write(quoteIdentifier(stmt.Lang))
+	d.writeByte(' ')
+
+	d.writeByte('(')
+
+	if stmt.Fromsql != nil {
+		d.write("FROM SQL WITH FUNCTION ")
+		d.functionWithArgtypes(stmt.Fromsql)
+	}
+
+	if stmt.Fromsql != nil && stmt.Tosql != nil {
+		d.write(", ")
+	}
+
+	if stmt.Tosql != nil {
+		d.write("TO SQL WITH FUNCTION ")
+		d.functionWithArgtypes(stmt.Tosql)
+	}
+
+	d.writeByte(')')
+}
+
+// Access method types, see AMTYPE_* in pg_am.h
+const (
+	amtypeIndex = "i"
+	amtypeTable = "t"
+)
+
+func (d *deparser) createAmStmt(stmt *CreateAmStmt) {
+	d.write("CREATE ACCESS METHOD ")
+	d.write(quoteIdentifier(stmt.Amname))
+	d.writeByte(' ')
+
+	d.write("TYPE ")
+	switch stmt.Amtype {
+	case amtypeIndex:
+		d.write("INDEX ")
+	case amtypeTable:
+		d.write("TABLE ")
+	}
+
+	d.write("HANDLER ")
+	d.handlerName(stmt.HandlerName)
+}
+
+func (d *deparser) publicationObjectList(pubobjects []*Node) {
+	for i, node := range pubobjects {
+		obj := node.GetPublicationObjSpec()
+
+		switch obj.GetPubobjtype() {
+		case PublicationObjSpecType_PUBLICATIONOBJ_TABLE:
+			d.write("TABLE ")
+			d.rangeVar(obj.Pubtable.GetRelation(), deparseContextNone)
+
+			if len(obj.Pubtable.GetColumns()) > 0 {
+				d.writeByte('(')
+				d.columnList(obj.Pubtable.Columns)
+				d.writeByte(')')
+			}
+
+			if obj.Pubtable.GetWhereClause() != nil {
+				d.write(" WHERE (")
+				d.expr(obj.Pubtable.WhereClause)
+				d.write(")")
+			}
+		case PublicationObjSpecType_PUBLICATIONOBJ_TABLES_IN_SCHEMA:
+			d.write("TABLES IN SCHEMA ")
+			d.write(quoteIdentifier(obj.Name))
+		case PublicationObjSpecType_PUBLICATIONOBJ_TABLES_IN_CUR_SCHEMA:
+			d.write("TABLES IN SCHEMA CURRENT_SCHEMA")
+		default:
+			// The parser merges continuations before we get here
+			d.fail("unexpected publication object type: %s", obj.GetPubobjtype())
+		}
+
+		if i < len(pubobjects)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+func (d *deparser) createPublicationStmt(stmt *CreatePublicationStmt) {
+	d.write("CREATE PUBLICATION ")
+	d.write(quoteIdentifier(stmt.Pubname))
+	d.writeByte(' ')
+
+	if len(stmt.Pubobjects) > 0 {
+		d.write("FOR ")
+		d.publicationObjectList(stmt.Pubobjects)
+		d.writeByte(' ')
+	} else if stmt.ForAllTables {
+		d.write("FOR ALL TABLES ")
+	}
+
+	d.optDefinition(stmt.Options)
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) alterPublicationStmt(stmt *AlterPublicationStmt) {
+	d.write("ALTER PUBLICATION ")
+	d.colID(stmt.Pubname)
+	d.writeByte(' ')
+
+	if len(stmt.Pubobjects) > 0 {
+		switch stmt.Action {
+		case AlterPublicationAction_AP_SetObjects:
+			d.write("SET ")
+		case AlterPublicationAction_AP_AddObjects:
+			d.write("ADD ")
+		case AlterPublicationAction_AP_DropObjects:
+			d.write("DROP ")
+		}
+
+		d.publicationObjectList(stmt.Pubobjects)
+	} else if len(stmt.Options) > 0 {
+		d.write("SET ")
+		d.definition(stmt.Options)
+	} else {
+		d.fail("ALTER PUBLICATION without objects or options")
+	}
+}
+
+func (d *deparser) alterSeqStmt(stmt *AlterSeqStmt) {
+	d.write("ALTER SEQUENCE ")
+
+	if stmt.MissingOk {
+		d.write("IF EXISTS ")
+	}
+
+	d.rangeVar(stmt.Sequence, deparseContextNone)
+	d.writeByte(' ')
+
+	d.seqOptList(stmt.Options)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) alterSystemStmt(stmt *AlterSystemStmt) {
+	d.write("ALTER SYSTEM ")
+	d.variableSetStmt(stmt.Setstmt)
+}
+
+// commentObjectTypes holds the keywords of the object types supported by COMMENT ON
+var commentObjectTypes = map[ObjectType]string{
+	ObjectType_OBJECT_COLUMN:          "COLUMN",
+	ObjectType_OBJECT_INDEX:           "INDEX",
+	ObjectType_OBJECT_SEQUENCE:        "SEQUENCE",
+	ObjectType_OBJECT_STATISTIC_EXT:   "STATISTICS",
+	ObjectType_OBJECT_TABLE:           "TABLE",
+	ObjectType_OBJECT_VIEW:            "VIEW",
+	ObjectType_OBJECT_MATVIEW:         "MATERIALIZED VIEW",
+	ObjectType_OBJECT_COLLATION:       "COLLATION",
+	ObjectType_OBJECT_CONVERSION:      "CONVERSION",
+	ObjectType_OBJECT_FOREIGN_TABLE:   "FOREIGN TABLE",
+	ObjectType_OBJECT_TSCONFIGURATION: "TEXT SEARCH CONFIGURATION",
+	ObjectType_OBJECT_TSDICTIONARY:    "TEXT SEARCH DICTIONARY",
+	ObjectType_OBJECT_TSPARSER:        "TEXT SEARCH PARSER",
+	ObjectType_OBJECT_TSTEMPLATE:      "TEXT SEARCH TEMPLATE",
+	ObjectType_OBJECT_ACCESS_METHOD:   "ACCESS METHOD",
+	ObjectType_OBJECT_DATABASE:        "DATABASE",
+	ObjectType_OBJECT_EVENT_TRIGGER:   "EVENT TRIGGER",
+	ObjectType_OBJECT_EXTENSION:       "EXTENSION",
+	ObjectType_OBJECT_FDW:             "FOREIGN DATA WRAPPER",
+	ObjectType_OBJECT_LANGUAGE:        "LANGUAGE",
+	ObjectType_OBJECT_PUBLICATION:     "PUBLICATION",
+	ObjectType_OBJECT_ROLE:            "ROLE",
+	ObjectType_OBJECT_SCHEMA:          "SCHEMA",
+	ObjectType_OBJECT_FOREIGN_SERVER:  "SERVER",
+	ObjectType_OBJECT_SUBSCRIPTION:    "SUBSCRIPTION",
+	ObjectType_OBJECT_TABLESPACE:      "TABLESPACE",
+	ObjectType_OBJECT_TYPE:            "TYPE",
+	ObjectType_OBJECT_DOMAIN:          "DOMAIN",
+	ObjectType_OBJECT_AGGREGATE:       "AGGREGATE",
+	ObjectType_OBJECT_FUNCTION:        "FUNCTION",
+	ObjectType_OBJECT_OPERATOR:        "OPERATOR",
+	ObjectType_OBJECT_TABCONSTRAINT:   "CONSTRAINT",
+	ObjectType_OBJECT_DOMCONSTRAINT:   "CONSTRAINT",
+	ObjectType_OBJECT_POLICY:          "POLICY",
+	ObjectType_OBJECT_PROCEDURE:       "PROCEDURE",
+	ObjectType_OBJECT_ROUTINE:         "ROUTINE",
+	ObjectType_OBJECT_RULE:            "RULE",
+	ObjectType_OBJECT_TRANSFORM:       "TRANSFORM",
+	ObjectType_OBJECT_TRIGGER:         "TRIGGER",
+	ObjectType_OBJECT_OPCLASS:         "OPERATOR CLASS",
+	ObjectType_OBJECT_OPFAMILY:        "OPERATOR FAMILY",
+	ObjectType_OBJECT_LARGEOBJECT:     "LARGE OBJECT",
+	ObjectType_OBJECT_CAST:            "CAST",
+}
+
+func (d *deparser) commentStmt(stmt *CommentStmt) {
+	d.write("COMMENT ON ")
+
+	keyword, ok := commentObjectTypes[stmt.Objtype]
+	if !ok {
+		d.fail("unsupported COMMENT ON object type: %s", stmt.Objtype)
+	}
+	d.write(keyword)
+	d.writeByte(' ')
+
+	l := stmt.Object.GetList().GetItems()
+	switch stmt.Objtype {
+	case ObjectType_OBJECT_COLUMN, ObjectType_OBJECT_INDEX, ObjectType_OBJECT_SEQUENCE,
+		ObjectType_OBJECT_STATISTIC_EXT, ObjectType_OBJECT_TABLE, ObjectType_OBJECT_VIEW,
+		ObjectType_OBJECT_MATVIEW, ObjectType_OBJECT_COLLATION, ObjectType_OBJECT_CONVERSION,
+		ObjectType_OBJECT_FOREIGN_TABLE, ObjectType_OBJECT_TSCONFIGURATION, ObjectType_OBJECT_TSDICTIONARY,
+		ObjectType_OBJECT_TSPARSER, ObjectType_OBJECT_TSTEMPLATE:
+		d.anyName(l)
+	case ObjectType_OBJECT_ACCESS_METHOD, ObjectType_OBJECT_DATABASE, ObjectType_OBJECT_EVENT_TRIGGER,
+		ObjectType_OBJECT_EXTENSION, ObjectType_OBJECT_FDW, ObjectType_OBJECT_LANGUAGE,
+		ObjectType_OBJECT_PUBLICATION, ObjectType_OBJECT_ROLE, ObjectType_OBJECT_SCHEMA,
+		ObjectType_OBJECT_FOREIGN_SERVER, ObjectType_OBJECT_SUBSCRIPTION, ObjectType_OBJECT_TABLESPACE:
+		d.write(quoteIdentifier(strVal(stmt.Object)))
+	case ObjectType_OBJECT_TYPE, ObjectType_OBJECT_DOMAIN:
+		d.typeName(stmt.Object.GetTypeName())
+	case ObjectType_OBJECT_AGGREGATE:
+		d.aggregateWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_FUNCTION, ObjectType_OBJECT_PROCEDURE, ObjectType_OBJECT_ROUTINE:
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_OPERATOR:
+		d.operatorWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_TABCONSTRAINT, ObjectType_OBJECT_POLICY, ObjectType_OBJECT_RULE, ObjectType_OBJECT_TRIGGER:
+		d.write(quoteIdentifier(strVal(l[len(l)-1])))
+		d.write(" ON ")
+		d.anyNameSkipLast(l)
+	case ObjectType_OBJECT_DOMCONSTRAINT:
+		d.write(quoteIdentifier(strVal(l[len(l)-1])))
+		d.write(" ON DOMAIN ")
+		d.typeName(l[0].GetTypeName())
+	case ObjectType_OBJECT_TRANSFORM:
+		d.write("FOR ")
+		d.typeName(l[0].GetTypeName())
+		d.write(" LANGUAGE ")
+		d.write(quoteIdentifier(strVal(l[1])))
+	case ObjectType_OBJECT_OPCLASS, ObjectType_OBJECT_OPFAMILY:
+		d.anyNameSkipFirst(l)
+		d.write(" USING ")
+		d.write(quoteIdentifier(strVal(l[0])))
+	case ObjectType_OBJECT_LARGEOBJECT:
+		d.value(stmt.Object, deparseContextNone)
+	case ObjectType_OBJECT_CAST:
+		d.writeByte('(')
+		d.typeName(l[0].GetTypeName())
+		d.write(" AS ")
+		d.typeName(l[1].GetTypeName())
+		d.writeByte(')')
+	}
+
+	d.write(" IS ")
+
+	if stmt.Comment != "" {
+		d.stringLiteral(stmt.Comment)
+	} else {
+		d.write("NULL")
+	}
+}
+
+func (d *deparser) statsElem(elem *StatsElem) {
+	// Only one of name or expr can be set
+	if elem.Name != "" {
+		d.write(elem.Name)
+	} else if elem.Expr != nil {
+		d.writeByte('(')
+		d.expr(elem.Expr)
+		d.writeByte(')')
+	}
+}
+
+func (d *deparser) createStatsStmt(stmt *CreateStatsStmt) {
+	d.write("CREATE STATISTICS ")
+
+	if stmt.IfNotExists {
+		d.write("IF NOT EXISTS ")
+	}
+
+	d.anyName(stmt.Defnames)
+	d.writeByte(' ')
+
+	if len(stmt.StatTypes) > 0 {
+		d.writeByte('(')
+		d.nameList(stmt.StatTypes)
+		d.write(") ")
+	}
+
+	d.write("ON ")
+	for i, expr := range stmt.Exprs {
+		d.statsElem(expr.GetStatsElem())
+		if i < len(stmt.Exprs)-1 {
+			d.write(", ")
+		}
+	}
+
+	d.write(" FROM ")
+	d.fromList(stmt.Relations)
+}
+
+func (d *deparser) alterCollationStmt(stmt *AlterCollationStmt) {
+	d.write("ALTER COLLATION ")
+	d.anyName(stmt.Collname)
+	d.write(" REFRESH VERSION")
+}
+
+func (d *deparser) alterDatabaseStmt(stmt *AlterDatabaseStmt) {
+	d.write("ALTER DATABASE ")
+	d.colID(stmt.Dbname)
+	d.writeByte(' ')
+	d.createdbOptList(stmt.Options)
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) alterDatabaseSetStmt(stmt *AlterDatabaseSetStmt) {
+	d.write("ALTER DATABASE ")
+	d.colID(stmt.Dbname)
+	d.writeByte(' ')
+	d.variableSetStmt(stmt.Setstmt)
+}
+
+func (d *deparser) alterStatsStmt(stmt *AlterStatsStmt) {
+	d.write("ALTER STATISTICS ")
+
+	if stmt.MissingOk {
+		d.write("IF EXISTS ")
+	}
+
+	d.anyName(stmt.Defnames)
+	d.writeByte(' ')
+
+	d.writef("SET STATISTICS %d", stmt.Stxstattarget)
+}
+
+func (d *deparser) alterTSDictionaryStmt(stmt *AlterTSDictionaryStmt) {
+	d.write("ALTER TEXT SEARCH DICTIONARY ")
+
+	d.anyName(stmt.Dictname)
+	d.writeByte(' ')
+
+	d.definition(stmt.Options)
+}
+
+func (d *deparser) alterTSConfigurationStmt(stmt *AlterTSConfigurationStmt) {
+	d.write("ALTER TEXT SEARCH CONFIGURATION ")
+	d.anyName(stmt.Cfgname)
+	d.writeByte(' ')
+
+	switch stmt.Kind {
+	case AlterTSConfigType_ALTER_TSCONFIG_ADD_MAPPING:
+		d.write("ADD MAPPING FOR ")
+		d.nameList(stmt.Tokentype)
+		d.write(" WITH ")
+		d.anyNameList(stmt.Dicts)
+	case AlterTSConfigType_ALTER_TSCONFIG_ALTER_MAPPING_FOR_TOKEN:
+		d.write("ALTER MAPPING FOR ")
+		d.nameList(stmt.Tokentype)
+		d.write(" WITH ")
+		d.anyNameList(stmt.Dicts)
+	case AlterTSConfigType_ALTER_TSCONFIG_REPLACE_DICT:
+		d.write("ALTER MAPPING REPLACE ")
+		d.anyName(stmt.Dicts[0].GetList().GetItems())
+		d.write(" WITH ")
+		d.anyName(stmt.Dicts[1].GetList().GetItems())
+	case AlterTSConfigType_ALTER_TSCONFIG_REPLACE_DICT_FOR_TOKEN:
+		d.write("ALTER MAPPING FOR ")
+		d.nameList(stmt.Tokentype)
+		d.write(" REPLACE ")
+		d.anyName(stmt.Dicts[0].GetList().GetItems())
+		d.write(" WITH ")
+		d.anyName(stmt.Dicts[1].GetList().GetItems())
+	case AlterTSConfigType_ALTER_TSCONFIG_DROP_MAPPING:
+		d.write("DROP MAPPING ")
+		if stmt.MissingOk {
+			d.write("IF EXISTS ")
+		}
+		d.write("FOR ")
+		d.nameList(stmt.Tokentype)
+	}
+}
+
+func (d *deparser) variableShowStmt(stmt *VariableShowStmt) {
+	d.write("SHOW ")
+
+	switch stmt.Name {
+	case "timezone":
+		d.write("TIME ZONE")
+	case "transaction_isolation":
+		d.write("TRANSACTION ISOLATION LEVEL")
+	case "session_authorization":
+		d.write("SESSION AUTHORIZATION")
+	case "all":
+		d.write("ALL")
+	default:
+		d.write(quoteIdentifier(stmt.Name))
+	}
+}
+
+func (d *deparser) checkPointStmt(stmt *CheckPointStmt) {
+	d.write("CHECKPOINT")
+}
+
+// publicationNames appends the publication names of a subscription
+func (d *deparser) publicationNames(publication []*Node) {
+	for i, name := range publication {
+		d.colLabel(strVal(name))
+		if i < len(publication)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+func (d *deparser) createSubscriptionStmt(stmt *CreateSubscriptionStmt) {
+	d.write("CREATE SUBSCRIPTION ")
+	d.write(quoteIdentifier(stmt.Subname))
+
+	d.write(" CONNECTION ")
+	if stmt.Conninfo != "" {
+		d.stringLiteral(stmt.Conninfo)
+	} else {
+		d.write("''")
+	}
+
+	d.write(" PUBLICATION ")
+
+	d.publicationNames(stmt.Publication)
+	d.writeByte(' ')
+
+	d.optDefinition(stmt.Options)
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) alterSubscriptionStmt(stmt *AlterSubscriptionStmt) {
+	d.write("ALTER SUBSCRIPTION ")
+	d.write(quoteIdentifier(stmt.Subname))
+	d.writeByte(' ')
+
+	switch stmt.Kind {
+	case AlterSubscriptionType_ALTER_SUBSCRIPTION_OPTIONS:
+		d.write("SET ")
+		d.definition(stmt.Options)
+	case AlterSubscriptionType_ALTER_SUBSCRIPTION_SKIP:
+		d.write("SKIP ")
+		d.definition(stmt.Options)
+	case AlterSubscriptionType_ALTER_SUBSCRIPTION_CONNECTION:
+		d.write("CONNECTION ")
+		d.stringLiteral(stmt.Conninfo)
+		d.writeByte(' ')
+	case AlterSubscriptionType_ALTER_SUBSCRIPTION_REFRESH:
+		d.write("REFRESH PUBLICATION ")
+		d.optDefinition(stmt.Options)
+	case AlterSubscriptionType_ALTER_SUBSCRIPTION_ADD_PUBLICATION:
+		d.write("ADD PUBLICATION ")
+		d.publicationNames(stmt.Publication)
+		d.writeByte(' ')
+		d.optDefinition(stmt.Options)
+	case AlterSubscriptionType_ALTER_SUBSCRIPTION_DROP_PUBLICATION:
+		d.write("DROP PUBLICATION ")
+		d.publicationNames(stmt.Publication)
+		d.writeByte(' ')
+		d.optDefinition(stmt.Options)
+	case AlterSubscriptionType_ALTER_SUBSCRIPTION_SET_PUBLICATION:
+		d.write("SET PUBLICATION ")
+		d.publicationNames(stmt.Publication)
+		d.writeByte(' ')
+		d.optDefinition(stmt.Options)
+	case AlterSubscriptionType_ALTER_SUBSCRIPTION_ENABLED:
+		if len(stmt.Options) != 1 || stmt.Options[0].GetDefElem().GetDefname() != "enabled" {
+			d.fail("unexpected options for ALTER SUBSCRIPTION ENABLE/DISABLE")
+		}
+		if optBooleanValue(stmt.Options[0].GetDefElem().Arg) {
+			d.write(" ENABLE ")
+		} else {
+			d.write(" DISABLE ")
+		}
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) dropSubscriptionStmt(stmt *DropSubscriptionStmt) {
+	d.write("DROP SUBSCRIPTION ")
+
+	if stmt.MissingOk {
+		d.write("IF EXISTS ")
+	}
+
+	d.write(stmt.Subname)
+}
+
+func (d *deparser) callStmt(stmt *CallStmt) {
+	d.write("CALL ")
+	d.funcCall(stmt.Funccall)
+}
+
+func (d *deparser) alterOwnerStmt(stmt *AlterOwnerStmt) {
+	d.write("ALTER ")
+
+	switch stmt.ObjectType {
+	case ObjectType_OBJECT_AGGREGATE:
+		d.write("AGGREGATE ")
+		d.aggregateWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_COLLATION:
+		d.write("COLLATION ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_CONVERSION:
+		d.write("CONVERSION ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_DATABASE:
+		d.write("DATABASE ")
+		d.colID(strVal(stmt.Object))
+	case ObjectType_OBJECT_DOMAIN:
+		d.write("DOMAIN ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_FUNCTION:
+		d.write("FUNCTION ")
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_LANGUAGE:
+		d.write("LANGUAGE ")
+		d.colID(strVal(stmt.Object))
+	case ObjectType_OBJECT_LARGEOBJECT:
+		d.write("LARGE OBJECT ")
+		d.numericOnly(stmt.Object)
+	case ObjectType_OBJECT_OPERATOR:
+		d.write("OPERATOR ")
+		d.operatorWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_OPCLASS:
+		l := stmt.Object.GetList().GetItems()
+		d.write("OPERATOR CLASS ")
+		d.anyNameSkipFirst(l)
+		d.write(" USING ")
+		d.colID(strVal(l[0]))
+	case ObjectType_OBJECT_OPFAMILY:
+		l := stmt.Object.GetList().GetItems()
+		d.write("OPERATOR FAMILY ")
+		d.anyNameSkipFirst(l)
+		d.write(" USING ")
+		d.colID(strVal(l[0]))
+	case ObjectType_OBJECT_PROCEDURE:
+		d.write("PROCEDURE ")
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_ROUTINE:
+		d.write("ROUTINE ")
+		d.functionWithArgtypes(stmt.Object.GetObjectWithArgs())
+	case ObjectType_OBJECT_SCHEMA:
+		d.write("SCHEMA ")
+		d.colID(strVal(stmt.Object))
+	case ObjectType_OBJECT_TYPE:
+		d.write("TYPE ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_TABLESPACE:
+		d.write("TABLESPACE ")
+		d.colID(strVal(stmt.Object))
+	case ObjectType_OBJECT_STATISTIC_EXT:
+		d.write("STATISTICS ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_TSDICTIONARY:
+		d.write("TEXT SEARCH DICTIONARY ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_TSCONFIGURATION:
+		d.write("TEXT SEARCH CONFIGURATION ")
+		d.anyName(stmt.Object.GetList().GetItems())
+	case ObjectType_OBJECT_FDW:
+		d.write("FOREIGN DATA WRAPPER ")
+		d.colID(strVal(stmt.Object))
+	case ObjectType_OBJECT_FOREIGN_SERVER:
+		d.write("SERVER ")
+		d.colID(strVal(stmt.Object))
+	case ObjectType_OBJECT_EVENT_TRIGGER:
+		d.write("EVENT TRIGGER ")
+		d.colID(strVal(stmt.Object))
+	case ObjectType_OBJECT_PUBLICATION:
+		d.write("PUBLICATION ")
+		d.colID(strVal(stmt.Object))
+	case ObjectType_OBJECT_SUBSCRIPTION:
+		d.write("SUBSCRIPTION ")
+		d.colID(strVal(stmt.Object))
+	default:
+		d.fail("unsupported ALTER OWNER object type: %s", stmt.ObjectType)
+	}
+
+	d.write(" OWNER TO ")
+	d.roleSpec(stmt.Newowner)
+}
+
+// "operator_def_list" in gram.y
+func (d *deparser) operatorDefList(defs []*Node) {
+	for i, def := range defs {
+		defElem := d.defElem(def)
+		d.write(quoteIdentifier(defElem.GetDefname()))
+		d.write(" = ")
+		if defElem.GetArg() != nil {
+			d.defArg(defElem.Arg, true)
+		} else {
+			d.write("NONE")
+		}
+
+		if i < len(defs)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+func (d *deparser) alterOperatorStmt(stmt *AlterOperatorStmt) {
+	d.write("ALTER OPERATOR ")
+	d.operatorWithArgtypes(stmt.Opername)
+	d.write(" SET (")
+	d.operatorDefList(stmt.Options)
+	d.writeByte(')')
+}
+
+func (d *deparser) alterTypeStmt(stmt *AlterTypeStmt) {
+	d.write("ALTER TYPE ")
+	d.anyName(stmt.TypeName)
+	d.write(" SET (")
+	d.operatorDefList(stmt.Options)
+	d.writeByte(')')
+}
+
+func (d *deparser) dropOwnedStmt(stmt *DropOwnedStmt) {
+	d.write("DROP OWNED BY ")
+	d.roleList(stmt.Roles)
+	d.writeByte(' ')
+	d.optDropBehavior(stmt.Behavior)
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) reassignOwnedStmt(stmt *ReassignOwnedStmt) {
+	d.write("REASSIGN OWNED BY ")
+
+	d.roleList(stmt.Roles)
+	d.writeByte(' ')
+
+	d.write("TO ")
+	d.roleSpec(stmt.Newrole)
+}
+
+func (d *deparser) closePortalStmt(stmt *ClosePortalStmt) {
+	d.write("CLOSE ")
+	if stmt.Portalname != "" {
+		d.write(quoteIdentifier(stmt.Portalname))
+	} else {
+		d.write("ALL")
+	}
+}
+
+// Trigger type bits, see TRIGGER_TYPE_* in pg_trigger.h
+const (
+	triggerTypeBefore   = 1 << 1
+	triggerTypeInsert   = 1 << 2
+	triggerTypeDelete   = 1 << 3
+	triggerTypeUpdate   = 1 << 4
+	triggerTypeTruncate = 1 << 5
+	triggerTypeInstead  = 1 << 6
+	triggerTypeAfter    = 0
+)
+
+func (d *deparser) createTrigStmt(stmt *CreateTrigStmt) {
+	d.write("CREATE ")
+	if stmt.Replace {
+		d.write("OR REPLACE ")
+	}
+	if stmt.Isconstraint {
+		d.write("CONSTRAINT ")
+	}
+	d.write("TRIGGER ")
+
+	d.write(quoteIdentifier(stmt.Trigname))
+	d.writeByte(' ')
+
+	switch stmt.Timing {
+	case triggerTypeBefore:
+		d.write("BEFORE ")
+	case triggerTypeAfter:
+		d.write("AFTER ")
+	case triggerTypeInstead:
+		d.write("INSTEAD OF ")
+	default:
+		d.fail("unsupported trigger timing: %d", stmt.Timing)
+	}
+
+	skipEventsOr := true
+	if stmt.Events&triggerTypeInsert != 0 {
+		d.write("INSERT ")
+		skipEventsOr = false
+	}
+	if stmt.Events&triggerTypeDelete != 0 {
+		if !skipEventsOr {
+			d.write("OR ")
+		}
+		d.write("DELETE ")
+		skipEventsOr = false
+	}
+	if stmt.Events&triggerTypeUpdate != 0 {
+		if !skipEventsOr {
+			d.write("OR ")
+		}
+		d.write("UPDATE ")
+		if len(stmt.Columns) > 0 {
+			d.write("OF ")
+			d.columnList(stmt.Columns)
+			d.writeByte(' ')
+		}
+		skipEventsOr = false
+	}
+	if stmt.Events&triggerTypeTruncate != 0 {
+		if !skipEventsOr {
+			d.write("OR ")
+		}
+		d.write("TRUNCATE ")
+	}
+
+	d.write("ON ")
+	d.rangeVar(stmt.Relation, deparseContextNone)
+	d.writeByte(' ')
+
+	if len(stmt.TransitionRels) > 0 {
+		d.write("REFERENCING ")
+		for _, rel := range stmt.TransitionRels {
+			d.triggerTransition(rel.GetTriggerTransition())
+			d.writeByte(' ')
+		}
+	}
+
+	if stmt.Constrrel != nil {
+		d.write("FROM ")
+		d.rangeVar(stmt.Constrrel, deparseContextNone)
+		d.writeByte(' ')
+	}
+
+	if stmt.Deferrable {
+		d.write("DEFERRABLE ")
+	}
+
+	if stmt.Initdeferred {
+		d.write("INITIALLY DEFERRED ")
+	}
+
+	if stmt.Row {
+		d.write("FOR EACH ROW ")
+	}
+
+	if stmt.WhenClause != nil {
+		d.write("WHEN (")
+		d.expr(stmt.WhenClause)
+		d.write(") ")
+	}
+
+	d.write("EXECUTE FUNCTION ")
+	d.funcName(stmt.Funcname)
+	d.writeByte('(')
+	for i, arg := range stmt.Args {
+		d.stringLiteral(strVal(arg))
+		if i < len(stmt.Args)-1 {
+			d.write(", ")
+		}
+	}
+	d.writeByte(')')
+}
+
+func (d *deparser) triggerTransition(transition *TriggerTransition) {
+	if transition.IsNew {
+		d.write("NEW ")
+	} else {
+		d.write("OLD ")
+	}
+
+	if transition.IsTable {
+		d.write("TABLE ")
+	} else {
+		d.write("ROW ")
+	}
+
+	d.write(quoteIdentifier(transition.Name))
+}
+
+func (d *deparser) clusterStmt(stmt *ClusterStmt) {
+	d.write("CLUSTER ")
+
+	d.utilityOptionList(stmt.Params)
+
+	if stmt.Relation != nil {
+		d.rangeVar(stmt.Relation, deparseContextNone)
+		d.writeByte(' ')
+	}
+
+	if stmt.Indexname != "" {
+		d.write("USING ")
+		d.write(quoteIdentifier(stmt.Indexname))
+		d.writeByte(' ')
+	}
+
+	d.removeTrailingSpace()
+}
@@ -0,0 +1,1465 @@
+package pg_query
+
+import (
+	"strconv"
+)
+
+// isPgCatalogFunc returns whether the function name is the given (schema qualified) pg_catalog function
+func isPgCatalogFunc(funcname []*Node, name string) bool {
+	return len(funcname) == 2 && strVal(funcname[0]) == "pg_catalog" && strVal(funcname[1]) == name
+}
+
+func (d *deparser) funcCall(funcCall *FuncCall) {
+	args := funcCall.Args
+	sqlSyntax := funcCall.Funcformat == CoercionForm_COERCE_SQL_SYNTAX
+
+	switch {
+	case isPgCatalogFunc(funcCall.Funcname, "overlay") && len(args) == 4:
+		// Note that this is a bit odd, but "OVERLAY" is a keyword on its own merit, and only accepts the
+		// keyword parameter style when its called as a keyword, not as a regular function (i.e. pg_catalog.overlay)
+		d.write("OVERLAY(")
+		d.expr(args[0])
+		d.write(" PLACING ")
+		d.expr(args[1])
+		d.write(" FROM ")
+		d.expr(args[2])
+		d.write(" FOR ")
+		d.expr(args[3])
+		d.writeByte(')')
+		return
+	case sqlSyntax && isPgCatalogFunc(funcCall.Funcname, "substring"):
+		// "SUBSTRING" is a keyword on its own merit, and only accepts the
+		// keyword parameter style when its called as a keyword, not as a regular function (i.e. pg_catalog.substring)
+		if len(args) != 2 && len(args) != 3 {
+			d.fail("SUBSTRING with %d arguments", len(args))
+		}
+		d.write("SUBSTRING(")
+		d.expr(args[0])
+		d.write(" FROM ")
+		d.expr(args[1])
+		if len(args) == 3 {
+			d.write(" FOR ")
+			d.expr(args[2])
+		}
+		d.writeByte(')')
+		return
+	case sqlSyntax && isPgCatalogFunc(funcCall.Funcname, "position") && len(args) == 2:
+		// "POSITION" is a keyword on its own merit, and only accepts the
+		// keyword parameter style when its called as a keyword, not as a regular function (i.e. pg_catalog.position)
+		// Note that the first and second arguments are switched in this format
+		d.write("POSITION(")
+		d.bExpr(args[1])
+		d.write(" IN ")
+		d.bExpr(args[0])
+		d.writeByte(')')
+		return
+	case sqlSyntax && isPgCatalogFunc(funcCall.Funcname, "overlay") && len(args) == 3:
+		// "OVERLAY" is a keyword on its own merit, and only accepts the
+		// keyword parameter style when its called as a keyword, not as a regular function (i.e. pg_catalog.overlay)
+		d.write("overlay(")
+		d.expr(args[0])
+		d.write(" placing ")
+		d.expr(args[1])
+		d.write(" from ")
+		d.expr(args[2])
+		d.writeByte(')')
+		return
+	case sqlSyntax && isPgCatalogFunc(funcCall.Funcname, "pg_collation_for") && len(args) == 1:
+		// "collation for" is a keyword on its own merit, and only accepts the
+		// keyword parameter style when its called as a keyword, not as a regular function (i.e. pg_catalog.pg_collation_for)
+		d.write("collation for (")
+		d.expr(args[0])
+		d.writeByte(')')
+		return
+	case sqlSyntax && isPgCatalogFunc(funcCall.Funcname, "extract") && len(args) == 2:
+		// "EXTRACT" is a keyword on its own merit, and only accepts the
+		// keyword parameter style when its called as a keyword, not as a regular function (i.e. pg_catalog.extract)
+		d.write("extract (")
+		d.expr(args[0])
+		d.write(" FROM ")
+		d.expr(args[1])
+		d.writeByte(')')
+		return
+	case sqlSyntax && isPgCatalogFunc(funcCall.Funcname, "overlaps") && len(args) == 4:
+		// "OVERLAPS" is a keyword on its own merit, and only accepts the
+		// keyword parameter style when its called as a keyword, not as a regular function (i.e. pg_catalog.overlaps)
+		// format: (start_1, end_1) overlaps (start_2, end_2)
+		d.writeByte('(')
+		d.expr(args[0])
+		d.write(", ")
+		d.expr(args[1])
+		d.write(") ")
+		d.write("overlaps ")
+		d.writeByte('(')
+		d.expr(args[2])
+		d.write(", ")
+		d.expr(args[3])
+		d.write(") ")
+		return
+	case sqlSyntax && (isPgCatalogFunc(funcCall.Funcname, "ltrim") ||
+		isPgCatalogFunc(funcCall.Funcname, "btrim") ||
+		isPgCatalogFunc(funcCall.Funcname, "rtrim")):
+		// "TRIM " is a keyword on its own merit, and only accepts the
+		// keyword parameter style when its called as a keyword, not as a regular function (i.e. pg_catalog.ltrim)
+		// Note that the first and second arguments are switched in this format
+		if len(args) != 1 && len(args) != 2 {
+			d.fail("TRIM with %d arguments", len(args))
+		}
+		d.write("TRIM (")
+		switch strVal(funcCall.Funcname[1]) {
+		case "ltrim":
+			d.write("LEADING ")
+		case "btrim":
+			d.write("BOTH ")
+		case "rtrim":
+			d.write("TRAILING ")
+		}
+		if len(args) == 2 {
+			d.expr(args[1])
+		}
+		d.write(" FROM ")
+		d.expr(args[0])
+		d.writeByte(')')
+		return
+	case sqlSyntax && isPgCatalogFunc(funcCall.Funcname, "timezone") && len(args) == 2:
+		// "AT TIME ZONE" is a keyword on its own merit, and only accepts the
+		// keyword parameter style when its called as a keyword, not as a regular function (i.e. pg_catalog.timezone)
+		// Note that the arguments are swapped in this case
+		_, needParens := args[1].GetNode().(*Node_AExpr)
+		if needParens {
+			d.writeByte('(')
+		}
+		d.expr(args[1])
+		if needParens {
+			d.writeByte(')')
+		}
+		d.write(" AT TIME ZONE ")
+		d.expr(args[0])
+		return
+	case sqlSyntax && isPgCatalogFunc(funcCall.Funcname, "normalize"):
+		// "NORMALIZE" is a keyword on its own merit, and only accepts the
+		// keyword parameter style when its called as a keyword, not as a regular function (i.e. pg_catalog.normalize)
+		if len(args) != 1 && len(args) != 2 {
+			d.fail("NORMALIZE with %d arguments", len(args))
+		}
+		d.write("normalize (")
+		d.expr(args[0])
+		if len(args) == 2 {
+			d.write(", ")
+			d.value(aConstVal(args[1].GetAConst()), deparseContextNone)
+		}
+		d.writeByte(')')
+		return
+	case sqlSyntax && isPgCatalogFunc(funcCall.Funcname, "is_normalized"):
+		// "IS NORMALIZED" is a keyword on its own merit, and only accepts the
+		// keyword parameter style when its called as a keyword, not as a regular function (i.e. pg_catalog.is_normalized)
+		if len(args) != 1 && len(args) != 2 {
+			d.fail("IS NORMALIZED with %d arguments", len(args))
+		}
+		d.expr(args[0])
+		d.write(" IS ")
+		if len(args) == 2 {
+			d.value(aConstVal(args[1].GetAConst()), deparseContextNone)
+		}
+		d.write(" NORMALIZED ")
+		return
+	case sqlSyntax && isPgCatalogFunc(funcCall.Funcname, "xmlexists") && len(args) == 2:
+		d.write("xmlexists (")
+		d.expr(args[0])
+		d.write(" PASSING ")
+		d.expr(args[1])
+		d.writeByte(')')
+		return
+	case sqlSyntax && isPgCatalogFunc(funcCall.Funcname, "system_user"):
+		d.write("SYSTEM_USER")
+		return
+	}
+
+	d.funcName(funcCall.Funcname)
+	d.writeByte('(')
+
+	if funcCall.AggDistinct {
+		d.write("DISTINCT ")
+	}
+
+	if funcCall.AggStar {
+		d.writeByte('*')
+	} else {
+		for i, arg := range args {
+			if funcCall.FuncVariadic && i == len(args)-1 {
+				d.write("VARIADIC ")
+			}
+			d.funcArgExpr(arg)
+			if i < len(args)-1 {
+				d.write(", ")
+			}
+		}
+	}
+	d.writeByte(' ')
+
+	if len(funcCall.AggOrder) > 0 && !funcCall.AggWithinGroup {
+		d.optSortClause(funcCall.AggOrder)
+	}
+
+	d.removeTrailingSpace()
+	d.write(") ")
+
+	if len(funcCall.AggOrder) > 0 && funcCall.AggWithinGroup {
+		d.write("WITHIN GROUP (")
+		d.optSortClause(funcCall.AggOrder)
+		d.removeTrailingSpace()
+		d.write(") ")
+	}
+
+	if funcCall.AggFilter.GetNode() != nil {
+		d.write("FILTER (WHERE ")
+		d.expr(funcCall.AggFilter)
+		d.write(") ")
+	}
+
+	if funcCall.Over != nil {
+		d.write("OVER ")
+		if funcCall.Over.Name != "" {
+			d.write(funcCall.Over.Name)
+		} else {
+			d.windowDef(funcCall.Over)
+		}
+	}
+
+	d.removeTrailingSpace()
+}
+
+// Frame options of a window definition, as in nodes/parsenodes.h
+const (
+	frameOptionNonDefault              = 0x00001 // any specified?
+	frameOptionRange                   = 0x00002 // RANGE behavior
+	frameOptionRows                    = 0x00004 // ROWS behavior
+	frameOptionGroups                  = 0x00008 // GROUPS behavior
+	frameOptionBetween                 = 0x00010 // BETWEEN given?
+	frameOptionStartUnboundedPreceding = 0x00020 // start is U. P.
+	frameOptionEndUnboundedPreceding   = 0x00040 // (disallowed)
+	frameOptionStartUnboundedFollowing = 0x00080 // (disallowed)
+	frameOptionEndUnboundedFollowing   = 0x00100 // end is U. F.
+	frameOptionStartCurrentRow         = 0x00200 // start is C. R.
+	frameOptionEndCurrentRow           = 0x00400 // end is C. R.
+	frameOptionStartOffsetPreceding    = 0x00800 // start is O. P.
+	frameOptionEndOffsetPreceding      = 0x01000 // end is O. P.
+	frameOptionStartOffsetFollowing    = 0x02000 // start is O. F.
+	frameOptionEndOffsetFollowing      = 0x04000 // end is O. F.
+	frameOptionExcludeCurrentRow       = 0x08000 // omit C.R.
+	frameOptionExcludeGroup            = 0x10000 // omit C.R. & peers
+	frameOptionExcludeTies             = 0x20000 // omit C.R.'s peers
+)
+
+func (d *deparser) windowDef(windowDef *WindowDef) {
+	// The parent node is responsible for outputting windowDef.Name
+
+	d.writeByte('(')
+
+	if windowDef.Refname != "" {
+		d.write(quoteIdentifier(windowDef.Refname))
+		d.writeByte(' ')
+	}
+
+	if len(windowDef.PartitionClause) > 0 {
+		d.write("PARTITION BY ")
+		d.exprList(windowDef.PartitionClause)
+		d.writeByte(' ')
+	}
+
+	d.optSortClause(windowDef.OrderClause)
+
+	if opts := windowDef.FrameOptions; opts&frameOptionNonDefault != 0 {
+		if opts&frameOptionRange != 0 {
+			d.write("RANGE ")
+		} else if opts&frameOptionRows != 0 {
+			d.write("ROWS ")
+		} else if opts&frameOptionGroups != 0 {
+			d.write("GROUPS ")
+		}
+
+		if opts&frameOptionBetween != 0 {
+			d.write("BETWEEN ")
+		}
+
+		// frame_start
+		switch {
+		case opts&frameOptionStartUnboundedPreceding != 0:
+			d.write("UNBOUNDED PRECEDING ")
+		case opts&frameOptionStartUnboundedFollowing != 0:
+			d.fail("error in windowDef: frame starting at UNBOUNDED FOLLOWING")
+		case opts&frameOptionStartCurrentRow != 0:
+			d.write("CURRENT ROW ")
+		case opts&frameOptionStartOffsetPreceding != 0:
+			d.expr(windowDef.StartOffset)
+			d.write(" PRECEDING ")
+		case opts&frameOptionStartOffsetFollowing != 0:
+			d.expr(windowDef.StartOffset)
+			d.write(" FOLLOWING ")
+		}
+
+		if opts&frameOptionBetween != 0 {
+			d.write("AND ")
+
+			// frame_end
+			switch {
+			case opts&frameOptionEndUnboundedPreceding != 0:
+				d.fail("error in windowDef: frame ending at UNBOUNDED PRECEDING")
+			case opts&frameOptionEndUnboundedFollowing != 0:
+				d.write("UNBOUNDED FOLLOWING ")
+			case opts&frameOptionEndCurrentRow != 0:
+				d.write("CURRENT ROW ")
+			case opts&frameOptionEndOffsetPreceding != 0:
+				d.expr(windowDef.EndOffset)
+				d.write(" PRECEDING ")
+			case opts&frameOptionEndOffsetFollowing != 0:
+				d.expr(windowDef.EndOffset)
+				d.write(" FOLLOWING ")
+			}
+		}
+
+		if opts&frameOptionExcludeCurrentRow != 0 {
+			d.write("EXCLUDE CURRENT ROW ")
+		} else if opts&frameOptionExcludeGroup != 0 {
+			d.write("EXCLUDE GROUP ")
+		} else if opts&frameOptionExcludeTies != 0 {
+			d.write("EXCLUDE TIES ")
+		}
+	}
+
+	d.removeTrailingSpace()
+	d.writeByte(')')
+}
+
+func (d *deparser) columnRef(columnRef *ColumnRef) {
+	if len(columnRef.Fields) == 0 {
+		d.fail("error in columnRef: no fields")
+	}
+
+	switch first := columnRef.Fields[0].Node.(type) {
+	case *Node_AStar:
+		d.writeByte('*')
+	case *Node_String_:
+		d.colLabel(first.String_.Sval)
+	}
+
+	d.optIndirection(columnRef.Fields, 1)
+}
+
+func (d *deparser) subLink(subLink *SubLink) {
+	switch subLink.SubLinkType {
+	case SubLinkType_EXISTS_SUBLINK:
+		d.write("EXISTS ")
+		d.subquery(func() {
+			d.selectStmt(subLink.Subselect.GetSelectStmt())
+		})
+	case SubLinkType_ALL_SUBLINK:
+		d.expr(subLink.Testexpr)
+		d.writeByte(' ')
+		d.subqueryOp(subLink.OperName)
+		d.write(" ALL ")
+		d.subquery(func() {
+			d.selectStmt(subLink.Subselect.GetSelectStmt())
+		})
+	case SubLinkType_ANY_SUBLINK:
+		d.expr(subLink.Testexpr)
+		if len(subLink.OperName) > 0 {
+			d.writeByte(' ')
+			d.subqueryOp(subLink.OperName)
+			d.write(" ANY ")
+		} else {
+			d.write(" IN ")
+		}
+		d.subquery(func() {
+			d.selectStmt(subLink.Subselect.GetSelectStmt())
+		})
+	case SubLinkType_EXPR_SUBLINK:
+		d.subquery(func() {
+			d.selectStmt(subLink.Subselect.GetSelectStmt())
+		})
+	case SubLinkType_ARRAY_SUBLINK:
+		d.write("ARRAY")
+		d.subquery(func() {
+			d.selectStmt(subLink.Subselect.GetSelectStmt())
+		})
+	default:
+		// Not present in raw parse trees
+		d.fail("unsupported sublink type: %s", subLink.SubLinkType)
+	}
+}
+
+// needsAExprOperandParens returns whether an operand of an A_Expr has to be wrapped in parens
+func needsAExprOperandParens(node *Node) bool {
+	switch node.GetNode().(type) {
+	case *Node_BoolExpr, *Node_BooleanTest, *Node_NullTest, *Node_AExpr:
+		return true
+	}
+	return false
+}
+
+func (d *deparser) aExpr(aExpr *A_Expr, context deparseContext) {
+	needLexprParens := needsAExprOperandParens(aExpr.Lexpr)
+	needRexprParens := needsAExprOperandParens(aExpr.Rexpr)
+
+	switch aExpr.Kind {
+	case A_Expr_Kind_AEXPR_OP: // normal operator
+		needOuterParens := context == deparseContextAExpr
+
+		if needOuterParens {
+			d.writeByte('(')
+		}
+		if aExpr.Lexpr.GetNode() != nil {
+			if needLexprParens {
+				d.writeByte('(')
+			}
+			d.expr(aExpr.Lexpr)
+			if needLexprParens {
+				d.writeByte(')')
+			}
+			d.writeByte(' ')
+		}
+		d.qualOp(aExpr.Name)
+		if aExpr.Rexpr.GetNode() != nil {
+			d.writeByte(' ')
+			if needRexprParens {
+				d.writeByte('(')
+			}
+			d.expr(aExpr.Rexpr)
+			if needRexprParens {
+				d.writeByte(')')
+			}
+		}
+		if needOuterParens {
+			d.writeByte(')')
+		}
+	case A_Expr_Kind_AEXPR_OP_ANY: // scalar op ANY (array)
+		d.expr(aExpr.Lexpr)
+		d.writeByte(' ')
+		d.subqueryOp(aExpr.Name)
+		d.write(" ANY(")
+		d.expr(aExpr.Rexpr)
+		d.writeByte(')')
+	case A_Expr_Kind_AEXPR_OP_ALL: // scalar op ALL (array)
+		d.expr(aExpr.Lexpr)
+		d.writeByte(' ')
+		d.subqueryOp(aExpr.Name)
+		d.write(" ALL(")
+		d.expr(aExpr.Rexpr)
+		d.writeByte(')')
+	case A_Expr_Kind_AEXPR_DISTINCT: // IS DISTINCT FROM - name must be "="
+		if needLexprParens {
+			d.writeByte('(')
+		}
+		d.expr(aExpr.Lexpr)
+		if needLexprParens {
+			d.writeByte(')')
+		}
+		d.write(" IS DISTINCT FROM ")
+		if needRexprParens {
+			d.writeByte('(')
+		}
+		d.expr(aExpr.Rexpr)
+		if needRexprParens {
+			d.writeByte(')')
+		}
+	case A_Expr_Kind_AEXPR_NOT_DISTINCT: // IS NOT DISTINCT FROM - name must be "="
+		d.expr(aExpr.Lexpr)
+		d.write(" IS NOT DISTINCT FROM ")
+		d.expr(aExpr.Rexpr)
+	case A_Expr_Kind_AEXPR_NULLIF: // NULLIF - name must be "="
+		d.write("NULLIF(")
+		d.expr(aExpr.Lexpr)
+		d.write(", ")
+		d.expr(aExpr.Rexpr)
+		d.writeByte(')')
+	case A_Expr_Kind_AEXPR_IN: // [NOT] IN - name must be "=" or "<>"
+		d.expr(aExpr.Lexpr)
+		d.writeByte(' ')
+		switch name := aExprName(aExpr); name {
+		case "=":
+			d.write("IN ")
+		case "<>":
+			d.write("NOT IN ")
+		default:
+			d.fail("unsupported IN operator: %s", name)
+		}
+		d.writeByte('(')
+		if subLink := aExpr.Rexpr.GetSubLink(); subLink != nil {
+			d.subLink(subLink)
+		} else {
+			d.exprList(aExpr.Rexpr.GetList().GetItems())
+		}
+		d.writeByte(')')
+	case A_Expr_Kind_AEXPR_LIKE: // [NOT] LIKE - name must be "~~" or "!~~"
+		d.expr(aExpr.Lexpr)
+		d.writeByte(' ')
+		switch name := aExprName(aExpr); name {
+		case "~~":
+			d.write("LIKE ")
+		case "!~~":
+			d.write("NOT LIKE ")
+		default:
+			d.fail("unsupported LIKE operator: %s", name)
+		}
+		d.expr(aExpr.Rexpr)
+	case A_Expr_Kind_AEXPR_ILIKE: // [NOT] ILIKE - name must be "~~*" or "!~~*"
+		d.expr(aExpr.Lexpr)
+		d.writeByte(' ')
+		switch name := aExprName(aExpr); name {
+		case "~~*":
+			d.write("ILIKE ")
+		case "!~~*":
+			d.write("NOT ILIKE ")
+		default:
+			d.fail("unsupported ILIKE operator: %s", name)
+		}
+		d.expr(aExpr.Rexpr)
+	case A_Expr_Kind_AEXPR_SIMILAR: // [NOT] SIMILAR - name must be "~" or "!~"
+		d.expr(aExpr.Lexpr)
+		d.writeByte(' ')
+		switch name := aExprName(aExpr); name {
+		case "~":
+			d.write("SIMILAR TO ")
+		case "!~":
+			d.write("NOT SIMILAR TO ")
+		default:
+			d.fail("unsupported SIMILAR TO operator: %s", name)
+		}
+		n := aExpr.Rexpr.GetFuncCall()
+		if !isPgCatalogFunc(n.GetFuncname(), "similar_to_escape") || len(n.Args) != 1 && len(n.Args) != 2 {
+			d.fail("error in aExpr: SIMILAR TO without similar_to_escape")
+		}
+		d.expr(n.Args[0])
+		if len(n.Args) == 2 {
+			d.write(" ESCAPE ")
+			d.expr(n.Args[1])
+		}
+	case A_Expr_Kind_AEXPR_BETWEEN, // name must be "BETWEEN"
+		A_Expr_Kind_AEXPR_NOT_BETWEEN,     // name must be "NOT BETWEEN"
+		A_Expr_Kind_AEXPR_BETWEEN_SYM,     // name must be "BETWEEN SYMMETRIC"
+		A_Expr_Kind_AEXPR_NOT_BETWEEN_SYM: // name must be "NOT BETWEEN SYMMETRIC"
+		d.expr(aExpr.Lexpr)
+		d.writeByte(' ')
+		d.write(aExprName(aExpr))
+		d.writeByte(' ')
+		bounds := aExpr.Rexpr.GetList().GetItems()
+		for i, bound := range bounds {
+			d.expr(bound)
+			if i < len(bounds)-1 {
+				d.write(" AND ")
+			}
+		}
+	default:
+		d.fail("unsupported A_Expr kind: %s", aExpr.Kind)
+	}
+}
+
+// aExprName returns the single operator name of an A_Expr
+func aExprName(aExpr *A_Expr) string {
+	if len(aExpr.Name) != 1 {
+		return ""
+	}
+	return strVal(aExpr.Name[0])
+}
+
+// isAndOrExpr returns whether the node is an AND or OR expression
+func isAndOrExpr(node *Node) bool {
+	boolExpr := node.GetBoolExpr()
+	return boolExpr != nil && (boolExpr.Boolop == BoolExprType_AND_EXPR || boolExpr.Boolop == BoolExprType_OR_EXPR)
+}
+
+func (d *deparser) boolExpr(boolExpr *BoolExpr) {
+	switch boolExpr.Boolop {
+	case BoolExprType_AND_EXPR, BoolExprType_OR_EXPR:
+		op := "AND"
+		if boolExpr.Boolop == BoolExprType_OR_EXPR {
+			op = "OR"
+		}
+		arg := func(i int) {
+			// Put parantheses around AND + OR nodes that are inside
+			needParens := isAndOrExpr(boolExpr.Args[i])
+			if needParens {
+				d.writeByte('(')
+			}
+			d.expr(boolExpr.Args[i])
+			if needParens {
+				d.writeByte(')')
+			}
+		}
+
+		singleLine := func() {
+			for i := range boolExpr.Args {
+				if i > 0 {
+					d.writeByte(' ')
+					d.write(op)
+					d.writeByte(' ')
+				}
+				arg(i)
+			}
+		}
+		if d.format == nil || len(boolExpr.Args) < 2 {
+			singleLine()
+			break
+		}
+
+		// When formatting, start every further condition on its own line if they don't fit
+		if d.singleLine(singleLine) {
+			break
+		}
+		arg(0)
+		d.indent++
+		for i := 1; i < len(boolExpr.Args); i++ {
+			d.newline()
+			d.write(op)
+			d.writeByte(' ')
+			arg(i)
+		}
+		d.indent--
+	case BoolExprType_NOT_EXPR:
+		if len(boolExpr.Args) != 1 {
+			d.fail("NOT with %d arguments", len(boolExpr.Args))
+		}
+		needParens := isAndOrExpr(boolExpr.Args[0])
+		d.write("NOT ")
+		if needParens {
+			d.writeByte('(')
+		}
+		d.expr(boolExpr.Args[0])
+		if needParens {
+			d.writeByte(')')
+		}
+	default:
+		d.fail("unsupported boolean expression: %s", boolExpr.Boolop)
+	}
+}
+
+func (d *deparser) collateClause(collateClause *CollateClause) {
+	if collateClause.Arg.GetNode() != nil {
+		_, needParens := collateClause.Arg.Node.(*Node_AExpr)
+		if needParens {
+			d.writeByte('(')
+		}
+		d.expr(collateClause.Arg)
+		if needParens {
+			d.writeByte(')')
+		}
+		d.writeByte(' ')
+	}
+	d.write("COLLATE ")
+	d.anyName(collateClause.Collname)
+}
+
+func (d *deparser) sortBy(sortBy *SortBy) {
+	if sortBy == nil {
+		d.fail("missing SortBy")
+	}
+	d.expr(sortBy.Node)
+	d.writeByte(' ')
+
+	switch sortBy.SortbyDir {
+	case SortByDir_SORTBY_ASC:
+		d.write("ASC ")
+	case SortByDir_SORTBY_DESC:
+		d.write("DESC ")
+	case SortByDir_SORTBY_USING:
+		d.write("USING ")
+		d.qualOp(sortBy.UseOp)
+	}
+
+	switch sortBy.SortbyNulls {
+	case SortByNulls_SORTBY_NULLS_FIRST:
+		d.write("NULLS FIRST ")
+	case SortByNulls_SORTBY_NULLS_LAST:
+		d.write("NULLS LAST ")
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) paramRef(paramRef *ParamRef) {
+	if paramRef.Number == 0 {
+		d.writeByte('?')
+	} else {
+		d.write("$" + strconv.Itoa(int(paramRef.Number)))
+	}
+}
+
+func (d *deparser) sqlValueFunction(sqlValueFunction *SQLValueFunction) {
+	switch sqlValueFunction.Op {
+	case SQLValueFunctionOp_SVFOP_CURRENT_DATE:
+		d.write("current_date")
+	case SQLValueFunctionOp_SVFOP_CURRENT_TIME, SQLValueFunctionOp_SVFOP_CURRENT_TIME_N:
+		d.write("current_time")
+	case SQLValueFunctionOp_SVFOP_CURRENT_TIMESTAMP, SQLValueFunctionOp_SVFOP_CURRENT_TIMESTAMP_N:
+		d.write("current_timestamp")
+	case SQLValueFunctionOp_SVFOP_LOCALTIME, SQLValueFunctionOp_SVFOP_LOCALTIME_N:
+		d.write("localtime")
+	case SQLValueFunctionOp_SVFOP_LOCALTIMESTAMP, SQLValueFunctionOp_SVFOP_LOCALTIMESTAMP_N:
+		d.write("localtimestamp")
+	case SQLValueFunctionOp_SVFOP_CURRENT_ROLE:
+		d.write("current_role")
+	case SQLValueFunctionOp_SVFOP_CURRENT_USER:
+		d.write("current_user")
+	case SQLValueFunctionOp_SVFOP_USER:
+		d.write("user")
+	case SQLValueFunctionOp_SVFOP_SESSION_USER:
+		d.write("session_user")
+	case SQLValueFunctionOp_SVFOP_CURRENT_CATALOG:
+		d.write("current_catalog")
+	case SQLValueFunctionOp_SVFOP_CURRENT_SCHEMA:
+		d.write("current_schema")
+	}
+
+	// The precision of the _N variants
+	if sqlValueFunction.Typmod != -1 {
+		d.writef("(%d)", sqlValueFunction.Typmod)
+	}
+}
+
+func (d *deparser) aArrayExpr(arrayExpr *A_ArrayExpr) {
+	d.write("ARRAY[")
+	d.exprList(arrayExpr.Elements)
+	d.writeByte(']')
+}
+
+func (d *deparser) rowExpr(rowExpr *RowExpr) {
+	switch rowExpr.RowFormat {
+	case CoercionForm_COERCE_EXPLICIT_CALL:
+		d.write("ROW")
+	case CoercionForm_COERCE_IMPLICIT_CAST:
+		// No prefix
+	default:
+		// Not present in raw parser output
+		d.fail("unsupported row format: %s", rowExpr.RowFormat)
+	}
+
+	d.write("(")
+	d.exprList(rowExpr.Args)
+	d.writeByte(')')
+}
+
+func (d *deparser) typeCast(typeCast *TypeCast, context deparseContext) {
+	needParens := false
+
+	if typeCast.TypeName == nil {
+		d.fail("error in typeCast: missing type name")
+	}
+
+	if _, ok := typeCast.Arg.GetNode().(*Node_AExpr); ok || context == deparseContextFuncExpr {
+		d.write("CAST(")
+		d.expr(typeCast.Arg)
+		d.write(" AS ")
+		d.typeName(typeCast.TypeName)
+		d.writeByte(')')
+		return
+	}
+
+	if aConst := typeCast.Arg.GetAConst(); aConst != nil {
+		names := typeCast.TypeName.Names
+		if len(names) == 2 && strVal(names[0]) == "pg_catalog" {
+			typename := strVal(names[1])
+			if typename == "bpchar" && len(typeCast.TypeName.Typmods) == 0 {
+				d.write("char ")
+				d.aConst(aConst)
+				return
+			} else if typename == "bool" && aConst.GetSval() != nil {
+				// Handle "bool" or "false" in the statement, which is represented as a typecast
+				// (other boolean casts should be represented as a cast, i.e. don't need special handling)
+				switch aConst.GetSval().Sval {
+				case "t":
+					d.write("true")
+					return
+				case "f":
+					d.write("false")
+					return
+				}
+			} else if typename == "interval" && context == deparseContextSetStatement && aConst.GetSval() != nil {
+				d.write("interval ")
+				d.aConst(aConst)
+				d.intervalTypmods(typeCast.TypeName)
+				return
+			}
+		}
+
+		// Ensure negative values have wrapping parentheses
+		if aConst.GetFval() != nil || aConst.GetIval() != nil && aConst.GetIval().Ival < 0 {
+			needParens = true
+		}
+
+		if len(names) == 1 && strVal(names[0]) == "point" && aConst.Location > typeCast.TypeName.Location {
+			d.write(" point ")
+			d.aConst(aConst)
+			return
+		}
+	}
+
+	if needParens {
+		d.writeByte('(')
+	}
+	d.expr(typeCast.Arg)
+	if needParens {
+		d.writeByte(')')
+	}
+
+	d.write("::")
+	d.typeName(typeCast.TypeName)
+}
+
+// typmodList appends the integer type modifiers of a type, as in "timestamp(3) with time zone"
+func (d *deparser) typmodList(typmods []*Node) {
+	d.writeByte('(')
+	for i, typmod := range typmods {
+		d.writef("%d", aConstInt(typmod))
+		if i < len(typmods)-1 {
+			d.write(", ")
+		}
+	}
+	d.write(") ")
+}
+
+func (d *deparser) typeName(typeName *TypeName) {
+	if typeName == nil {
+		d.fail("error in typeName: missing type name")
+	}
+
+	skipTypmods := false
+
+	if typeName.Setof {
+		d.write("SETOF ")
+	}
+
+	if len(typeName.Names) == 2 && strVal(typeName.Names[0]) == "pg_catalog" {
+		switch name := strVal(typeName.Names[1]); {
+		case name == "bpchar":
+			d.write("char")
+		case name == "varchar":
+			d.write("varchar")
+		case name == "numeric":
+			d.write("numeric")
+		case name == "bool":
+			d.write("boolean")
+		case name == "int2":
+			d.write("smallint")
+		case name == "int4":
+			d.write("int")
+		case name == "int8":
+			d.write("bigint")
+		case name == "real" || name == "float4":
+			d.write("real")
+		case name == "float8":
+			d.write("double precision")
+		case name == "time":
+			d.write("time")
+		case name == "timetz":
+			d.write("time ")
+			if len(typeName.Typmods) > 0 {
+				d.typmodList(typeName.Typmods)
+			}
+			d.write("with time zone")
+			skipTypmods = true
+		case name == "timestamp":
+			d.write("timestamp")
+		case name == "timestamptz":
+			d.write("timestamp ")
+			if len(typeName.Typmods) > 0 {
+				d.typmodList(typeName.Typmods)
+			}
+			d.write("with time zone")
+			skipTypmods = true
+		case name == "interval" && len(typeName.Typmods) == 0:
+			d.write("interval")
+		case name == "interval":
+			d.write("interval")
+			d.intervalTypmods(typeName)
+			skipTypmods = true
+		default:
+			d.write("pg_catalog.")
+			d.write(name)
+		}
+	} else {
+		d.anyName(typeName.Names)
+	}
+
+	if len(typeName.Typmods) > 0 && !skipTypmods {
+		d.writeByte('(')
+		for i, typmod := range typeName.Typmods {
+			switch n := typmod.Node.(type) {
+			case *Node_AConst:
+				d.aConst(n.AConst)
+			case *Node_ParamRef:
+				d.paramRef(n.ParamRef)
+			case *Node_ColumnRef:
+				d.columnRef(n.ColumnRef)
+			default:
+				d.fail("unpermitted node type in type modifiers: %s", nodeName(typmod))
+			}
+			if i < len(typeName.Typmods)-1 {
+				d.write(", ")
+			}
+		}
+		d.writeByte(')')
+	}
+
+	for _, bound := range typeName.ArrayBounds {
+		d.writeByte('[')
+		if bound.GetInteger() != nil && intVal(bound) != -1 {
+			d.signedIconst(bound)
+		}
+		d.writeByte(']')
+	}
+
+	if typeName.PctType {
+		d.write("%type")
+	}
+}
+
+// Interval field masks, as in utils/datetime.h and utils/timestamp.h
+const (
+	intervalMaskMonth  = 1 << 1
+	intervalMaskYear   = 1 << 2
+	intervalMaskDay    = 1 << 3
+	intervalMaskHour   = 1 << 10
+	intervalMaskMinute = 1 << 11
+	intervalMaskSecond = 1 << 12
+
+	intervalFullRange     = 0x7FFF
+	intervalFullPrecision = 0xFFFF
+)
+
+// Handle typemods for Interval types separately
+// so that they can be applied appropriately for different contexts.
+// For example, when using `SET` a query like `INTERVAL 'x' hour TO minute`
+// the `INTERVAL` keyword is specified first.
+// In all other contexts, intervals use the `'x'::interval` style.
+func (d *deparser) intervalTypmods(typeName *TypeName) {
+	if len(typeName.Typmods) == 0 || typeName.Typmods[0].GetAConst().GetIval() == nil {
+		d.fail("error in intervalTypmods: missing interval fields")
+	}
+
+	// This logic is based on intervaltypmodout in timestamp.c
+	switch fields := aConstInt(typeName.Typmods[0]); fields {
+	case intervalMaskYear:
+		d.write(" year")
+	case intervalMaskMonth:
+		d.write(" month")
+	case intervalMaskDay:
+		d.write(" day")
+	case intervalMaskHour:
+		d.write(" hour")
+	case intervalMaskMinute:
+		d.write(" minute")
+	case intervalMaskSecond:
+		d.write(" second")
+	case intervalMaskYear | intervalMaskMonth:
+		d.write(" year to month")
+	case intervalMaskDay | intervalMaskHour:
+		d.write(" day to hour")
+	case intervalMaskDay | intervalMaskHour | intervalMaskMinute:
+		d.write(" day to minute")
+	case intervalMaskDay | intervalMaskHour | intervalMaskMinute | intervalMaskSecond:
+		d.write(" day to second")
+	case intervalMaskHour | intervalMaskMinute:
+		d.write(" hour to minute")
+	case intervalMaskHour | intervalMaskMinute | intervalMaskSecond:
+		d.write(" hour to second")
+	case intervalMaskMinute | intervalMaskSecond:
+		d.write(" minute to second")
+	case intervalFullRange:
+		// Nothing
+	default:
+		d.fail("unsupported interval fields: %d", fields)
+	}
+
+	if len(typeName.Typmods) == 2 {
+		if precision := aConstInt(typeName.Typmods[1]); precision != intervalFullPrecision {
+			d.writef("(%d)", precision)
+		}
+	}
+}
+
+func (d *deparser) nullTest(nullTest *NullTest) {
+	d.expr(nullTest.Arg)
+	switch nullTest.Nulltesttype {
+	case NullTestType_IS_NULL:
+		d.write(" IS NULL")
+	case NullTestType_IS_NOT_NULL:
+		d.write(" IS NOT NULL")
+	}
+}
+
+func (d *deparser) caseExpr(caseExpr *CaseExpr) {
+	d.write("CASE ")
+
+	if caseExpr.Arg.GetNode() != nil {
+		d.expr(caseExpr.Arg)
+		d.writeByte(' ')
+	}
+
+	for _, arg := range caseExpr.Args {
+		d.caseWhen(arg.GetCaseWhen())
+		d.writeByte(' ')
+	}
+
+	if caseExpr.Defresult.GetNode() != nil {
+		d.write("ELSE ")
+		d.expr(caseExpr.Defresult)
+		d.writeByte(' ')
+	}
+
+	d.write("END")
+}
+
+func (d *deparser) caseWhen(caseWhen *CaseWhen) {
+	d.write("WHEN ")
+	d.expr(caseWhen.Expr)
+	d.write(" THEN ")
+	d.expr(caseWhen.Result)
+}
+
+func (d *deparser) aIndirection(aIndirection *A_Indirection) {
+	needParens := false
+	switch aIndirection.Arg.GetNode().(type) {
+	case *Node_AIndirection, *Node_FuncCall, *Node_AExpr, *Node_TypeCast, *Node_RowExpr:
+		needParens = true
+	case *Node_ColumnRef:
+		needParens = len(aIndirection.Indirection) > 0 && aIndirection.Indirection[0].GetAIndices() == nil
+	}
+
+	if needParens {
+		d.writeByte('(')
+	}
+
+	d.expr(aIndirection.Arg)
+
+	if needParens {
+		d.writeByte(')')
+	}
+
+	d.optIndirection(aIndirection.Indirection, 0)
+}
+
+func (d *deparser) aIndices(aIndices *A_Indices) {
+	d.writeByte('[')
+	if aIndices.Lidx.GetNode() != nil {
+		d.expr(aIndices.Lidx)
+	}
+	if aIndices.IsSlice {
+		d.writeByte(':')
+	}
+	if aIndices.Uidx.GetNode() != nil {
+		d.expr(aIndices.Uidx)
+	}
+	d.writeByte(']')
+}
+
+func (d *deparser) coalesceExpr(coalesceExpr *CoalesceExpr) {
+	d.write("COALESCE(")
+	d.exprList(coalesceExpr.Args)
+	d.writeByte(')')
+}
+
+func (d *deparser) minMaxExpr(minMaxExpr *MinMaxExpr) {
+	switch minMaxExpr.Op {
+	case MinMaxOp_IS_GREATEST:
+		d.write("GREATEST(")
+	case MinMaxOp_IS_LEAST:
+		d.write("LEAST(")
+	}
+	d.exprList(minMaxExpr.Args)
+	d.writeByte(')')
+}
+
+func (d *deparser) booleanTest(booleanTest *BooleanTest) {
+	_, needParens := booleanTest.Arg.GetNode().(*Node_BoolExpr)
+
+	if needParens {
+		d.writeByte('(')
+	}
+
+	d.expr(booleanTest.Arg)
+
+	if needParens {
+		d.writeByte(')')
+	}
+
+	switch booleanTest.Booltesttype {
+	case BoolTestType_IS_TRUE:
+		d.write(" IS TRUE")
+	case BoolTestType_IS_NOT_TRUE:
+		d.write(" IS NOT TRUE")
+	case BoolTestType_IS_FALSE:
+		d.write(" IS FALSE")
+	case BoolTestType_IS_NOT_FALSE:
+		d.write(" IS NOT FALSE")
+	case BoolTestType_IS_UNKNOWN:
+		d.write(" IS UNKNOWN")
+	case BoolTestType_IS_NOT_UNKNOWN:
+		d.write(" IS NOT UNKNOWN")
+	default:
+		d.fail("unsupported boolean test: %s", booleanTest.Booltesttype)
+	}
+}
+
+// Values of the standalone argument of XMLROOT, as in utils/xml.h
+const (
+	xmlStandaloneYes = iota
+	xmlStandaloneNo
+	xmlStandaloneNoValue
+)
+
+func (d *deparser) xmlExpr(xmlExpr *XmlExpr) {
+	switch xmlExpr.Op {
+	case XmlExprOp_IS_XMLCONCAT: // XMLCONCAT(args)
+		d.write("xmlconcat(")
+		d.exprList(xmlExpr.Args)
+		d.writeByte(')')
+	case XmlExprOp_IS_XMLELEMENT: // XMLELEMENT(name, xml_attributes, args)
+		d.write("xmlelement(name ")
+		d.write(quoteIdentifier(xmlExpr.Name))
+		if len(xmlExpr.NamedArgs) > 0 {
+			d.write(", xmlattributes(")
+			d.xmlAttributeList(xmlExpr.NamedArgs)
+			d.write(")")
+		}
+		if len(xmlExpr.Args) > 0 {
+			d.write(", ")
+			d.exprList(xmlExpr.Args)
+		}
+		d.write(")")
+	case XmlExprOp_IS_XMLFOREST: // XMLFOREST(xml_attributes)
+		d.write("xmlforest(")
+		d.xmlAttributeList(xmlExpr.NamedArgs)
+		d.writeByte(')')
+	case XmlExprOp_IS_XMLPARSE: // XMLPARSE(text, is_doc, preserve_ws)
+		if len(xmlExpr.Args) != 2 {
+			d.fail("XMLPARSE with %d arguments", len(xmlExpr.Args))
+		}
+		d.write("xmlparse(")
+		d.xmlOption(xmlExpr.Xmloption)
+		d.expr(xmlExpr.Args[0])
+		d.writeByte(')')
+	case XmlExprOp_IS_XMLPI: // XMLPI(name [, args])
+		d.write("xmlpi(name ")
+		d.write(quoteIdentifier(xmlExpr.Name))
+		if len(xmlExpr.Args) > 0 {
+			d.write(", ")
+			d.expr(xmlExpr.Args[0])
+		}
+		d.writeByte(')')
+	case XmlExprOp_IS_XMLROOT: // XMLROOT(xml, version, standalone)
+		if len(xmlExpr.Args) != 3 {
+			d.fail("XMLROOT with %d arguments", len(xmlExpr.Args))
+		}
+		d.write("xmlroot(")
+		d.expr(xmlExpr.Args[0])
+		d.write(", version ")
+		if xmlExpr.Args[1].GetAConst().GetIsnull() {
+			d.write("NO VALUE")
+		} else {
+			d.expr(xmlExpr.Args[1])
+		}
+		switch aConstInt(xmlExpr.Args[2]) {
+		case xmlStandaloneYes:
+			d.write(", STANDALONE YES")
+		case xmlStandaloneNo:
+			d.write(", STANDALONE NO")
+		case xmlStandaloneNoValue:
+			d.write(", STANDALONE NO VALUE")
+		}
+		d.writeByte(')')
+	case XmlExprOp_IS_DOCUMENT: // xmlval IS DOCUMENT
+		if len(xmlExpr.Args) != 1 {
+			d.fail("IS DOCUMENT with %d arguments", len(xmlExpr.Args))
+		}
+		d.expr(xmlExpr.Args[0])
+		d.write(" IS DOCUMENT")
+	default:
+		// XMLSERIALIZE is represented as XmlSerialize in raw parse trees
+		d.fail("unsupported XML expression: %s", xmlExpr.Op)
+	}
+}
+
+// xmlOption appends the DOCUMENT or CONTENT option of XMLPARSE and XMLSERIALIZE
+func (d *deparser) xmlOption(option XmlOptionType) {
+	switch option {
+	case XmlOptionType_XMLOPTION_DOCUMENT:
+		d.write("document ")
+	case XmlOptionType_XMLOPTION_CONTENT:
+		d.write("content ")
+	default:
+		d.fail("unsupported XML option: %s", option)
+	}
+}
+
+func (d *deparser) xmlSerialize(xmlSerialize *XmlSerialize) {
+	d.write("xmlserialize(")
+	d.xmlOption(xmlSerialize.Xmloption)
+	d.expr(xmlSerialize.Expr)
+	d.write(" AS ")
+	d.typeName(xmlSerialize.TypeName)
+
+	if xmlSerialize.Indent {
+		d.write(" INDENT")
+	}
+
+	d.write(")")
+}
+
+func (d *deparser) jsonFormat(jsonFormat *JsonFormat) {
+	if jsonFormat == nil || jsonFormat.FormatType <= JsonFormatType_JS_FORMAT_DEFAULT {
+		return
+	}
+
+	d.write("FORMAT JSON ")
+
+	switch jsonFormat.Encoding {
+	case JsonEncoding_JS_ENC_UTF8:
+		d.write("ENCODING utf8 ")
+	case JsonEncoding_JS_ENC_UTF16:
+		d.write("ENCODING utf16 ")
+	case JsonEncoding_JS_ENC_UTF32:
+		d.write("ENCODING utf32 ")
+	}
+}
+
+func (d *deparser) jsonIsPredicate(j *JsonIsPredicate) {
+	d.expr(j.Expr)
+	d.writeByte(' ')
+
+	d.jsonFormat(j.Format)
+
+	d.write("IS ")
+
+	switch j.ItemType {
+	case JsonValueType_JS_TYPE_ANY:
+		d.write("JSON ")
+	case JsonValueType_JS_TYPE_ARRAY:
+		d.write("JSON ARRAY ")
+	case JsonValueType_JS_TYPE_OBJECT:
+		d.write("JSON OBJECT ")
+	case JsonValueType_JS_TYPE_SCALAR:
+		d.write("JSON SCALAR ")
+	}
+
+	if j.UniqueKeys {
+		d.write("WITH UNIQUE ")
+	}
+
+	d.removeTrailingSpace()
+}
+
+// "json_value_expr" in gram.y
+func (d *deparser) jsonValueExpr(jsonValueExpr *JsonValueExpr) {
+	d.expr(jsonValueExpr.RawExpr)
+	d.writeByte(' ')
+	d.jsonFormat(jsonValueExpr.Format)
+}
+
+// "json_value_expr_list" in gram.y
+func (d *deparser) jsonValueExprList(exprs []*Node) {
+	for i, expr := range exprs {
+		d.jsonValueExpr(expr.GetJsonValueExpr())
+		d.removeTrailingSpace()
+		if i < len(exprs)-1 {
+			d.write(", ")
+		}
+	}
+	d.writeByte(' ')
+}
+
+// "json_name_and_value" in gram.y
+func (d *deparser) jsonKeyValue(jsonKeyValue *JsonKeyValue) {
+	d.expr(jsonKeyValue.Key)
+	d.write(": ")
+	d.jsonValueExpr(jsonKeyValue.Value)
+}
+
+// "json_name_and_value_list" in gram.y
+func (d *deparser) jsonKeyValueList(exprs []*Node) {
+	for i, expr := range exprs {
+		d.jsonKeyValue(expr.GetJsonKeyValue())
+		d.removeTrailingSpace()
+		if i < len(exprs)-1 {
+			d.write(", ")
+		}
+	}
+	d.writeByte(' ')
+}
+
+func (d *deparser) jsonOutput(jsonOutput *JsonOutput) {
+	if jsonOutput == nil {
+		return
+	}
+
+	d.write("RETURNING ")
+	d.typeName(jsonOutput.TypeName)
+	d.writeByte(' ')
+	d.jsonFormat(jsonOutput.GetReturning().GetFormat())
+}
+
+// jsonAggConstructor appends the FILTER and OVER clauses of a JSON aggregate
+func (d *deparser) jsonAggConstructor(constructor *JsonAggConstructor) {
+	if constructor.AggFilter.GetNode() != nil {
+		d.write("FILTER (WHERE ")
+		d.expr(constructor.AggFilter)
+		d.write(") ")
+	}
+
+	if over := constructor.Over; over != nil {
+		d.write("OVER ")
+		if over.Name != "" {
+			d.write(over.Name)
+		} else {
+			d.windowDef(over)
+		}
+	}
+}
+
+func (d *deparser) jsonObjectAgg(jsonObjectAgg *JsonObjectAgg) {
+	if jsonObjectAgg.Constructor == nil {
+		d.fail("error in jsonObjectAgg: missing constructor")
+	}
+
+	d.write("JSON_OBJECTAGG(")
+	d.jsonKeyValue(jsonObjectAgg.Arg)
+
+	if jsonObjectAgg.AbsentOnNull {
+		d.write("ABSENT ON NULL ")
+	}
+
+	if jsonObjectAgg.Unique {
+		d.write("WITH UNIQUE ")
+	}
+
+	d.jsonOutput(jsonObjectAgg.Constructor.Output)
+
+	d.removeTrailingSpace()
+	d.write(") ")
+
+	d.jsonAggConstructor(jsonObjectAgg.Constructor)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) jsonArrayAgg(jsonArrayAgg *JsonArrayAgg) {
+	if jsonArrayAgg.Constructor == nil {
+		d.fail("error in jsonArrayAgg: missing constructor")
+	}
+
+	d.write("JSON_ARRAYAGG(")
+	d.jsonValueExpr(jsonArrayAgg.Arg)
+	d.optSortClause(jsonArrayAgg.Constructor.AggOrder)
+
+	if !jsonArrayAgg.AbsentOnNull {
+		d.write("NULL ON NULL ")
+	}
+
+	d.jsonOutput(jsonArrayAgg.Constructor.Output)
+
+	d.removeTrailingSpace()
+	d.write(") ")
+
+	d.jsonAggConstructor(jsonArrayAgg.Constructor)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) jsonObjectConstructor(jsonObjectConstructor *JsonObjectConstructor) {
+	d.write("JSON_OBJECT(")
+	d.jsonKeyValueList(jsonObjectConstructor.Exprs)
+
+	if jsonObjectConstructor.AbsentOnNull {
+		d.write("ABSENT ON NULL ")
+	}
+
+	if jsonObjectConstructor.Unique {
+		d.write("WITH UNIQUE ")
+	}
+
+	d.jsonOutput(jsonObjectConstructor.Output)
+
+	d.removeTrailingSpace()
+	d.writeByte(')')
+}
+
+func (d *deparser) jsonArrayConstructor(jsonArrayConstructor *JsonArrayConstructor) {
+	d.write("JSON_ARRAY(")
+	d.jsonValueExprList(jsonArrayConstructor.Exprs)
+
+	if !jsonArrayConstructor.AbsentOnNull {
+		d.write("NULL ON NULL ")
+	}
+
+	d.jsonOutput(jsonArrayConstructor.Output)
+
+	d.removeTrailingSpace()
+	d.writeByte(')')
+}
+
+func (d *deparser) jsonArrayQueryConstructor(jsonArrayQueryConstructor *JsonArrayQueryConstructor) {
+	d.write("JSON_ARRAY(")
+
+	d.selectStmt(jsonArrayQueryConstructor.Query.GetSelectStmt())
+	d.jsonFormat(jsonArrayQueryConstructor.Format)
+	d.jsonOutput(jsonArrayQueryConstructor.Output)
+
+	d.removeTrailingSpace()
+	d.writeByte(')')
+}
+
+func (d *deparser) groupingFunc(groupingFunc *GroupingFunc) {
+	d.write("GROUPING(")
+	d.exprList(groupingFunc.Args)
+	d.writeByte(')')
+}
+
+// aConstVal returns the value of an A_Const as a value node (Integer, Float, Boolean, String or BitString)
+func aConstVal(aConst *A_Const) *Node {
+	switch v := aConst.GetVal().(type) {
+	case *A_Const_Ival:
+		return &Node{Node: &Node_Integer{Integer: v.Ival}}
+	case *A_Const_Fval:
+		return &Node{Node: &Node_Float{Float: v.Fval}}
+	case *A_Const_Boolval:
+		return &Node{Node: &Node_Boolean{Boolean: v.Boolval}}
+	case *A_Const_Sval:
+		return &Node{Node: &Node_String_{String_: v.Sval}}
+	case *A_Const_Bsval:
+		return &Node{Node: &Node_BitString{BitString: v.Bsval}}
+	}
+	return nil
+}
+
+func (d *deparser) value(value *Node, context deparseContext) {
+	switch v := value.GetNode().(type) {
+	case nil:
+		d.write("NULL")
+	case *Node_Integer, *Node_Float:
+		d.numericOnly(value)
+	case *Node_Boolean:
+		if v.Boolean.Boolval {
+			d.write("true")
+		} else {
+			d.write("false")
+		}
+	case *Node_String_:
+		switch context {
+		case deparseContextIdentifier:
+			d.write(quoteIdentifier(v.String_.Sval))
+		case deparseContextConstant:
+			d.stringLiteral(v.String_.Sval)
+		default:
+			d.write(v.String_.Sval)
+		}
+	case *Node_BitString:
+		bsval := v.BitString.Bsval
+		if len(bsval) >= 1 && (bsval[0] == 'x' || bsval[0] == 'b') {
+			d.writeByte(bsval[0])
+			d.stringLiteral(bsval[1:])
+		} else {
+			d.fail("invalid bit string: %s", bsval)
+		}
+	default:
+		d.fail("unrecognized value node type: %s", nodeName(value))
+	}
+}
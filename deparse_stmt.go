@@ -0,0 +1,1612 @@
+package pg_query
+
+// "table_ref" in gram.y
+func (d *deparser) tableRef(node *Node) {
+	d.nodeComments(node)
+	switch n := node.GetNode().(type) {
+	case *Node_RangeVar:
+		d.rangeVar(n.RangeVar, deparseContextNone)
+	case *Node_RangeTableSample:
+		d.rangeTableSample(n.RangeTableSample)
+	case *Node_RangeFunction:
+		d.rangeFunction(n.RangeFunction)
+	case *Node_RangeTableFunc:
+		d.rangeTableFunc(n.RangeTableFunc)
+	case *Node_RangeSubselect:
+		d.rangeSubselect(n.RangeSubselect)
+	case *Node_JoinExpr:
+		d.joinExpr(n.JoinExpr)
+	default:
+		d.fail("unpermitted node type in table_ref: %s", nodeName(node))
+	}
+	d.nodeTrailingComments(node)
+}
+
+// "from_list" in gram.y
+func (d *deparser) fromList(l []*Node) {
+	d.list(len(l), func(i int) {
+		d.tableRef(l[i])
+	})
+}
+
+// "from_clause" in gram.y
+//
+// Note this method adds a trailing space if a value is output
+func (d *deparser) fromClause(l []*Node) {
+	if len(l) > 0 {
+		d.newline()
+		d.write("FROM ")
+		d.fromList(l)
+		d.writeByte(' ')
+	}
+}
+
+// "where_clause" in gram.y
+//
+// Note this method adds a trailing space if a value is output
+func (d *deparser) whereClause(node *Node) {
+	if node.GetNode() != nil {
+		d.newline()
+		d.write("WHERE ")
+		d.expr(node)
+		d.writeByte(' ')
+	}
+}
+
+// "where_or_current_clause" in gram.y
+//
+// Note this method adds a trailing space if a value is output
+func (d *deparser) whereOrCurrentClause(node *Node) {
+	if node.GetNode() == nil {
+		return
+	}
+	d.newline()
+	d.write("WHERE ")
+	if currentOfExpr := node.GetCurrentOfExpr(); currentOfExpr != nil {
+		d.write("CURRENT OF ")
+		d.write(quoteIdentifier(currentOfExpr.CursorName))
+	} else {
+		d.expr(node)
+	}
+	d.writeByte(' ')
+}
+
+// "group_by_list" in gram.y
+func (d *deparser) groupByList(l []*Node) {
+	d.list(len(l), func(i int) {
+		if groupingSet := l[i].GetGroupingSet(); groupingSet != nil {
+			d.groupingSet(groupingSet)
+		} else {
+			d.expr(l[i])
+		}
+	})
+}
+
+// "set_target" in gram.y
+func (d *deparser) setTarget(resTarget *ResTarget) {
+	d.colID(resTarget.GetName())
+	d.optIndirection(resTarget.GetIndirection(), 0)
+}
+
+// "any_name_list" in gram.y
+func (d *deparser) anyNameList(l []*Node) {
+	for i, item := range l {
+		d.anyName(item.GetList().GetItems())
+		if i < len(l)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "name_list" in gram.y
+func (d *deparser) nameList(l []*Node) {
+	for i, item := range l {
+		d.colID(strVal(item))
+		if i < len(l)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "opt_sort_clause" and "json_array_aggregate_order_by_clause_opt" in gram.y
+//
+// Note this method adds a trailing space if a value is output
+func (d *deparser) optSortClause(l []*Node) {
+	if len(l) > 0 {
+		d.write("ORDER BY ")
+		d.list(len(l), func(i int) {
+			d.sortBy(l[i].GetSortBy())
+		})
+		d.writeByte(' ')
+	}
+}
+
+// "func_arg_expr" in gram.y
+func (d *deparser) funcArgExpr(node *Node) {
+	if namedArgExpr := node.GetNamedArgExpr(); namedArgExpr != nil {
+		d.write(namedArgExpr.Name)
+		d.write(" := ")
+		d.expr(namedArgExpr.Arg)
+	} else {
+		d.expr(node)
+	}
+}
+
+// "set_clause_list" in gram.y
+func (d *deparser) setClauseList(targetList []*Node) {
+	// A multi-assignment (a, b) = ... spans one target per column
+	var starts []int
+	for i := 0; i < len(targetList); {
+		starts = append(starts, i)
+		if r := targetList[i].GetResTarget().GetVal().GetMultiAssignRef(); r != nil && r.Ncolumns > 1 {
+			i += int(r.Ncolumns)
+		} else {
+			i++
+		}
+	}
+
+	d.list(len(starts), func(k int) {
+		i := starts[k]
+		resTarget := targetList[i].GetResTarget()
+		if resTarget.GetVal() == nil {
+			d.fail("error in setClauseList: ResTarget without val")
+		}
+		if r := resTarget.Val.GetMultiAssignRef(); r != nil {
+			d.writeByte('(')
+			for j := i; j < len(targetList); j++ {
+				d.setTarget(targetList[j].GetResTarget())
+				if j == int(r.Ncolumns)-1 { // Last element in this multi-assign
+					break
+				} else if j < len(targetList)-1 {
+					d.write(", ")
+				}
+			}
+			d.write(") = ")
+			d.expr(r.Source)
+		} else {
+			d.setTarget(resTarget)
+			d.write(" = ")
+			d.expr(resTarget.Val)
+		}
+	})
+}
+
+// "func_expr_windowless" in gram.y
+func (d *deparser) funcExprWindowless(node *Node) {
+	switch n := node.Node.(type) {
+	case *Node_FuncCall:
+		d.funcCall(n.FuncCall)
+	case *Node_SqlvalueFunction:
+		d.sqlValueFunction(n.SqlvalueFunction)
+	case *Node_TypeCast:
+		d.typeCast(n.TypeCast, deparseContextFuncExpr)
+	case *Node_CoalesceExpr:
+		d.coalesceExpr(n.CoalesceExpr)
+	case *Node_MinMaxExpr:
+		d.minMaxExpr(n.MinMaxExpr)
+	case *Node_XmlExpr:
+		d.xmlExpr(n.XmlExpr)
+	case *Node_XmlSerialize:
+		d.xmlSerialize(n.XmlSerialize)
+	default:
+		d.fail("unpermitted node type in func_expr_windowless: %s", nodeName(node))
+	}
+}
+
+// "opt_collate" in gram.y
+//
+// Note this method adds a trailing space if a value is output
+func (d *deparser) optCollate(l []*Node) {
+	if len(l) > 0 {
+		d.write("COLLATE ")
+		d.anyName(l)
+		d.writeByte(' ')
+	}
+}
+
+// "index_elem" in gram.y
+func (d *deparser) indexElem(indexElem *IndexElem) {
+	if indexElem.Name != "" {
+		d.colID(indexElem.Name)
+		d.writeByte(' ')
+	} else if indexElem.Expr.GetNode() != nil {
+		switch indexElem.Expr.Node.(type) {
+		// Simple function calls can be written without wrapping parens
+		case *Node_FuncCall, // func_application
+			*Node_SqlvalueFunction, *Node_CoalesceExpr, *Node_MinMaxExpr, *Node_XmlExpr, *Node_XmlSerialize: // func_expr_common_subexpr
+			d.funcExprWindowless(indexElem.Expr)
+			d.writeByte(' ')
+		default:
+			d.writeByte('(')
+			d.expr(indexElem.Expr)
+			d.write(") ")
+		}
+	} else {
+		d.fail("error in indexElem: neither name nor expression set")
+	}
+
+	d.optCollate(indexElem.Collation)
+
+	if len(indexElem.Opclass) > 0 {
+		d.anyName(indexElem.Opclass)
+		if len(indexElem.Opclassopts) > 0 {
+			d.relOptions(indexElem.Opclassopts)
+		}
+		d.writeByte(' ')
+	}
+
+	switch indexElem.Ordering {
+	case SortByDir_SORTBY_ASC:
+		d.write("ASC ")
+	case SortByDir_SORTBY_DESC:
+		d.write("DESC ")
+	case SortByDir_SORTBY_USING:
+		// Not allowed in CREATE INDEX
+		d.fail("error in indexElem: USING ordering")
+	}
+
+	switch indexElem.NullsOrdering {
+	case SortByNulls_SORTBY_NULLS_FIRST:
+		d.write("NULLS FIRST ")
+	case SortByNulls_SORTBY_NULLS_LAST:
+		d.write("NULLS LAST ")
+	}
+
+	d.removeTrailingSpace()
+}
+
+// "qualified_name_list" in gram.y
+func (d *deparser) qualifiedNameList(l []*Node) {
+	for i, item := range l {
+		d.rangeVar(item.GetRangeVar(), deparseContextNone)
+		if i < len(l)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "OptInherit" in gram.y
+//
+// Note this method adds a trailing space if a value is output
+func (d *deparser) optInherit(l []*Node) {
+	if len(l) > 0 {
+		d.write("INHERITS (")
+		d.qualifiedNameList(l)
+		d.write(") ")
+	}
+}
+
+// "privilege_target" in gram.y
+func (d *deparser) privilegeTarget(targtype GrantTargetType, objtype ObjectType, objs []*Node) {
+	switch targtype {
+	case GrantTargetType_ACL_TARGET_OBJECT:
+		switch objtype {
+		case ObjectType_OBJECT_TABLE:
+			d.qualifiedNameList(objs)
+		case ObjectType_OBJECT_SEQUENCE:
+			d.write("SEQUENCE ")
+			d.qualifiedNameList(objs)
+		case ObjectType_OBJECT_FDW:
+			d.write("FOREIGN DATA WRAPPER ")
+			d.nameList(objs)
+		case ObjectType_OBJECT_FOREIGN_SERVER:
+			d.write("FOREIGN SERVER ")
+			d.nameList(objs)
+		case ObjectType_OBJECT_FUNCTION:
+			d.write("FUNCTION ")
+			d.functionWithArgtypesList(objs)
+		case ObjectType_OBJECT_PROCEDURE:
+			d.write("PROCEDURE ")
+			d.functionWithArgtypesList(objs)
+		case ObjectType_OBJECT_ROUTINE:
+			d.write("ROUTINE ")
+			d.functionWithArgtypesList(objs)
+		case ObjectType_OBJECT_DATABASE:
+			d.write("DATABASE ")
+			d.nameList(objs)
+		case ObjectType_OBJECT_DOMAIN:
+			d.write("DOMAIN ")
+			d.anyNameList(objs)
+		case ObjectType_OBJECT_LANGUAGE:
+			d.write("LANGUAGE ")
+			d.nameList(objs)
+		case ObjectType_OBJECT_LARGEOBJECT:
+			d.write("LARGE OBJECT ")
+			d.numericOnlyList(objs)
+		case ObjectType_OBJECT_SCHEMA:
+			d.write("SCHEMA ")
+			d.nameList(objs)
+		case ObjectType_OBJECT_TABLESPACE:
+			d.write("TABLESPACE ")
+			d.nameList(objs)
+		case ObjectType_OBJECT_TYPE:
+			d.write("TYPE ")
+			d.anyNameList(objs)
+		default:
+			d.fail("unsupported privilege target object type: %s", objtype)
+		}
+	case GrantTargetType_ACL_TARGET_ALL_IN_SCHEMA:
+		switch objtype {
+		case ObjectType_OBJECT_TABLE:
+			d.write("ALL TABLES IN SCHEMA ")
+		case ObjectType_OBJECT_SEQUENCE:
+			d.write("ALL SEQUENCES IN SCHEMA ")
+		case ObjectType_OBJECT_FUNCTION:
+			d.write("ALL FUNCTIONS IN SCHEMA ")
+		case ObjectType_OBJECT_PROCEDURE:
+			d.write("ALL PROCEDURES IN SCHEMA ")
+		case ObjectType_OBJECT_ROUTINE:
+			d.write("ALL ROUTINES IN SCHEMA ")
+		default:
+			d.fail("unsupported privilege target object type: %s", objtype)
+		}
+		d.nameList(objs)
+	case GrantTargetType_ACL_TARGET_DEFAULTS: // defacl_privilege_target
+		switch objtype {
+		case ObjectType_OBJECT_TABLE:
+			d.write("TABLES")
+		case ObjectType_OBJECT_FUNCTION:
+			d.write("FUNCTIONS")
+		case ObjectType_OBJECT_SEQUENCE:
+			d.write("SEQUENCES")
+		case ObjectType_OBJECT_TYPE:
+			d.write("TYPES")
+		case ObjectType_OBJECT_SCHEMA:
+			d.write("SCHEMAS")
+		default:
+			d.fail("unsupported privilege target object type: %s", objtype)
+		}
+	}
+}
+
+// "opclass_item_list" in gram.y
+func (d *deparser) opclassItemList(items []*Node) {
+	for i, item := range items {
+		d.createOpClassItem(item.GetCreateOpClassItem())
+		if i < len(items)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+// "createdb_opt_list" in gram.y
+func (d *deparser) createdbOptList(l []*Node) {
+	for i, item := range l {
+		defElem := d.defElem(item)
+		if defElem.Defname == "connection_limit" {
+			d.write("CONNECTION LIMIT")
+		} else {
+			d.genericDefElemName(defElem.Defname)
+		}
+		d.writeByte(' ')
+
+		switch arg := defElem.Arg.GetNode().(type) {
+		case nil:
+			d.write("DEFAULT")
+		case *Node_Integer:
+			d.signedIconst(defElem.Arg)
+		case *Node_String_:
+			d.optBooleanOrString(arg.String_.Sval)
+		}
+		if i < len(l)-1 {
+			d.writeByte(' ')
+		}
+	}
+}
+
+// "utility_option_list" in gram.y
+func (d *deparser) utilityOptionList(options []*Node) {
+	if len(options) == 0 {
+		return
+	}
+	d.writeByte('(')
+	for i, option := range options {
+		defElem := d.defElem(option)
+		d.genericDefElemName(defElem.Defname)
+		if defElem.Arg != nil {
+			d.writeByte(' ')
+			switch arg := defElem.Arg.Node.(type) {
+			case *Node_Integer, *Node_Float:
+				d.numericOnly(defElem.Arg)
+			case *Node_String_:
+				d.optBooleanOrString(arg.String_.Sval)
+			default:
+				d.fail("unpermitted node type in utility option: %s", nodeName(defElem.Arg))
+			}
+		}
+		if i < len(options)-1 {
+			d.write(", ")
+		}
+	}
+	d.write(") ")
+}
+
+// setOpArgNeedsParens returns whether an argument of a set operation has to be wrapped in parens
+func setOpArgNeedsParens(stmt *SelectStmt) bool {
+	return len(stmt.SortClause) > 0 ||
+		stmt.LimitOffset != nil ||
+		stmt.LimitCount != nil ||
+		len(stmt.LockingClause) > 0 ||
+		stmt.WithClause != nil ||
+		stmt.Op > SetOperation_SETOP_NONE
+}
+
+func (d *deparser) selectStmt(stmt *SelectStmt) {
+	if stmt == nil {
+		d.fail("missing SelectStmt")
+	}
+	if stmt.WithClause != nil {
+		d.withClause(stmt.WithClause)
+		d.writeByte(' ')
+		d.newline()
+	}
+
+	switch stmt.Op {
+	case SetOperation_SET_OPERATION_UNDEFINED, SetOperation_SETOP_NONE:
+		if len(stmt.ValuesLists) > 0 {
+			d.write("VALUES ")
+			d.list(len(stmt.ValuesLists), func(i int) {
+				d.writeByte('(')
+				d.exprList(stmt.ValuesLists[i].GetList().GetItems())
+				d.writeByte(')')
+			})
+			d.writeByte(' ')
+			break
+		}
+
+		d.write("SELECT ")
+
+		if len(stmt.TargetList) > 0 {
+			if len(stmt.DistinctClause) > 0 {
+				d.write("DISTINCT ")
+				if stmt.DistinctClause[0].GetNode() != nil {
+					d.write("ON (")
+					d.exprList(stmt.DistinctClause)
+					d.write(") ")
+				}
+			}
+			d.targetList(stmt.TargetList)
+			d.writeByte(' ')
+		}
+
+		if stmt.IntoClause != nil {
+			d.newline()
+			d.write("INTO ")
+			d.optTemp(stmt.IntoClause.GetRel().GetRelpersistence())
+			d.intoClause(stmt.IntoClause)
+			d.writeByte(' ')
+		}
+
+		d.fromClause(stmt.FromClause)
+		d.whereClause(stmt.WhereClause)
+
+		if len(stmt.GroupClause) > 0 {
+			d.newline()
+			d.write("GROUP BY ")
+			if stmt.GroupDistinct {
+				d.write("DISTINCT ")
+			}
+			d.groupByList(stmt.GroupClause)
+			d.writeByte(' ')
+		}
+
+		if stmt.HavingClause.GetNode() != nil {
+			d.newline()
+			d.write("HAVING ")
+			d.expr(stmt.HavingClause)
+			d.writeByte(' ')
+		}
+
+		if len(stmt.WindowClause) > 0 {
+			d.newline()
+			d.write("WINDOW ")
+			d.list(len(stmt.WindowClause), func(i int) {
+				windowDef := stmt.WindowClause[i].GetWindowDef()
+				d.write(windowDef.GetName())
+				d.write(" AS ")
+				d.windowDef(windowDef)
+			})
+			d.writeByte(' ')
+		}
+	case SetOperation_SETOP_UNION, SetOperation_SETOP_INTERSECT, SetOperation_SETOP_EXCEPT:
+		needLargParens := setOpArgNeedsParens(stmt.Larg)
+		needRargParens := setOpArgNeedsParens(stmt.Rarg)
+		if needLargParens {
+			d.subquery(func() {
+				d.selectStmt(stmt.Larg)
+			})
+		} else {
+			d.selectStmt(stmt.Larg)
+		}
+		d.writeByte(' ')
+		d.newline()
+		switch stmt.Op {
+		case SetOperation_SETOP_UNION:
+			d.write("UNION ")
+		case SetOperation_SETOP_INTERSECT:
+			d.write("INTERSECT ")
+		case SetOperation_SETOP_EXCEPT:
+			d.write("EXCEPT ")
+		}
+		if stmt.All {
+			d.write("ALL ")
+		}
+		d.newline()
+		if needRargParens {
+			d.subquery(func() {
+				d.selectStmt(stmt.Rarg)
+			})
+		} else {
+			d.selectStmt(stmt.Rarg)
+		}
+		d.writeByte(' ')
+	default:
+		d.fail("unsupported set operation: %s", stmt.Op)
+	}
+
+	if len(stmt.SortClause) > 0 {
+		d.newline()
+		d.optSortClause(stmt.SortClause)
+	}
+
+	if stmt.LimitCount.GetNode() != nil {
+		d.newline()
+		if stmt.LimitOption == LimitOption_LIMIT_OPTION_COUNT {
+			d.write("LIMIT ")
+		} else if stmt.LimitOption == LimitOption_LIMIT_OPTION_WITH_TIES {
+			d.write("FETCH FIRST ")
+		}
+
+		if aConst := stmt.LimitCount.GetAConst(); aConst != nil && aConst.Isnull {
+			d.write("ALL")
+		} else if stmt.LimitOption == LimitOption_LIMIT_OPTION_WITH_TIES {
+			d.cExpr(stmt.LimitCount)
+		} else {
+			d.expr(stmt.LimitCount)
+		}
+		d.writeByte(' ')
+
+		if stmt.LimitOption == LimitOption_LIMIT_OPTION_WITH_TIES {
+			d.write("ROWS WITH TIES ")
+		}
+	}
+
+	if stmt.LimitOffset.GetNode() != nil {
+		d.newline()
+		d.write("OFFSET ")
+		d.expr(stmt.LimitOffset)
+		d.writeByte(' ')
+	}
+
+	if len(stmt.LockingClause) > 0 {
+		for i, item := range stmt.LockingClause {
+			d.newline()
+			d.lockingClause(item.GetLockingClause())
+			if i < len(stmt.LockingClause)-1 {
+				d.writeByte(' ')
+			}
+		}
+		d.writeByte(' ')
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) intoClause(intoClause *IntoClause) {
+	d.rangeVar(intoClause.Rel, deparseContextNone) // target relation name
+
+	if len(intoClause.ColNames) > 0 {
+		d.writeByte('(')
+		d.columnList(intoClause.ColNames)
+		d.writeByte(')')
+	}
+	d.writeByte(' ')
+
+	if intoClause.AccessMethod != "" {
+		d.write("USING ")
+		d.write(quoteIdentifier(intoClause.AccessMethod))
+		d.writeByte(' ')
+	}
+
+	d.optWith(intoClause.Options)
+
+	switch intoClause.OnCommit {
+	case OnCommitAction_ONCOMMIT_PRESERVE_ROWS:
+		d.write("ON COMMIT PRESERVE ROWS ")
+	case OnCommitAction_ONCOMMIT_DELETE_ROWS:
+		d.write("ON COMMIT DELETE ROWS ")
+	case OnCommitAction_ONCOMMIT_DROP:
+		d.write("ON COMMIT DROP ")
+	}
+
+	if intoClause.TableSpaceName != "" {
+		d.write("TABLESPACE ")
+		d.write(quoteIdentifier(intoClause.TableSpaceName))
+		d.writeByte(' ')
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) rangeVar(rangeVar *RangeVar, context deparseContext) {
+	if rangeVar == nil {
+		d.fail("error in rangeVar: missing relation")
+	}
+	if !rangeVar.Inh && context != deparseContextCreateType && context != deparseContextAlterType {
+		d.write("ONLY ")
+	}
+
+	if rangeVar.Catalogname != "" {
+		d.write(quoteIdentifier(rangeVar.Catalogname))
+		d.writeByte('.')
+	}
+
+	if rangeVar.Schemaname != "" {
+		d.write(quoteIdentifier(rangeVar.Schemaname))
+		d.writeByte('.')
+	}
+
+	d.write(quoteIdentifier(rangeVar.Relname))
+	d.writeByte(' ')
+
+	if rangeVar.Alias != nil {
+		if context == deparseContextInsertRelation {
+			d.write("AS ")
+		}
+		d.alias(rangeVar.Alias)
+		d.writeByte(' ')
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) rawStmt(rawStmt *RawStmt) {
+	if rawStmt.GetStmt().GetNode() == nil {
+		d.fail("error in rawStmt: RawStmt with empty Stmt")
+	}
+	d.stmt(rawStmt.Stmt)
+}
+
+func (d *deparser) alias(alias *Alias) {
+	d.write(quoteIdentifier(alias.Aliasname))
+
+	if len(alias.Colnames) > 0 {
+		d.writeByte('(')
+		d.nameList(alias.Colnames)
+		d.writeByte(')')
+	}
+}
+
+func (d *deparser) aConst(aConst *A_Const) {
+	var val *Node
+	if !aConst.Isnull {
+		val = aConstVal(aConst)
+	}
+	d.value(val, deparseContextConstant)
+}
+
+func (d *deparser) insertOverride(override OverridingKind) {
+	switch override {
+	case OverridingKind_OVERRIDING_USER_VALUE:
+		d.write("OVERRIDING USER VALUE ")
+	case OverridingKind_OVERRIDING_SYSTEM_VALUE:
+		d.write("OVERRIDING SYSTEM VALUE ")
+	}
+}
+
+func (d *deparser) insertStmt(insertStmt *InsertStmt) {
+	if insertStmt.WithClause != nil {
+		d.withClause(insertStmt.WithClause)
+		d.writeByte(' ')
+		d.newline()
+	}
+
+	d.write("INSERT INTO ")
+	d.rangeVar(insertStmt.Relation, deparseContextInsertRelation)
+	d.writeByte(' ')
+
+	if len(insertStmt.Cols) > 0 {
+		d.writeByte('(')
+		d.insertColumnList(insertStmt.Cols)
+		d.write(") ")
+	}
+
+	d.insertOverride(insertStmt.Override)
+	d.newline()
+
+	if selectStmt := insertStmt.SelectStmt.GetSelectStmt(); selectStmt != nil {
+		d.selectStmt(selectStmt)
+		d.writeByte(' ')
+	} else {
+		d.write("DEFAULT VALUES ")
+	}
+
+	if insertStmt.OnConflictClause != nil {
+		d.newline()
+		d.onConflictClause(insertStmt.OnConflictClause)
+		d.writeByte(' ')
+	}
+
+	if len(insertStmt.ReturningList) > 0 {
+		d.newline()
+		d.write("RETURNING ")
+		d.targetList(insertStmt.ReturningList)
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) inferClause(inferClause *InferClause) {
+	if len(inferClause.IndexElems) > 0 {
+		d.writeByte('(')
+		for i, item := range inferClause.IndexElems {
+			d.indexElem(item.GetIndexElem())
+			if i < len(inferClause.IndexElems)-1 {
+				d.write(", ")
+			}
+		}
+		d.write(") ")
+	}
+
+	if inferClause.Conname != "" {
+		d.write("ON CONSTRAINT ")
+		d.write(quoteIdentifier(inferClause.Conname))
+		d.writeByte(' ')
+	}
+
+	d.whereClause(inferClause.WhereClause)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) onConflictClause(onConflictClause *OnConflictClause) {
+	d.write("ON CONFLICT ")
+
+	if onConflictClause.Infer != nil {
+		d.inferClause(onConflictClause.Infer)
+		d.writeByte(' ')
+	}
+
+	switch onConflictClause.Action {
+	case OnConflictAction_ONCONFLICT_NOTHING:
+		d.write("DO NOTHING ")
+	case OnConflictAction_ONCONFLICT_UPDATE:
+		d.write("DO UPDATE ")
+	default:
+		d.fail("unsupported ON CONFLICT action: %s", onConflictClause.Action)
+	}
+
+	if len(onConflictClause.TargetList) > 0 {
+		d.newline()
+		d.write("SET ")
+		d.setClauseList(onConflictClause.TargetList)
+		d.writeByte(' ')
+	}
+
+	d.whereClause(onConflictClause.WhereClause)
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) updateStmt(updateStmt *UpdateStmt) {
+	if updateStmt.WithClause != nil {
+		d.withClause(updateStmt.WithClause)
+		d.writeByte(' ')
+		d.newline()
+	}
+
+	d.write("UPDATE ")
+	d.rangeVar(updateStmt.Relation, deparseContextNone)
+	d.writeByte(' ')
+
+	if len(updateStmt.TargetList) > 0 {
+		d.newline()
+		d.write("SET ")
+		d.setClauseList(updateStmt.TargetList)
+		d.writeByte(' ')
+	}
+
+	d.fromClause(updateStmt.FromClause)
+	d.whereOrCurrentClause(updateStmt.WhereClause)
+
+	if len(updateStmt.ReturningList) > 0 {
+		d.newline()
+		d.write("RETURNING ")
+		d.targetList(updateStmt.ReturningList)
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) mergeStmt(mergeStmt *MergeStmt) {
+	if mergeStmt.WithClause != nil {
+		d.withClause(mergeStmt.WithClause)
+		d.writeByte(' ')
+		d.newline()
+	}
+
+	d.write("MERGE INTO ")
+	d.rangeVar(mergeStmt.Relation, deparseContextNone)
+	d.writeByte(' ')
+
+	d.newline()
+	d.write("USING ")
+	d.tableRef(mergeStmt.SourceRelation)
+	d.writeByte(' ')
+
+	d.write("ON ")
+	d.expr(mergeStmt.JoinCondition)
+	d.writeByte(' ')
+
+	for i, item := range mergeStmt.MergeWhenClauses {
+		clause := item.GetMergeWhenClause()
+		if clause == nil {
+			d.fail("unexpected node in MERGE WHEN clauses: %s", nodeName(item))
+		}
+
+		d.newline()
+		d.write("WHEN ")
+		if !clause.Matched {
+			d.write("NOT ")
+		}
+		d.write("MATCHED ")
+
+		if clause.Condition.GetNode() != nil {
+			d.write("AND ")
+			d.expr(clause.Condition)
+			d.writeByte(' ')
+		}
+
+		d.write("THEN ")
+
+		switch clause.CommandType {
+		case CmdType_CMD_INSERT:
+			d.write("INSERT ")
+			if len(clause.TargetList) > 0 {
+				d.writeByte('(')
+				d.insertColumnList(clause.TargetList)
+				d.write(") ")
+			}
+			d.insertOverride(clause.Override)
+			if len(clause.Values) > 0 {
+				d.write("VALUES (")
+				d.exprList(clause.Values)
+				d.write(")")
+			} else {
+				d.write("DEFAULT VALUES ")
+			}
+		case CmdType_CMD_UPDATE:
+			d.write("UPDATE SET ")
+			d.setClauseList(clause.TargetList)
+		case CmdType_CMD_DELETE:
+			d.write("DELETE")
+		case CmdType_CMD_NOTHING:
+			d.write("DO NOTHING")
+		default:
+			d.fail("unpermitted command type in merge statement: %s", clause.CommandType)
+		}
+
+		if i < len(mergeStmt.MergeWhenClauses)-1 {
+			d.writeByte(' ')
+		}
+	}
+}
+
+func (d *deparser) deleteStmt(deleteStmt *DeleteStmt) {
+	if deleteStmt.WithClause != nil {
+		d.withClause(deleteStmt.WithClause)
+		d.writeByte(' ')
+		d.newline()
+	}
+
+	d.write("DELETE FROM ")
+	d.rangeVar(deleteStmt.Relation, deparseContextNone)
+	d.writeByte(' ')
+
+	if len(deleteStmt.UsingClause) > 0 {
+		d.newline()
+		d.write("USING ")
+		d.fromList(deleteStmt.UsingClause)
+		d.writeByte(' ')
+	}
+
+	d.whereOrCurrentClause(deleteStmt.WhereClause)
+
+	if len(deleteStmt.ReturningList) > 0 {
+		d.newline()
+		d.write("RETURNING ")
+		d.targetList(deleteStmt.ReturningList)
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) lockingClause(lockingClause *LockingClause) {
+	switch lockingClause.Strength {
+	case LockClauseStrength_LCS_FORKEYSHARE:
+		d.write("FOR KEY SHARE ")
+	case LockClauseStrength_LCS_FORSHARE:
+		d.write("FOR SHARE ")
+	case LockClauseStrength_LCS_FORNOKEYUPDATE:
+		d.write("FOR NO KEY UPDATE ")
+	case LockClauseStrength_LCS_FORUPDATE:
+		d.write("FOR UPDATE ")
+	default:
+		// No such clause, only used in PlanRowMark
+		d.fail("unsupported locking clause strength: %s", lockingClause.Strength)
+	}
+
+	if len(lockingClause.LockedRels) > 0 {
+		d.write("OF ")
+		d.qualifiedNameList(lockingClause.LockedRels)
+	}
+
+	switch lockingClause.WaitPolicy {
+	case LockWaitPolicy_LockWaitError:
+		d.write("NOWAIT")
+	case LockWaitPolicy_LockWaitSkip:
+		d.write("SKIP LOCKED")
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) withClause(withClause *WithClause) {
+	d.write("WITH ")
+	if withClause.Recursive {
+		d.write("RECURSIVE ")
+	}
+
+	for i, item := range withClause.Ctes {
+		d.commonTableExpr(item.GetCommonTableExpr())
+		if i < len(withClause.Ctes)-1 {
+			d.write(", ")
+		}
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) joinExpr(joinExpr *JoinExpr) {
+	needAliasParens := joinExpr.Alias != nil
+	rarg := joinExpr.Rarg.GetJoinExpr()
+	needRargParens := rarg != nil && rarg.Alias == nil
+
+	if needAliasParens {
+		d.writeByte('(')
+	}
+
+	d.tableRef(joinExpr.Larg)
+
+	d.writeByte(' ')
+	d.newline()
+
+	if joinExpr.IsNatural {
+		d.write("NATURAL ")
+	}
+
+	switch joinExpr.Jointype {
+	case JoinType_JOIN_INNER: // matching tuple pairs only
+		if !joinExpr.IsNatural && joinExpr.Quals.GetNode() == nil && len(joinExpr.UsingClause) == 0 {
+			d.write("CROSS ")
+		}
+	case JoinType_JOIN_LEFT: // pairs + unmatched LHS tuples
+		d.write("LEFT ")
+	case JoinType_JOIN_FULL: // pairs + unmatched LHS + unmatched RHS
+		d.write("FULL ")
+	case JoinType_JOIN_RIGHT: // pairs + unmatched RHS tuples
+		d.write("RIGHT ")
+	default:
+		// Only used by the planner/executor, not seen in parser output
+		d.fail("unsupported join type: %s", joinExpr.Jointype)
+	}
+
+	d.write("JOIN ")
+
+	if needRargParens {
+		d.writeByte('(')
+	}
+	d.tableRef(joinExpr.Rarg)
+	if needRargParens {
+		d.writeByte(')')
+	}
+	d.writeByte(' ')
+
+	if joinExpr.Quals.GetNode() != nil {
+		d.write("ON ")
+		d.expr(joinExpr.Quals)
+		d.writeByte(' ')
+	}
+
+	if len(joinExpr.UsingClause) > 0 {
+		d.write("USING (")
+		d.nameList(joinExpr.UsingClause)
+		d.write(") ")
+
+		if joinExpr.JoinUsingAlias != nil {
+			d.write("AS ")
+			d.write(joinExpr.JoinUsingAlias.Aliasname)
+		}
+	}
+
+	if needAliasParens {
+		d.write(") ")
+	}
+
+	if joinExpr.Alias != nil {
+		d.alias(joinExpr.Alias)
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) cteSearchClause(searchClause *CTESearchClause) {
+	d.write(" SEARCH ")
+	if searchClause.SearchBreadthFirst {
+		d.write("BREADTH ")
+	} else {
+		d.write("DEPTH ")
+	}
+
+	d.write("FIRST BY ")
+
+	d.columnList(searchClause.SearchColList)
+
+	d.write(" SET ")
+	d.write(quoteIdentifier(searchClause.SearchSeqColumn))
+}
+
+func (d *deparser) cteCycleClause(cycleClause *CTECycleClause) {
+	d.write(" CYCLE ")
+
+	d.columnList(cycleClause.CycleColList)
+
+	d.write(" SET ")
+	d.write(quoteIdentifier(cycleClause.CycleMarkColumn))
+
+	if cycleClause.CycleMarkValue.GetNode() != nil {
+		d.write(" TO ")
+		d.expr(cycleClause.CycleMarkValue)
+	}
+
+	if cycleClause.CycleMarkDefault.GetNode() != nil {
+		d.write(" DEFAULT ")
+		d.expr(cycleClause.CycleMarkDefault)
+	}
+
+	d.write(" USING ")
+	d.write(quoteIdentifier(cycleClause.CyclePathColumn))
+}
+
+func (d *deparser) commonTableExpr(cte *CommonTableExpr) {
+	if cte == nil {
+		d.fail("missing CommonTableExpr")
+	}
+	d.colID(cte.Ctename)
+
+	if len(cte.Aliascolnames) > 0 {
+		d.writeByte('(')
+		d.nameList(cte.Aliascolnames)
+		d.writeByte(')')
+	}
+	d.writeByte(' ')
+
+	d.write("AS ")
+	switch cte.Ctematerialized {
+	case CTEMaterialize_CTEMaterializeAlways:
+		d.write("MATERIALIZED ")
+	case CTEMaterialize_CTEMaterializeNever:
+		d.write("NOT MATERIALIZED ")
+	}
+
+	d.subquery(func() {
+		d.preparableStmt(cte.Ctequery)
+	})
+
+	if cte.SearchClause != nil {
+		d.cteSearchClause(cte.SearchClause)
+	}
+	if cte.CycleClause != nil {
+		d.cteCycleClause(cte.CycleClause)
+	}
+}
+
+func (d *deparser) rangeSubselect(rangeSubselect *RangeSubselect) {
+	if rangeSubselect.Lateral {
+		d.write("LATERAL ")
+	}
+
+	d.subquery(func() {
+		d.selectStmt(rangeSubselect.Subquery.GetSelectStmt())
+	})
+
+	if rangeSubselect.Alias != nil {
+		d.writeByte(' ')
+		d.alias(rangeSubselect.Alias)
+	}
+}
+
+// columnDefList appends the column definitions of a function returning a record
+func (d *deparser) columnDefList(l []*Node) {
+	for i, item := range l {
+		d.columnDef(item.GetColumnDef())
+		if i < len(l)-1 {
+			d.write(", ")
+		}
+	}
+}
+
+func (d *deparser) rangeFunction(rangeFunc *RangeFunction) {
+	if rangeFunc.Lateral {
+		d.write("LATERAL ")
+	}
+
+	if rangeFunc.IsRowsfrom {
+		d.write("ROWS FROM ")
+		d.writeByte('(')
+		for i, item := range rangeFunc.Functions {
+			lfunc := item.GetList().GetItems()
+			if len(lfunc) != 2 {
+				d.fail("error in rangeFunction: function list with %d elements", len(lfunc))
+			}
+			d.funcExprWindowless(lfunc[0])
+			d.writeByte(' ')
+			if coldeflist := lfunc[1].GetList().GetItems(); len(coldeflist) > 0 {
+				d.write("AS (")
+				d.columnDefList(coldeflist)
+				d.writeByte(')')
+			}
+			if i < len(rangeFunc.Functions)-1 {
+				d.write(", ")
+			}
+		}
+		d.writeByte(')')
+	} else {
+		if len(rangeFunc.Functions) == 0 || len(rangeFunc.Functions[0].GetList().GetItems()) != 2 {
+			d.fail("error in rangeFunction: missing function")
+		}
+		d.funcExprWindowless(rangeFunc.Functions[0].GetList().Items[0])
+	}
+	d.writeByte(' ')
+
+	if rangeFunc.Ordinality {
+		d.write("WITH ORDINALITY ")
+	}
+
+	if rangeFunc.Alias != nil {
+		d.alias(rangeFunc.Alias)
+		d.writeByte(' ')
+	}
+
+	if len(rangeFunc.Coldeflist) > 0 {
+		if rangeFunc.Alias == nil {
+			d.write("AS ")
+		}
+		d.writeByte('(')
+		d.columnDefList(rangeFunc.Coldeflist)
+		d.writeByte(')')
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) rangeTableSample(rangeTableSample *RangeTableSample) {
+	d.rangeVar(rangeTableSample.Relation.GetRangeVar(), deparseContextNone)
+
+	d.write(" TABLESAMPLE ")
+
+	d.funcName(rangeTableSample.Method)
+	d.writeByte('(')
+	d.exprList(rangeTableSample.Args)
+	d.write(") ")
+
+	if rangeTableSample.Repeatable.GetNode() != nil {
+		d.write("REPEATABLE (")
+		d.expr(rangeTableSample.Repeatable)
+		d.write(") ")
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) rangeTableFuncCol(rangeTableFuncCol *RangeTableFuncCol) {
+	d.write(quoteIdentifier(rangeTableFuncCol.Colname))
+	d.writeByte(' ')
+
+	if rangeTableFuncCol.ForOrdinality {
+		d.write("FOR ORDINALITY ")
+	} else {
+		d.typeName(rangeTableFuncCol.TypeName)
+		d.writeByte(' ')
+
+		if rangeTableFuncCol.Colexpr.GetNode() != nil {
+			d.write("PATH ")
+			d.expr(rangeTableFuncCol.Colexpr)
+			d.writeByte(' ')
+		}
+
+		if rangeTableFuncCol.Coldefexpr.GetNode() != nil {
+			d.write("DEFAULT ")
+			d.expr(rangeTableFuncCol.Coldefexpr)
+			d.writeByte(' ')
+		}
+
+		if rangeTableFuncCol.IsNotNull {
+			d.write("NOT NULL ")
+		}
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) rangeTableFunc(rangeTableFunc *RangeTableFunc) {
+	if rangeTableFunc.Lateral {
+		d.write("LATERAL ")
+	}
+
+	d.write("xmltable(")
+	if len(rangeTableFunc.Namespaces) > 0 {
+		d.write("xmlnamespaces(")
+		d.xmlNamespaceList(rangeTableFunc.Namespaces)
+		d.write("), ")
+	}
+
+	d.writeByte('(')
+	d.expr(rangeTableFunc.Rowexpr)
+	d.writeByte(')')
+
+	d.write(" PASSING ")
+	d.expr(rangeTableFunc.Docexpr)
+
+	d.write(" COLUMNS ")
+	for i, item := range rangeTableFunc.Columns {
+		d.rangeTableFuncCol(item.GetRangeTableFuncCol())
+		if i < len(rangeTableFunc.Columns)-1 {
+			d.write(", ")
+		}
+	}
+
+	d.write(") ")
+
+	if rangeTableFunc.Alias != nil {
+		d.write("AS ")
+		d.alias(rangeTableFunc.Alias)
+	}
+
+	d.removeTrailingSpace()
+}
+
+func (d *deparser) groupingSet(groupingSet *GroupingSet) {
+	switch groupingSet.Kind {
+	case GroupingSetKind_GROUPING_SET_EMPTY:
+		d.write("()")
+	case GroupingSetKind_GROUPING_SET_ROLLUP:
+		d.write("ROLLUP (")
+		d.exprList(groupingSet.Content)
+		d.writeByte(')')
+	case GroupingSetKind_GROUPING_SET_CUBE:
+		d.write("CUBE (")
+		d.exprList(groupingSet.Content)
+		d.writeByte(')')
+	case GroupingSetKind_GROUPING_SET_SETS:
+		d.write("GROUPING SETS (")
+		d.groupByList(groupingSet.Content)
+		d.writeByte(')')
+	default:
+		// GROUPING_SET_SIMPLE is not present in raw parse trees
+		d.fail("unsupported grouping set kind: %s", groupingSet.Kind)
+	}
+}
+
+// "PreparableStmt" in gram.y
+func (d *deparser) preparableStmt(node *Node) {
+	switch n := node.GetNode().(type) {
+	case *Node_SelectStmt:
+		d.selectStmt(n.SelectStmt)
+	case *Node_InsertStmt:
+		d.insertStmt(n.InsertStmt)
+	case *Node_UpdateStmt:
+		d.updateStmt(n.UpdateStmt)
+	case *Node_DeleteStmt:
+		d.deleteStmt(n.DeleteStmt)
+	case *Node_MergeStmt:
+		d.mergeStmt(n.MergeStmt)
+	default:
+		d.fail("unsupported preparable statement: %s", nodeName(node))
+	}
+}
+
+// "RuleActionStmt" in gram.y
+func (d *deparser) ruleActionStmt(node *Node) {
+	switch n := node.GetNode().(type) {
+	case *Node_SelectStmt:
+		d.selectStmt(n.SelectStmt)
+	case *Node_InsertStmt:
+		d.insertStmt(n.InsertStmt)
+	case *Node_UpdateStmt:
+		d.updateStmt(n.UpdateStmt)
+	case *Node_DeleteStmt:
+		d.deleteStmt(n.DeleteStmt)
+	case *Node_NotifyStmt:
+		d.notifyStmt(n.NotifyStmt)
+	default:
+		d.fail("unsupported rule action statement: %s", nodeName(node))
+	}
+}
+
+// "ExplainableStmt" in gram.y
+func (d *deparser) explainableStmt(node *Node) {
+	switch n := node.GetNode().(type) {
+	case *Node_SelectStmt:
+		d.selectStmt(n.SelectStmt)
+	case *Node_InsertStmt:
+		d.insertStmt(n.InsertStmt)
+	case *Node_UpdateStmt:
+		d.updateStmt(n.UpdateStmt)
+	case *Node_DeleteStmt:
+		d.deleteStmt(n.DeleteStmt)
+	case *Node_DeclareCursorStmt:
+		d.declareCursorStmt(n.DeclareCursorStmt)
+	case *Node_CreateTableAsStmt:
+		d.createTableAsStmt(n.CreateTableAsStmt)
+	case *Node_RefreshMatViewStmt:
+		d.refreshMatViewStmt(n.RefreshMatViewStmt)
+	case *Node_ExecuteStmt:
+		d.executeStmt(n.ExecuteStmt)
+	case *Node_MergeStmt:
+		d.mergeStmt(n.MergeStmt)
+	default:
+		d.fail("unsupported explainable statement: %s", nodeName(node))
+	}
+}
+
+// "schema_stmt" in gram.y
+func (d *deparser) schemaStmt(node *Node) {
+	switch n := node.GetNode().(type) {
+	case *Node_CreateStmt:
+		d.createStmt(n.CreateStmt, false)
+	case *Node_IndexStmt:
+		d.indexStmt(n.IndexStmt)
+	case *Node_CreateSeqStmt:
+		d.createSeqStmt(n.CreateSeqStmt)
+	case *Node_CreateTrigStmt:
+		d.createTrigStmt(n.CreateTrigStmt)
+	case *Node_GrantStmt:
+		d.grantStmt(n.GrantStmt)
+	case *Node_ViewStmt:
+		d.viewStmt(n.ViewStmt)
+	default:
+		d.fail("unsupported schema statement: %s", nodeName(node))
+	}
+}
+
+// "stmt" in gram.y
+func (d *deparser) stmt(node *Node) {
+	switch n := node.GetNode().(type) {
+	case *Node_AlterEventTrigStmt:
+		d.alterEventTrigStmt(n.AlterEventTrigStmt)
+	case *Node_AlterCollationStmt:
+		d.alterCollationStmt(n.AlterCollationStmt)
+	case *Node_AlterDatabaseStmt:
+		d.alterDatabaseStmt(n.AlterDatabaseStmt)
+	case *Node_AlterDatabaseSetStmt:
+		d.alterDatabaseSetStmt(n.AlterDatabaseSetStmt)
+	case *Node_AlterDefaultPrivilegesStmt:
+		d.alterDefaultPrivilegesStmt(n.AlterDefaultPrivilegesStmt)
+	case *Node_AlterDomainStmt:
+		d.alterDomainStmt(n.AlterDomainStmt)
+	case *Node_AlterEnumStmt:
+		d.alterEnumStmt(n.AlterEnumStmt)
+	case *Node_AlterExtensionStmt:
+		d.alterExtensionStmt(n.AlterExtensionStmt)
+	case *Node_AlterExtensionContentsStmt:
+		d.alterExtensionContentsStmt(n.AlterExtensionContentsStmt)
+	case *Node_AlterFdwStmt:
+		d.alterFdwStmt(n.AlterFdwStmt)
+	case *Node_AlterForeignServerStmt:
+		d.alterForeignServerStmt(n.AlterForeignServerStmt)
+	case *Node_AlterFunctionStmt:
+		d.alterFunctionStmt(n.AlterFunctionStmt)
+	case *Node_AlterObjectDependsStmt:
+		d.alterObjectDependsStmt(n.AlterObjectDependsStmt)
+	case *Node_AlterObjectSchemaStmt:
+		d.alterObjectSchemaStmt(n.AlterObjectSchemaStmt)
+	case *Node_AlterOwnerStmt:
+		d.alterOwnerStmt(n.AlterOwnerStmt)
+	case *Node_AlterOperatorStmt:
+		d.alterOperatorStmt(n.AlterOperatorStmt)
+	case *Node_AlterTypeStmt:
+		d.alterTypeStmt(n.AlterTypeStmt)
+	case *Node_AlterPolicyStmt:
+		d.alterPolicyStmt(n.AlterPolicyStmt)
+	case *Node_AlterSeqStmt:
+		d.alterSeqStmt(n.AlterSeqStmt)
+	case *Node_AlterSystemStmt:
+		d.alterSystemStmt(n.AlterSystemStmt)
+	case *Node_AlterTableMoveAllStmt:
+		d.alterTableMoveAllStmt(n.AlterTableMoveAllStmt)
+	case *Node_AlterTableStmt:
+		d.alterTableStmt(n.AlterTableStmt)
+	case *Node_AlterTableSpaceOptionsStmt:
+		d.alterTableSpaceOptionsStmt(n.AlterTableSpaceOptionsStmt)
+	case *Node_AlterPublicationStmt:
+		d.alterPublicationStmt(n.AlterPublicationStmt)
+	case *Node_AlterRoleSetStmt:
+		d.alterRoleSetStmt(n.AlterRoleSetStmt)
+	case *Node_AlterRoleStmt:
+		d.alterRoleStmt(n.AlterRoleStmt)
+	case *Node_AlterSubscriptionStmt:
+		d.alterSubscriptionStmt(n.AlterSubscriptionStmt)
+	case *Node_AlterStatsStmt:
+		d.alterStatsStmt(n.AlterStatsStmt)
+	case *Node_AlterTsconfigurationStmt:
+		d.alterTSConfigurationStmt(n.AlterTsconfigurationStmt)
+	case *Node_AlterTsdictionaryStmt:
+		d.alterTSDictionaryStmt(n.AlterTsdictionaryStmt)
+	case *Node_AlterUserMappingStmt:
+		d.alterUserMappingStmt(n.AlterUserMappingStmt)
+	case *Node_CallStmt:
+		d.callStmt(n.CallStmt)
+	case *Node_CheckPointStmt:
+		d.checkPointStmt(n.CheckPointStmt)
+	case *Node_ClosePortalStmt:
+		d.closePortalStmt(n.ClosePortalStmt)
+	case *Node_ClusterStmt:
+		d.clusterStmt(n.ClusterStmt)
+	case *Node_CommentStmt:
+		d.commentStmt(n.CommentStmt)
+	case *Node_ConstraintsSetStmt:
+		d.constraintsSetStmt(n.ConstraintsSetStmt)
+	case *Node_CopyStmt:
+		d.copyStmt(n.CopyStmt)
+	case *Node_CreateAmStmt:
+		d.createAmStmt(n.CreateAmStmt)
+	case *Node_CreateTableAsStmt:
+		d.createTableAsStmt(n.CreateTableAsStmt)
+	case *Node_CreateCastStmt:
+		d.createCastStmt(n.CreateCastStmt)
+	case *Node_CreateConversionStmt:
+		d.createConversionStmt(n.CreateConversionStmt)
+	case *Node_CreateDomainStmt:
+		d.createDomainStmt(n.CreateDomainStmt)
+	case *Node_CreateExtensionStmt:
+		d.createExtensionStmt(n.CreateExtensionStmt)
+	case *Node_CreateFdwStmt:
+		d.createFdwStmt(n.CreateFdwStmt)
+	case *Node_CreateForeignServerStmt:
+		d.createForeignServerStmt(n.CreateForeignServerStmt)
+	case *Node_CreateForeignTableStmt:
+		d.createForeignTableStmt(n.CreateForeignTableStmt)
+	case *Node_CreateFunctionStmt:
+		d.createFunctionStmt(n.CreateFunctionStmt)
+	case *Node_CreateOpClassStmt:
+		d.createOpClassStmt(n.CreateOpClassStmt)
+	case *Node_CreateOpFamilyStmt:
+		d.createOpFamilyStmt(n.CreateOpFamilyStmt)
+	case *Node_CreatePublicationStmt:
+		d.createPublicationStmt(n.CreatePublicationStmt)
+	case *Node_AlterOpFamilyStmt:
+		d.alterOpFamilyStmt(n.AlterOpFamilyStmt)
+	case *Node_CreatePolicyStmt:
+		d.createPolicyStmt(n.CreatePolicyStmt)
+	case *Node_CreatePlangStmt:
+		d.createPLangStmt(n.CreatePlangStmt)
+	case *Node_CreateSchemaStmt:
+		d.createSchemaStmt(n.CreateSchemaStmt)
+	case *Node_CreateSeqStmt:
+		d.createSeqStmt(n.CreateSeqStmt)
+	case *Node_CreateStmt:
+		d.createStmt(n.CreateStmt, false)
+	case *Node_CreateSubscriptionStmt:
+		d.createSubscriptionStmt(n.CreateSubscriptionStmt)
+	case *Node_CreateStatsStmt:
+		d.createStatsStmt(n.CreateStatsStmt)
+	case *Node_CreateTableSpaceStmt:
+		d.createTableSpaceStmt(n.CreateTableSpaceStmt)
+	case *Node_CreateTransformStmt:
+		d.createTransformStmt(n.CreateTransformStmt)
+	case *Node_CreateTrigStmt:
+		d.createTrigStmt(n.CreateTrigStmt)
+	case *Node_CreateEventTrigStmt:
+		d.createEventTrigStmt(n.CreateEventTrigStmt)
+	case *Node_CreateRoleStmt:
+		d.createRoleStmt(n.CreateRoleStmt)
+	case *Node_CreateUserMappingStmt:
+		d.createUserMappingStmt(n.CreateUserMappingStmt)
+	case *Node_CreatedbStmt:
+		d.createdbStmt(n.CreatedbStmt)
+	case *Node_DeallocateStmt:
+		d.deallocateStmt(n.DeallocateStmt)
+	case *Node_DeclareCursorStmt:
+		d.declareCursorStmt(n.DeclareCursorStmt)
+	case *Node_DefineStmt:
+		d.defineStmt(n.DefineStmt)
+	case *Node_DeleteStmt:
+		d.deleteStmt(n.DeleteStmt)
+	case *Node_DiscardStmt:
+		d.discardStmt(n.DiscardStmt)
+	case *Node_DoStmt:
+		d.doStmt(n.DoStmt)
+	case *Node_DropOwnedStmt:
+		d.dropOwnedStmt(n.DropOwnedStmt)
+	case *Node_DropStmt:
+		d.dropStmt(n.DropStmt)
+	case *Node_DropSubscriptionStmt:
+		d.dropSubscriptionStmt(n.DropSubscriptionStmt)
+	case *Node_DropTableSpaceStmt:
+		d.dropTableSpaceStmt(n.DropTableSpaceStmt)
+	case *Node_DropRoleStmt:
+		d.dropRoleStmt(n.DropRoleStmt)
+	case *Node_DropUserMappingStmt:
+		d.dropUserMappingStmt(n.DropUserMappingStmt)
+	case *Node_DropdbStmt:
+		d.dropdbStmt(n.DropdbStmt)
+	case *Node_ExecuteStmt:
+		d.executeStmt(n.ExecuteStmt)
+	case *Node_ExplainStmt:
+		d.explainStmt(n.ExplainStmt)
+	case *Node_FetchStmt:
+		d.fetchStmt(n.FetchStmt)
+	case *Node_GrantStmt:
+		d.grantStmt(n.GrantStmt)
+	case *Node_GrantRoleStmt:
+		d.grantRoleStmt(n.GrantRoleStmt)
+	case *Node_ImportForeignSchemaStmt:
+		d.importForeignSchemaStmt(n.ImportForeignSchemaStmt)
+	case *Node_IndexStmt:
+		d.indexStmt(n.IndexStmt)
+	case *Node_InsertStmt:
+		d.insertStmt(n.InsertStmt)
+	case *Node_ListenStmt:
+		d.listenStmt(n.ListenStmt)
+	case *Node_RefreshMatViewStmt:
+		d.refreshMatViewStmt(n.RefreshMatViewStmt)
+	case *Node_LoadStmt:
+		d.loadStmt(n.LoadStmt)
+	case *Node_LockStmt:
+		d.lockStmt(n.LockStmt)
+	case *Node_MergeStmt:
+		d.mergeStmt(n.MergeStmt)
+	case *Node_NotifyStmt:
+		d.notifyStmt(n.NotifyStmt)
+	case *Node_PrepareStmt:
+		d.prepareStmt(n.PrepareStmt)
+	case *Node_ReassignOwnedStmt:
+		d.reassignOwnedStmt(n.ReassignOwnedStmt)
+	case *Node_ReindexStmt:
+		d.reindexStmt(n.ReindexStmt)
+	case *Node_RenameStmt:
+		d.renameStmt(n.RenameStmt)
+	case *Node_RuleStmt:
+		d.ruleStmt(n.RuleStmt)
+	case *Node_SecLabelStmt:
+		d.secLabelStmt(n.SecLabelStmt)
+	case *Node_SelectStmt:
+		d.selectStmt(n.SelectStmt)
+	case *Node_TransactionStmt:
+		d.transactionStmt(n.TransactionStmt)
+	case *Node_TruncateStmt:
+		d.truncateStmt(n.TruncateStmt)
+	case *Node_UnlistenStmt:
+		d.unlistenStmt(n.UnlistenStmt)
+	case *Node_UpdateStmt:
+		d.updateStmt(n.UpdateStmt)
+	case *Node_VacuumStmt:
+		d.vacuumStmt(n.VacuumStmt)
+	case *Node_VariableSetStmt:
+		d.variableSetStmt(n.VariableSetStmt)
+	case *Node_VariableShowStmt:
+		d.variableShowStmt(n.VariableShowStmt)
+	case *Node_ViewStmt:
+		d.viewStmt(n.ViewStmt)
+	// These node types are created by DefineStmt grammar for CREATE TYPE in some cases
+	case *Node_CompositeTypeStmt:
+		d.compositeTypeStmt(n.CompositeTypeStmt)
+	case *Node_CreateEnumStmt:
+		d.createEnumStmt(n.CreateEnumStmt)
+	case *Node_CreateRangeStmt:
+		d.createRangeStmt(n.CreateRangeStmt)
+	default:
+		d.fail("unsupported top-level node type: %s", nodeName(node))
+	}
+}
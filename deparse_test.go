@@ -0,0 +1,424 @@
+//go:build cgo
+// +build cgo
+
+package pg_query_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+	"github.com/cossacklabs/pg_query_go/v5/parser"
+	"google.golang.org/protobuf/proto"
+)
+
+var deparseTests = []struct {
+	input    string
+	expected string
+}{
+	{
+		"SELECT 1",
+		"SELECT 1",
+	},
+	{
+		"select a, b from x where y = 'foo' order by 2 desc",
+		"SELECT a, b FROM x WHERE y = 'foo' ORDER BY 2 DESC",
+	},
+	{
+		"SELECT * FROM \"User\" u JOIN posts p USING (id) WHERE u.\"select\" IS NOT NULL",
+		"SELECT * FROM \"User\" u JOIN posts p USING (id) WHERE u.\"select\" IS NOT NULL",
+	},
+	{
+		"create table if not exists t (id serial primary key, name text not null default 'x')",
+		"CREATE TABLE IF NOT EXISTS t (id serial PRIMARY KEY, name text NOT NULL DEFAULT 'x')",
+	},
+	{
+		"grant select, insert on table a, b to alice with grant option",
+		"GRANT select, insert ON a, b TO alice WITH GRANT OPTION",
+	},
+}
+
+func TestDeparse(t *testing.T) {
+	for _, test := range deparseTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		actual, err := pg_query.Deparse(tree)
+		if err != nil {
+			t.Errorf("Deparse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		if actual != test.expected {
+			t.Errorf("Deparse(%s)\nexpected %s\nactual %s\n\n", test.input, test.expected, actual)
+		}
+	}
+}
+
+// deparseCompareTests are deparsed both in Go and with the C deparser of libpg_query,
+// which have to produce identical output
+var deparseCompareTests = []string{
+	"SELECT DISTINCT ON (a) a, count(*) FILTER (WHERE b > 1) OVER (PARTITION BY c ORDER BY d ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) FROM t GROUP BY ROLLUP (a, b) HAVING count(*) > 1 LIMIT 10 OFFSET 5",
+	"WITH RECURSIVE r(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM r WHERE n < 10) SELECT * FROM r",
+	"SELECT * FROM a LEFT JOIN LATERAL (SELECT * FROM b WHERE b.id = a.id) s ON true",
+	"SELECT CASE WHEN a THEN 1 ELSE 2 END, a::int, CAST(b AS varchar(10)), c[1:2], ARRAY[1, 2], ROW(1, 2), coalesce(a, b), greatest(1, 2)",
+	"SELECT x FROM t WHERE a IN (1, 2) AND b NOT LIKE 'x%' AND c BETWEEN 1 AND 2 AND EXISTS (SELECT 1) AND d = ANY (SELECT e FROM f)",
+	"SELECT * FROM t FOR UPDATE SKIP LOCKED",
+	"SELECT json_object('a': 1), xmlelement(name foo, xmlattributes(1 AS bar)), interval '1 day', now(), current_user",
+	"INSERT INTO t (a, b) VALUES (1, 2), (3, DEFAULT) ON CONFLICT (a) DO UPDATE SET b = excluded.b WHERE t.a > 0 RETURNING *",
+	"INSERT INTO t SELECT * FROM s ON CONFLICT DO NOTHING",
+	"UPDATE t SET a = 1, (b, c) = (SELECT 1, 2) FROM s WHERE t.id = s.id RETURNING a",
+	"DELETE FROM t USING s WHERE t.id = s.id",
+	"MERGE INTO t USING s ON t.id = s.id WHEN MATCHED THEN UPDATE SET a = s.a WHEN NOT MATCHED THEN INSERT (id, a) VALUES (s.id, s.a)",
+	"CREATE TABLE t (id bigint GENERATED ALWAYS AS IDENTITY, a int REFERENCES b (id) ON DELETE CASCADE, CHECK (a > 0), UNIQUE (a)) PARTITION BY RANGE (id)",
+	"CREATE TABLE p PARTITION OF t FOR VALUES FROM (1) TO (10)",
+	"CREATE TEMPORARY TABLE t (LIKE s INCLUDING ALL) ON COMMIT DROP",
+	"CREATE UNLOGGED TABLE t AS SELECT 1 WITH NO DATA",
+	"CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS i ON t USING gin (a, (lower(b))) INCLUDE (c) WHERE d IS NULL",
+	"CREATE OR REPLACE VIEW v (a) AS SELECT 1 WITH CASCADED CHECK OPTION",
+	"CREATE MATERIALIZED VIEW m AS SELECT 1",
+	"REFRESH MATERIALIZED VIEW CONCURRENTLY m WITH NO DATA",
+	"CREATE OR REPLACE FUNCTION f(a int, OUT b text) RETURNS SETOF record LANGUAGE sql IMMUTABLE AS $$SELECT 1$$",
+	"CREATE PROCEDURE p() BEGIN ATOMIC SELECT 1; END",
+	"CREATE TRIGGER tr BEFORE INSERT OR UPDATE OF a ON t FOR EACH ROW WHEN (new.a > 0) EXECUTE FUNCTION f('x')",
+	"CREATE EVENT TRIGGER e ON ddl_command_start WHEN tag IN ('CREATE TABLE') EXECUTE FUNCTION f()",
+	"CREATE SEQUENCE IF NOT EXISTS s INCREMENT BY 2 START WITH 10 CACHE 5",
+	"ALTER SEQUENCE s RESTART WITH 1",
+	"CREATE SCHEMA IF NOT EXISTS s AUTHORIZATION alice",
+	"CREATE DOMAIN d AS int CHECK (VALUE > 0)",
+	"CREATE TYPE e AS ENUM ('a', 'b')",
+	"CREATE TYPE c AS (a int, b text)",
+	"CREATE TYPE r AS RANGE (subtype = int4)",
+	"CREATE EXTENSION IF NOT EXISTS hstore WITH SCHEMA public",
+	"CREATE ROLE alice WITH LOGIN PASSWORD 'x' CONNECTION LIMIT 5 VALID UNTIL '2030-01-01'",
+	"ALTER ROLE alice WITH NOSUPERUSER CREATEDB",
+	"ALTER GROUP g ADD USER alice, bob",
+	"DROP ROLE IF EXISTS alice, bob",
+	"GRANT ALL ON ALL TABLES IN SCHEMA public TO alice",
+	"REVOKE GRANT OPTION FOR SELECT (a, b) ON t FROM PUBLIC CASCADE",
+	"GRANT admin TO alice WITH ADMIN OPTION",
+	"ALTER DEFAULT PRIVILEGES IN SCHEMA s GRANT SELECT ON TABLES TO alice",
+	"ALTER TABLE IF EXISTS t ADD COLUMN a int, DROP COLUMN b CASCADE, ALTER COLUMN c SET DEFAULT 1, ALTER COLUMN d TYPE text USING d::text",
+	"ALTER TABLE t ADD CONSTRAINT fk FOREIGN KEY (a) REFERENCES b (id) NOT VALID",
+	"ALTER TABLE t RENAME COLUMN a TO b",
+	"ALTER TABLE t RENAME TO u",
+	"ALTER TABLE t SET SCHEMA s",
+	"ALTER TABLE t OWNER TO alice",
+	"ALTER TABLE t REPLICA IDENTITY FULL",
+	"ALTER TABLE t ATTACH PARTITION p FOR VALUES IN (1, 2)",
+	"ALTER FUNCTION f(int) IMMUTABLE",
+	"ALTER TYPE e ADD VALUE IF NOT EXISTS 'c' BEFORE 'a'",
+	"ALTER DOMAIN d SET NOT NULL",
+	"ALTER DATABASE db SET search_path TO public",
+	"ALTER SYSTEM SET work_mem = '4MB'",
+	"ALTER EXTENSION hstore UPDATE TO '2.0'",
+	"ALTER OPERATOR + (int, int) SET (restrict = NONE)",
+	"ALTER SUBSCRIPTION s SET PUBLICATION p WITH (refresh = false)",
+	"ALTER PUBLICATION p ADD TABLE t",
+	"ALTER POLICY p ON t TO alice USING (true)",
+	"ALTER TEXT SEARCH CONFIGURATION c ADD MAPPING FOR word WITH simple",
+	"ALTER STATISTICS s SET STATISTICS 100",
+	"ALTER EVENT TRIGGER e DISABLE",
+	"DROP TABLE IF EXISTS a, b CASCADE",
+	"DROP INDEX CONCURRENTLY i",
+	"DROP FUNCTION f(int), g()",
+	"DROP OWNED BY alice CASCADE",
+	"DROP SUBSCRIPTION IF EXISTS s",
+	"DROP DATABASE IF EXISTS db WITH (FORCE)",
+	"REASSIGN OWNED BY alice TO bob",
+	"TRUNCATE t, u RESTART IDENTITY CASCADE",
+	"COMMENT ON TABLE t IS 'x'",
+	"COMMENT ON COLUMN t.a IS NULL",
+	"COMMENT ON CONSTRAINT c ON t IS 'x'",
+	"COMMENT ON FUNCTION f(int) IS 'x'",
+	"SECURITY LABEL FOR selinux ON TABLE t IS 'x'",
+	"CREATE POLICY p ON t AS RESTRICTIVE FOR SELECT TO alice USING (a = current_user)",
+	"CREATE PUBLICATION p FOR TABLE t (a, b) WHERE (a > 1), TABLES IN SCHEMA s",
+	"CREATE SUBSCRIPTION s CONNECTION 'host=x' PUBLICATION p, q WITH (enabled = false)",
+	"CREATE STATISTICS IF NOT EXISTS s (ndistinct) ON a, b FROM t",
+	"CREATE TABLESPACE ts OWNER alice LOCATION '/tmp'",
+	"CREATE ACCESS METHOD am TYPE INDEX HANDLER h",
+	"CREATE TRANSFORM FOR int LANGUAGE plpgsql (FROM SQL WITH FUNCTION f(internal), TO SQL WITH FUNCTION g(internal))",
+	"CREATE CAST (int AS text) WITH FUNCTION f(int) AS IMPLICIT",
+	"CREATE OPERATOR CLASS c DEFAULT FOR TYPE int USING btree AS OPERATOR 1 <, FUNCTION 1 f(int, int)",
+	"CREATE FOREIGN DATA WRAPPER w HANDLER h",
+	"CREATE SERVER s FOREIGN DATA WRAPPER w OPTIONS (host 'x')",
+	"CREATE USER MAPPING FOR alice SERVER s OPTIONS (user 'x')",
+	"CREATE FOREIGN TABLE f (a int) SERVER s",
+	"IMPORT FOREIGN SCHEMA r LIMIT TO (a) FROM SERVER s INTO l",
+	"CREATE RULE r AS ON INSERT TO t DO INSTEAD NOTHING",
+	"CREATE RULE r AS ON UPDATE TO t DO ALSO (NOTIFY t; DELETE FROM u)",
+	"CREATE LANGUAGE plsample HANDLER h",
+	"CREATE CONVERSION c FOR 'LATIN1' TO 'UTF8' FROM f",
+	"CREATE DATABASE db WITH OWNER alice TEMPLATE template0",
+	"BEGIN ISOLATION LEVEL SERIALIZABLE READ ONLY",
+	"COMMIT AND CHAIN",
+	"SAVEPOINT s",
+	"ROLLBACK TO SAVEPOINT s",
+	"PREPARE TRANSACTION 'x'",
+	"SET LOCAL search_path TO a, b",
+	"SET TIME ZONE INTERVAL '+00:00' HOUR TO MINUTE",
+	"RESET ALL",
+	"SHOW TIME ZONE",
+	"SHOW work_mem",
+	"VACUUM (VERBOSE, ANALYZE) t (a)",
+	"ANALYZE t",
+	"CLUSTER t USING i",
+	"REINDEX (VERBOSE) TABLE CONCURRENTLY t",
+	"LOCK TABLE t IN ACCESS EXCLUSIVE MODE NOWAIT",
+	"SET CONSTRAINTS ALL DEFERRED",
+	"EXPLAIN (ANALYZE, FORMAT JSON) SELECT 1",
+	"COPY t (a, b) FROM STDIN WITH (FORMAT csv, HEADER)",
+	"COPY (SELECT 1) TO '/tmp/x'",
+	"DO $$BEGIN END$$",
+	"DISCARD ALL",
+	"LOAD 'x'",
+	"CHECKPOINT",
+	"LISTEN c",
+	"NOTIFY c, 'payload'",
+	"UNLISTEN *",
+	"PREPARE q (int) AS SELECT $1",
+	"EXECUTE q (1)",
+	"DEALLOCATE ALL",
+	"DECLARE c SCROLL CURSOR WITH HOLD FOR SELECT 1",
+	"FETCH BACKWARD 5 c",
+	"MOVE LAST c",
+	"CLOSE ALL",
+	"CALL p(1, 2)",
+	"CREATE AGGREGATE a (int) (sfunc = f, stype = int)",
+	"CREATE OPERATOR === (leftarg = int, rightarg = int, procedure = f)",
+	"ALTER OPERATOR FAMILY f USING btree ADD OPERATOR 1 < (int, int)",
+	"ALTER TABLE ALL IN TABLESPACE a SET TABLESPACE b",
+	"ALTER TABLESPACE ts SET (seq_page_cost = 1)",
+	"ALTER FOREIGN DATA WRAPPER w NO VALIDATOR",
+	"ALTER SERVER s VERSION '2'",
+	"ALTER USER MAPPING FOR alice SERVER s OPTIONS (SET user 'y')",
+	"DROP USER MAPPING IF EXISTS FOR alice SERVER s",
+	"DROP TABLESPACE IF EXISTS ts",
+	"ALTER COLLATION c REFRESH VERSION",
+	"ALTER TEXT SEARCH DICTIONARY d (stopwords = 'x')",
+	"ALTER FUNCTION f() DEPENDS ON EXTENSION e",
+	"ALTER TYPE t SET (storage = plain)",
+}
+
+func TestDeparseMatchesC(t *testing.T) {
+	inputs := deparseCompareTests
+	for _, test := range parseTests {
+		inputs = append(inputs, test.input)
+	}
+
+	var fingerprintTests []fingerprintTest
+	file, err := ioutil.ReadFile("./testdata/fingerprint.json")
+	if err != nil {
+		t.Errorf("Could not load test file: %v\n", err)
+	}
+	err = json.Unmarshal(file, &fingerprintTests)
+	if err != nil {
+		t.Errorf("Could not parse test file: %v\n", err)
+	}
+	for _, test := range fingerprintTests {
+		inputs = append(inputs, test.Input)
+	}
+
+	for _, input := range inputs {
+		fmt.Printf(".")
+
+		tree, err := pg_query.Parse(input)
+		if err != nil {
+			// Some fingerprint tests don't parse
+			continue
+		}
+
+		protobufTree, err := proto.Marshal(tree)
+		if err != nil {
+			t.Errorf("proto.Marshal(%s)\nerror %s\n\n", input, err)
+			continue
+		}
+		expected, expectedErr := parser.DeparseFromProtobuf(protobufTree)
+
+		actual, err := pg_query.Deparse(tree)
+		if (err != nil) != (expectedErr != nil) {
+			t.Errorf("Deparse(%s)\nexpected error %v\nactual error %v\n\n", input, expectedErr, err)
+			continue
+		}
+
+		if actual != expected {
+			t.Errorf("Deparse(%s)\nexpected %s\nactual %s\n\n", input, expected, actual)
+		}
+	}
+
+	fmt.Printf("\n")
+}
+
+func TestDeparseError(t *testing.T) {
+	tree := &pg_query.ParseResult{
+		Stmts: []*pg_query.RawStmt{
+			{Stmt: pg_query.MakeAConstIntNode(1, 0)},
+		},
+	}
+
+	_, err := pg_query.Deparse(tree)
+	if err == nil || err.Error() != "deparse: unsupported top-level node type: A_Const" {
+		t.Errorf("Deparse(A_Const)\nexpected error deparse: unsupported top-level node type: A_Const\nactual %v\n\n", err)
+	}
+}
+
+var deparseMalformedTests = []struct {
+	input       string
+	malform     func(stmt *pg_query.Node)
+	expectedErr string
+}{
+	{
+		"UPDATE t SET a = (SELECT b FROM u) WHERE id = 1",
+		func(stmt *pg_query.Node) {
+			stmt.GetUpdateStmt().TargetList[0].GetResTarget().Val.GetSubLink().Subselect = nil
+		},
+		"deparse: missing SelectStmt",
+	},
+	{
+		"BEGIN ISOLATION LEVEL SERIALIZABLE",
+		func(stmt *pg_query.Node) {
+			stmt.GetTransactionStmt().Options[0] = pg_query.MakeAConstIntNode(1, 0)
+		},
+		"deparse: unexpected node in option list: A_Const",
+	},
+	{
+		"CREATE TABLE t (a int)",
+		func(stmt *pg_query.Node) {
+			stmt.GetCreateStmt().Relation = nil
+		},
+		"deparse: missing relation",
+	},
+	{
+		"SELECT a FROM t ORDER BY a",
+		func(stmt *pg_query.Node) {
+			stmt.GetSelectStmt().SortClause[0] = stmt.GetSelectStmt().TargetList[0]
+		},
+		"deparse: missing SortBy",
+	},
+	{
+		"WITH c AS (SELECT 1) SELECT * FROM c",
+		func(stmt *pg_query.Node) {
+			stmt.GetSelectStmt().WithClause.Ctes[0] = pg_query.MakeStrNode("c")
+		},
+		"deparse: missing CommonTableExpr",
+	},
+	{
+		"SELECT * FROM t",
+		func(stmt *pg_query.Node) {
+			stmt.GetSelectStmt().FromClause[0] = nil
+		},
+		"deparse: unpermitted node type in table_ref: <nil>",
+	},
+}
+
+func TestDeparseMalformed(t *testing.T) {
+	for _, test := range deparseMalformedTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+		test.malform(tree.Stmts[0].Stmt)
+
+		_, err = pg_query.Deparse(tree)
+		if err == nil {
+			t.Errorf("Deparse(%s)\nexpected error but none returned\n\n", test.input)
+		} else if err.Error() != test.expectedErr {
+			t.Errorf("Deparse(%s)\nexpected error %s\nactual error %s\n\n", test.input, test.expectedErr, err)
+		}
+	}
+}
+
+var deparseNodeTests = []struct {
+	input    string
+	node     func(stmt *pg_query.Node) *pg_query.Node
+	expected string
+}{
+	{
+		"SELECT * FROM x WHERE y = 1 AND z IS NOT NULL",
+		func(stmt *pg_query.Node) *pg_query.Node { return stmt.GetSelectStmt().WhereClause },
+		"y = 1 AND z IS NOT NULL",
+	},
+	{
+		"SELECT a + 1 AS b FROM x",
+		func(stmt *pg_query.Node) *pg_query.Node { return stmt.GetSelectStmt().TargetList[0] },
+		"a + 1 AS b",
+	},
+	{
+		"SELECT * FROM public.x AS y",
+		func(stmt *pg_query.Node) *pg_query.Node { return stmt.GetSelectStmt().FromClause[0] },
+		"public.x y",
+	},
+	{
+		"SELECT * FROM x JOIN y ON x.id = y.id",
+		func(stmt *pg_query.Node) *pg_query.Node { return stmt.GetSelectStmt().FromClause[0] },
+		"x JOIN y ON x.id = y.id",
+	},
+	{
+		"SELECT a::varchar(10)[]",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			typeName := stmt.GetSelectStmt().TargetList[0].GetResTarget().Val.GetTypeCast().TypeName
+			return &pg_query.Node{Node: &pg_query.Node_TypeName{TypeName: typeName}}
+		},
+		"varchar(10)[]",
+	},
+	{
+		"SELECT * FROM x ORDER BY a DESC NULLS LAST",
+		func(stmt *pg_query.Node) *pg_query.Node { return stmt.GetSelectStmt().SortClause[0] },
+		"a DESC NULLS LAST",
+	},
+	{
+		"WITH a AS (SELECT 1) SELECT * FROM a",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return &pg_query.Node{Node: &pg_query.Node_WithClause{WithClause: stmt.GetSelectStmt().WithClause}}
+		},
+		"WITH a AS (SELECT 1)",
+	},
+	{
+		"SELECT * FROM x GROUP BY a, b",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return pg_query.MakeListNode(stmt.GetSelectStmt().GroupClause)
+		},
+		"a, b",
+	},
+	{
+		"SELECT * FROM x WHERE a IN (SELECT b FROM y)",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().WhereClause.GetSubLink().Subselect
+		},
+		"SELECT b FROM y",
+	},
+}
+
+func TestDeparseNode(t *testing.T) {
+	for _, test := range deparseNodeTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		actual, err := pg_query.DeparseNode(test.node(tree.Stmts[0].Stmt))
+		if err != nil {
+			t.Errorf("DeparseNode(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		if actual != test.expected {
+			t.Errorf("DeparseNode(%s)\nexpected %s\nactual %s\n\n", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestDeparseNodeError(t *testing.T) {
+	_, err := pg_query.DeparseNode(nil)
+	if err == nil || err.Error() != "deparse: empty node" {
+		t.Errorf("DeparseNode(nil)\nexpected error deparse: empty node\nactual %v\n\n", err)
+	}
+}
@@ -0,0 +1,135 @@
+//go:build cgo
+// +build cgo
+
+package pg_query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Editor rewrites a query by replacing the source text of individual nodes, leaving the
+// formatting, comments and letter case of everything else untouched
+type Editor struct {
+	source string
+	tree   *ParseResult
+	tokens *sourceTokens
+	edits  []sourceEdit
+}
+
+// sourceEdit replaces the bytes start to end of the source with text
+type sourceEdit struct {
+	start, end int
+	text       string
+}
+
+// NewEditor parses the given SQL for editing
+func NewEditor(source string) (*Editor, error) {
+	tree, err := Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := scanSourceTokens(source)
+	if err != nil {
+		return nil, err
+	}
+	return &Editor{source: source, tree: tree, tokens: tokens}, nil
+}
+
+// Tree returns the parse tree of the source, whose nodes can be passed to Replace
+//
+// The tree describes the original source, it doesn't change when replacements are made.
+func (e *Editor) Tree() *ParseResult {
+	return e.tree
+}
+
+// Replace replaces the source text of a node of the tree with the given SQL text
+//
+// Top-level statements span from their first to their last token, other nodes span the
+// range returned by Span without the alias of table references and target list entries,
+// which is kept. An error is returned if the node has no location in the source, if its
+// span doesn't cover all of its tokens, or if it overlaps a previously replaced node.
+func (e *Editor) Replace(node *Node, text string) error {
+	start, end := e.span(node)
+	if start < 0 {
+		return fmt.Errorf("editor: %s has no location in the source", nodeName(node))
+	}
+	if !e.tokens.covers(node, start, end) {
+		return fmt.Errorf("editor: the source text of %s can't be determined from %q", nodeName(node), e.source[start:end])
+	}
+	return e.replaceRange(start, end, text)
+}
+
+// ReplaceNode replaces the source text of a node of the tree with the deparsed replacement node
+func (e *Editor) ReplaceNode(node *Node, replacement *Node) error {
+	text, err := DeparseNode(replacement)
+	if err != nil {
+		return err
+	}
+	return e.Replace(node, text)
+}
+
+// ReplaceStmt replaces the statement with the given index with the given SQL text
+func (e *Editor) ReplaceStmt(index int, text string) error {
+	if index < 0 || index >= len(e.tree.GetStmts()) {
+		return fmt.Errorf("editor: statement %d out of range", index)
+	}
+	start, end := e.tokens.stmtSpan(e.tree.Stmts[index])
+	if start < 0 {
+		return fmt.Errorf("editor: statement %d has no location in the source", index)
+	}
+	return e.replaceRange(start, end, text)
+}
+
+// String returns the source with all replacements applied
+func (e *Editor) String() string {
+	edits := append([]sourceEdit(nil), e.edits...)
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].start < edits[j].start
+	})
+
+	var b strings.Builder
+	offset := 0
+	for _, edit := range edits {
+		b.WriteString(e.source[offset:edit.start])
+		b.WriteString(edit.text)
+		offset = edit.end
+	}
+	b.WriteString(e.source[offset:])
+	return b.String()
+}
+
+func (e *Editor) span(node *Node) (start, end int) {
+	for _, stmt := range e.tree.GetStmts() {
+		if stmt.Stmt == node {
+			return e.tokens.stmtSpan(stmt)
+		}
+	}
+	return e.tokens.nodeSpan(node, false)
+}
+
+func (e *Editor) replaceRange(start, end int, text string) error {
+	for _, edit := range e.edits {
+		if start < edit.end && edit.start < end {
+			return fmt.Errorf("editor: replacement of %q overlaps replacement of %q", e.source[start:end], e.source[edit.start:edit.end])
+		}
+	}
+	e.edits = append(e.edits, sourceEdit{start: start, end: end, text: text})
+	return nil
+}
+
+// covers returns whether a byte range contains all the located tokens of a node and doesn't
+// end in the middle of an expression, e.g. after a sign, a type cast or an opening parenthesis
+func (t *sourceTokens) covers(node *Node, start, end int) bool {
+	l := locator{first: -1, last: -1}
+	l.message(node.ProtoReflect())
+	if l.first < 0 {
+		return true
+	}
+	last, j := t.at(l.last), t.at(end-1)
+	if l.first < start || last >= len(t.tokens) || int(t.tokens[last].End) > end || j >= len(t.tokens) {
+		return false
+	}
+	return !t.is(j, Token_ASCII_45, Token_ASCII_43, Token_TYPECAST, Token_ASCII_46, Token_ASCII_40, Token_ASCII_91)
+}
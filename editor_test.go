@@ -0,0 +1,153 @@
+//go:build cgo
+// +build cgo
+
+package pg_query_test
+
+import (
+	"strings"
+	"testing"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+)
+
+var editorTests = []struct {
+	input    string
+	edit     func(e *pg_query.Editor) error
+	expected string
+	// removed are the deparsed replaced nodes, which must be gone from the edited query
+	removed []string
+}{
+	{
+		"select *\n  FROM   public.Users u -- users\n  WHERE u.id = 1",
+		func(e *pg_query.Editor) error {
+			return e.Replace(e.Tree().Stmts[0].Stmt.GetSelectStmt().FromClause[0], "accounts")
+		},
+		"select *\n  FROM   accounts u -- users\n  WHERE u.id = 1",
+		[]string{"public.users"},
+	},
+	{
+		"SELECT coalesce(a, 0), f( x ) FROM t",
+		func(e *pg_query.Editor) error {
+			targets := e.Tree().Stmts[0].Stmt.GetSelectStmt().TargetList
+			if err := e.Replace(targets[0].GetResTarget().Val, "a"); err != nil {
+				return err
+			}
+			return e.Replace(targets[1].GetResTarget().Val, "g(x)")
+		},
+		"SELECT a, g(x) FROM t",
+		[]string{"coalesce(a, 0)", "f(x)"},
+	},
+	{
+		"select 1 from t where (a + 1) * 2 > 3 and b in (1, 2)",
+		func(e *pg_query.Editor) error {
+			where := e.Tree().Stmts[0].Stmt.GetSelectStmt().WhereClause.GetBoolExpr()
+			if err := e.Replace(where.Args[0].GetAExpr().Lexpr.GetAExpr().Lexpr, "a"); err != nil {
+				return err
+			}
+			return e.ReplaceNode(where.Args[1], pg_query.MakeBoolExprNode(pg_query.BoolExprType_NOT_EXPR, []*pg_query.Node{pg_query.MakeColumnRefNode([]*pg_query.Node{pg_query.MakeStrNode("c")}, 0)}, 0))
+		},
+		"select 1 from t where (a) * 2 > 3 and NOT c",
+		[]string{"a + 1", "b IN (1, 2)"},
+	},
+	{
+		"SELECT 1;\n/* keep */ select  2 ;",
+		func(e *pg_query.Editor) error {
+			return e.ReplaceStmt(1, "SELECT 3")
+		},
+		"SELECT 1;\n/* keep */ SELECT 3 ;",
+		[]string{"SELECT 2"},
+	},
+	{
+		"select x from t",
+		func(e *pg_query.Editor) error {
+			return e.Replace(e.Tree().Stmts[0].Stmt, "SELECT y FROM t")
+		},
+		"SELECT y FROM t",
+		[]string{"SELECT x"},
+	},
+	{
+		"SELECT * FROM t WHERE b = -100 AND c = x::varchar(10)[]",
+		func(e *pg_query.Editor) error {
+			args := e.Tree().Stmts[0].Stmt.GetSelectStmt().WhereClause.GetBoolExpr().Args
+			if err := e.Replace(args[0].GetAExpr().Rexpr, "$1"); err != nil {
+				return err
+			}
+			return e.Replace(args[1].GetAExpr().Rexpr, "y")
+		},
+		"SELECT * FROM t WHERE b = $1 AND c = y",
+		[]string{"100", "varchar"},
+	},
+}
+
+func TestEditor(t *testing.T) {
+	for _, test := range editorTests {
+		editor, err := pg_query.NewEditor(test.input)
+		if err != nil {
+			t.Errorf("NewEditor(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		if err = test.edit(editor); err != nil {
+			t.Errorf("Editor(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		actual := editor.String()
+		if actual != test.expected {
+			t.Errorf("Editor(%s)\nexpected %s\nactual %s\n\n", test.input, test.expected, actual)
+		}
+
+		tree, err := pg_query.Parse(actual)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", actual, err)
+			continue
+		}
+		deparsed, err := pg_query.Deparse(tree)
+		if err != nil {
+			t.Errorf("Deparse(%s)\nerror %s\n\n", actual, err)
+			continue
+		}
+		for _, removed := range test.removed {
+			if strings.Contains(deparsed, removed) {
+				t.Errorf("Editor(%s)\nexpected %s to be replaced\nactual %s\n\n", test.input, removed, deparsed)
+			}
+		}
+	}
+}
+
+func TestEditorOverlap(t *testing.T) {
+	input := "SELECT a + b FROM t"
+	editor, err := pg_query.NewEditor(input)
+	if err != nil {
+		t.Fatalf("NewEditor(%s)\nerror %s\n\n", input, err)
+	}
+
+	sum := editor.Tree().Stmts[0].Stmt.GetSelectStmt().TargetList[0].GetResTarget().Val
+	if err = editor.Replace(sum.GetAExpr().Rexpr, "c"); err != nil {
+		t.Fatalf("Replace(b)\nerror %s\n\n", err)
+	}
+
+	expected := "editor: replacement of \"a + b\" overlaps replacement of \"b\""
+	err = editor.Replace(sum, "1")
+	if err == nil || err.Error() != expected {
+		t.Errorf("Replace(a + b)\nexpected error %s\nactual %v\n\n", expected, err)
+	}
+}
+
+func TestEditorUncovered(t *testing.T) {
+	input := "SELECT a::int FROM t"
+	editor, err := pg_query.NewEditor(input)
+	if err != nil {
+		t.Fatalf("NewEditor(%s)\nerror %s\n\n", input, err)
+	}
+
+	// A location pointing at the cast rather than the column, as in a malformed tree
+	column := editor.Tree().Stmts[0].Stmt.GetSelectStmt().TargetList[0].GetResTarget().Val.GetTypeCast().Arg
+	column.GetColumnRef().Location = int32(strings.Index(input, "::"))
+
+	expected := "editor: the source text of ColumnRef can't be determined from \"::\""
+	err = editor.Replace(column, "b")
+	if err == nil || err.Error() != expected {
+		t.Errorf("Replace(a)\nexpected error %s\nactual %v\n\n", expected, err)
+	}
+}
@@ -0,0 +1,368 @@
+package pg_query
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fingerprintVersion is the version of the libpg_query fingerprints reproduced by
+// FingerprintTree, also used as the seed of their hash (PG_QUERY_FINGERPRINT_VERSION)
+const fingerprintVersion = 3
+
+// fingerprintMaxDepth is the depth from which nodes are left out of fingerprints
+const fingerprintMaxDepth = 100
+
+// unfingerprintedNodes are the nodes left out of fingerprints, so that queries differing
+// only by them (e.g. by their constants) get the same fingerprint
+var unfingerprintedNodes = map[protoreflect.Name]bool{
+	"A_Const":      true,
+	"Alias":        true,
+	"ParamRef":     true,
+	"SetToDefault": true,
+	// Not supported by pg_query_fingerprint.c
+	"DistinctExpr": true,
+	"NullIfExpr":   true,
+	"IntList":      true,
+	"OidList":      true,
+}
+
+// unfingerprintedFields are the fields left out of fingerprints, besides location and xpr
+var unfingerprintedFields = map[string]bool{
+	"RawStmt.stmt_len":               true,
+	"RawStmt.stmt_location":          true,
+	"DeclareCursorStmt.portalname":   true,
+	"ClosePortalStmt.portalname":     true,
+	"FetchStmt.portalname":           true,
+	"CreateFunctionStmt.options":     true,
+	"FunctionParameter.name":         true,
+	"DoStmt.args":                    true,
+	"NotifyStmt.conditionname":       true,
+	"ListenStmt.conditionname":       true,
+	"UnlistenStmt.conditionname":     true,
+	"TransactionStmt.gid":            true,
+	"TransactionStmt.options":        true,
+	"TransactionStmt.savepoint_name": true,
+	"PrepareStmt.name":               true,
+	"ExecuteStmt.name":               true,
+	"DeallocateStmt.name":            true,
+}
+
+// sortedFingerprintLists are the list fields whose items are fingerprinted in the order of
+// their hashes, without duplicates, so that their order doesn't matter
+var sortedFingerprintLists = map[string]bool{
+	"fromClause":  true,
+	"targetList":  true,
+	"cols":        true,
+	"rexpr":       true,
+	"valuesLists": true,
+	"args":        true,
+}
+
+// fingerprintFields caches the fields of the messages, ordered by name like in
+// pg_query_fingerprint_defs.c
+var fingerprintFields sync.Map
+
+func sortedFields(desc protoreflect.MessageDescriptor) []protoreflect.FieldDescriptor {
+	if fields, ok := fingerprintFields.Load(desc.FullName()); ok {
+		return fields.([]protoreflect.FieldDescriptor)
+	}
+	fields := make([]protoreflect.FieldDescriptor, desc.Fields().Len())
+	for i := range fields {
+		fields[i] = desc.Fields().Get(i)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].JSONName() < fields[j].JSONName()
+	})
+	fingerprintFields.Store(desc.FullName(), fields)
+	return fields
+}
+
+// fingerprinter computes the input of the hash of a fingerprint, like pg_query_fingerprint.c
+type fingerprinter struct {
+	// hash orders the items of sorted lists. Without it they are ordered by their fingerprint
+	// input instead, which is enough to compare fingerprints.
+	hash func([]byte) uint64
+	buf  []byte
+	// parts holds the strings written to buf, if recording them
+	parts  []string
+	record bool
+}
+
+func newFingerprinter(hash func([]byte) uint64, record bool) *fingerprinter {
+	f := &fingerprinter{hash: hash, record: record}
+	if record {
+		f.parts = []string{}
+	}
+	return f
+}
+
+func (f *fingerprinter) write(s string) {
+	f.buf = append(f.buf, s...)
+	if f.record {
+		f.parts = append(f.parts, s)
+	}
+}
+
+// sameFingerprint returns whether two expressions have the same fingerprint, like
+// expressions differing only by their constants
+func sameFingerprint(a, b *Node) bool {
+	fa, fb := newFingerprinter(nil, false), newFingerprinter(nil, false)
+	fa.node(a, nil, "", 0)
+	fb.node(b, nil, "", 0)
+	return bytes.Equal(fa.buf, fb.buf)
+}
+
+func (f *fingerprinter) tree(tree *ParseResult) {
+	for _, stmt := range tree.GetStmts() {
+		f.write("RawStmt")
+		f.fields(stmt.ProtoReflect(), nil, "", 1)
+	}
+}
+
+// node fingerprints a node, like _fingerprintNode
+func (f *fingerprinter) node(node *Node, parent protoreflect.Message, field string, depth int) {
+	if depth >= fingerprintMaxDepth || node == nil {
+		return
+	}
+	switch n := node.Node.(type) {
+	case nil:
+	case *Node_List:
+		f.list(n.List.Items, parent, field, depth)
+	case *Node_Integer:
+		if n.Integer.Ival != 0 {
+			f.write("Integer")
+			f.write("ival")
+			f.write(strconv.Itoa(int(n.Integer.Ival)))
+		}
+	case *Node_Float:
+		if n.Float.Fval != "" {
+			f.write("Float")
+			f.write("str")
+			f.write(n.Float.Fval)
+		}
+	case *Node_Boolean:
+		f.write("Boolean")
+		f.write("boolval")
+		f.write(strconv.FormatBool(n.Boolean.Boolval))
+	case *Node_String_:
+		f.write("String")
+		f.write("str")
+		f.write(n.String_.Sval)
+	case *Node_BitString:
+		if n.BitString.Bsval != "" {
+			f.write("BitString")
+			f.write("str")
+			f.write(n.BitString.Bsval)
+		}
+	default:
+		// A cast constant is a constant
+		switch node.GetTypeCast().GetArg().GetNode().(type) {
+		case *Node_AConst, *Node_ParamRef:
+			return
+		}
+		msg := nodeMessage(node).ProtoReflect()
+		name := msg.Descriptor().Name()
+		if unfingerprintedNodes[name] {
+			return
+		}
+		f.write(string(name))
+		f.fields(msg, parent, field, depth)
+	}
+}
+
+// list fingerprints the items of a list, like _fingerprintList
+func (f *fingerprinter) list(items []*Node, parent protoreflect.Message, field string, depth int) {
+	if !sortedFingerprintLists[field] {
+		for _, item := range items {
+			f.node(item, parent, field, depth+1)
+		}
+		return
+	}
+
+	type listItem struct {
+		hash  uint64
+		buf   []byte
+		parts []string
+	}
+	sorted := make([]listItem, len(items))
+	for i, item := range items {
+		sub := newFingerprinter(f.hash, f.record)
+		sub.node(item, parent, field, depth+1)
+		sorted[i] = listItem{buf: sub.buf, parts: sub.parts}
+		if f.hash != nil {
+			sorted[i].hash = f.hash(sub.buf)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].hash != sorted[j].hash {
+			return sorted[i].hash < sorted[j].hash
+		}
+		return f.hash == nil && bytes.Compare(sorted[i].buf, sorted[j].buf) < 0
+	})
+	for i, item := range sorted {
+		if i > 0 && item.hash == sorted[i-1].hash && (f.hash != nil || bytes.Equal(item.buf, sorted[i-1].buf)) {
+			continue
+		}
+		f.buf = append(f.buf, item.buf...)
+		f.parts = append(f.parts, item.parts...)
+	}
+}
+
+// field fingerprints the name and the value of a field, leaving the name out if the value
+// is empty, unless keepEmpty is set
+func (f *fingerprinter) field(name string, keepEmpty bool, value func()) {
+	n, p := len(f.buf), len(f.parts)
+	f.write(name)
+	m := len(f.buf)
+	value()
+	if len(f.buf) == m && !keepEmpty {
+		f.buf, f.parts = f.buf[:n], f.parts[:p]
+	}
+}
+
+// fields fingerprints the fields of a node, like the _fingerprint<Node> functions, where
+// parent is the node holding it in field
+func (f *fingerprinter) fields(msg protoreflect.Message, parent protoreflect.Message, field string, depth int) {
+	desc := msg.Descriptor()
+	if unfingerprintedNodes[desc.Name()] {
+		return
+	}
+	for _, fd := range sortedFields(desc) {
+		name := fd.JSONName()
+		if name == "location" || name == "xpr" || unfingerprintedFields[string(desc.Name())+"."+name] {
+			continue
+		}
+		value := msg.Get(fd)
+
+		switch string(desc.Name()) + "." + name {
+		case "A_Expr.kind":
+			kind := A_Expr_Kind(value.Enum())
+			if kind == A_Expr_Kind_AEXPR_OP_ANY || kind == A_Expr_Kind_AEXPR_IN {
+				kind = A_Expr_Kind_AEXPR_OP
+			}
+			f.write(name)
+			f.write(kind.String())
+			continue
+		case "RangeVar.relname":
+			// Leave out numbers from table names, e.g. of partitions, unless it's a temporary table
+			if value.String() != "" && msg.Get(desc.Fields().ByName("relpersistence")).String() != "t" {
+				f.write(name)
+				f.write(stripNumbers(value.String()))
+			}
+			continue
+		case "ResTarget.name":
+			// Leave out the names of output columns
+			if parent != nil && field == "targetList" {
+				if _, ok := parent.Interface().(*SelectStmt); ok {
+					continue
+				}
+			}
+		case "CreateForeignTableStmt.base":
+			f.write(name)
+			f.fields(value.Message(), msg, name, depth)
+			continue
+		case "AlterObjectDependsStmt.extname":
+			if sval := value.Message().Interface().(*String).GetSval(); sval != "" {
+				f.write(name)
+				f.write(sval)
+			}
+			continue
+		}
+
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := value.List()
+			if list.Len() == 0 {
+				continue
+			}
+			items := make([]*Node, list.Len())
+			for i := range items {
+				items[i] = list.Get(i).Message().Interface().(*Node)
+			}
+			// A list holding NIL, e.g. the distinctClause of SELECT DISTINCT, isn't empty
+			f.field(name, len(items) == 1 && items[0].GetNode() == nil, func() {
+				if depth+1 < fingerprintMaxDepth {
+					f.list(items, msg, name, depth+1)
+				}
+			})
+		case fd.IsList():
+			// Bitmapsets
+			f.write(name)
+			for i := 0; i < value.List().Len(); i++ {
+				f.write(scalarString(fd, value.List().Get(i)))
+			}
+		case fd.Message() != nil:
+			if !msg.Has(fd) {
+				continue
+			}
+			if node, ok := value.Message().Interface().(*Node); ok {
+				f.field(name, false, func() {
+					f.node(node, msg, name, depth+1)
+				})
+			} else {
+				f.field(name, false, func() {
+					f.fields(value.Message(), msg, name, depth+1)
+				})
+			}
+		case fd.Kind() == protoreflect.EnumKind:
+			f.write(name)
+			if enum := fd.Enum().Values().ByNumber(value.Enum()); enum != nil {
+				f.write(string(enum.Name()))
+			}
+		default:
+			if s := scalarString(fd, value); s != "" {
+				f.write(name)
+				f.write(s)
+			}
+		}
+	}
+}
+
+// scalarString returns the string fingerprinted for a scalar field value, or "" if it's
+// left out, like for false and 0
+func scalarString(fd protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if value.Bool() {
+			return "true"
+		}
+	case protoreflect.Int32Kind, protoreflect.Int64Kind:
+		if value.Int() != 0 {
+			return strconv.FormatInt(value.Int(), 10)
+		}
+	case protoreflect.Uint32Kind:
+		// Printed with %d, as a signed integer
+		if value.Uint() != 0 {
+			return strconv.Itoa(int(int32(value.Uint())))
+		}
+	case protoreflect.Uint64Kind:
+		if value.Uint() != 0 || fd.IsList() {
+			return strconv.FormatUint(value.Uint(), 10)
+		}
+	case protoreflect.DoubleKind:
+		if value.Float() != 0 {
+			return strconv.FormatFloat(value.Float(), 'f', 6, 64)
+		}
+	case protoreflect.StringKind:
+		return value.String()
+	}
+	return ""
+}
+
+// stripNumbers removes the runs of two digits or more from a table name
+func stripNumbers(name string) string {
+	digit := func(i int) bool {
+		return i >= 0 && i < len(name) && name[i] >= '0' && name[i] <= '9'
+	}
+	stripped := make([]byte, 0, len(name))
+	for i := 0; i < len(name); i++ {
+		if !digit(i) || !digit(i+1) && !digit(i-1) {
+			stripped = append(stripped, name[i])
+		}
+	}
+	return string(stripped)
+}
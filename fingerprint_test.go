@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"reflect"
 	"strconv"
 	"testing"
 
@@ -59,6 +60,88 @@ func TestFingerprint(t *testing.T) {
 	fmt.Printf("\n")
 }
 
+func TestFingerprintTree(t *testing.T) {
+	var fingerprintTests []fingerprintTest
+
+	file, err := ioutil.ReadFile("./testdata/fingerprint.json")
+	if err != nil {
+		t.Errorf("Could not load test file: %v\n", err)
+	}
+
+	err = json.Unmarshal(file, &fingerprintTests)
+	if err != nil {
+		t.Errorf("Could not parse test file: %v\n", err)
+	}
+
+	for _, test := range fingerprintTests {
+		tree, err := pg_query.Parse(test.Input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.Input, err)
+			continue
+		}
+
+		fingerprint := fmt.Sprintf("%016x", pg_query.FingerprintTree(tree))
+		if fingerprint != test.ExpectedHash {
+			t.Errorf("FingerprintTree(%s)\nexpected %s\nactual %s\n\n", test.Input, test.ExpectedHash, fingerprint)
+		}
+	}
+}
+
+func TestFingerprintDebug(t *testing.T) {
+	var fingerprintTests []fingerprintTest
+
+	file, err := ioutil.ReadFile("./testdata/fingerprint.json")
+	if err != nil {
+		t.Errorf("Could not load test file: %v\n", err)
+	}
+
+	err = json.Unmarshal(file, &fingerprintTests)
+	if err != nil {
+		t.Errorf("Could not parse test file: %v\n", err)
+	}
+
+	for _, test := range fingerprintTests {
+		hash, parts, err := pg_query.FingerprintDebug(test.Input)
+		if err != nil {
+			t.Errorf("FingerprintDebug(%s)\nparse error %s\n\n", test.Input, err)
+			continue
+		}
+
+		if fingerprint := fmt.Sprintf("%016x", hash); fingerprint != test.ExpectedHash {
+			t.Errorf("FingerprintDebug(%s)\nexpected %s\nactual %s\n\n", test.Input, test.ExpectedHash, fingerprint)
+		}
+		if !reflect.DeepEqual(parts, test.ExpectedParts) {
+			t.Errorf("FingerprintDebug(%s)\nexpected parts %q\nactual parts %q\n\n", test.Input, test.ExpectedParts, parts)
+		}
+	}
+}
+
+func TestFingerprintDebugError(t *testing.T) {
+	_, _, err := pg_query.FingerprintDebug("SELECT $")
+	if err == nil {
+		t.Errorf("FingerprintDebug(SELECT $)\nexpected error but none returned\n\n")
+	}
+}
+
+func TestFingerprintTreeMatchesFingerprint(t *testing.T) {
+	for _, input := range deparseCompareTests {
+		expected, err := pg_query.FingerprintToUInt64(input)
+		if err != nil {
+			t.Errorf("FingerprintToUInt64(%s)\nerror %s\n\n", input, err)
+			continue
+		}
+		tree, err := pg_query.Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", input, err)
+			continue
+		}
+
+		if actual := pg_query.FingerprintTree(tree); actual != expected {
+			t.Errorf("FingerprintTree(%s)\nexpected %d\nactual %d\n\n", input, expected, actual)
+		}
+	}
+}
+
 var hashTests = []struct {
 	input    string
 	seed     uint64
@@ -0,0 +1,305 @@
+package pg_query
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// KeywordCase selects the letter case of SQL keywords in formatted output
+type KeywordCase int
+
+const (
+	// KeywordCaseUpper writes keywords in upper case, like Deparse
+	KeywordCaseUpper KeywordCase = iota
+	// KeywordCaseLower writes keywords in lower case
+	KeywordCaseLower
+)
+
+// CommaStyle selects where commas go when a list is split over multiple lines
+type CommaStyle int
+
+const (
+	// CommaStyleTrailing ends every line but the last with a comma
+	CommaStyleTrailing CommaStyle = iota
+	// CommaStyleLeading starts every line but the first with a comma
+	CommaStyleLeading
+)
+
+// FormatOptions configure the output of Format
+type FormatOptions struct {
+	// Indent is the number of spaces per indentation level (default 2)
+	Indent int
+	// KeywordCase is the letter case of keywords (default upper case)
+	KeywordCase KeywordCase
+	// MaxLineWidth is the line width above which lists and AND/OR conditions are
+	// split over multiple lines (default 80)
+	MaxLineWidth int
+	// CommaStyle is the placement of commas in split lists (default trailing)
+	CommaStyle CommaStyle
+}
+
+const (
+	defaultFormatIndent       = 2
+	defaultFormatMaxLineWidth = 80
+)
+
+// Format - Deparses a given Go parse tree into formatted SQL
+//
+// Every clause of a query starts on its own line, subqueries and CTEs are indented, and
+// lists and conditions that don't fit into the maximum line width are split into one
+// item per line. Statements are separated by a semicolon and an empty line.
+func Format(tree *ParseResult, options FormatOptions) (output string, err error) {
+	if options.Indent <= 0 {
+		options.Indent = defaultFormatIndent
+	}
+	if options.MaxLineWidth <= 0 {
+		options.MaxLineWidth = defaultFormatMaxLineWidth
+	}
+
+	d := &deparser{format: &options, lineStart: -1}
+	defer d.recover(&err)
+
+	for i, stmt := range tree.GetStmts() {
+		d.rawStmt(stmt)
+		if i < len(tree.Stmts)-1 {
+			d.write(";\n\n")
+		}
+	}
+
+	if options.KeywordCase == KeywordCaseLower {
+		return lowerKeywords(d.String()), nil
+	}
+	return d.String(), nil
+}
+
+// newline starts a new line at the current indentation level when formatting
+//
+// Trailing spaces of the current line are removed. Without formatting this does nothing,
+// so the surrounding code has to write the separator needed for single-line output.
+func (d *deparser) newline() {
+	if d.format == nil {
+		return
+	}
+	if d.lineStart >= 0 {
+		panic(lineOverflow{})
+	}
+
+	d.buf = bytes.TrimRight(d.buf, " ")
+	if len(d.buf) == 0 {
+		return
+	}
+	if d.buf[len(d.buf)-1] != '\n' {
+		d.buf = append(d.buf, '\n')
+	}
+	d.buf = append(d.buf, strings.Repeat(" ", d.indent*d.format.Indent)...)
+}
+
+// subquery appends a parenthesized statement, which is indented on its own lines when formatting
+func (d *deparser) subquery(stmt func()) {
+	d.writeByte('(')
+	d.indent++
+	d.newline()
+	stmt()
+	d.indent--
+	d.newline()
+	d.writeByte(')')
+}
+
+// list appends the n items of a comma-separated list
+//
+// When formatting, a list that doesn't fit on the current line is written with every item
+// on its own, indented line.
+func (d *deparser) list(n int, item func(i int)) {
+	singleLine := func() {
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				d.write(", ")
+			}
+			item(i)
+		}
+	}
+	if d.format == nil || n < 2 {
+		singleLine()
+		return
+	}
+	if d.singleLine(singleLine) {
+		return
+	}
+
+	d.indent++
+	for i := 0; i < n; i++ {
+		d.newline()
+		if i > 0 && d.format.CommaStyle == CommaStyleLeading {
+			d.write(", ")
+		}
+		item(i)
+		if i < n-1 && d.format.CommaStyle == CommaStyleTrailing {
+			d.writeByte(',')
+		}
+	}
+	d.indent--
+}
+
+// lineOverflow is raised (through panic) to stop writing output that has to fit on a single line
+type lineOverflow struct{}
+
+// singleLine writes the output of render if it fits on the current line within the maximum
+// line width, and returns whether it did
+//
+// Nested lists and conditions are written on the same line too, rather than trying to fit
+// and splitting each of them, and writing stops as soon as the line is too long. So every
+// node is tried once per enclosing list, keeping formatting linear in the size of the output.
+func (d *deparser) singleLine(render func()) (fits bool) {
+	if d.lineStart >= 0 {
+		// Already within a single line
+		render()
+		return true
+	}
+
+	start, indent := len(d.buf), d.indent
+	d.lineStart = bytes.LastIndexByte(d.buf, '\n') + 1
+	defer func() {
+		d.lineStart = -1
+		if r := recover(); r != nil {
+			if _, ok := r.(lineOverflow); !ok {
+				panic(r)
+			}
+			d.buf, d.indent = d.buf[:start], indent
+			fits = false
+		}
+	}()
+	render()
+	if !d.fits(start) {
+		d.buf = d.buf[:start]
+		return false
+	}
+	return true
+}
+
+// checkLine stops writing output with lineOverflow if it has to fit on a single line, but doesn't
+func (d *deparser) checkLine() {
+	if d.lineStart < 0 || len(d.buf)-d.lineStart <= d.format.MaxLineWidth {
+		return
+	}
+	if utf8.RuneCount(d.buf[d.lineStart:]) > d.format.MaxLineWidth {
+		panic(lineOverflow{})
+	}
+}
+
+// fits returns whether the output written since start is on a single line within the maximum line width
+func (d *deparser) fits(start int) bool {
+	if bytes.IndexByte(d.buf[start:], '\n') >= 0 {
+		return false
+	}
+	lineStart := bytes.LastIndexByte(d.buf, '\n') + 1
+	return utf8.RuneCount(d.buf[lineStart:]) <= d.format.MaxLineWidth
+}
+
+// lowerKeywords lowercases all unquoted words that are written in upper case by the deparser
+//
+// The deparser always quotes identifiers containing upper case letters, so these are keywords.
+// String constants, quoted identifiers and dollar-quoted strings are left untouched.
+func lowerKeywords(sql string) string {
+	out := []byte(sql)
+	for i := 0; i < len(out); {
+		c := out[i]
+		switch {
+		case c == '\'' || c == '"':
+			i = skipQuoted(out, i)
+		case c == '$' && i+1 < len(out) && !isDigit(out[i+1]):
+			i = skipDollarQuoted(out, i)
+		case isDigit(c):
+			for i < len(out) && (isIdentChar(out[i]) || out[i] == '.') {
+				i++
+			}
+		case isIdentStart(c):
+			start := i
+			upper := true
+			for i < len(out) && isIdentChar(out[i]) {
+				if out[i] >= 'a' && out[i] <= 'z' {
+					upper = false
+				}
+				i++
+			}
+			if !upper {
+				continue
+			}
+			// E'...' strings keep their backslash escapes, so skip over them as a whole
+			if i-start == 1 && out[start] == 'E' && i < len(out) && out[i] == '\'' {
+				out[start] = 'e'
+				i = skipEscapeString(out, i)
+				continue
+			}
+			for j := start; j < i; j++ {
+				if out[j] >= 'A' && out[j] <= 'Z' {
+					out[j] += 'a' - 'A'
+				}
+			}
+		default:
+			i++
+		}
+	}
+	return string(out)
+}
+
+// skipQuoted returns the position after the '...' string or "..." identifier starting at i
+func skipQuoted(s []byte, i int) int {
+	quote := s[i]
+	for i++; i < len(s); i++ {
+		if s[i] == quote {
+			if i+1 < len(s) && s[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return i
+}
+
+// skipEscapeString returns the position after the E'...' string whose quote starts at i
+func skipEscapeString(s []byte, i int) int {
+	for i++; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '\'':
+			if i+1 < len(s) && s[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return i
+}
+
+// skipDollarQuoted returns the position after the $tag$...$tag$ string starting at i,
+// or after the $ if it doesn't start a dollar-quoted string
+func skipDollarQuoted(s []byte, i int) int {
+	end := i + 1
+	for end < len(s) && s[end] != '$' && isIdentChar(s[end]) {
+		end++
+	}
+	if end >= len(s) || s[end] != '$' {
+		return i + 1
+	}
+	tag := s[i : end+1]
+	if closing := bytes.Index(s[end+1:], tag); closing >= 0 {
+		return end + 1 + closing + len(tag)
+	}
+	return len(s)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c >= 0x80
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '$'
+}
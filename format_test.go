@@ -0,0 +1,155 @@
+//go:build cgo
+// +build cgo
+
+package pg_query_test
+
+import (
+	"fmt"
+	"testing"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+)
+
+var formatTests = []struct {
+	input    string
+	options  pg_query.FormatOptions
+	expected string
+}{
+	{
+		"SELECT a, b FROM x WHERE y = 1 ORDER BY a LIMIT 10",
+		pg_query.FormatOptions{},
+		"SELECT a, b\nFROM x\nWHERE y = 1\nORDER BY a\nLIMIT 10",
+	},
+	{
+		"select a, b from x where y = 'SELECT \"A\"' and \"Z\" = E'\\\\' and z = $$ FROM $$",
+		pg_query.FormatOptions{KeywordCase: pg_query.KeywordCaseLower},
+		"select a, b\nfrom x\nwhere y = 'SELECT \"A\"' and \"Z\" = e'\\\\' and z = ' FROM '",
+	},
+	{
+		"WITH t AS (SELECT id FROM users WHERE active) SELECT * FROM t JOIN orders o ON o.user_id = t.id",
+		pg_query.FormatOptions{},
+		"WITH t AS (\n  SELECT id\n  FROM users\n  WHERE active\n)\nSELECT *\nFROM t\nJOIN orders o ON o.user_id = t.id",
+	},
+	{
+		"SELECT * FROM (SELECT a FROM x WHERE a IN (SELECT b FROM y)) s",
+		pg_query.FormatOptions{Indent: 4},
+		"SELECT *\nFROM (\n    SELECT a\n    FROM x\n    WHERE a IN (\n        SELECT b\n        FROM y\n    )\n) s",
+	},
+	{
+		"SELECT first_column, second_column, third_column FROM x WHERE first_column = 1 AND second_column = 2",
+		pg_query.FormatOptions{MaxLineWidth: 40},
+		"SELECT\n  first_column,\n  second_column,\n  third_column\nFROM x\nWHERE first_column = 1\n  AND second_column = 2",
+	},
+	{
+		"SELECT first_column, second_column, third_column FROM x",
+		pg_query.FormatOptions{MaxLineWidth: 40, CommaStyle: pg_query.CommaStyleLeading},
+		"SELECT\n  first_column\n  , second_column\n  , third_column\nFROM x",
+	},
+	{
+		"SELECT 1 UNION ALL SELECT 2; UPDATE x SET a = 1 WHERE b = 2 RETURNING a",
+		pg_query.FormatOptions{},
+		"SELECT 1\nUNION ALL\nSELECT 2;\n\nUPDATE x\nSET a = 1\nWHERE b = 2\nRETURNING a",
+	},
+	{
+		"INSERT INTO x (a, b) VALUES (1, 2), (3, 4) ON CONFLICT DO NOTHING",
+		pg_query.FormatOptions{},
+		"INSERT INTO x (a, b)\nVALUES (1, 2), (3, 4)\nON CONFLICT DO NOTHING",
+	},
+}
+
+func TestFormat(t *testing.T) {
+	for _, test := range formatTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		actual, err := pg_query.Format(tree, test.options)
+		if err != nil {
+			t.Errorf("Format(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		if actual != test.expected {
+			t.Errorf("Format(%s)\nexpected %s\nactual %s\n\n", test.input, test.expected, actual)
+		}
+	}
+}
+
+// Formatting only changes whitespace and keyword case, so the formatted query has to deparse
+// to the same SQL as the original one
+func TestFormatRoundTrip(t *testing.T) {
+	options := pg_query.FormatOptions{KeywordCase: pg_query.KeywordCaseLower, MaxLineWidth: 20}
+	for _, test := range deparseCompareTests {
+		tree, err := pg_query.Parse(test)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test, err)
+			continue
+		}
+
+		formatted, err := pg_query.Format(tree, options)
+		if err != nil {
+			t.Errorf("Format(%s)\nerror %s\n\n", test, err)
+			continue
+		}
+
+		formattedTree, err := pg_query.Parse(formatted)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", formatted, err)
+			continue
+		}
+
+		expected, _ := pg_query.Deparse(tree)
+		actual, err := pg_query.Deparse(formattedTree)
+		if err != nil {
+			t.Errorf("Deparse(%s)\nerror %s\n\n", formatted, err)
+			continue
+		}
+
+		if actual != expected {
+			t.Errorf("Format(%s)\nexpected %s\nactual %s\n\n", test, expected, actual)
+		}
+	}
+}
+
+// Formatting deeply nested conditions and subqueries has to take linear time, rather than
+// trying every combination of single-line and split lists
+func TestFormatDeepNesting(t *testing.T) {
+	where := "x = 1"
+	subquery := "SELECT 1"
+	for i := 0; i < 40; i++ {
+		op := "AND"
+		if i%2 == 1 {
+			op = "OR"
+		}
+		where = fmt.Sprintf("column_%d = 'value_%d' %s (%s)", i, i, op, where)
+		subquery = fmt.Sprintf("SELECT a_%d, b_%d, (%s) FROM t_%d", i, i, subquery, i)
+	}
+
+	for _, input := range []string{"SELECT * FROM t WHERE " + where, subquery} {
+		tree, err := pg_query.Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", input, err)
+			continue
+		}
+
+		formatted, err := pg_query.Format(tree, pg_query.FormatOptions{})
+		if err != nil {
+			t.Errorf("Format(%s)\nerror %s\n\n", input, err)
+			continue
+		}
+
+		formattedTree, err := pg_query.Parse(formatted)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", formatted, err)
+			continue
+		}
+
+		expected, _ := pg_query.Deparse(tree)
+		actual, _ := pg_query.Deparse(formattedTree)
+		if actual != expected {
+			t.Errorf("Format(%s)\nexpected %s\nactual %s\n\n", input, expected, actual)
+		}
+	}
+}
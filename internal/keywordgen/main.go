@@ -0,0 +1,54 @@
+// Command keywordgen generates keywords.go from the keyword list of the bundled PostgreSQL
+// parser (parser/include/postgres/parser/kwlist.h), for use in identifier quoting. Run it
+// through "go generate" after updating the parser sources.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"regexp"
+)
+
+var keywordRe = regexp.MustCompile(`^PG_KEYWORD\("([^"]+)", \w+, (\w+), \w+\)`)
+
+func main() {
+	input := flag.String("input", "parser/include/postgres/parser/kwlist.h", "keyword list to read")
+	output := flag.String("output", "keywords.go", "file to write the keyword table to")
+	flag.Parse()
+
+	f, err := os.Open(*input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by internal/keywordgen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package pg_query\n\n")
+	fmt.Fprintf(&buf, "// keywords maps every SQL keyword to its category\n")
+	fmt.Fprintf(&buf, "var keywords = map[string]KeywordKind{\n")
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if m := keywordRe.FindStringSubmatch(scanner.Text()); m != nil {
+			fmt.Fprintf(&buf, "%q: KeywordKind_%s,\n", m[1], m[2])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
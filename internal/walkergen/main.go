@@ -0,0 +1,413 @@
+// Command walkergen generates walker_generated.go from the protobuf descriptors in pg_query.pb.go.
+// It reads them from the source of pg_query.pb.go rather than importing the package, so that it
+// still runs when the package doesn't build, e.g. while walker_generated.go is out of date.
+//
+// For every message that can (directly or through nested messages) contain a Node it emits a walk
+// function passing pointers to all of those fields, in declaration order and named by their JSON
+// field name, to a treeWalker. On top of these it emits the WalkSubtree method of every Node type and the
+// walkNode type switch. Run it through "go generate" after updating pg_query.pb.go.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// message describes a protobuf message together with its generated Go type
+type message struct {
+	desc   protoreflect.MessageDescriptor
+	goName string
+	// goFields maps the protobuf field names to the names of the generated Go struct fields
+	goFields map[string]string
+}
+
+func (m *message) name() string {
+	return m.goName
+}
+
+// goField returns the name of the Go struct field generated for the given protobuf field
+func (m *message) goField(field protoreflect.FieldDescriptor) string {
+	name, ok := m.goFields[string(field.Name())]
+	if !ok {
+		log.Fatalf("%s: no Go field for %s", m.desc.FullName(), field.Name())
+	}
+	return name
+}
+
+// oneofWrapper describes the Go type generated for a field of the Node oneof
+type oneofWrapper struct {
+	goName  string
+	goField string
+}
+
+// goFile holds what walkergen needs from a .pb.go file: the file descriptor and the names
+// of the generated Go types and fields. It is read from the source, so that walkergen
+// doesn't depend on the package it generates code for.
+type goFile struct {
+	desc protoreflect.FileDescriptor
+	// structs maps the Go struct names to their protobuf field names and Go field names
+	structs map[string]map[string]string
+	// messages maps the descriptor paths (e.g. "[11]") to the Go names of the messages
+	messages map[string]string
+	// nodeWrappers maps the fields of the Node oneof to their Go wrapper types
+	nodeWrappers map[string]oneofWrapper
+}
+
+func parseGoFile(path string) (*goFile, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	f := &goFile{
+		structs:      map[string]map[string]string{},
+		messages:     map[string]string{},
+		nodeWrappers: map[string]oneofWrapper{},
+	}
+	var rawDesc []byte
+	var wrappers []string
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if st, ok := spec.Type.(*ast.StructType); ok {
+						f.structs[spec.Name.Name] = structFields(st)
+					}
+				case *ast.ValueSpec:
+					if len(spec.Names) == 1 && strings.HasSuffix(spec.Names[0].Name, "_rawDesc") && len(spec.Values) == 1 {
+						if rawDesc, err = constBytes(spec.Values[0]); err != nil {
+							return nil, fmt.Errorf("%s: %v", spec.Names[0].Name, err)
+						}
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if decl.Recv == nil || len(decl.Recv.List) != 1 {
+				continue
+			}
+			star, ok := decl.Recv.List[0].Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			recv, ok := star.X.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			switch decl.Name.Name {
+			case "isNode_Node":
+				wrappers = append(wrappers, recv.Name)
+			case "Descriptor":
+				if path := descriptorPath(decl); path != "" {
+					f.messages[path] = recv.Name
+				}
+			}
+		}
+	}
+	if rawDesc == nil {
+		return nil, fmt.Errorf("%s: no raw file descriptor", path)
+	}
+
+	for _, name := range wrappers {
+		fields := f.structs[name]
+		if len(fields) != 1 {
+			return nil, fmt.Errorf("%s: expected a single field in oneof wrapper", name)
+		}
+		for field, goField := range fields {
+			f.nodeWrappers[field] = oneofWrapper{goName: name, goField: goField}
+		}
+	}
+
+	fdProto := &descriptorpb.FileDescriptorProto{}
+	if err := proto.Unmarshal(rawDesc, fdProto); err != nil {
+		return nil, err
+	}
+	f.desc, err = protodesc.NewFile(fdProto, nil)
+	return f, err
+}
+
+// structFields maps the protobuf field names of a generated struct to its Go field names,
+// using the protobuf struct tags
+func structFields(st *ast.StructType) map[string]string {
+	fields := map[string]string{}
+	for _, field := range st.Fields.List {
+		if field.Tag == nil || len(field.Names) != 1 {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		for _, part := range strings.Split(reflect.StructTag(tag).Get("protobuf"), ",") {
+			if strings.HasPrefix(part, "name=") {
+				fields[strings.TrimPrefix(part, "name=")] = field.Names[0].Name
+			}
+		}
+	}
+	return fields
+}
+
+// constBytes returns the value of a byte slice literal, or of a string constant like newer
+// versions of protoc-gen-go generate for raw descriptors
+func constBytes(expr ast.Expr) ([]byte, error) {
+	switch expr := expr.(type) {
+	case *ast.CompositeLit:
+		b := make([]byte, 0, len(expr.Elts))
+		for _, elt := range expr.Elts {
+			lit, ok := elt.(*ast.BasicLit)
+			if !ok || lit.Kind != token.INT {
+				return nil, fmt.Errorf("unexpected element %T", elt)
+			}
+			v, err := strconv.ParseUint(lit.Value, 0, 8)
+			if err != nil {
+				return nil, err
+			}
+			b = append(b, byte(v))
+		}
+		return b, nil
+	case *ast.BasicLit:
+		s, err := strconv.Unquote(expr.Value)
+		return []byte(s), err
+	case *ast.BinaryExpr:
+		x, err := constBytes(expr.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := constBytes(expr.Y)
+		return append(x, y...), err
+	case *ast.CallExpr:
+		// []byte("...")
+		if len(expr.Args) == 1 {
+			return constBytes(expr.Args[0])
+		}
+	}
+	return nil, fmt.Errorf("unexpected expression %T", expr)
+}
+
+// descriptorPath returns the message path returned by a generated Descriptor method,
+// formatted like "[11]"
+func descriptorPath(decl *ast.FuncDecl) string {
+	if decl.Body == nil || len(decl.Body.List) != 1 {
+		return ""
+	}
+	ret, ok := decl.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 2 {
+		return ""
+	}
+	lit, ok := ret.Results[1].(*ast.CompositeLit)
+	if !ok {
+		return ""
+	}
+	var path []string
+	for _, elt := range lit.Elts {
+		idx, ok := elt.(*ast.BasicLit)
+		if !ok {
+			return ""
+		}
+		path = append(path, idx.Value)
+	}
+	return "[" + strings.Join(path, " ") + "]"
+}
+
+type generator struct {
+	file     *goFile
+	nodeDesc protoreflect.MessageDescriptor
+	messages map[protoreflect.FullName]*message
+	// reachesNode caches whether a message can contain a Node
+	reachesNode map[protoreflect.FullName]bool
+	buf         bytes.Buffer
+}
+
+func newGenerator(file *goFile) *generator {
+	g := &generator{
+		file:        file,
+		nodeDesc:    file.desc.Messages().ByName("Node"),
+		messages:    map[protoreflect.FullName]*message{},
+		reachesNode: map[protoreflect.FullName]bool{},
+	}
+	if g.nodeDesc == nil {
+		log.Fatal("no Node message")
+	}
+	g.addMessages(file.desc.Messages(), nil)
+	g.computeReachability()
+	return g
+}
+
+// addMessages adds the given messages and their nested messages, where path is the
+// descriptor path of their parent
+func (g *generator) addMessages(msgs protoreflect.MessageDescriptors, path []int) {
+	for i := 0; i < msgs.Len(); i++ {
+		desc := msgs.Get(i)
+		msgPath := append(append([]int{}, path...), i)
+		goName, ok := g.file.messages[fmt.Sprint(msgPath)]
+		if !ok {
+			log.Fatalf("%s: no Go type", desc.FullName())
+		}
+		g.messages[desc.FullName()] = &message{
+			desc:     desc,
+			goName:   goName,
+			goFields: g.file.structs[goName],
+		}
+		g.addMessages(desc.Messages(), msgPath)
+	}
+}
+
+func (g *generator) computeReachability() {
+	for changed := true; changed; {
+		changed = false
+		for name, m := range g.messages {
+			if g.reachesNode[name] {
+				continue
+			}
+			fields := m.desc.Fields()
+			for i := 0; i < fields.Len(); i++ {
+				if g.isNodeField(fields.Get(i)) || g.isWalkableField(fields.Get(i)) {
+					g.reachesNode[name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+}
+
+func (g *generator) isNodeField(field protoreflect.FieldDescriptor) bool {
+	return field.Message() != nil && field.Message().FullName() == g.nodeDesc.FullName()
+}
+
+func (g *generator) isWalkableField(field protoreflect.FieldDescriptor) bool {
+	return field.Message() != nil && !g.isNodeField(field) && g.reachesNode[field.Message().FullName()]
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) generate() []byte {
+	g.printf("// Code generated by internal/walkergen; DO NOT EDIT.\n\n")
+	g.printf("package pg_query\n\n")
+
+	// WalkSubtree methods and the walkNode type switch, in the order of the Node oneof
+	type variant struct {
+		wrapper oneofWrapper
+		msg     *message
+	}
+	var variants []variant
+	fields := g.nodeDesc.Oneofs().ByName("node").Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		wrapper, ok := g.file.nodeWrappers[string(field.Name())]
+		if !ok {
+			log.Fatalf("%s: no oneof wrapper type", field.FullName())
+		}
+		variants = append(variants, variant{
+			wrapper: wrapper,
+			msg:     g.messages[field.Message().FullName()],
+		})
+	}
+
+	for _, v := range variants {
+		g.printf("func (n *%s) WalkSubtree(visit Visit) error {\n", v.wrapper.goName)
+		if g.reachesNode[v.msg.desc.FullName()] {
+			g.printf("return walk%s(n.%s, visitWalker(visit))\n", v.msg.name(), v.wrapper.goField)
+		} else {
+			g.printf("return nil\n")
+		}
+		g.printf("}\n\n")
+	}
+
+	g.printf("// walkNode passes all child nodes of the given node to the walker\n")
+	g.printf("func walkNode(node *Node, w treeWalker) error {\n")
+	g.printf("switch n := node.Node.(type) {\n")
+	for _, v := range variants {
+		if g.reachesNode[v.msg.desc.FullName()] {
+			g.printf("case *%s:\nreturn walk%s(n.%s, w)\n", v.wrapper.goName, v.msg.name(), v.wrapper.goField)
+		}
+	}
+	g.printf("}\nreturn nil\n}\n\n")
+
+	// walk functions for all messages that can contain nodes, sorted by Go type name
+	var names []string
+	for name := range g.messages {
+		if g.reachesNode[name] && name != g.nodeDesc.FullName() {
+			names = append(names, string(name))
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return g.messages[protoreflect.FullName(names[i])].name() < g.messages[protoreflect.FullName(names[j])].name()
+	})
+	for _, name := range names {
+		g.generateWalkFunc(g.messages[protoreflect.FullName(name)])
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v\n%s", err, g.buf.String())
+	}
+	return src
+}
+
+func (g *generator) generateWalkFunc(m *message) {
+	g.printf("func walk%s(n *%s, w treeWalker) error {\n", m.name(), m.name())
+	g.printf("if n == nil {\nreturn nil\n}\n")
+
+	fields := m.desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !g.isNodeField(field) && !g.isWalkableField(field) {
+			continue
+		}
+		if field.ContainingOneof() != nil || field.IsMap() {
+			log.Fatalf("%s.%s: oneof and map fields are not supported", m.desc.FullName(), field.Name())
+		}
+
+		goField := m.goField(field)
+		jsonName := field.JSONName()
+		switch {
+		case g.isNodeField(field) && field.IsList():
+			g.printf("if err := w.list(%q, &n.%s); err != nil {\nreturn err\n}\n", jsonName, goField)
+		case g.isNodeField(field):
+			g.printf("if err := w.node(%q, &n.%s); err != nil {\nreturn err\n}\n", jsonName, goField)
+		case field.IsList():
+			g.printf("for _, item := range n.%s {\n", goField)
+			g.printf("if err := walk%s(item, w.message(%q)); err != nil {\nreturn err\n}\n", g.messages[field.Message().FullName()].name(), jsonName)
+			g.printf("}\n")
+		default:
+			g.printf("if n.%s != nil {\n", goField)
+			g.printf("if err := walk%s(n.%s, w.message(%q)); err != nil {\nreturn err\n}\n", g.messages[field.Message().FullName()].name(), goField, jsonName)
+			g.printf("}\n")
+		}
+	}
+	g.printf("return nil\n}\n\n")
+}
+
+func main() {
+	input := flag.String("input", "pg_query.pb.go", "protoc-gen-go output to read the protobuf definitions from")
+	output := flag.String("output", "walker_generated.go", "file to write the generated walker to")
+	flag.Parse()
+
+	file, err := parseGoFile(*input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*output, newGenerator(file).generate(), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,478 @@
+// Code generated by internal/keywordgen; DO NOT EDIT.
+
+package pg_query
+
+// keywords maps every SQL keyword to its category
+var keywords = map[string]KeywordKind{
+	"abort":             KeywordKind_UNRESERVED_KEYWORD,
+	"absent":            KeywordKind_UNRESERVED_KEYWORD,
+	"absolute":          KeywordKind_UNRESERVED_KEYWORD,
+	"access":            KeywordKind_UNRESERVED_KEYWORD,
+	"action":            KeywordKind_UNRESERVED_KEYWORD,
+	"add":               KeywordKind_UNRESERVED_KEYWORD,
+	"admin":             KeywordKind_UNRESERVED_KEYWORD,
+	"after":             KeywordKind_UNRESERVED_KEYWORD,
+	"aggregate":         KeywordKind_UNRESERVED_KEYWORD,
+	"all":               KeywordKind_RESERVED_KEYWORD,
+	"also":              KeywordKind_UNRESERVED_KEYWORD,
+	"alter":             KeywordKind_UNRESERVED_KEYWORD,
+	"always":            KeywordKind_UNRESERVED_KEYWORD,
+	"analyse":           KeywordKind_RESERVED_KEYWORD,
+	"analyze":           KeywordKind_RESERVED_KEYWORD,
+	"and":               KeywordKind_RESERVED_KEYWORD,
+	"any":               KeywordKind_RESERVED_KEYWORD,
+	"array":             KeywordKind_RESERVED_KEYWORD,
+	"as":                KeywordKind_RESERVED_KEYWORD,
+	"asc":               KeywordKind_RESERVED_KEYWORD,
+	"asensitive":        KeywordKind_UNRESERVED_KEYWORD,
+	"assertion":         KeywordKind_UNRESERVED_KEYWORD,
+	"assignment":        KeywordKind_UNRESERVED_KEYWORD,
+	"asymmetric":        KeywordKind_RESERVED_KEYWORD,
+	"at":                KeywordKind_UNRESERVED_KEYWORD,
+	"atomic":            KeywordKind_UNRESERVED_KEYWORD,
+	"attach":            KeywordKind_UNRESERVED_KEYWORD,
+	"attribute":         KeywordKind_UNRESERVED_KEYWORD,
+	"authorization":     KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"backward":          KeywordKind_UNRESERVED_KEYWORD,
+	"before":            KeywordKind_UNRESERVED_KEYWORD,
+	"begin":             KeywordKind_UNRESERVED_KEYWORD,
+	"between":           KeywordKind_COL_NAME_KEYWORD,
+	"bigint":            KeywordKind_COL_NAME_KEYWORD,
+	"binary":            KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"bit":               KeywordKind_COL_NAME_KEYWORD,
+	"boolean":           KeywordKind_COL_NAME_KEYWORD,
+	"both":              KeywordKind_RESERVED_KEYWORD,
+	"breadth":           KeywordKind_UNRESERVED_KEYWORD,
+	"by":                KeywordKind_UNRESERVED_KEYWORD,
+	"cache":             KeywordKind_UNRESERVED_KEYWORD,
+	"call":              KeywordKind_UNRESERVED_KEYWORD,
+	"called":            KeywordKind_UNRESERVED_KEYWORD,
+	"cascade":           KeywordKind_UNRESERVED_KEYWORD,
+	"cascaded":          KeywordKind_UNRESERVED_KEYWORD,
+	"case":              KeywordKind_RESERVED_KEYWORD,
+	"cast":              KeywordKind_RESERVED_KEYWORD,
+	"catalog":           KeywordKind_UNRESERVED_KEYWORD,
+	"chain":             KeywordKind_UNRESERVED_KEYWORD,
+	"char":              KeywordKind_COL_NAME_KEYWORD,
+	"character":         KeywordKind_COL_NAME_KEYWORD,
+	"characteristics":   KeywordKind_UNRESERVED_KEYWORD,
+	"check":             KeywordKind_RESERVED_KEYWORD,
+	"checkpoint":        KeywordKind_UNRESERVED_KEYWORD,
+	"class":             KeywordKind_UNRESERVED_KEYWORD,
+	"close":             KeywordKind_UNRESERVED_KEYWORD,
+	"cluster":           KeywordKind_UNRESERVED_KEYWORD,
+	"coalesce":          KeywordKind_COL_NAME_KEYWORD,
+	"collate":           KeywordKind_RESERVED_KEYWORD,
+	"collation":         KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"column":            KeywordKind_RESERVED_KEYWORD,
+	"columns":           KeywordKind_UNRESERVED_KEYWORD,
+	"comment":           KeywordKind_UNRESERVED_KEYWORD,
+	"comments":          KeywordKind_UNRESERVED_KEYWORD,
+	"commit":            KeywordKind_UNRESERVED_KEYWORD,
+	"committed":         KeywordKind_UNRESERVED_KEYWORD,
+	"compression":       KeywordKind_UNRESERVED_KEYWORD,
+	"concurrently":      KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"configuration":     KeywordKind_UNRESERVED_KEYWORD,
+	"conflict":          KeywordKind_UNRESERVED_KEYWORD,
+	"connection":        KeywordKind_UNRESERVED_KEYWORD,
+	"constraint":        KeywordKind_RESERVED_KEYWORD,
+	"constraints":       KeywordKind_UNRESERVED_KEYWORD,
+	"content":           KeywordKind_UNRESERVED_KEYWORD,
+	"continue":          KeywordKind_UNRESERVED_KEYWORD,
+	"conversion":        KeywordKind_UNRESERVED_KEYWORD,
+	"copy":              KeywordKind_UNRESERVED_KEYWORD,
+	"cost":              KeywordKind_UNRESERVED_KEYWORD,
+	"create":            KeywordKind_RESERVED_KEYWORD,
+	"cross":             KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"csv":               KeywordKind_UNRESERVED_KEYWORD,
+	"cube":              KeywordKind_UNRESERVED_KEYWORD,
+	"current":           KeywordKind_UNRESERVED_KEYWORD,
+	"current_catalog":   KeywordKind_RESERVED_KEYWORD,
+	"current_date":      KeywordKind_RESERVED_KEYWORD,
+	"current_role":      KeywordKind_RESERVED_KEYWORD,
+	"current_schema":    KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"current_time":      KeywordKind_RESERVED_KEYWORD,
+	"current_timestamp": KeywordKind_RESERVED_KEYWORD,
+	"current_user":      KeywordKind_RESERVED_KEYWORD,
+	"cursor":            KeywordKind_UNRESERVED_KEYWORD,
+	"cycle":             KeywordKind_UNRESERVED_KEYWORD,
+	"data":              KeywordKind_UNRESERVED_KEYWORD,
+	"database":          KeywordKind_UNRESERVED_KEYWORD,
+	"day":               KeywordKind_UNRESERVED_KEYWORD,
+	"deallocate":        KeywordKind_UNRESERVED_KEYWORD,
+	"dec":               KeywordKind_COL_NAME_KEYWORD,
+	"decimal":           KeywordKind_COL_NAME_KEYWORD,
+	"declare":           KeywordKind_UNRESERVED_KEYWORD,
+	"default":           KeywordKind_RESERVED_KEYWORD,
+	"defaults":          KeywordKind_UNRESERVED_KEYWORD,
+	"deferrable":        KeywordKind_RESERVED_KEYWORD,
+	"deferred":          KeywordKind_UNRESERVED_KEYWORD,
+	"definer":           KeywordKind_UNRESERVED_KEYWORD,
+	"delete":            KeywordKind_UNRESERVED_KEYWORD,
+	"delimiter":         KeywordKind_UNRESERVED_KEYWORD,
+	"delimiters":        KeywordKind_UNRESERVED_KEYWORD,
+	"depends":           KeywordKind_UNRESERVED_KEYWORD,
+	"depth":             KeywordKind_UNRESERVED_KEYWORD,
+	"desc":              KeywordKind_RESERVED_KEYWORD,
+	"detach":            KeywordKind_UNRESERVED_KEYWORD,
+	"dictionary":        KeywordKind_UNRESERVED_KEYWORD,
+	"disable":           KeywordKind_UNRESERVED_KEYWORD,
+	"discard":           KeywordKind_UNRESERVED_KEYWORD,
+	"distinct":          KeywordKind_RESERVED_KEYWORD,
+	"do":                KeywordKind_RESERVED_KEYWORD,
+	"document":          KeywordKind_UNRESERVED_KEYWORD,
+	"domain":            KeywordKind_UNRESERVED_KEYWORD,
+	"double":            KeywordKind_UNRESERVED_KEYWORD,
+	"drop":              KeywordKind_UNRESERVED_KEYWORD,
+	"each":              KeywordKind_UNRESERVED_KEYWORD,
+	"else":              KeywordKind_RESERVED_KEYWORD,
+	"enable":            KeywordKind_UNRESERVED_KEYWORD,
+	"encoding":          KeywordKind_UNRESERVED_KEYWORD,
+	"encrypted":         KeywordKind_UNRESERVED_KEYWORD,
+	"end":               KeywordKind_RESERVED_KEYWORD,
+	"enum":              KeywordKind_UNRESERVED_KEYWORD,
+	"escape":            KeywordKind_UNRESERVED_KEYWORD,
+	"event":             KeywordKind_UNRESERVED_KEYWORD,
+	"except":            KeywordKind_RESERVED_KEYWORD,
+	"exclude":           KeywordKind_UNRESERVED_KEYWORD,
+	"excluding":         KeywordKind_UNRESERVED_KEYWORD,
+	"exclusive":         KeywordKind_UNRESERVED_KEYWORD,
+	"execute":           KeywordKind_UNRESERVED_KEYWORD,
+	"exists":            KeywordKind_COL_NAME_KEYWORD,
+	"explain":           KeywordKind_UNRESERVED_KEYWORD,
+	"expression":        KeywordKind_UNRESERVED_KEYWORD,
+	"extension":         KeywordKind_UNRESERVED_KEYWORD,
+	"external":          KeywordKind_UNRESERVED_KEYWORD,
+	"extract":           KeywordKind_COL_NAME_KEYWORD,
+	"false":             KeywordKind_RESERVED_KEYWORD,
+	"family":            KeywordKind_UNRESERVED_KEYWORD,
+	"fetch":             KeywordKind_RESERVED_KEYWORD,
+	"filter":            KeywordKind_UNRESERVED_KEYWORD,
+	"finalize":          KeywordKind_UNRESERVED_KEYWORD,
+	"first":             KeywordKind_UNRESERVED_KEYWORD,
+	"float":             KeywordKind_COL_NAME_KEYWORD,
+	"following":         KeywordKind_UNRESERVED_KEYWORD,
+	"for":               KeywordKind_RESERVED_KEYWORD,
+	"force":             KeywordKind_UNRESERVED_KEYWORD,
+	"foreign":           KeywordKind_RESERVED_KEYWORD,
+	"format":            KeywordKind_UNRESERVED_KEYWORD,
+	"forward":           KeywordKind_UNRESERVED_KEYWORD,
+	"freeze":            KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"from":              KeywordKind_RESERVED_KEYWORD,
+	"full":              KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"function":          KeywordKind_UNRESERVED_KEYWORD,
+	"functions":         KeywordKind_UNRESERVED_KEYWORD,
+	"generated":         KeywordKind_UNRESERVED_KEYWORD,
+	"global":            KeywordKind_UNRESERVED_KEYWORD,
+	"grant":             KeywordKind_RESERVED_KEYWORD,
+	"granted":           KeywordKind_UNRESERVED_KEYWORD,
+	"greatest":          KeywordKind_COL_NAME_KEYWORD,
+	"group":             KeywordKind_RESERVED_KEYWORD,
+	"grouping":          KeywordKind_COL_NAME_KEYWORD,
+	"groups":            KeywordKind_UNRESERVED_KEYWORD,
+	"handler":           KeywordKind_UNRESERVED_KEYWORD,
+	"having":            KeywordKind_RESERVED_KEYWORD,
+	"header":            KeywordKind_UNRESERVED_KEYWORD,
+	"hold":              KeywordKind_UNRESERVED_KEYWORD,
+	"hour":              KeywordKind_UNRESERVED_KEYWORD,
+	"identity":          KeywordKind_UNRESERVED_KEYWORD,
+	"if":                KeywordKind_UNRESERVED_KEYWORD,
+	"ilike":             KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"immediate":         KeywordKind_UNRESERVED_KEYWORD,
+	"immutable":         KeywordKind_UNRESERVED_KEYWORD,
+	"implicit":          KeywordKind_UNRESERVED_KEYWORD,
+	"import":            KeywordKind_UNRESERVED_KEYWORD,
+	"in":                KeywordKind_RESERVED_KEYWORD,
+	"include":           KeywordKind_UNRESERVED_KEYWORD,
+	"including":         KeywordKind_UNRESERVED_KEYWORD,
+	"increment":         KeywordKind_UNRESERVED_KEYWORD,
+	"indent":            KeywordKind_UNRESERVED_KEYWORD,
+	"index":             KeywordKind_UNRESERVED_KEYWORD,
+	"indexes":           KeywordKind_UNRESERVED_KEYWORD,
+	"inherit":           KeywordKind_UNRESERVED_KEYWORD,
+	"inherits":          KeywordKind_UNRESERVED_KEYWORD,
+	"initially":         KeywordKind_RESERVED_KEYWORD,
+	"inline":            KeywordKind_UNRESERVED_KEYWORD,
+	"inner":             KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"inout":             KeywordKind_COL_NAME_KEYWORD,
+	"input":             KeywordKind_UNRESERVED_KEYWORD,
+	"insensitive":       KeywordKind_UNRESERVED_KEYWORD,
+	"insert":            KeywordKind_UNRESERVED_KEYWORD,
+	"instead":           KeywordKind_UNRESERVED_KEYWORD,
+	"int":               KeywordKind_COL_NAME_KEYWORD,
+	"integer":           KeywordKind_COL_NAME_KEYWORD,
+	"intersect":         KeywordKind_RESERVED_KEYWORD,
+	"interval":          KeywordKind_COL_NAME_KEYWORD,
+	"into":              KeywordKind_RESERVED_KEYWORD,
+	"invoker":           KeywordKind_UNRESERVED_KEYWORD,
+	"is":                KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"isnull":            KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"isolation":         KeywordKind_UNRESERVED_KEYWORD,
+	"join":              KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"json":              KeywordKind_UNRESERVED_KEYWORD,
+	"json_array":        KeywordKind_COL_NAME_KEYWORD,
+	"json_arrayagg":     KeywordKind_COL_NAME_KEYWORD,
+	"json_object":       KeywordKind_COL_NAME_KEYWORD,
+	"json_objectagg":    KeywordKind_COL_NAME_KEYWORD,
+	"key":               KeywordKind_UNRESERVED_KEYWORD,
+	"keys":              KeywordKind_UNRESERVED_KEYWORD,
+	"label":             KeywordKind_UNRESERVED_KEYWORD,
+	"language":          KeywordKind_UNRESERVED_KEYWORD,
+	"large":             KeywordKind_UNRESERVED_KEYWORD,
+	"last":              KeywordKind_UNRESERVED_KEYWORD,
+	"lateral":           KeywordKind_RESERVED_KEYWORD,
+	"leading":           KeywordKind_RESERVED_KEYWORD,
+	"leakproof":         KeywordKind_UNRESERVED_KEYWORD,
+	"least":             KeywordKind_COL_NAME_KEYWORD,
+	"left":              KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"level":             KeywordKind_UNRESERVED_KEYWORD,
+	"like":              KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"limit":             KeywordKind_RESERVED_KEYWORD,
+	"listen":            KeywordKind_UNRESERVED_KEYWORD,
+	"load":              KeywordKind_UNRESERVED_KEYWORD,
+	"local":             KeywordKind_UNRESERVED_KEYWORD,
+	"localtime":         KeywordKind_RESERVED_KEYWORD,
+	"localtimestamp":    KeywordKind_RESERVED_KEYWORD,
+	"location":          KeywordKind_UNRESERVED_KEYWORD,
+	"lock":              KeywordKind_UNRESERVED_KEYWORD,
+	"locked":            KeywordKind_UNRESERVED_KEYWORD,
+	"logged":            KeywordKind_UNRESERVED_KEYWORD,
+	"mapping":           KeywordKind_UNRESERVED_KEYWORD,
+	"match":             KeywordKind_UNRESERVED_KEYWORD,
+	"matched":           KeywordKind_UNRESERVED_KEYWORD,
+	"materialized":      KeywordKind_UNRESERVED_KEYWORD,
+	"maxvalue":          KeywordKind_UNRESERVED_KEYWORD,
+	"merge":             KeywordKind_UNRESERVED_KEYWORD,
+	"method":            KeywordKind_UNRESERVED_KEYWORD,
+	"minute":            KeywordKind_UNRESERVED_KEYWORD,
+	"minvalue":          KeywordKind_UNRESERVED_KEYWORD,
+	"mode":              KeywordKind_UNRESERVED_KEYWORD,
+	"month":             KeywordKind_UNRESERVED_KEYWORD,
+	"move":              KeywordKind_UNRESERVED_KEYWORD,
+	"name":              KeywordKind_UNRESERVED_KEYWORD,
+	"names":             KeywordKind_UNRESERVED_KEYWORD,
+	"national":          KeywordKind_COL_NAME_KEYWORD,
+	"natural":           KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"nchar":             KeywordKind_COL_NAME_KEYWORD,
+	"new":               KeywordKind_UNRESERVED_KEYWORD,
+	"next":              KeywordKind_UNRESERVED_KEYWORD,
+	"nfc":               KeywordKind_UNRESERVED_KEYWORD,
+	"nfd":               KeywordKind_UNRESERVED_KEYWORD,
+	"nfkc":              KeywordKind_UNRESERVED_KEYWORD,
+	"nfkd":              KeywordKind_UNRESERVED_KEYWORD,
+	"no":                KeywordKind_UNRESERVED_KEYWORD,
+	"none":              KeywordKind_COL_NAME_KEYWORD,
+	"normalize":         KeywordKind_COL_NAME_KEYWORD,
+	"normalized":        KeywordKind_UNRESERVED_KEYWORD,
+	"not":               KeywordKind_RESERVED_KEYWORD,
+	"nothing":           KeywordKind_UNRESERVED_KEYWORD,
+	"notify":            KeywordKind_UNRESERVED_KEYWORD,
+	"notnull":           KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"nowait":            KeywordKind_UNRESERVED_KEYWORD,
+	"null":              KeywordKind_RESERVED_KEYWORD,
+	"nullif":            KeywordKind_COL_NAME_KEYWORD,
+	"nulls":             KeywordKind_UNRESERVED_KEYWORD,
+	"numeric":           KeywordKind_COL_NAME_KEYWORD,
+	"object":            KeywordKind_UNRESERVED_KEYWORD,
+	"of":                KeywordKind_UNRESERVED_KEYWORD,
+	"off":               KeywordKind_UNRESERVED_KEYWORD,
+	"offset":            KeywordKind_RESERVED_KEYWORD,
+	"oids":              KeywordKind_UNRESERVED_KEYWORD,
+	"old":               KeywordKind_UNRESERVED_KEYWORD,
+	"on":                KeywordKind_RESERVED_KEYWORD,
+	"only":              KeywordKind_RESERVED_KEYWORD,
+	"operator":          KeywordKind_UNRESERVED_KEYWORD,
+	"option":            KeywordKind_UNRESERVED_KEYWORD,
+	"options":           KeywordKind_UNRESERVED_KEYWORD,
+	"or":                KeywordKind_RESERVED_KEYWORD,
+	"order":             KeywordKind_RESERVED_KEYWORD,
+	"ordinality":        KeywordKind_UNRESERVED_KEYWORD,
+	"others":            KeywordKind_UNRESERVED_KEYWORD,
+	"out":               KeywordKind_COL_NAME_KEYWORD,
+	"outer":             KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"over":              KeywordKind_UNRESERVED_KEYWORD,
+	"overlaps":          KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"overlay":           KeywordKind_COL_NAME_KEYWORD,
+	"overriding":        KeywordKind_UNRESERVED_KEYWORD,
+	"owned":             KeywordKind_UNRESERVED_KEYWORD,
+	"owner":             KeywordKind_UNRESERVED_KEYWORD,
+	"parallel":          KeywordKind_UNRESERVED_KEYWORD,
+	"parameter":         KeywordKind_UNRESERVED_KEYWORD,
+	"parser":            KeywordKind_UNRESERVED_KEYWORD,
+	"partial":           KeywordKind_UNRESERVED_KEYWORD,
+	"partition":         KeywordKind_UNRESERVED_KEYWORD,
+	"passing":           KeywordKind_UNRESERVED_KEYWORD,
+	"password":          KeywordKind_UNRESERVED_KEYWORD,
+	"placing":           KeywordKind_RESERVED_KEYWORD,
+	"plans":             KeywordKind_UNRESERVED_KEYWORD,
+	"policy":            KeywordKind_UNRESERVED_KEYWORD,
+	"position":          KeywordKind_COL_NAME_KEYWORD,
+	"preceding":         KeywordKind_UNRESERVED_KEYWORD,
+	"precision":         KeywordKind_COL_NAME_KEYWORD,
+	"prepare":           KeywordKind_UNRESERVED_KEYWORD,
+	"prepared":          KeywordKind_UNRESERVED_KEYWORD,
+	"preserve":          KeywordKind_UNRESERVED_KEYWORD,
+	"primary":           KeywordKind_RESERVED_KEYWORD,
+	"prior":             KeywordKind_UNRESERVED_KEYWORD,
+	"privileges":        KeywordKind_UNRESERVED_KEYWORD,
+	"procedural":        KeywordKind_UNRESERVED_KEYWORD,
+	"procedure":         KeywordKind_UNRESERVED_KEYWORD,
+	"procedures":        KeywordKind_UNRESERVED_KEYWORD,
+	"program":           KeywordKind_UNRESERVED_KEYWORD,
+	"publication":       KeywordKind_UNRESERVED_KEYWORD,
+	"quote":             KeywordKind_UNRESERVED_KEYWORD,
+	"range":             KeywordKind_UNRESERVED_KEYWORD,
+	"read":              KeywordKind_UNRESERVED_KEYWORD,
+	"real":              KeywordKind_COL_NAME_KEYWORD,
+	"reassign":          KeywordKind_UNRESERVED_KEYWORD,
+	"recheck":           KeywordKind_UNRESERVED_KEYWORD,
+	"recursive":         KeywordKind_UNRESERVED_KEYWORD,
+	"ref":               KeywordKind_UNRESERVED_KEYWORD,
+	"references":        KeywordKind_RESERVED_KEYWORD,
+	"referencing":       KeywordKind_UNRESERVED_KEYWORD,
+	"refresh":           KeywordKind_UNRESERVED_KEYWORD,
+	"reindex":           KeywordKind_UNRESERVED_KEYWORD,
+	"relative":          KeywordKind_UNRESERVED_KEYWORD,
+	"release":           KeywordKind_UNRESERVED_KEYWORD,
+	"rename":            KeywordKind_UNRESERVED_KEYWORD,
+	"repeatable":        KeywordKind_UNRESERVED_KEYWORD,
+	"replace":           KeywordKind_UNRESERVED_KEYWORD,
+	"replica":           KeywordKind_UNRESERVED_KEYWORD,
+	"reset":             KeywordKind_UNRESERVED_KEYWORD,
+	"restart":           KeywordKind_UNRESERVED_KEYWORD,
+	"restrict":          KeywordKind_UNRESERVED_KEYWORD,
+	"return":            KeywordKind_UNRESERVED_KEYWORD,
+	"returning":         KeywordKind_RESERVED_KEYWORD,
+	"returns":           KeywordKind_UNRESERVED_KEYWORD,
+	"revoke":            KeywordKind_UNRESERVED_KEYWORD,
+	"right":             KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"role":              KeywordKind_UNRESERVED_KEYWORD,
+	"rollback":          KeywordKind_UNRESERVED_KEYWORD,
+	"rollup":            KeywordKind_UNRESERVED_KEYWORD,
+	"routine":           KeywordKind_UNRESERVED_KEYWORD,
+	"routines":          KeywordKind_UNRESERVED_KEYWORD,
+	"row":               KeywordKind_COL_NAME_KEYWORD,
+	"rows":              KeywordKind_UNRESERVED_KEYWORD,
+	"rule":              KeywordKind_UNRESERVED_KEYWORD,
+	"savepoint":         KeywordKind_UNRESERVED_KEYWORD,
+	"scalar":            KeywordKind_UNRESERVED_KEYWORD,
+	"schema":            KeywordKind_UNRESERVED_KEYWORD,
+	"schemas":           KeywordKind_UNRESERVED_KEYWORD,
+	"scroll":            KeywordKind_UNRESERVED_KEYWORD,
+	"search":            KeywordKind_UNRESERVED_KEYWORD,
+	"second":            KeywordKind_UNRESERVED_KEYWORD,
+	"security":          KeywordKind_UNRESERVED_KEYWORD,
+	"select":            KeywordKind_RESERVED_KEYWORD,
+	"sequence":          KeywordKind_UNRESERVED_KEYWORD,
+	"sequences":         KeywordKind_UNRESERVED_KEYWORD,
+	"serializable":      KeywordKind_UNRESERVED_KEYWORD,
+	"server":            KeywordKind_UNRESERVED_KEYWORD,
+	"session":           KeywordKind_UNRESERVED_KEYWORD,
+	"session_user":      KeywordKind_RESERVED_KEYWORD,
+	"set":               KeywordKind_UNRESERVED_KEYWORD,
+	"setof":             KeywordKind_COL_NAME_KEYWORD,
+	"sets":              KeywordKind_UNRESERVED_KEYWORD,
+	"share":             KeywordKind_UNRESERVED_KEYWORD,
+	"show":              KeywordKind_UNRESERVED_KEYWORD,
+	"similar":           KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"simple":            KeywordKind_UNRESERVED_KEYWORD,
+	"skip":              KeywordKind_UNRESERVED_KEYWORD,
+	"smallint":          KeywordKind_COL_NAME_KEYWORD,
+	"snapshot":          KeywordKind_UNRESERVED_KEYWORD,
+	"some":              KeywordKind_RESERVED_KEYWORD,
+	"sql":               KeywordKind_UNRESERVED_KEYWORD,
+	"stable":            KeywordKind_UNRESERVED_KEYWORD,
+	"standalone":        KeywordKind_UNRESERVED_KEYWORD,
+	"start":             KeywordKind_UNRESERVED_KEYWORD,
+	"statement":         KeywordKind_UNRESERVED_KEYWORD,
+	"statistics":        KeywordKind_UNRESERVED_KEYWORD,
+	"stdin":             KeywordKind_UNRESERVED_KEYWORD,
+	"stdout":            KeywordKind_UNRESERVED_KEYWORD,
+	"storage":           KeywordKind_UNRESERVED_KEYWORD,
+	"stored":            KeywordKind_UNRESERVED_KEYWORD,
+	"strict":            KeywordKind_UNRESERVED_KEYWORD,
+	"strip":             KeywordKind_UNRESERVED_KEYWORD,
+	"subscription":      KeywordKind_UNRESERVED_KEYWORD,
+	"substring":         KeywordKind_COL_NAME_KEYWORD,
+	"support":           KeywordKind_UNRESERVED_KEYWORD,
+	"symmetric":         KeywordKind_RESERVED_KEYWORD,
+	"sysid":             KeywordKind_UNRESERVED_KEYWORD,
+	"system":            KeywordKind_UNRESERVED_KEYWORD,
+	"system_user":       KeywordKind_RESERVED_KEYWORD,
+	"table":             KeywordKind_RESERVED_KEYWORD,
+	"tables":            KeywordKind_UNRESERVED_KEYWORD,
+	"tablesample":       KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"tablespace":        KeywordKind_UNRESERVED_KEYWORD,
+	"temp":              KeywordKind_UNRESERVED_KEYWORD,
+	"template":          KeywordKind_UNRESERVED_KEYWORD,
+	"temporary":         KeywordKind_UNRESERVED_KEYWORD,
+	"text":              KeywordKind_UNRESERVED_KEYWORD,
+	"then":              KeywordKind_RESERVED_KEYWORD,
+	"ties":              KeywordKind_UNRESERVED_KEYWORD,
+	"time":              KeywordKind_COL_NAME_KEYWORD,
+	"timestamp":         KeywordKind_COL_NAME_KEYWORD,
+	"to":                KeywordKind_RESERVED_KEYWORD,
+	"trailing":          KeywordKind_RESERVED_KEYWORD,
+	"transaction":       KeywordKind_UNRESERVED_KEYWORD,
+	"transform":         KeywordKind_UNRESERVED_KEYWORD,
+	"treat":             KeywordKind_COL_NAME_KEYWORD,
+	"trigger":           KeywordKind_UNRESERVED_KEYWORD,
+	"trim":              KeywordKind_COL_NAME_KEYWORD,
+	"true":              KeywordKind_RESERVED_KEYWORD,
+	"truncate":          KeywordKind_UNRESERVED_KEYWORD,
+	"trusted":           KeywordKind_UNRESERVED_KEYWORD,
+	"type":              KeywordKind_UNRESERVED_KEYWORD,
+	"types":             KeywordKind_UNRESERVED_KEYWORD,
+	"uescape":           KeywordKind_UNRESERVED_KEYWORD,
+	"unbounded":         KeywordKind_UNRESERVED_KEYWORD,
+	"uncommitted":       KeywordKind_UNRESERVED_KEYWORD,
+	"unencrypted":       KeywordKind_UNRESERVED_KEYWORD,
+	"union":             KeywordKind_RESERVED_KEYWORD,
+	"unique":            KeywordKind_RESERVED_KEYWORD,
+	"unknown":           KeywordKind_UNRESERVED_KEYWORD,
+	"unlisten":          KeywordKind_UNRESERVED_KEYWORD,
+	"unlogged":          KeywordKind_UNRESERVED_KEYWORD,
+	"until":             KeywordKind_UNRESERVED_KEYWORD,
+	"update":            KeywordKind_UNRESERVED_KEYWORD,
+	"user":              KeywordKind_RESERVED_KEYWORD,
+	"using":             KeywordKind_RESERVED_KEYWORD,
+	"vacuum":            KeywordKind_UNRESERVED_KEYWORD,
+	"valid":             KeywordKind_UNRESERVED_KEYWORD,
+	"validate":          KeywordKind_UNRESERVED_KEYWORD,
+	"validator":         KeywordKind_UNRESERVED_KEYWORD,
+	"value":             KeywordKind_UNRESERVED_KEYWORD,
+	"values":            KeywordKind_COL_NAME_KEYWORD,
+	"varchar":           KeywordKind_COL_NAME_KEYWORD,
+	"variadic":          KeywordKind_RESERVED_KEYWORD,
+	"varying":           KeywordKind_UNRESERVED_KEYWORD,
+	"verbose":           KeywordKind_TYPE_FUNC_NAME_KEYWORD,
+	"version":           KeywordKind_UNRESERVED_KEYWORD,
+	"view":              KeywordKind_UNRESERVED_KEYWORD,
+	"views":             KeywordKind_UNRESERVED_KEYWORD,
+	"volatile":          KeywordKind_UNRESERVED_KEYWORD,
+	"when":              KeywordKind_RESERVED_KEYWORD,
+	"where":             KeywordKind_RESERVED_KEYWORD,
+	"whitespace":        KeywordKind_UNRESERVED_KEYWORD,
+	"window":            KeywordKind_RESERVED_KEYWORD,
+	"with":              KeywordKind_RESERVED_KEYWORD,
+	"within":            KeywordKind_UNRESERVED_KEYWORD,
+	"without":           KeywordKind_UNRESERVED_KEYWORD,
+	"work":              KeywordKind_UNRESERVED_KEYWORD,
+	"wrapper":           KeywordKind_UNRESERVED_KEYWORD,
+	"write":             KeywordKind_UNRESERVED_KEYWORD,
+	"xml":               KeywordKind_UNRESERVED_KEYWORD,
+	"xmlattributes":     KeywordKind_COL_NAME_KEYWORD,
+	"xmlconcat":         KeywordKind_COL_NAME_KEYWORD,
+	"xmlelement":        KeywordKind_COL_NAME_KEYWORD,
+	"xmlexists":         KeywordKind_COL_NAME_KEYWORD,
+	"xmlforest":         KeywordKind_COL_NAME_KEYWORD,
+	"xmlnamespaces":     KeywordKind_COL_NAME_KEYWORD,
+	"xmlparse":          KeywordKind_COL_NAME_KEYWORD,
+	"xmlpi":             KeywordKind_COL_NAME_KEYWORD,
+	"xmlroot":           KeywordKind_COL_NAME_KEYWORD,
+	"xmlserialize":      KeywordKind_COL_NAME_KEYWORD,
+	"xmltable":          KeywordKind_COL_NAME_KEYWORD,
+	"year":              KeywordKind_UNRESERVED_KEYWORD,
+	"yes":               KeywordKind_UNRESERVED_KEYWORD,
+	"zone":              KeywordKind_UNRESERVED_KEYWORD,
+}
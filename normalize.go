@@ -0,0 +1,738 @@
+package pg_query
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// LiteralKind is the kind of value of a Literal
+type LiteralKind int
+
+const (
+	// LiteralInteger is an integer constant, e.g. 42 or -1
+	LiteralInteger LiteralKind = iota
+	// LiteralFloat is a numeric constant that isn't an int4, e.g. 1.5 or 10000000000
+	LiteralFloat
+	// LiteralString is a string constant, e.g. 'abc', E'\n' or $$abc$$
+	LiteralString
+	// LiteralBitString is a bit string constant, e.g. B'0101' or X'1F'
+	LiteralBitString
+	// LiteralBoolean is TRUE or FALSE
+	LiteralBoolean
+	// LiteralNull is NULL
+	LiteralNull
+)
+
+func (k LiteralKind) String() string {
+	switch k {
+	case LiteralInteger:
+		return "integer"
+	case LiteralFloat:
+		return "float"
+	case LiteralString:
+		return "string"
+	case LiteralBitString:
+		return "bit string"
+	case LiteralBoolean:
+		return "boolean"
+	case LiteralNull:
+		return "NULL"
+	}
+	return "unknown"
+}
+
+// Literal is a constant replaced by a $n parameter reference during normalization
+type Literal struct {
+	// Param is the number n of the $n parameter reference replacing the constant
+	Param int
+	// Text is the source text of the constant, e.g. "'it''s'" or "-1"
+	Text string
+	// Value is the value of the constant: the unquoted string, the digits of a number,
+	// "true" or "false", or the bit string with its "b" or "x" prefix, e.g. "b0101". It's
+	// empty for NULL.
+	Value string
+	// Location is the byte offset of the constant in the query
+	Location int32
+	Kind     LiteralKind
+}
+
+// constLocation is a constant found by constLocator
+type constLocation struct {
+	// node is the A_Const node of the constant, if any
+	node     *Node
+	location int32
+	// param is the number of the parameter replacing the constant, or minus the number of
+	// a new parameter, to be added to the highest parameter number of the query
+	param int
+	kind  LiteralKind
+	value string
+}
+
+// constLocator finds the constants of a parse tree replaced by normalization, in the order
+// and with the parameter numbers of const_record_walker in pg_query_normalize.c
+type constLocator struct {
+	// query is the source text of the parse tree, or empty if it's unknown
+	query  string
+	consts []constLocation
+	// nextParam is the next new parameter number (highest_normalize_param_id)
+	nextParam int
+	// highestParam is the highest number of the parameter references of the query
+	highestParam int
+	// paramRefs records the parameter numbers assigned or found in a target list entry, if
+	// not nil
+	paramRefs []int
+}
+
+func newConstLocator(query string) *constLocator {
+	return &constLocator{query: query, nextParam: 1}
+}
+
+func (l *constLocator) record(node *Node, location int32, kind LiteralKind, value string) {
+	// -1 indicates unknown or undefined location
+	if location < 0 {
+		return
+	}
+	l.consts = append(l.consts, constLocation{node: node, location: location, param: -l.nextParam, kind: kind, value: value})
+	l.nextParam++
+	if l.paramRefs != nil {
+		l.paramRefs = append(l.paramRefs, -l.nextParam+1)
+	}
+}
+
+// recordDefElemArg records the string argument of an option, which has no location of its
+// own, at the first quote or dollar sign after the option name
+func (l *constLocator) recordDefElemArg(defElem *DefElem, arg *Node) {
+	i := strings.IndexAny(l.query[clampLocation(defElem.Location, l.query):], "'$")
+	if i >= 0 {
+		l.record(nil, int32(i)+clampLocation(defElem.Location, l.query), LiteralString, strVal(arg))
+	}
+}
+
+// recordMatchingString records a string constant of the query with the given value
+func (l *constLocator) recordMatchingString(value string) {
+	if value == "" {
+		return
+	}
+	if i := strings.Index(l.query, value); i >= 0 {
+		l.record(nil, int32(i)-1, LiteralString, value)
+	}
+}
+
+func clampLocation(location int32, query string) int32 {
+	if location < 0 {
+		return 0
+	}
+	if int(location) > len(query) {
+		return int32(len(query))
+	}
+	return location
+}
+
+func (l *constLocator) walkList(nodes []*Node) {
+	for _, node := range nodes {
+		l.walk(node)
+	}
+}
+
+// walk visits a node or message of the parse tree, like const_record_walker
+func (l *constLocator) walk(msg proto.Message) {
+	if msg == nil || !msg.ProtoReflect().IsValid() {
+		return
+	}
+	if node, ok := msg.(*Node); ok {
+		if aConst := node.GetAConst(); aConst != nil {
+			kind, value := constValue(aConst)
+			l.record(node, aConst.Location, kind, value)
+		} else if inner := nodeMessage(node); inner != nil {
+			l.walk(inner)
+		}
+		return
+	}
+
+	switch n := msg.(type) {
+	case *ParamRef:
+		if int(n.Number) > l.highestParam {
+			l.highestParam = int(n.Number)
+		}
+		if l.paramRefs != nil {
+			l.paramRefs = append(l.paramRefs, int(n.Number))
+		}
+	case *DefElem:
+		if list := n.Arg.GetList(); list != nil && len(list.Items) == 1 && list.Items[0].GetString_() != nil {
+			l.recordDefElemArg(n, list.Items[0])
+		} else if n.Arg.GetString_() != nil {
+			l.recordDefElemArg(n, n.Arg)
+		}
+		l.walk(n.Arg)
+	case *RawStmt:
+		l.walk(n.Stmt)
+	case *VariableSetStmt:
+		l.walkList(n.Args)
+	case *CopyStmt:
+		l.walk(n.Query)
+	case *ExplainStmt:
+		l.walk(n.Query)
+	case *CreateRoleStmt:
+		l.walkList(n.Options)
+	case *AlterRoleStmt:
+		l.walkList(n.Options)
+	case *DeclareCursorStmt:
+		l.walk(n.Query)
+	case *CreateFunctionStmt:
+		l.walkList(n.Options)
+	case *DoStmt:
+		l.walkList(n.Args)
+	case *CreateSubscriptionStmt:
+		l.recordMatchingString(n.Conninfo)
+	case *AlterSubscriptionStmt:
+		l.recordMatchingString(n.Conninfo)
+	case *CreateUserMappingStmt:
+		l.walkList(n.Options)
+	case *AlterUserMappingStmt:
+		l.walkList(n.Options)
+	case *TypeName:
+		// Don't normalize constants in typmods or arrayBounds
+	case *SelectStmt:
+		l.selectStmt(n)
+	default:
+		l.walkChildren(msg)
+	}
+}
+
+// targetParams holds the parameter numbers of the constants of a target list entry
+type targetParams struct {
+	val    *Node
+	params []int
+}
+
+func (l *constLocator) selectStmt(stmt *SelectStmt) {
+	l.walkList(stmt.DistinctClause)
+	l.walk(stmt.IntoClause)
+	var targets []targetParams
+	for _, target := range stmt.TargetList {
+		l.paramRefs = make([]int, 0, 1)
+		l.walk(target)
+		targets = append(targets, targetParams{val: target.GetResTarget().GetVal(), params: l.paramRefs})
+		l.paramRefs = nil
+	}
+	l.walkList(stmt.FromClause)
+	l.walk(stmt.WhereClause)
+
+	for _, item := range stmt.GroupClause {
+		// Keep GROUP BY 1, like pg_stat_statements does
+		if item.GetAConst().GetIval() != nil {
+			continue
+		}
+
+		// Give the constants of a GROUP BY item the parameters of the same expression in the
+		// target list, or the query would be invalid
+		var match *targetParams
+		for i := range targets {
+			if sameFingerprint(item, targets[i].val) {
+				match = &targetParams{val: targets[i].val, params: targets[i].params}
+				targets = append(targets[:i], targets[i+1:]...)
+				break
+			}
+		}
+		n := len(l.consts)
+		l.walk(item)
+		if match != nil && len(match.params) == len(l.consts)-n {
+			for i := n; i < len(l.consts); i++ {
+				l.consts[i].param = match.params[i-n]
+			}
+			l.nextParam -= len(match.params)
+		}
+	}
+	for _, item := range stmt.SortClause {
+		// Keep ORDER BY 1
+		if item.GetSortBy().GetNode().GetAConst().GetIval() != nil {
+			continue
+		}
+		l.walk(item)
+	}
+	l.walk(stmt.HavingClause)
+	l.walkList(stmt.WindowClause)
+	l.walkList(stmt.ValuesLists)
+	l.walk(stmt.LimitOffset)
+	l.walk(stmt.LimitCount)
+	l.walkList(stmt.LockingClause)
+	l.walk(stmt.WithClause)
+	l.walk(stmt.Larg)
+	l.walk(stmt.Rarg)
+}
+
+// walkChildren visits the children of the node types of raw_expression_tree_walker, in its
+// order; other nodes are left alone
+func (l *constLocator) walkChildren(msg proto.Message) {
+	switch n := msg.(type) {
+	case *List:
+		l.walkList(n.Items)
+	case *RangeVar:
+		l.walk(n.Alias)
+	case *GroupingFunc:
+		l.walkList(n.Args)
+	case *SubLink:
+		l.walk(n.Testexpr)
+		l.walk(n.Subselect)
+	case *CaseExpr:
+		l.walk(n.Arg)
+		for _, arg := range n.Args {
+			l.walk(arg.GetCaseWhen().GetExpr())
+			l.walk(arg.GetCaseWhen().GetResult())
+		}
+		l.walk(n.Defresult)
+	case *RowExpr:
+		l.walkList(n.Args)
+	case *CoalesceExpr:
+		l.walkList(n.Args)
+	case *MinMaxExpr:
+		l.walkList(n.Args)
+	case *XmlExpr:
+		l.walkList(n.NamedArgs)
+		l.walkList(n.Args)
+	case *JsonReturning:
+		l.walk(n.Format)
+	case *JsonValueExpr:
+		l.walk(n.RawExpr)
+		l.walk(n.FormattedExpr)
+		l.walk(n.Format)
+	case *JsonConstructorExpr:
+		l.walkList(n.Args)
+		l.walk(n.Func)
+		l.walk(n.Coercion)
+		l.walk(n.Returning)
+	case *JsonIsPredicate:
+		l.walk(n.Expr)
+	case *NullTest:
+		l.walk(n.Arg)
+	case *BooleanTest:
+		l.walk(n.Arg)
+	case *JoinExpr:
+		l.walk(n.Larg)
+		l.walk(n.Rarg)
+		l.walk(n.Quals)
+		l.walk(n.Alias)
+	case *IntoClause:
+		l.walk(n.Rel)
+		l.walk(n.ViewQuery)
+	case *InsertStmt:
+		l.walk(n.Relation)
+		l.walkList(n.Cols)
+		l.walk(n.SelectStmt)
+		l.walk(n.OnConflictClause)
+		l.walkList(n.ReturningList)
+		l.walk(n.WithClause)
+	case *DeleteStmt:
+		l.walk(n.Relation)
+		l.walkList(n.UsingClause)
+		l.walk(n.WhereClause)
+		l.walkList(n.ReturningList)
+		l.walk(n.WithClause)
+	case *UpdateStmt:
+		l.walk(n.Relation)
+		l.walkList(n.TargetList)
+		l.walk(n.WhereClause)
+		l.walkList(n.FromClause)
+		l.walkList(n.ReturningList)
+		l.walk(n.WithClause)
+	case *MergeStmt:
+		l.walk(n.Relation)
+		l.walk(n.SourceRelation)
+		l.walk(n.JoinCondition)
+		l.walkList(n.MergeWhenClauses)
+		l.walk(n.WithClause)
+	case *MergeWhenClause:
+		l.walk(n.Condition)
+		l.walkList(n.TargetList)
+		l.walkList(n.Values)
+	case *PLAssignStmt:
+		l.walkList(n.Indirection)
+		l.walk(n.Val)
+	case *A_Expr:
+		l.walk(n.Lexpr)
+		l.walk(n.Rexpr)
+	case *BoolExpr:
+		l.walkList(n.Args)
+	case *FuncCall:
+		l.walkList(n.Args)
+		l.walkList(n.AggOrder)
+		l.walk(n.AggFilter)
+		l.walk(n.Over)
+	case *NamedArgExpr:
+		l.walk(n.Arg)
+	case *A_Indices:
+		l.walk(n.Lidx)
+		l.walk(n.Uidx)
+	case *A_Indirection:
+		l.walk(n.Arg)
+		l.walkList(n.Indirection)
+	case *A_ArrayExpr:
+		l.walkList(n.Elements)
+	case *ResTarget:
+		l.walkList(n.Indirection)
+		l.walk(n.Val)
+	case *MultiAssignRef:
+		l.walk(n.Source)
+	case *TypeCast:
+		l.walk(n.Arg)
+		l.walk(n.TypeName)
+	case *CollateClause:
+		l.walk(n.Arg)
+	case *SortBy:
+		l.walk(n.Node)
+	case *WindowDef:
+		l.walkList(n.PartitionClause)
+		l.walkList(n.OrderClause)
+		l.walk(n.StartOffset)
+		l.walk(n.EndOffset)
+	case *RangeSubselect:
+		l.walk(n.Subquery)
+		l.walk(n.Alias)
+	case *RangeFunction:
+		l.walkList(n.Functions)
+		l.walk(n.Alias)
+		l.walkList(n.Coldeflist)
+	case *RangeTableSample:
+		l.walk(n.Relation)
+		l.walkList(n.Args)
+		l.walk(n.Repeatable)
+	case *RangeTableFunc:
+		l.walk(n.Docexpr)
+		l.walk(n.Rowexpr)
+		l.walkList(n.Namespaces)
+		l.walkList(n.Columns)
+		l.walk(n.Alias)
+	case *RangeTableFuncCol:
+		l.walk(n.Colexpr)
+		l.walk(n.Coldefexpr)
+	case *ColumnDef:
+		l.walk(n.TypeName)
+		l.walk(n.RawDefault)
+		l.walk(n.CollClause)
+	case *IndexElem:
+		l.walk(n.Expr)
+	case *GroupingSet:
+		l.walkList(n.Content)
+	case *LockingClause:
+		l.walkList(n.LockedRels)
+	case *XmlSerialize:
+		l.walk(n.Expr)
+		l.walk(n.TypeName)
+	case *WithClause:
+		l.walkList(n.Ctes)
+	case *InferClause:
+		l.walkList(n.IndexElems)
+		l.walk(n.WhereClause)
+	case *OnConflictClause:
+		l.walk(n.Infer)
+		l.walkList(n.TargetList)
+		l.walk(n.WhereClause)
+	case *CommonTableExpr:
+		l.walk(n.Ctequery)
+	case *JsonOutput:
+		l.walk(n.TypeName)
+		l.walk(n.Returning)
+	case *JsonKeyValue:
+		l.walk(n.Key)
+		l.walk(n.Value)
+	case *JsonObjectConstructor:
+		l.walk(n.Output)
+		l.walkList(n.Exprs)
+	case *JsonArrayConstructor:
+		l.walk(n.Output)
+		l.walkList(n.Exprs)
+	case *JsonAggConstructor:
+		l.walk(n.Output)
+		l.walkList(n.AggOrder)
+		l.walk(n.AggFilter)
+		l.walk(n.Over)
+	case *JsonObjectAgg:
+		l.walk(n.Constructor)
+		l.walk(n.Arg)
+	case *JsonArrayAgg:
+		l.walk(n.Constructor)
+		l.walk(n.Arg)
+	case *JsonArrayQueryConstructor:
+		l.walk(n.Output)
+		l.walk(n.Query)
+	}
+}
+
+// nodeMessage returns the message held by a node, or nil if it's empty
+func nodeMessage(node *Node) proto.Message {
+	msg := node.ProtoReflect()
+	field := msg.WhichOneof(msg.Descriptor().Oneofs().ByName("node"))
+	if field == nil {
+		return nil
+	}
+	return msg.Get(field).Message().Interface()
+}
+
+// constValue returns the kind and value of a constant
+func constValue(aConst *A_Const) (LiteralKind, string) {
+	switch {
+	case aConst.Isnull:
+		return LiteralNull, ""
+	case aConst.GetIval() != nil:
+		return LiteralInteger, strconv.Itoa(int(aConst.GetIval().Ival))
+	case aConst.GetFval() != nil:
+		return LiteralFloat, aConst.GetFval().Fval
+	case aConst.GetBoolval() != nil:
+		return LiteralBoolean, strconv.FormatBool(aConst.GetBoolval().Boolval)
+	case aConst.GetBsval() != nil:
+		return LiteralBitString, aConst.GetBsval().Bsval
+	}
+	return LiteralString, aConst.GetSval().GetSval()
+}
+
+// locateConsts returns the constants replaced by normalization of a query, ordered by
+// location, and the highest number of the parameter references of the query
+func locateConsts(query string, tree *ParseResult, tokens []*ScanToken) ([]Literal, int) {
+	locator := newConstLocator(query)
+	for _, stmt := range tree.GetStmts() {
+		locator.walk(stmt)
+	}
+	return locator.literals(tokens), locator.highestParam
+}
+
+// literals fills in the source text of the constants found by the locator, using the
+// tokens of the query like fill_in_constant_lengths in pg_query_normalize.c, and returns
+// them ordered by location
+//
+// Constants found twice at the same location are only returned once.
+func (l *constLocator) literals(tokens []*ScanToken) []Literal {
+	consts := append([]constLocation(nil), l.consts...)
+	sort.SliceStable(consts, func(i, j int) bool {
+		return consts[i].location < consts[j].location
+	})
+
+	var literals []Literal
+	last := int32(-1)
+	t := 0
+	for _, c := range consts {
+		if c.location <= last {
+			continue
+		}
+		for t < len(tokens) && tokens[t].Start < c.location {
+			t++
+		}
+		// A negative number is a minus sign and a number token
+		if t < len(tokens) && l.query[c.location] == '-' {
+			t++
+		}
+		if t >= len(tokens) {
+			break
+		}
+		text := l.query[c.location:tokens[t].End]
+		if len(text) > 4 && (text[0] == 'u' || text[0] == 'U') && text[1] == '&' && text[2] == '\'' {
+			// The lexer consumes the whitespace after a Unicode string looking for UESCAPE
+			text = strings.TrimRight(text, " \t\n\r\f\v")
+		}
+		literals = append(literals, Literal{Param: l.param(c), Text: text, Value: c.value, Location: c.location, Kind: c.kind})
+		last = c.location
+	}
+	return literals
+}
+
+// NormalizeTree returns a copy of a parse tree with its constants replaced by ParamRef nodes,
+// numbered like Normalize numbers them, and the constants it replaced ordered by location
+//
+// The tree doesn't hold the source text of the constants, so the Text of the literals is
+// their deparsed text (e.g. 'abc' for $$abc$$). The string arguments of options, e.g. of
+// PASSWORD 'secret', which Normalize replaces too, are kept since they aren't A_Const nodes.
+func NormalizeTree(tree *ParseResult) (*ParseResult, []Literal) {
+	tree = proto.Clone(tree).(*ParseResult)
+	locator := newConstLocator("")
+	for _, stmt := range tree.GetStmts() {
+		locator.walk(stmt)
+	}
+
+	consts := append([]constLocation(nil), locator.consts...)
+	sort.SliceStable(consts, func(i, j int) bool {
+		return consts[i].location < consts[j].location
+	})
+	var literals []Literal
+	for _, c := range consts {
+		if c.node == nil {
+			continue
+		}
+		// Copies of a constant share the parameter of the first one, like in Normalize
+		n := len(literals)
+		if n == 0 || literals[n-1].Location != c.location {
+			text, _ := DeparseNode(c.node)
+			literals = append(literals, Literal{Param: locator.param(c), Text: text, Value: c.value, Location: c.location, Kind: c.kind})
+			n++
+		}
+		c.node.Node = &Node_ParamRef{ParamRef: &ParamRef{Number: int32(literals[n-1].Param), Location: c.location}}
+	}
+	return tree, literals
+}
+
+// param returns the number of the parameter replacing a constant
+func (l *constLocator) param(c constLocation) int {
+	if c.param < 0 {
+		return l.highestParam - c.param
+	}
+	return c.param
+}
+
+// replaceLiterals returns the query with the literals replaced by their parameters
+func replaceLiterals(query string, literals []Literal) string {
+	var b strings.Builder
+	offset := 0
+	for _, literal := range literals {
+		b.WriteString(query[offset:literal.Location])
+		fmt.Fprintf(&b, "$%d", literal.Param)
+		offset = int(literal.Location) + len(literal.Text)
+	}
+	b.WriteString(query[offset:])
+	return b.String()
+}
+
+// ParamStyle is the style of the parameter references replacing constants
+type ParamStyle int
+
+const (
+	// ParamStyleDollar numbers parameter references like PostgreSQL does: $1, $2, ...
+	ParamStyleDollar ParamStyle = iota
+	// ParamStyleQuestion replaces all constants with ?
+	ParamStyleQuestion
+)
+
+// NormalizeOptions changes which constants NormalizeWithOptions replaces, and how
+//
+// The zero value normalizes like Normalize. ORDER BY and GROUP BY ordinals (e.g. ORDER BY 1)
+// are always kept, since replacing them would change the meaning of the query.
+type NormalizeOptions struct {
+	// CollapseLists replaces IN (...) lists and VALUES lists made only of constants with a
+	// single parameter reference, whatever their length, e.g. a IN ($1) or VALUES ($1)
+	CollapseLists bool
+	// KeepIntegersBelow keeps the integer constants whose absolute value is below it, e.g. 2
+	// keeps -1, 0 and 1
+	KeepIntegersBelow int64
+	// KeepBooleans keeps TRUE and FALSE
+	KeepBooleans bool
+	// ParamStyle is the style of the parameter references replacing constants. Parameter
+	// references of the query itself are left as they are.
+	ParamStyle ParamStyle
+}
+
+// keep returns whether a literal is left in the query
+func (o NormalizeOptions) keep(literal Literal) bool {
+	switch literal.Kind {
+	case LiteralInteger:
+		value, err := strconv.ParseInt(literal.Value, 10, 64)
+		return err == nil && value < o.KeepIntegersBelow && -value < o.KeepIntegersBelow
+	case LiteralBoolean:
+		return o.KeepBooleans
+	}
+	return false
+}
+
+// placeholder is the replacement of a constant, or of a list of constants, in the query
+type placeholder struct {
+	start, end int
+	param      int
+}
+
+// normalizeWithOptions replaces the literals of a query, found by NormalizeWithValues, as
+// specified by the options
+func normalizeWithOptions(query string, tree *ParseResult, literals []Literal, highestParam int, options NormalizeOptions) string {
+	var lists map[int32]int32
+	if options.CollapseLists {
+		lists = constLists(tree)
+	}
+
+	var placeholders []placeholder
+	for _, literal := range literals {
+		// The first constant of a list is replaced by a placeholder covering the whole list
+		if n := len(placeholders); n > 0 && int(literal.Location) < placeholders[n-1].end {
+			continue
+		}
+		end := int(literal.Location) + len(literal.Text)
+		if last, ok := lists[literal.Location]; ok {
+			for _, l := range literals {
+				if l.Location == last {
+					end = int(l.Location) + len(l.Text)
+				}
+			}
+		} else if options.keep(literal) {
+			continue
+		}
+		placeholders = append(placeholders, placeholder{start: int(literal.Location), end: end, param: literal.Param})
+	}
+
+	// Number the new parameters left without gaps, in the order Normalize numbers them
+	var params []int
+	for _, p := range placeholders {
+		if p.param > highestParam {
+			params = append(params, p.param)
+		}
+	}
+	sort.Ints(params)
+	numbers := map[int]int{}
+	for _, param := range params {
+		if _, ok := numbers[param]; !ok {
+			numbers[param] = highestParam + len(numbers) + 1
+		}
+	}
+
+	var b strings.Builder
+	offset := 0
+	for _, p := range placeholders {
+		b.WriteString(query[offset:p.start])
+		switch {
+		case options.ParamStyle == ParamStyleQuestion:
+			b.WriteString("?")
+		case p.param > highestParam:
+			fmt.Fprintf(&b, "$%d", numbers[p.param])
+		default:
+			fmt.Fprintf(&b, "$%d", p.param)
+		}
+		offset = p.end
+	}
+	b.WriteString(query[offset:])
+	return b.String()
+}
+
+// constLists returns the location of the last constant of the IN (...) and VALUES lists made
+// only of constants, by the location of their first constant
+func constLists(tree *ParseResult) map[int32]int32 {
+	lists := map[int32]int32{}
+	add := func(items []*Node) {
+		if len(items) == 0 {
+			return
+		}
+		for _, item := range items {
+			if item.GetAConst() == nil {
+				return
+			}
+		}
+		lists[items[0].GetAConst().Location] = items[len(items)-1].GetAConst().Location
+	}
+	for _, stmt := range tree.GetStmts() {
+		_ = Walk(func(node *Node) (bool, error) {
+			switch n := node.Node.(type) {
+			case *Node_AExpr:
+				if n.AExpr.Kind == A_Expr_Kind_AEXPR_IN {
+					add(n.AExpr.Rexpr.GetList().GetItems())
+				}
+			case *Node_SelectStmt:
+				var items []*Node
+				for _, row := range n.SelectStmt.ValuesLists {
+					if len(row.GetList().GetItems()) == 0 {
+						return true, nil
+					}
+					items = append(items, row.GetList().GetItems()...)
+				}
+				add(items)
+			}
+			return true, nil
+		}, stmt.GetStmt())
+	}
+	return lists
+}
@@ -94,3 +94,220 @@ func TestNormalizeUtility(t *testing.T) {
 		}
 	}
 }
+
+var normalizeWithValuesTests = []struct {
+	input         string
+	expectedQuery string
+	expectedArgs  []pg_query.Literal
+}{
+	{
+		"SELECT 1",
+		"SELECT $1",
+		[]pg_query.Literal{{Param: 1, Text: "1", Value: "1", Location: 7, Kind: pg_query.LiteralInteger}},
+	},
+	{
+		"SELECT -1, 1.5, 'it''s', B'01', X'1F', true, NULL",
+		"SELECT $1, $2, $3, $4, $5, $6, $7",
+		[]pg_query.Literal{
+			{Param: 1, Text: "-1", Value: "-1", Location: 7, Kind: pg_query.LiteralInteger},
+			{Param: 2, Text: "1.5", Value: "1.5", Location: 11, Kind: pg_query.LiteralFloat},
+			{Param: 3, Text: "'it''s'", Value: "it's", Location: 16, Kind: pg_query.LiteralString},
+			{Param: 4, Text: "B'01'", Value: "b01", Location: 25, Kind: pg_query.LiteralBitString},
+			{Param: 5, Text: "X'1F'", Value: "x1F", Location: 32, Kind: pg_query.LiteralBitString},
+			{Param: 6, Text: "true", Value: "true", Location: 39, Kind: pg_query.LiteralBoolean},
+			{Param: 7, Text: "NULL", Value: "", Location: 45, Kind: pg_query.LiteralNull},
+		},
+	},
+	{
+		"SELECT a FROM x WHERE b = $1 LIMIT 10",
+		"SELECT a FROM x WHERE b = $1 LIMIT $2",
+		[]pg_query.Literal{{Param: 2, Text: "10", Value: "10", Location: 35, Kind: pg_query.LiteralInteger}},
+	},
+	{
+		"SELECT a + 1 FROM x GROUP BY a + 1 ORDER BY 1",
+		"SELECT a + $1 FROM x GROUP BY a + $1 ORDER BY 1",
+		[]pg_query.Literal{
+			{Param: 1, Text: "1", Value: "1", Location: 11, Kind: pg_query.LiteralInteger},
+			{Param: 1, Text: "1", Value: "1", Location: 33, Kind: pg_query.LiteralInteger},
+		},
+	},
+	{
+		"WITH a AS (SELECT 1) UPDATE x SET b = 2",
+		"WITH a AS (SELECT $2) UPDATE x SET b = $1",
+		[]pg_query.Literal{
+			{Param: 2, Text: "1", Value: "1", Location: 18, Kind: pg_query.LiteralInteger},
+			{Param: 1, Text: "2", Value: "2", Location: 38, Kind: pg_query.LiteralInteger},
+		},
+	},
+	{
+		"ALTER ROLE postgres PASSWORD 'xyz'",
+		"ALTER ROLE postgres PASSWORD $1",
+		[]pg_query.Literal{{Param: 1, Text: "'xyz'", Value: "xyz", Location: 29, Kind: pg_query.LiteralString}},
+	},
+	{
+		"SELECT x::varchar(10) FROM y",
+		"SELECT x::varchar(10) FROM y",
+		nil,
+	},
+}
+
+func TestNormalizeWithValues(t *testing.T) {
+	for _, test := range normalizeWithValuesTests {
+		query, args, err := pg_query.NormalizeWithValues(test.input)
+
+		if err != nil {
+			t.Errorf("NormalizeWithValues(%s)\nerror %s\n\n", test.input, err)
+		} else if query != test.expectedQuery || !reflect.DeepEqual(args, test.expectedArgs) {
+			t.Errorf("NormalizeWithValues(%s)\nexpected %s %+v\nactual %s %+v\n\n", test.input, test.expectedQuery, test.expectedArgs, query, args)
+		}
+	}
+}
+
+func TestNormalizeWithValuesMatchesNormalize(t *testing.T) {
+	for _, input := range deparseCompareTests {
+		expected, err := pg_query.Normalize(input)
+		if err != nil {
+			continue
+		}
+		actual, _, err := pg_query.NormalizeWithValues(input)
+
+		if err != nil {
+			t.Errorf("NormalizeWithValues(%s)\nerror %s\n\n", input, err)
+		} else if actual != expected {
+			t.Errorf("NormalizeWithValues(%s)\nexpected %s\nactual %s\n\n", input, expected, actual)
+		}
+	}
+}
+
+var normalizeWithOptionsTests = []struct {
+	input    string
+	options  pg_query.NormalizeOptions
+	expected string
+}{
+	{
+		"SELECT * FROM x WHERE a IN (1, 2, 3) AND b = true LIMIT 5",
+		pg_query.NormalizeOptions{},
+		"SELECT * FROM x WHERE a IN ($1, $2, $3) AND b = $4 LIMIT $5",
+	},
+	{
+		"SELECT * FROM x WHERE a IN (1, 2, 3) AND b NOT IN ('c') AND d IN (1, d)",
+		pg_query.NormalizeOptions{CollapseLists: true},
+		"SELECT * FROM x WHERE a IN ($1) AND b NOT IN ($2) AND d IN ($3, d)",
+	},
+	{
+		"INSERT INTO x VALUES (1, 'a'), (2, 'b'), (3, 'c')",
+		pg_query.NormalizeOptions{CollapseLists: true},
+		"INSERT INTO x VALUES ($1)",
+	},
+	{
+		"INSERT INTO x VALUES (1, now())",
+		pg_query.NormalizeOptions{CollapseLists: true},
+		"INSERT INTO x VALUES ($1, now())",
+	},
+	{
+		"SELECT * FROM x WHERE a = 1 AND b = -100 AND c = false LIMIT 10 OFFSET 100",
+		pg_query.NormalizeOptions{KeepIntegersBelow: 100, KeepBooleans: true},
+		"SELECT * FROM x WHERE a = 1 AND b = $1 AND c = false LIMIT 10 OFFSET $2",
+	},
+	{
+		"SELECT a + 1, 'b' FROM x WHERE c = $1 GROUP BY a + 1, 2 ORDER BY 1",
+		pg_query.NormalizeOptions{},
+		"SELECT a + $2, $3 FROM x WHERE c = $1 GROUP BY a + $2, 2 ORDER BY 1",
+	},
+	{
+		"SELECT * FROM x WHERE a IN (1, 2) AND b = 'c' AND d = $1",
+		pg_query.NormalizeOptions{CollapseLists: true, ParamStyle: pg_query.ParamStyleQuestion},
+		"SELECT * FROM x WHERE a IN (?) AND b = ? AND d = $1",
+	},
+}
+
+func TestNormalizeWithOptions(t *testing.T) {
+	for _, test := range normalizeWithOptionsTests {
+		actual, err := pg_query.NormalizeWithOptions(test.input, test.options)
+
+		if err != nil {
+			t.Errorf("NormalizeWithOptions(%s, %+v)\nerror %s\n\n", test.input, test.options, err)
+		} else if actual != test.expected {
+			t.Errorf("NormalizeWithOptions(%s, %+v)\nexpected %s\nactual %s\n\n", test.input, test.options, test.expected, actual)
+		}
+	}
+}
+
+var normalizeTreeTests = []struct {
+	input         string
+	expectedQuery string
+	expectedArgs  []pg_query.Literal
+}{
+	{
+		"SELECT a + 1, $$x$$ FROM y WHERE b = $1 GROUP BY a + 1 ORDER BY 1",
+		"SELECT a + $2, $3 FROM y WHERE b = $1 GROUP BY a + $2 ORDER BY 1",
+		[]pg_query.Literal{
+			{Param: 2, Text: "1", Value: "1", Location: 11, Kind: pg_query.LiteralInteger},
+			{Param: 3, Text: "'x'", Value: "x", Location: 14, Kind: pg_query.LiteralString},
+			{Param: 2, Text: "1", Value: "1", Location: 53, Kind: pg_query.LiteralInteger},
+		},
+	},
+	{
+		"SELECT -1.5, NULL, false",
+		"SELECT $1, $2, $3",
+		[]pg_query.Literal{
+			{Param: 1, Text: "-1.5", Value: "-1.5", Location: 7, Kind: pg_query.LiteralFloat},
+			{Param: 2, Text: "NULL", Value: "", Location: 13, Kind: pg_query.LiteralNull},
+			{Param: 3, Text: "false", Value: "false", Location: 19, Kind: pg_query.LiteralBoolean},
+		},
+	},
+	{
+		"ALTER ROLE postgres PASSWORD 'xyz'",
+		"ALTER ROLE postgres WITH PASSWORD 'xyz'",
+		nil,
+	},
+}
+
+func TestNormalizeTree(t *testing.T) {
+	for _, test := range normalizeTreeTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+		normalized, args := pg_query.NormalizeTree(tree)
+		query, err := pg_query.Deparse(normalized)
+
+		if err != nil {
+			t.Errorf("NormalizeTree(%s)\nerror %s\n\n", test.input, err)
+		} else if query != test.expectedQuery || !reflect.DeepEqual(args, test.expectedArgs) {
+			t.Errorf("NormalizeTree(%s)\nexpected %s %+v\nactual %s %+v\n\n", test.input, test.expectedQuery, test.expectedArgs, query, args)
+		}
+	}
+}
+
+func TestNormalizeTreeMatchesNormalize(t *testing.T) {
+	for _, input := range deparseCompareTests {
+		normalized, err := pg_query.Normalize(input)
+		if err != nil {
+			continue
+		}
+		// Normalized utility statements may not parse again, e.g. VALID UNTIL $1
+		normalizedTree, err := pg_query.Parse(normalized)
+		if err != nil {
+			continue
+		}
+		expected, err := pg_query.Deparse(normalizedTree)
+		if err != nil {
+			continue
+		}
+		tree, err := pg_query.Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", input, err)
+			continue
+		}
+		actualTree, _ := pg_query.NormalizeTree(tree)
+		actual, err := pg_query.Deparse(actualTree)
+
+		if err != nil {
+			t.Errorf("NormalizeTree(%s)\nerror %s\n\n", input, err)
+		} else if actual != expected {
+			t.Errorf("NormalizeTree(%s)\nexpected %s\nactual %s\n\n", input, expected, actual)
+		}
+	}
+}
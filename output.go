@@ -0,0 +1,85 @@
+package pg_query
+
+import "fmt"
+
+// ResultColumnKind describes where the values of an output column come from
+type ResultColumnKind int
+
+const (
+	// ResultColumnTable is a column reading a table column
+	ResultColumnTable ResultColumnKind = iota
+	// ResultColumnExpression is a column computed by an expression
+	ResultColumnExpression
+	// ResultColumnConstant is a column holding a constant, e.g. 1 or 'a'::text
+	ResultColumnConstant
+)
+
+func (k ResultColumnKind) String() string {
+	switch k {
+	case ResultColumnTable:
+		return "table"
+	case ResultColumnExpression:
+		return "expression"
+	case ResultColumnConstant:
+		return "constant"
+	}
+	return "unknown"
+}
+
+// ResultColumn describes an output column of a query
+type ResultColumn struct {
+	Name string
+	Kind ResultColumnKind
+	// Type is the deparsed type name of the column, e.g. "int" or "varchar(20)", or "" if
+	// it's unknown
+	Type string
+	// Source is the FROM-clause item the column is read from, or nil if it's computed
+	Source *ColumnSource
+	// Origin is the table column the output column reads, traced through subqueries and
+	// CTEs, or nil if it's computed
+	Origin *ColumnOrigin
+}
+
+// OutputColumns returns the output columns of a statement, as a RowDescription would
+// report them: those of a SELECT, set operation or VALUES list, or the RETURNING list of
+// an INSERT, UPDATE or DELETE
+//
+// Columns are named after their ResTarget names like PostgreSQL does, e.g. "count" or
+// "?column?", and star references are expanded with the columns of the catalog. The type
+// of a column is known if it reads a table column, or is a constant or a type cast.
+// INSERT, UPDATE and DELETE statements without RETURNING, and MERGE statements, which
+// can't have one before PostgreSQL 17, have no output columns.
+//
+// An error is returned for other statements, and if a star covers a table missing in the
+// catalog or a function whose columns are unknown.
+func OutputColumns(stmt *Node, schema Catalog) ([]ResultColumn, error) {
+	if !isQuery(stmt) {
+		return nil, fmt.Errorf("%s has no output columns", nodeName(stmt))
+	}
+	r := &columnResolver{catalog: schema, resolved: map[*Node]scopeColumn{}, stars: map[*Node][]*Node{}}
+	columns, _ := r.stmt(stmt, nil)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return resultColumns(columns), nil
+}
+
+func resultColumns(columns []scopeColumn) []ResultColumn {
+	var results []ResultColumn
+	for _, column := range columns {
+		result := ResultColumn{
+			Name:   column.name,
+			Kind:   ResultColumnExpression,
+			Type:   column.typ,
+			Source: column.source,
+			Origin: column.origin,
+		}
+		if column.origin != nil {
+			result.Kind = ResultColumnTable
+		} else if column.constant {
+			result.Kind = ResultColumnConstant
+		}
+		results = append(results, result)
+	}
+	return results
+}
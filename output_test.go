@@ -0,0 +1,144 @@
+//go:build cgo
+// +build cgo
+
+package pg_query_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+)
+
+var outputColumnsTests = []struct {
+	input    string
+	expected []string
+}{
+	{
+		"SELECT u.id, email AS address, 1, 'a'::varchar(10) AS a, count(*) FROM users u",
+		[]string{
+			"id table text public.users.id",
+			"address table text public.users.email",
+			"?column? constant int",
+			"a constant varchar(10)",
+			"count expression",
+		},
+	},
+	{
+		"SELECT * FROM orders",
+		[]string{"id table text public.orders.id", "user_id table text public.orders.user_id", "total table text public.orders.total"},
+	},
+	{
+		"SELECT id FROM users UNION SELECT user_id FROM orders",
+		[]string{"id expression text"},
+	},
+	{
+		"SELECT id, 1.5 FROM users UNION ALL SELECT id, 2.5 FROM users",
+		[]string{"id table text public.users.id", "?column? constant numeric"},
+	},
+	{
+		"SELECT 2147483648 AS a, -9223372036854775808 AS b, 9223372036854775808 AS c",
+		[]string{"a constant bigint", "b constant bigint", "c constant numeric"},
+	},
+	{
+		"SELECT 0x80000000 AS a, 0o7777777777777 AS b, -0b11111111111111111111111111111111 AS c, 1_000_000_000_000 AS d, 0x8000000000000000 AS e, 010000000000 AS f",
+		[]string{"a constant bigint", "b constant bigint", "c constant bigint", "d constant bigint", "e constant numeric", "f constant bigint"},
+	},
+	{
+		"SELECT * FROM (SELECT 1, 2) s",
+		[]string{"?column? constant int", "?column? constant int"},
+	},
+	{
+		"SELECT * FROM (users u JOIN orders o ON true) j",
+		[]string{
+			"id table text public.users.id", "email table text public.users.email", "name table text public.users.name",
+			"id table text public.orders.id", "user_id table text public.orders.user_id", "total table text public.orders.total",
+		},
+	},
+	{
+		"VALUES (1, 'a'), (2, NULL)",
+		[]string{"column1 constant int", "column2 constant text"},
+	},
+	{
+		"SELECT s.n, s.total * 2 AS double FROM (SELECT 1 AS n, total FROM orders) s",
+		[]string{"n constant int", "double expression"},
+	},
+	{
+		"INSERT INTO users (email) VALUES ('a') RETURNING id, now() AS created",
+		[]string{"id table text public.users.id", "created expression"},
+	},
+	{
+		"UPDATE orders SET total = 0 RETURNING *",
+		[]string{"id table text public.orders.id", "user_id table text public.orders.user_id", "total table text public.orders.total"},
+	},
+	{
+		"DELETE FROM orders RETURNING total::numeric(10,2)",
+		[]string{"total expression numeric(10, 2)"},
+	},
+	{
+		"DELETE FROM orders",
+		nil,
+	},
+	{
+		"MERGE INTO orders o USING users u ON o.user_id = u.id WHEN MATCHED THEN DELETE",
+		nil,
+	},
+}
+
+func describeResultColumn(column pg_query.ResultColumn) string {
+	description := column.Name + " " + column.Kind.String()
+	if column.Type != "" {
+		description += " " + column.Type
+	}
+	if column.Origin != nil {
+		description += fmt.Sprintf(" %s.%s.%s", column.Origin.Schema, column.Origin.Table, column.Origin.Column)
+	}
+	return description
+}
+
+func TestOutputColumns(t *testing.T) {
+	for _, test := range outputColumnsTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+		columns, err := pg_query.OutputColumns(tree.Stmts[0].Stmt, resolveCatalog)
+		if err != nil {
+			t.Errorf("OutputColumns(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		var actual []string
+		for _, column := range columns {
+			actual = append(actual, describeResultColumn(column))
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("OutputColumns(%s)\nexpected %q\nactual %q\n\n", test.input, test.expected, actual)
+		}
+	}
+}
+
+var outputColumnsErrorTests = []struct {
+	input    string
+	expected string
+}{
+	{"SELECT * FROM logs", `cannot expand "*": the columns of "logs" are unknown`},
+	{"SELECT x.* FROM users", `missing FROM-clause entry for table "x"`},
+	{"CREATE TABLE t (a int)", "CreateStmt has no output columns"},
+}
+
+func TestOutputColumnsErrors(t *testing.T) {
+	for _, test := range outputColumnsErrorTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+		_, err = pg_query.OutputColumns(tree.Stmts[0].Stmt, resolveCatalog)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("OutputColumns(%s)\nexpected %s\nactual %v\n\n", test.input, test.expected, err)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package pg_query
+
+import "sort"
+
+// ParamContext describes how a parameter is bound to a column or clause
+type ParamContext int
+
+const (
+	// ParamContextInsert is a value inserted into a column by INSERT or MERGE ... THEN INSERT
+	ParamContextInsert ParamContext = iota
+	// ParamContextComparison is a value compared to a column, e.g. col = $1 or col IN ($1, $2)
+	ParamContextComparison
+	// ParamContextAssignment is a value assigned to a column by UPDATE ... SET, ON CONFLICT DO
+	// UPDATE SET or MERGE ... THEN UPDATE SET
+	ParamContextAssignment
+	// ParamContextLimit is the row count of a LIMIT or FETCH FIRST clause
+	ParamContextLimit
+	// ParamContextOffset is the row count of an OFFSET clause
+	ParamContextOffset
+)
+
+func (c ParamContext) String() string {
+	switch c {
+	case ParamContextInsert:
+		return "insert"
+	case ParamContextComparison:
+		return "comparison"
+	case ParamContextAssignment:
+		return "assignment"
+	case ParamContextLimit:
+		return "limit"
+	case ParamContextOffset:
+		return "offset"
+	}
+	return "unknown"
+}
+
+// ParamBinding is a use of a parameter bound to a column, or to LIMIT or OFFSET
+type ParamBinding struct {
+	Context ParamContext
+	// Schema, Table and Column name the column the parameter is bound to. Schema is empty if
+	// the table isn't schema-qualified, Table is empty if the column doesn't come from a
+	// table (e.g. a subquery output column), and all are empty for LIMIT and OFFSET.
+	Schema string
+	Table  string
+	Column string
+	// Position is the 1-based position of the column in the column list of an INSERT, or
+	// in the table's columns if the list is omitted (Column is then empty), or 0
+	Position int
+	// Location is the byte offset of the parameter in the query
+	Location int32
+}
+
+// ParamInfo describes how a parameter ($1, $2, ...) is used by a parse tree
+type ParamInfo struct {
+	Number int32
+	// Type is the type name of the first explicit cast of the parameter, e.g. "int" for
+	// $1::int, or "" if it's never cast
+	Type     string
+	Bindings []ParamBinding
+	// Locations are the byte offsets of all references to the parameter in the query
+	Locations []int32
+}
+
+// comparisonOperators are the operators of A_Expr nodes binding a parameter to a column
+var comparisonOperators = map[string]bool{"=": true, "<>": true, "!=": true, "<": true, ">": true, "<=": true, ">=": true}
+
+// AnalyzeParams returns the parameters referenced by a parse tree, ordered by number, with
+// the columns they are bound to and their explicit type casts
+//
+// A parameter is bound to a column when it's the value of an INSERT column, the new value
+// of an UPDATE ... SET column, or compared to a column with a comparison operator, IN,
+// BETWEEN, LIKE, IS DISTINCT FROM or = ANY(...). It's also bound to the LIMIT and OFFSET
+// clauses using it. Column references are resolved to their tables without a catalog, so
+// a column of an unqualified reference is only known to come from a table if the query
+// has a single FROM-clause item providing it.
+func AnalyzeParams(tree *ParseResult) []ParamInfo {
+	a := &paramAnalyzer{params: map[int32]*ParamInfo{}, origins: map[*Node]*ColumnOrigin{}, castLocation: map[int32]int32{}}
+	for _, binding := range ResolveColumns(tree, nil) {
+		a.origins[binding.Ref] = binding.Origin
+	}
+	for _, stmt := range tree.GetStmts() {
+		_ = Walk(a.visit, stmt.GetStmt())
+	}
+
+	infos := make([]ParamInfo, 0, len(a.params))
+	for _, info := range a.params {
+		sort.Slice(info.Bindings, func(i, j int) bool {
+			return info.Bindings[i].Location < info.Bindings[j].Location
+		})
+		sort.Slice(info.Locations, func(i, j int) bool {
+			return info.Locations[i] < info.Locations[j]
+		})
+		infos = append(infos, *info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Number < infos[j].Number
+	})
+	return infos
+}
+
+// paramAnalyzer implements AnalyzeParams
+type paramAnalyzer struct {
+	params map[int32]*ParamInfo
+	// origins holds the table columns of the resolved column references
+	origins map[*Node]*ColumnOrigin
+	// castLocation holds the location of the first cast of each parameter
+	castLocation map[int32]int32
+}
+
+func (a *paramAnalyzer) visit(node *Node) (bool, error) {
+	switch n := node.Node.(type) {
+	case *Node_ParamRef:
+		info := a.param(n.ParamRef)
+		info.Locations = append(info.Locations, n.ParamRef.Location)
+	case *Node_TypeCast:
+		if param := n.TypeCast.GetArg().GetParamRef(); param != nil {
+			a.cast(param, n.TypeCast)
+		}
+	case *Node_AExpr:
+		a.aExpr(n.AExpr)
+	case *Node_SelectStmt:
+		a.limits(n.SelectStmt)
+	case *Node_InsertStmt:
+		a.insertStmt(n.InsertStmt)
+	case *Node_MultiAssignRef:
+		// Every column of SET (a, b) = (...) holds the same source row, visit it once
+		return n.MultiAssignRef.Colno <= 1, nil
+	case *Node_UpdateStmt:
+		a.assignments(n.UpdateStmt.TargetList, n.UpdateStmt.Relation)
+	case *Node_MergeStmt:
+		a.mergeStmt(n.MergeStmt)
+	}
+	return true, nil
+}
+
+func (a *paramAnalyzer) param(param *ParamRef) *ParamInfo {
+	info, ok := a.params[param.Number]
+	if !ok {
+		info = &ParamInfo{Number: param.Number}
+		a.params[param.Number] = info
+	}
+	return info
+}
+
+// cast records the type of a parameter cast if it's the first one in the query
+func (a *paramAnalyzer) cast(param *ParamRef, typeCast *TypeCast) {
+	info := a.param(param)
+	if first, ok := a.castLocation[param.Number]; ok && first < typeCast.Location {
+		return
+	}
+	a.castLocation[param.Number] = typeCast.Location
+	info.Type = typeNameString(typeCast.TypeName)
+}
+
+// bind records the binding of a parameter, if node is one (possibly cast)
+func (a *paramAnalyzer) bind(node *Node, binding ParamBinding) {
+	if typeCast := node.GetTypeCast(); typeCast != nil {
+		node = typeCast.Arg
+	}
+	param := node.GetParamRef()
+	if param == nil {
+		return
+	}
+	binding.Location = param.Location
+	info := a.param(param)
+	info.Bindings = append(info.Bindings, binding)
+}
+
+// tableColumn returns the binding of a column of the target table of a DML statement
+func tableColumn(context ParamContext, relation *RangeVar, column string) ParamBinding {
+	return ParamBinding{Context: context, Schema: relation.GetSchemaname(), Table: relation.GetRelname(), Column: column}
+}
+
+// aExpr binds the parameters compared to a column by an expression
+func (a *paramAnalyzer) aExpr(expr *A_Expr) {
+	switch expr.Kind {
+	case A_Expr_Kind_AEXPR_OP:
+		if len(expr.Name) != 1 || !comparisonOperators[strVal(expr.Name[0])] {
+			return
+		}
+	case A_Expr_Kind_AEXPR_OP_ANY, A_Expr_Kind_AEXPR_OP_ALL, A_Expr_Kind_AEXPR_DISTINCT, A_Expr_Kind_AEXPR_NOT_DISTINCT,
+		A_Expr_Kind_AEXPR_IN, A_Expr_Kind_AEXPR_LIKE, A_Expr_Kind_AEXPR_ILIKE, A_Expr_Kind_AEXPR_BETWEEN,
+		A_Expr_Kind_AEXPR_NOT_BETWEEN, A_Expr_Kind_AEXPR_BETWEEN_SYM, A_Expr_Kind_AEXPR_NOT_BETWEEN_SYM:
+	default:
+		return
+	}
+
+	if column, ok := a.column(expr.Lexpr); ok {
+		values := []*Node{expr.Rexpr}
+		if list := expr.Rexpr.GetList(); list != nil {
+			values = list.Items
+		}
+		for _, value := range values {
+			a.bind(value, column)
+		}
+	} else if column, ok := a.column(expr.Rexpr); ok && expr.Kind == A_Expr_Kind_AEXPR_OP {
+		a.bind(expr.Lexpr, column)
+	}
+}
+
+// column returns the binding to the column an expression references, if it's a (possibly
+// cast) column reference
+func (a *paramAnalyzer) column(node *Node) (ParamBinding, bool) {
+	if typeCast := node.GetTypeCast(); typeCast != nil {
+		node = typeCast.Arg
+	}
+	columnRef := node.GetColumnRef()
+	if columnRef == nil {
+		return ParamBinding{}, false
+	}
+	names, star := columnRefNames(columnRef)
+	if star || len(names) == 0 {
+		return ParamBinding{}, false
+	}
+	binding := ParamBinding{Context: ParamContextComparison, Column: names[len(names)-1]}
+	if origin := a.origins[node]; origin != nil {
+		binding.Schema, binding.Table, binding.Column = origin.Schema, origin.Table, origin.Column
+	}
+	return binding, true
+}
+
+// limits binds the parameters of the LIMIT and OFFSET clauses of a query and the queries of
+// its set operations
+func (a *paramAnalyzer) limits(stmt *SelectStmt) {
+	for ; stmt != nil; stmt = stmt.Rarg {
+		a.bind(stmt.LimitCount, ParamBinding{Context: ParamContextLimit})
+		a.bind(stmt.LimitOffset, ParamBinding{Context: ParamContextOffset})
+		a.limits(stmt.Larg)
+	}
+}
+
+func (a *paramAnalyzer) insertStmt(stmt *InsertStmt) {
+	a.insertValues(stmt.Relation, stmt.Cols, stmt.SelectStmt.GetSelectStmt())
+	if onConflict := stmt.OnConflictClause; onConflict != nil {
+		a.assignments(onConflict.TargetList, stmt.Relation)
+	}
+}
+
+// insertValues binds the parameters of the VALUES lists or the target list of the query
+// providing the rows of an INSERT to the columns they are inserted into
+func (a *paramAnalyzer) insertValues(relation *RangeVar, cols []*Node, query *SelectStmt) {
+	if query == nil || query.Op != SetOperation_SETOP_NONE {
+		return
+	}
+	rows := query.ValuesLists
+	if len(rows) == 0 {
+		var targets []*Node
+		for _, target := range query.TargetList {
+			targets = append(targets, target.GetResTarget().GetVal())
+		}
+		rows = []*Node{MakeListNode(targets)}
+	}
+	for _, row := range rows {
+		for i, value := range row.GetList().GetItems() {
+			binding := tableColumn(ParamContextInsert, relation, "")
+			binding.Position = i + 1
+			if i < len(cols) {
+				binding.Column = cols[i].GetResTarget().GetName()
+			}
+			a.bind(value, binding)
+		}
+	}
+}
+
+// assignments binds the parameters of the SET clause of an UPDATE, ON CONFLICT DO UPDATE
+// or MERGE ... THEN UPDATE to the columns they are assigned to
+func (a *paramAnalyzer) assignments(targetList []*Node, relation *RangeVar) {
+	for _, node := range targetList {
+		target := node.GetResTarget()
+		value := target.GetVal()
+		// SET (a, b) = ($1, $2)
+		if multi := value.GetMultiAssignRef(); multi != nil {
+			args := multi.GetSource().GetRowExpr().GetArgs()
+			if multi.Colno < 1 || int(multi.Colno) > len(args) {
+				continue
+			}
+			value = args[multi.Colno-1]
+		}
+		a.bind(value, tableColumn(ParamContextAssignment, relation, target.GetName()))
+	}
+}
+
+func (a *paramAnalyzer) mergeStmt(stmt *MergeStmt) {
+	for _, node := range stmt.MergeWhenClauses {
+		when := node.GetMergeWhenClause()
+		switch when.GetCommandType() {
+		case CmdType_CMD_INSERT:
+			a.insertValues(stmt.Relation, when.TargetList, &SelectStmt{
+				Op:          SetOperation_SETOP_NONE,
+				ValuesLists: []*Node{MakeListNode(when.Values)},
+			})
+		case CmdType_CMD_UPDATE:
+			a.assignments(when.TargetList, stmt.Relation)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+//go:build cgo
+// +build cgo
+
+package pg_query_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+)
+
+var analyzeParamsTests = []struct {
+	input    string
+	expected []string
+}{
+	{
+		"SELECT * FROM users WHERE id = $1 AND $2 < users.name",
+		[]string{"$1: comparison users.id", "$2: comparison users.name"},
+	},
+	{
+		"SELECT * FROM public.users u WHERE u.email LIKE $1::text AND id IN ($2, $3) LIMIT $4 OFFSET $5",
+		[]string{
+			"$1 text: comparison public.users.email",
+			"$2: comparison public.users.id",
+			"$3: comparison public.users.id",
+			"$4: limit",
+			"$5: offset",
+		},
+	},
+	{
+		"SELECT * FROM orders WHERE total BETWEEN $1 AND $2 OR user_id = ANY($3::int[])",
+		[]string{"$1: comparison orders.total", "$2: comparison orders.total", "$3 int[]: comparison orders.user_id"},
+	},
+	{
+		"INSERT INTO users (id, email) VALUES ($1, $2), ($3, lower($4)) ON CONFLICT (id) DO UPDATE SET email = $2",
+		[]string{
+			"$1: insert users.id #1",
+			"$2: insert users.email #2, assignment users.email",
+			"$3: insert users.id #1",
+			"$4",
+		},
+	},
+	{
+		"INSERT INTO users SELECT $1::bigint, $2",
+		[]string{"$1 bigint: insert users #1", "$2: insert users #2"},
+	},
+	{
+		"UPDATE users SET name = $1, (email, id) = ($2, $3) WHERE id = $4 RETURNING $5::int",
+		[]string{
+			"$1: assignment users.name",
+			"$2: assignment users.email",
+			"$3: assignment users.id",
+			"$4: comparison users.id",
+			"$5 int",
+		},
+	},
+	{
+		"MERGE INTO users u USING orders o ON u.id = o.user_id WHEN MATCHED THEN UPDATE SET name = $1 WHEN NOT MATCHED THEN INSERT (id) VALUES ($2)",
+		[]string{"$1: assignment users.name", "$2: insert users.id #1"},
+	},
+	{
+		"(SELECT id FROM users LIMIT $1) UNION (SELECT user_id FROM orders WHERE id <> $2 LIMIT $1)",
+		[]string{"$1: limit, limit", "$2: comparison orders.id"},
+	},
+	{
+		"SELECT * FROM users, orders WHERE id = $1 AND x = $2",
+		[]string{"$1: comparison id", "$2: comparison x"},
+	},
+	{
+		"SELECT 1",
+		[]string{},
+	},
+}
+
+func describeParam(param pg_query.ParamInfo) string {
+	description := fmt.Sprintf("$%d", param.Number)
+	if param.Type != "" {
+		description += " " + param.Type
+	}
+	for i, binding := range param.Bindings {
+		if i == 0 {
+			description += ": "
+		} else {
+			description += ", "
+		}
+		description += binding.Context.String()
+		name := binding.Column
+		if binding.Table != "" {
+			name = binding.Table + "." + binding.Column
+			if binding.Column == "" {
+				name = binding.Table
+			}
+		}
+		if binding.Schema != "" {
+			name = binding.Schema + "." + name
+		}
+		if name != "" {
+			description += " " + name
+		}
+		if binding.Position != 0 {
+			description += fmt.Sprintf(" #%d", binding.Position)
+		}
+	}
+	return description
+}
+
+func TestAnalyzeParams(t *testing.T) {
+	for _, test := range analyzeParamsTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		actual := []string{}
+		for _, param := range pg_query.AnalyzeParams(tree) {
+			actual = append(actual, describeParam(param))
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("AnalyzeParams(%s)\nexpected %q\nactual %q\n\n", test.input, test.expected, actual)
+		}
+	}
+}
+
+var analyzeParamsLocationsTests = []struct {
+	input    string
+	expected [][]int32
+}{
+	{
+		"SELECT $1 FROM users WHERE id = $2 OR email = $1",
+		[][]int32{{7, 46}, {32}},
+	},
+	{
+		"UPDATE users SET name = $1, (email, id) = ($2::text, $3)",
+		[][]int32{{24}, {43}, {53}},
+	},
+}
+
+func TestAnalyzeParamsLocations(t *testing.T) {
+	for _, test := range analyzeParamsLocationsTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		var actual [][]int32
+		for _, param := range pg_query.AnalyzeParams(tree) {
+			actual = append(actual, param.Locations)
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("AnalyzeParams(%s)\nexpected %v\nactual %v\n\n", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestAnalyzeParamsBindingLocation(t *testing.T) {
+	input := "SELECT $1 FROM users WHERE id = $2 OR email = $1"
+	tree, err := pg_query.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := pg_query.AnalyzeParams(tree)
+	if binding := params[0].Bindings[0]; binding.Location != 46 {
+		t.Errorf("AnalyzeParams(%s)\nexpected binding location 46\nactual %d\n\n", input, binding.Location)
+	}
+}
@@ -664,6 +664,130 @@ func TestParseConcurrency(t *testing.T) {
 	fmt.Println()
 }
 
+var parseWithOptionsTests = []struct {
+	input        string
+	options      pg_query.ParseOptions
+	expectedTree *pg_query.ParseResult
+}{
+	{
+		"a + 1",
+		pg_query.ParseOptions{Mode: pg_query.ParseModePlpgsqlExpr, StandardConformingStrings: true, BackslashQuote: true},
+		&pg_query.ParseResult{
+			Version: int32(160001),
+			Stmts: []*pg_query.RawStmt{
+				{
+					Stmt: &pg_query.Node{
+						Node: &pg_query.Node_SelectStmt{
+							SelectStmt: &pg_query.SelectStmt{
+								LimitOption: pg_query.LimitOption_LIMIT_OPTION_DEFAULT,
+								Op:          pg_query.SetOperation_SETOP_NONE,
+								TargetList: []*pg_query.Node{
+									pg_query.MakeResTargetNodeWithVal(
+										pg_query.MakeAExprNode(
+											pg_query.A_Expr_Kind_AEXPR_OP,
+											[]*pg_query.Node{pg_query.MakeStrNode("+")},
+											pg_query.MakeColumnRefNode([]*pg_query.Node{pg_query.MakeStrNode("a")}, 0),
+											pg_query.MakeAConstIntNode(1, 4),
+											2,
+										),
+										0,
+									),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		"varchar(32)[]",
+		pg_query.ParseOptions{Mode: pg_query.ParseModeTypeName, StandardConformingStrings: true, BackslashQuote: true},
+		&pg_query.ParseResult{
+			Version: int32(160001),
+			Stmts: []*pg_query.RawStmt{
+				{
+					Stmt: pg_query.MakeListNode([]*pg_query.Node{
+						pg_query.MakeStrNode("pg_catalog"),
+						pg_query.MakeStrNode("varchar"),
+					}),
+				},
+			},
+		},
+	},
+	{
+		`SELECT 'a\'b'`,
+		pg_query.ParseOptions{Mode: pg_query.ParseModeDefault, BackslashQuote: true},
+		&pg_query.ParseResult{
+			Version: int32(160001),
+			Stmts: []*pg_query.RawStmt{
+				{
+					Stmt: &pg_query.Node{
+						Node: &pg_query.Node_SelectStmt{
+							SelectStmt: &pg_query.SelectStmt{
+								LimitOption: pg_query.LimitOption_LIMIT_OPTION_DEFAULT,
+								Op:          pg_query.SetOperation_SETOP_NONE,
+								TargetList: []*pg_query.Node{
+									pg_query.MakeResTargetNodeWithVal(
+										pg_query.MakeAConstStrNode("a'b", 7),
+										7,
+									),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestParseWithOptions(t *testing.T) {
+	for _, test := range parseWithOptionsTests {
+		actualTree, err := pg_query.ParseWithOptions(test.input, test.options)
+
+		if err != nil {
+			t.Errorf("ParseWithOptions(%s)\nerror %s\n\n", test.input, err)
+		} else if diff := cmp.Diff(actualTree, test.expectedTree, protocmp.Transform()); diff != "" {
+			t.Errorf("ParseWithOptions(%s)\nprotobuf unexpected difference:\n%v", test.input, diff)
+		}
+	}
+}
+
+var parseWithOptionsErrorTests = []struct {
+	input       string
+	options     pg_query.ParseOptions
+	expectedErr string
+}{
+	{
+		`SELECT 'a\'b'`,
+		pg_query.DefaultParseOptions(),
+		`unterminated bit string literal at or near "b'"`,
+	},
+	{
+		`SELECT 'a\'b'`,
+		pg_query.ParseOptions{Mode: pg_query.ParseModeDefault, StandardConformingStrings: true},
+		`unterminated bit string literal at or near "b'"`,
+	},
+	{
+		`SELECT 'a\'b'`,
+		pg_query.ParseOptions{},
+		`unsafe use of \' in a string literal`,
+	},
+}
+
+func TestParseWithOptionsError(t *testing.T) {
+	for _, test := range parseWithOptionsErrorTests {
+		_, actualErr := pg_query.ParseWithOptions(test.input, test.options)
+
+		if actualErr == nil {
+			t.Errorf("ParseWithOptions(%s)\nexpected error but none returned\n\n", test.input)
+		} else if actualErr.Error() != test.expectedErr {
+			t.Errorf("ParseWithOptions(%s)\nexpected error %s\nactual error %s\n\n", test.input, test.expectedErr, actualErr)
+		}
+	}
+}
+
 var parsePlPgSQLTests = []struct {
 	input        string
 	expectedJSON string
@@ -697,6 +821,103 @@ func TestParsePlPgSQL(t *testing.T) {
 	}
 }
 
+func plpgsqlExprNode(val *pg_query.Node) *pg_query.Node {
+	return &pg_query.Node{
+		Node: &pg_query.Node_SelectStmt{
+			SelectStmt: &pg_query.SelectStmt{
+				LimitOption: pg_query.LimitOption_LIMIT_OPTION_DEFAULT,
+				Op:          pg_query.SetOperation_SETOP_NONE,
+				TargetList:  []*pg_query.Node{pg_query.MakeResTargetNodeWithVal(val, 0)},
+			},
+		},
+	}
+}
+
+func plpgsqlUnknownVar(refname string) *pg_query.PLpgSQLVar {
+	return &pg_query.PLpgSQLVar{Refname: refname, Datatype: &pg_query.PLpgSQLType{Typname: "UNKNOWN"}}
+}
+
+var parsePlPgSQLTreeTests = []struct {
+	input         string
+	expectedFuncs []*pg_query.PLpgSQLFunction
+}{
+	{
+		parsePlPgSQLTests[0].input,
+		[]*pg_query.PLpgSQLFunction{
+			{
+				Datums: []pg_query.PLpgSQLDatum{
+					plpgsqlUnknownVar("v_name"),
+					plpgsqlUnknownVar("v_version"),
+					plpgsqlUnknownVar("found"),
+				},
+				Action: &pg_query.PLpgSQLStmtBlock{
+					PLpgSQLStmtBase: pg_query.PLpgSQLStmtBase{Lineno: 1},
+					Body: []pg_query.PLpgSQLStmt{
+						&pg_query.PLpgSQLStmtIf{
+							PLpgSQLStmtBase: pg_query.PLpgSQLStmtBase{Lineno: 1},
+							Cond: &pg_query.PLpgSQLExpr{
+								Query: "v_version IS NULL",
+								Mode:  pg_query.ParseModePlpgsqlExpr,
+								Stmt: plpgsqlExprNode(&pg_query.Node{
+									Node: &pg_query.Node_NullTest{
+										NullTest: &pg_query.NullTest{
+											Arg:          pg_query.MakeColumnRefNode([]*pg_query.Node{pg_query.MakeStrNode("v_version")}, 0),
+											Nulltesttype: pg_query.NullTestType_IS_NULL,
+											Location:     10,
+										},
+									},
+								}),
+							},
+							ThenBody: []pg_query.PLpgSQLStmt{
+								&pg_query.PLpgSQLStmtReturn{
+									PLpgSQLStmtBase: pg_query.PLpgSQLStmtBase{Lineno: 1},
+									Expr: &pg_query.PLpgSQLExpr{
+										Query: "v_name",
+										Mode:  pg_query.ParseModePlpgsqlExpr,
+										Stmt:  plpgsqlExprNode(pg_query.MakeColumnRefNode([]*pg_query.Node{pg_query.MakeStrNode("v_name")}, 0)),
+									},
+								},
+							},
+						},
+						&pg_query.PLpgSQLStmtReturn{
+							PLpgSQLStmtBase: pg_query.PLpgSQLStmtBase{Lineno: 1},
+							Expr: &pg_query.PLpgSQLExpr{
+								Query: "v_name || '/' || v_version",
+								Mode:  pg_query.ParseModePlpgsqlExpr,
+								Stmt: plpgsqlExprNode(pg_query.MakeAExprNode(
+									pg_query.A_Expr_Kind_AEXPR_OP,
+									[]*pg_query.Node{pg_query.MakeStrNode("||")},
+									pg_query.MakeAExprNode(
+										pg_query.A_Expr_Kind_AEXPR_OP,
+										[]*pg_query.Node{pg_query.MakeStrNode("||")},
+										pg_query.MakeColumnRefNode([]*pg_query.Node{pg_query.MakeStrNode("v_name")}, 0),
+										pg_query.MakeAConstStrNode("/", 10),
+										7,
+									),
+									pg_query.MakeColumnRefNode([]*pg_query.Node{pg_query.MakeStrNode("v_version")}, 17),
+									14,
+								)),
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestParsePlPgSQLTree(t *testing.T) {
+	for _, test := range parsePlPgSQLTreeTests {
+		actualFuncs, err := pg_query.ParsePlPgSql(test.input)
+
+		if err != nil {
+			t.Errorf("ParsePlPgSql(%s)\nerror %s\n\n", test.input, err)
+		} else if diff := cmp.Diff(actualFuncs, test.expectedFuncs, protocmp.Transform()); diff != "" {
+			t.Errorf("ParsePlPgSql(%s)\nunexpected difference:\n%v", test.input, diff)
+		}
+	}
+}
+
 func TestScan(t *testing.T) {
 	smokeTest := func(input string) {
 		_, err := pg_query.Scan(input)
@@ -119,6 +119,39 @@ func ParseToProtobuf(input string) (result []byte, err error) {
 	return
 }
 
+// Parser option flags accepted by ParseToProtobufWithOptions, see PgQueryParseMode in pg_query.h
+const (
+	ParseModeDefault                 = int(C.PG_QUERY_PARSE_DEFAULT)
+	ParseModeTypeName                = int(C.PG_QUERY_PARSE_TYPE_NAME)
+	ParseModePlpgsqlExpr             = int(C.PG_QUERY_PARSE_PLPGSQL_EXPR)
+	ParseModePlpgsqlAssign1          = int(C.PG_QUERY_PARSE_PLPGSQL_ASSIGN1)
+	ParseModePlpgsqlAssign2          = int(C.PG_QUERY_PARSE_PLPGSQL_ASSIGN2)
+	ParseModePlpgsqlAssign3          = int(C.PG_QUERY_PARSE_PLPGSQL_ASSIGN3)
+	DisableBackslashQuote            = int(C.PG_QUERY_DISABLE_BACKSLASH_QUOTE)
+	DisableStandardConformingStrings = int(C.PG_QUERY_DISABLE_STANDARD_CONFORMING_STRINGS)
+	DisableEscapeStringWarning       = int(C.PG_QUERY_DISABLE_ESCAPE_STRING_WARNING)
+)
+
+// ParseToProtobufWithOptions - Parses the given SQL statement into a parse tree (Protobuf format),
+// using the given combination of parse mode and parser option flags
+func ParseToProtobufWithOptions(input string, parserOptions int) (result []byte, err error) {
+	inputC := C.CString(input)
+	defer C.free(unsafe.Pointer(inputC))
+
+	resultC := C.pg_query_parse_protobuf_opts(inputC, C.int(parserOptions))
+
+	defer C.pg_query_free_protobuf_parse_result(resultC)
+
+	if resultC.error != nil {
+		err = newPgQueryError(resultC.error)
+		return
+	}
+
+	result = []byte(C.GoStringN(resultC.parse_tree.data, C.int(resultC.parse_tree.len)))
+
+	return
+}
+
 // DeparseFromProtobuf - Deparses the given Protobuf format parse tree into a SQL statement
 func DeparseFromProtobuf(input []byte) (result string, err error) {
 	inputC := C.CBytes(input)
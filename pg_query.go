@@ -36,14 +36,79 @@ func Parse(input string) (tree *ParseResult, err error) {
 	return
 }
 
-// Deparses a given Go parse tree into a SQL statement
-func Deparse(tree *ParseResult) (output string, err error) {
-	protobufTree, err := proto.Marshal(tree)
+// ParseMode selects the grammar entry point used by ParseWithOptions
+type ParseMode int
+
+const (
+	// ParseModeDefault parses a list of SQL statements
+	ParseModeDefault ParseMode = ParseMode(parser.ParseModeDefault)
+	// ParseModeTypeName parses a single type name (e.g. "varchar(32)")
+	ParseModeTypeName ParseMode = ParseMode(parser.ParseModeTypeName)
+	// ParseModePlpgsqlExpr parses a PL/pgSQL expression
+	ParseModePlpgsqlExpr ParseMode = ParseMode(parser.ParseModePlpgsqlExpr)
+	// ParseModePlpgsqlAssign1 parses a PL/pgSQL assignment statement with a one-part target
+	ParseModePlpgsqlAssign1 ParseMode = ParseMode(parser.ParseModePlpgsqlAssign1)
+	// ParseModePlpgsqlAssign2 parses a PL/pgSQL assignment statement with a two-part target
+	ParseModePlpgsqlAssign2 ParseMode = ParseMode(parser.ParseModePlpgsqlAssign2)
+	// ParseModePlpgsqlAssign3 parses a PL/pgSQL assignment statement with a three-part target
+	ParseModePlpgsqlAssign3 ParseMode = ParseMode(parser.ParseModePlpgsqlAssign3)
+)
+
+// ParseOptions controls the parse mode and the server settings that affect parsing.
+//
+// The settings follow the server's boolean values, so the zero value parses with
+// standard_conforming_strings = off and backslash_quote = off. Start from
+// DefaultParseOptions to parse like Parse.
+type ParseOptions struct {
+	Mode ParseMode
+	// StandardConformingStrings treats backslashes in ordinary string literals as plain
+	// characters, like standard_conforming_strings = on. When false they are escapes.
+	StandardConformingStrings bool
+	// BackslashQuote accepts \' as a quote in string literals, like backslash_quote = on
+	BackslashQuote bool
+}
+
+// DefaultParseOptions returns the options matching the behaviour of Parse, with
+// standard_conforming_strings = on and backslash_quote = on
+func DefaultParseOptions() ParseOptions {
+	return ParseOptions{Mode: ParseModeDefault, StandardConformingStrings: true, BackslashQuote: true}
+}
+
+func (o ParseOptions) flags() int {
+	flags := int(o.Mode)
+	if !o.StandardConformingStrings {
+		flags |= parser.DisableStandardConformingStrings
+	}
+	if !o.BackslashQuote {
+		flags |= parser.DisableBackslashQuote
+	}
+	return flags
+}
+
+// ParseWithOptions - Parses the given input into a parse tree (Go struct format), using the given parse mode and settings
+func ParseWithOptions(input string, options ParseOptions) (tree *ParseResult, err error) {
+	protobufTree, err := parser.ParseToProtobufWithOptions(input, options.flags())
 	if err != nil {
 		return
 	}
 
-	output, err = parser.DeparseFromProtobuf(protobufTree)
+	tree = &ParseResult{}
+	err = proto.Unmarshal(protobufTree, tree)
+	return
+}
+
+// ParseWithComments - Parses the given SQL statement into a parse tree (Go struct format)
+// and the comments it contains, attached to the statements and nodes they precede
+func ParseWithComments(input string) (tree *ParseResult, comments []*Comment, err error) {
+	tree, err = Parse(input)
+	if err != nil {
+		return
+	}
+	scan, err := Scan(input)
+	if err != nil {
+		return
+	}
+	comments = attachComments(input, tree, scan)
 	return
 }
 
@@ -57,6 +122,36 @@ func Normalize(input string) (result string, err error) {
 	return parser.Normalize(input)
 }
 
+// NormalizeWithValues normalizes the passed SQL statement like Normalize, also returning the
+// constants replaced by $n parameter references, ordered by location
+func NormalizeWithValues(input string) (query string, values []Literal, err error) {
+	tree, err := Parse(input)
+	if err != nil {
+		return "", nil, err
+	}
+	tokens, err := scanSourceTokens(input)
+	if err != nil {
+		return "", nil, err
+	}
+	values, _ = locateConsts(input, tree, tokens.tokens)
+	return replaceLiterals(input, values), values, nil
+}
+
+// NormalizeWithOptions normalizes the passed SQL statement like Normalize, with the
+// constants replaced and the parameter reference style chosen by the options
+func NormalizeWithOptions(input string, options NormalizeOptions) (result string, err error) {
+	tree, err := Parse(input)
+	if err != nil {
+		return "", err
+	}
+	tokens, err := scanSourceTokens(input)
+	if err != nil {
+		return "", err
+	}
+	literals, highestParam := locateConsts(input, tree, tokens.tokens)
+	return normalizeWithOptions(input, tree, literals, highestParam, options), nil
+}
+
 // Normalize the passed utility statement to replace constant values with $n parameter references
 func NormalizeUtility(input string) (result string, err error) {
 	return parser.NormalizeUtility(input)
@@ -72,6 +167,31 @@ func FingerprintToUInt64(input string) (result uint64, err error) {
 	return parser.FingerprintToUInt64(input)
 }
 
+// FingerprintTree - Fingerprint a parse tree to a uint64 like FingerprintToUInt64, without
+// parsing it again, e.g. after rewriting it
+func FingerprintTree(tree *ParseResult) uint64 {
+	f := newFingerprinter(hashFingerprint, false)
+	f.tree(tree)
+	return hashFingerprint(f.buf)
+}
+
+// FingerprintDebug - Fingerprint the passed SQL statement to a uint64 like FingerprintToUInt64,
+// also returning the strings fed to the hash function, in order, to investigate why two queries
+// get different (or the same) fingerprints
+func FingerprintDebug(input string) (hash uint64, parts []string, err error) {
+	tree, err := Parse(input)
+	if err != nil {
+		return 0, nil, err
+	}
+	f := newFingerprinter(hashFingerprint, true)
+	f.tree(tree)
+	return hashFingerprint(f.buf), f.parts, nil
+}
+
+func hashFingerprint(input []byte) uint64 {
+	return HashXXH3_64(input, fingerprintVersion)
+}
+
 // HashXXH3_64 - Helper method to run XXH3 hash function (64-bit variant) on the given bytes, with the specified seed
 func HashXXH3_64(input []byte, seed uint64) (result uint64) {
 	return parser.HashXXH3_64(input, seed)
@@ -84,23 +204,3 @@ func SplitWithScanner(input string, trimSpace bool) (result []string, err error)
 func SplitWithParser(input string, trimSpace bool) (result []string, err error) {
 	return parser.SplitWithParser(input, trimSpace)
 }
-
-// Walk - Walk iterate thought Node recursively and apply Visit method
-func Walk(visit Visit, nodes ...*Node) error {
-	for _, node := range nodes {
-		if node == nil {
-			continue
-		}
-		kontinue, err := visit(node)
-		if err != nil {
-			return err
-		}
-		if kontinue {
-			err = WalkSubtree(node.Node, visit)
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return nil
-}
@@ -0,0 +1,848 @@
+//go:build cgo
+// +build cgo
+
+package pg_query
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PLpgSQLFunction is the parse tree of a single PL/pgSQL function body
+type PLpgSQLFunction struct {
+	NewVarno int
+	OldVarno int
+	// Datums holds all variables of the function, statements refer to them by index (varno)
+	Datums []PLpgSQLDatum
+	Action *PLpgSQLStmtBlock
+}
+
+// PLpgSQLExpr is an SQL expression or statement embedded in a PL/pgSQL function
+type PLpgSQLExpr struct {
+	Query string
+	Mode  ParseMode
+	// Stmt is Query parsed with Mode: a SelectStmt for expressions, a PLAssignStmt for
+	// assignments and the statement itself for embedded SQL commands
+	Stmt *Node
+}
+
+// PLpgSQLDatum is implemented by all PL/pgSQL variable kinds
+type PLpgSQLDatum interface {
+	plpgsqlDatum()
+}
+
+// PLpgSQLType is the declared data type of a PL/pgSQL variable
+type PLpgSQLType struct {
+	Typname string
+}
+
+// PLpgSQLVar is a scalar variable
+type PLpgSQLVar struct {
+	Refname              string
+	Lineno               int
+	Datatype             *PLpgSQLType
+	IsConst              bool
+	NotNull              bool
+	DefaultVal           *PLpgSQLExpr
+	CursorExplicitExpr   *PLpgSQLExpr
+	CursorExplicitArgrow int
+	CursorOptions        int
+}
+
+// PLpgSQLRowField is a single member of a PLpgSQLRow
+type PLpgSQLRowField struct {
+	Name  string
+	Varno int
+}
+
+// PLpgSQLRow is a row variable (e.g. the target list of SELECT INTO)
+type PLpgSQLRow struct {
+	Refname string
+	Lineno  int
+	// Fields contains nil entries for dropped columns
+	Fields []*PLpgSQLRowField
+}
+
+// PLpgSQLRec is a record variable
+type PLpgSQLRec struct {
+	Refname string
+	Dno     int
+	Lineno  int
+}
+
+// PLpgSQLRecField is a field of a record variable
+type PLpgSQLRecField struct {
+	Fieldname   string
+	Recparentno int
+}
+
+func (*PLpgSQLVar) plpgsqlDatum()      {}
+func (*PLpgSQLRow) plpgsqlDatum()      {}
+func (*PLpgSQLRec) plpgsqlDatum()      {}
+func (*PLpgSQLRecField) plpgsqlDatum() {}
+
+// PLpgSQLStmt is implemented by all PL/pgSQL statements
+type PLpgSQLStmt interface {
+	GetLineno() int
+}
+
+// PLpgSQLStmtBase holds the fields common to all PL/pgSQL statements
+type PLpgSQLStmtBase struct {
+	Lineno int
+}
+
+// GetLineno returns the line number of the statement within the function body
+func (s *PLpgSQLStmtBase) GetLineno() int {
+	return s.Lineno
+}
+
+// PLpgSQLStmtBlock is a BEGIN ... [EXCEPTION ...] END block
+type PLpgSQLStmtBlock struct {
+	PLpgSQLStmtBase
+	Label      string
+	Body       []PLpgSQLStmt
+	Exceptions []*PLpgSQLException
+}
+
+// PLpgSQLException is a single WHEN clause of an EXCEPTION block
+type PLpgSQLException struct {
+	Conditions []string
+	Action     []PLpgSQLStmt
+}
+
+// PLpgSQLStmtAssign is an assignment (target := expr), Expr is parsed as a PLAssignStmt
+type PLpgSQLStmtAssign struct {
+	PLpgSQLStmtBase
+	Varno int
+	Expr  *PLpgSQLExpr
+}
+
+// PLpgSQLStmtIf is an IF ... ELSIF ... ELSE statement
+type PLpgSQLStmtIf struct {
+	PLpgSQLStmtBase
+	Cond      *PLpgSQLExpr
+	ThenBody  []PLpgSQLStmt
+	ElsifList []*PLpgSQLIfElsif
+	ElseBody  []PLpgSQLStmt
+}
+
+// PLpgSQLIfElsif is a single ELSIF branch
+type PLpgSQLIfElsif struct {
+	Lineno int
+	Cond   *PLpgSQLExpr
+	Stmts  []PLpgSQLStmt
+}
+
+// PLpgSQLStmtCase is a CASE statement
+type PLpgSQLStmtCase struct {
+	PLpgSQLStmtBase
+	TExpr        *PLpgSQLExpr
+	TVarno       int
+	CaseWhenList []*PLpgSQLCaseWhen
+	HaveElse     bool
+	ElseStmts    []PLpgSQLStmt
+}
+
+// PLpgSQLCaseWhen is a single WHEN branch of a CASE statement
+type PLpgSQLCaseWhen struct {
+	Lineno int
+	Expr   *PLpgSQLExpr
+	Stmts  []PLpgSQLStmt
+}
+
+// PLpgSQLStmtLoop is an unconditional LOOP
+type PLpgSQLStmtLoop struct {
+	PLpgSQLStmtBase
+	Label string
+	Body  []PLpgSQLStmt
+}
+
+// PLpgSQLStmtWhile is a WHILE loop
+type PLpgSQLStmtWhile struct {
+	PLpgSQLStmtBase
+	Label string
+	Cond  *PLpgSQLExpr
+	Body  []PLpgSQLStmt
+}
+
+// PLpgSQLStmtForI is a FOR loop over an integer range
+type PLpgSQLStmtForI struct {
+	PLpgSQLStmtBase
+	Label   string
+	Var     *PLpgSQLVar
+	Lower   *PLpgSQLExpr
+	Upper   *PLpgSQLExpr
+	Step    *PLpgSQLExpr
+	Reverse bool
+	Body    []PLpgSQLStmt
+}
+
+// PLpgSQLStmtForS is a FOR loop over the rows of a query
+type PLpgSQLStmtForS struct {
+	PLpgSQLStmtBase
+	Label string
+	Var   PLpgSQLDatum
+	Body  []PLpgSQLStmt
+	Query *PLpgSQLExpr
+}
+
+// PLpgSQLStmtForC is a FOR loop over the rows of a bound cursor
+type PLpgSQLStmtForC struct {
+	PLpgSQLStmtBase
+	Label    string
+	Var      PLpgSQLDatum
+	Body     []PLpgSQLStmt
+	Curvar   int
+	Argquery *PLpgSQLExpr
+}
+
+// PLpgSQLStmtForeachA is a FOREACH loop over the elements of an array
+type PLpgSQLStmtForeachA struct {
+	PLpgSQLStmtBase
+	Label string
+	Varno int
+	Slice int
+	Expr  *PLpgSQLExpr
+	Body  []PLpgSQLStmt
+}
+
+// PLpgSQLStmtExit is an EXIT or CONTINUE statement
+type PLpgSQLStmtExit struct {
+	PLpgSQLStmtBase
+	IsExit bool
+	Label  string
+	Cond   *PLpgSQLExpr
+}
+
+// PLpgSQLStmtReturn is a RETURN statement
+type PLpgSQLStmtReturn struct {
+	PLpgSQLStmtBase
+	Expr *PLpgSQLExpr
+}
+
+// PLpgSQLStmtReturnNext is a RETURN NEXT statement
+type PLpgSQLStmtReturnNext struct {
+	PLpgSQLStmtBase
+	Expr *PLpgSQLExpr
+}
+
+// PLpgSQLStmtReturnQuery is a RETURN QUERY [EXECUTE] statement
+type PLpgSQLStmtReturnQuery struct {
+	PLpgSQLStmtBase
+	Query    *PLpgSQLExpr
+	Dynquery *PLpgSQLExpr
+	Params   []*PLpgSQLExpr
+}
+
+// PLpgSQLRaiseOptionType is the kind of a RAISE ... USING option
+type PLpgSQLRaiseOptionType int
+
+const (
+	PLpgSQLRaiseOptionErrcode PLpgSQLRaiseOptionType = iota
+	PLpgSQLRaiseOptionMessage
+	PLpgSQLRaiseOptionDetail
+	PLpgSQLRaiseOptionHint
+	PLpgSQLRaiseOptionColumn
+	PLpgSQLRaiseOptionConstraint
+	PLpgSQLRaiseOptionDatatype
+	PLpgSQLRaiseOptionTable
+	PLpgSQLRaiseOptionSchema
+)
+
+// PLpgSQLStmtRaise is a RAISE statement
+type PLpgSQLStmtRaise struct {
+	PLpgSQLStmtBase
+	ElogLevel int
+	Condname  string
+	Message   string
+	Params    []*PLpgSQLExpr
+	Options   []*PLpgSQLRaiseOption
+}
+
+// PLpgSQLRaiseOption is a single RAISE ... USING option
+type PLpgSQLRaiseOption struct {
+	OptType PLpgSQLRaiseOptionType
+	Expr    *PLpgSQLExpr
+}
+
+// PLpgSQLStmtAssert is an ASSERT statement
+type PLpgSQLStmtAssert struct {
+	PLpgSQLStmtBase
+	Cond    *PLpgSQLExpr
+	Message *PLpgSQLExpr
+}
+
+// PLpgSQLStmtExecSQL is a plain SQL command executed by the function
+type PLpgSQLStmtExecSQL struct {
+	PLpgSQLStmtBase
+	Sqlstmt *PLpgSQLExpr
+	Into    bool
+	Strict  bool
+	Target  PLpgSQLDatum
+}
+
+// PLpgSQLStmtDynExecute is an EXECUTE statement running a dynamically built query
+type PLpgSQLStmtDynExecute struct {
+	PLpgSQLStmtBase
+	Query  *PLpgSQLExpr
+	Into   bool
+	Strict bool
+	Target PLpgSQLDatum
+	Params []*PLpgSQLExpr
+}
+
+// PLpgSQLStmtDynForS is a FOR loop over the rows of a dynamically built query
+type PLpgSQLStmtDynForS struct {
+	PLpgSQLStmtBase
+	Label  string
+	Var    PLpgSQLDatum
+	Body   []PLpgSQLStmt
+	Query  *PLpgSQLExpr
+	Params []*PLpgSQLExpr
+}
+
+// PLpgSQLStmtGetDiag is a GET [STACKED] DIAGNOSTICS statement
+type PLpgSQLStmtGetDiag struct {
+	PLpgSQLStmtBase
+	IsStacked bool
+	DiagItems []*PLpgSQLDiagItem
+}
+
+// PLpgSQLDiagItem is a single GET DIAGNOSTICS item
+type PLpgSQLDiagItem struct {
+	Kind   string
+	Target int
+}
+
+// PLpgSQLStmtOpen is an OPEN cursor statement
+type PLpgSQLStmtOpen struct {
+	PLpgSQLStmtBase
+	Curvar        int
+	CursorOptions int
+	Argquery      *PLpgSQLExpr
+	Query         *PLpgSQLExpr
+	Dynquery      *PLpgSQLExpr
+	Params        []*PLpgSQLExpr
+}
+
+// PLpgSQLStmtFetch is a FETCH or MOVE cursor statement
+type PLpgSQLStmtFetch struct {
+	PLpgSQLStmtBase
+	Target              PLpgSQLDatum
+	Curvar              int
+	Direction           FetchDirection
+	HowMany             int64
+	Expr                *PLpgSQLExpr
+	IsMove              bool
+	ReturnsMultipleRows bool
+}
+
+// PLpgSQLStmtClose is a CLOSE cursor statement
+type PLpgSQLStmtClose struct {
+	PLpgSQLStmtBase
+	Curvar int
+}
+
+// PLpgSQLStmtPerform is a PERFORM statement
+type PLpgSQLStmtPerform struct {
+	PLpgSQLStmtBase
+	Expr *PLpgSQLExpr
+}
+
+// PLpgSQLStmtCall is a CALL or DO statement
+type PLpgSQLStmtCall struct {
+	PLpgSQLStmtBase
+	Expr   *PLpgSQLExpr
+	IsCall bool
+	Target PLpgSQLDatum
+}
+
+// PLpgSQLStmtCommit is a COMMIT statement
+type PLpgSQLStmtCommit struct {
+	PLpgSQLStmtBase
+	Chain bool
+}
+
+// PLpgSQLStmtRollback is a ROLLBACK statement
+type PLpgSQLStmtRollback struct {
+	PLpgSQLStmtBase
+	Chain bool
+}
+
+// ParsePlPgSql - Parses the given PL/pgSQL function statement into a parse tree (Go struct format),
+// with all embedded SQL expressions parsed as well
+func ParsePlPgSql(input string) (result []*PLpgSQLFunction, err error) {
+	funcsJSON, err := ParsePlPgSqlToJSON(input)
+	if err != nil {
+		return
+	}
+
+	var funcs []map[string]json.RawMessage
+	err = json.Unmarshal([]byte(funcsJSON), &funcs)
+	if err != nil {
+		return
+	}
+
+	d := &plpgsqlDecoder{}
+	for _, f := range funcs {
+		var obj plpgsqlObject
+		if err = json.Unmarshal(f["PLpgSQL_function"], &obj); err != nil {
+			return nil, err
+		}
+		result = append(result, d.function(obj))
+		if d.err != nil {
+			return nil, d.err
+		}
+	}
+	return
+}
+
+// plpgsqlObject is the JSON representation of a single PL/pgSQL node, as produced by pg_query_json_plpgsql.c
+type plpgsqlObject map[string]json.RawMessage
+
+// plpgsqlDecoder converts the JSON output of the C extension into Go structs,
+// remembering the first error so the conversion functions can stay linear
+type plpgsqlDecoder struct {
+	err error
+}
+
+func (d *plpgsqlDecoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *plpgsqlDecoder) decode(raw json.RawMessage, v interface{}) bool {
+	if raw == nil || d.err != nil {
+		return false
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		d.fail(err)
+		return false
+	}
+	return true
+}
+
+func (d *plpgsqlDecoder) str(obj plpgsqlObject, name string) (s string) {
+	d.decode(obj[name], &s)
+	return
+}
+
+func (d *plpgsqlDecoder) integer(obj plpgsqlObject, name string) (i int) {
+	d.decode(obj[name], &i)
+	return
+}
+
+func (d *plpgsqlDecoder) long(obj plpgsqlObject, name string) (i int64) {
+	d.decode(obj[name], &i)
+	return
+}
+
+func (d *plpgsqlDecoder) boolean(obj plpgsqlObject, name string) (b bool) {
+	d.decode(obj[name], &b)
+	return
+}
+
+// tagged unwraps a {"<node type>": {...}} value into its type name and fields
+func (d *plpgsqlDecoder) tagged(raw json.RawMessage) (tag string, obj plpgsqlObject) {
+	var wrapper map[string]plpgsqlObject
+	if !d.decode(raw, &wrapper) {
+		return
+	}
+	for tag, obj = range wrapper {
+		return
+	}
+	return
+}
+
+func (d *plpgsqlDecoder) list(obj plpgsqlObject, name string) (items []json.RawMessage) {
+	d.decode(obj[name], &items)
+	return
+}
+
+func (d *plpgsqlDecoder) function(obj plpgsqlObject) *PLpgSQLFunction {
+	f := &PLpgSQLFunction{
+		NewVarno: d.integer(obj, "new_varno"),
+		OldVarno: d.integer(obj, "old_varno"),
+	}
+	for _, raw := range d.list(obj, "datums") {
+		f.Datums = append(f.Datums, d.datum(raw))
+	}
+	if _, action := d.tagged(obj["action"]); action != nil {
+		f.Action = d.block(action)
+	}
+	return f
+}
+
+func (d *plpgsqlDecoder) expr(obj plpgsqlObject, name string) *PLpgSQLExpr {
+	_, fields := d.tagged(obj[name])
+	if fields == nil {
+		return nil
+	}
+	return d.exprFields(fields)
+}
+
+func (d *plpgsqlDecoder) exprFields(fields plpgsqlObject) *PLpgSQLExpr {
+	expr := &PLpgSQLExpr{
+		Query: d.str(fields, "query"),
+		Mode:  ParseMode(d.integer(fields, "parseMode")),
+	}
+	if d.err != nil {
+		return expr
+	}
+
+	options := DefaultParseOptions()
+	options.Mode = expr.Mode
+	tree, err := ParseWithOptions(expr.Query, options)
+	if err != nil {
+		d.fail(fmt.Errorf("could not parse PL/pgSQL expression %q: %w", expr.Query, err))
+		return expr
+	}
+	if len(tree.Stmts) > 0 {
+		expr.Stmt = tree.Stmts[0].Stmt
+	}
+	return expr
+}
+
+func (d *plpgsqlDecoder) exprList(obj plpgsqlObject, name string) (exprs []*PLpgSQLExpr) {
+	for _, raw := range d.list(obj, name) {
+		if _, fields := d.tagged(raw); fields != nil {
+			exprs = append(exprs, d.exprFields(fields))
+		}
+	}
+	return
+}
+
+func (d *plpgsqlDecoder) variable(obj plpgsqlObject, name string) PLpgSQLDatum {
+	if obj[name] == nil {
+		return nil
+	}
+	return d.datum(obj[name])
+}
+
+func (d *plpgsqlDecoder) datum(raw json.RawMessage) PLpgSQLDatum {
+	tag, obj := d.tagged(raw)
+	switch tag {
+	case "PLpgSQL_var":
+		return d.varDatum(obj)
+	case "PLpgSQL_row":
+		row := &PLpgSQLRow{
+			Refname: d.str(obj, "refname"),
+			Lineno:  d.integer(obj, "lineno"),
+		}
+		for _, raw := range d.list(obj, "fields") {
+			var field plpgsqlObject
+			d.decode(raw, &field)
+			if field == nil {
+				row.Fields = append(row.Fields, nil)
+				continue
+			}
+			row.Fields = append(row.Fields, &PLpgSQLRowField{
+				Name:  d.str(field, "name"),
+				Varno: d.integer(field, "varno"),
+			})
+		}
+		return row
+	case "PLpgSQL_rec":
+		return &PLpgSQLRec{
+			Refname: d.str(obj, "refname"),
+			Dno:     d.integer(obj, "dno"),
+			Lineno:  d.integer(obj, "lineno"),
+		}
+	case "PLpgSQL_recfield":
+		return &PLpgSQLRecField{
+			Fieldname:   d.str(obj, "fieldname"),
+			Recparentno: d.integer(obj, "recparentno"),
+		}
+	}
+	if d.err == nil {
+		d.fail(fmt.Errorf("unrecognized PL/pgSQL datum type %q", tag))
+	}
+	return nil
+}
+
+func (d *plpgsqlDecoder) varDatum(obj plpgsqlObject) *PLpgSQLVar {
+	v := &PLpgSQLVar{
+		Refname:              d.str(obj, "refname"),
+		Lineno:               d.integer(obj, "lineno"),
+		IsConst:              d.boolean(obj, "isconst"),
+		NotNull:              d.boolean(obj, "notnull"),
+		DefaultVal:           d.expr(obj, "default_val"),
+		CursorExplicitExpr:   d.expr(obj, "cursor_explicit_expr"),
+		CursorExplicitArgrow: d.integer(obj, "cursor_explicit_argrow"),
+		CursorOptions:        d.integer(obj, "cursor_options"),
+	}
+	if _, datatype := d.tagged(obj["datatype"]); datatype != nil {
+		v.Datatype = &PLpgSQLType{Typname: d.str(datatype, "typname")}
+	}
+	return v
+}
+
+func (d *plpgsqlDecoder) stmts(obj plpgsqlObject, name string) (stmts []PLpgSQLStmt) {
+	for _, raw := range d.list(obj, name) {
+		stmts = append(stmts, d.stmt(raw))
+	}
+	return
+}
+
+func (d *plpgsqlDecoder) block(obj plpgsqlObject) *PLpgSQLStmtBlock {
+	block := &PLpgSQLStmtBlock{
+		PLpgSQLStmtBase: d.base(obj),
+		Label:           d.str(obj, "label"),
+		Body:            d.stmts(obj, "body"),
+	}
+	if _, exceptions := d.tagged(obj["exceptions"]); exceptions != nil {
+		for _, raw := range d.list(exceptions, "exc_list") {
+			_, exc := d.tagged(raw)
+			exception := &PLpgSQLException{Action: d.stmts(exc, "action")}
+			for _, raw := range d.list(exc, "conditions") {
+				_, cond := d.tagged(raw)
+				exception.Conditions = append(exception.Conditions, d.str(cond, "condname"))
+			}
+			block.Exceptions = append(block.Exceptions, exception)
+		}
+	}
+	return block
+}
+
+func (d *plpgsqlDecoder) base(obj plpgsqlObject) PLpgSQLStmtBase {
+	return PLpgSQLStmtBase{Lineno: d.integer(obj, "lineno")}
+}
+
+func (d *plpgsqlDecoder) stmt(raw json.RawMessage) PLpgSQLStmt {
+	tag, obj := d.tagged(raw)
+	switch tag {
+	case "PLpgSQL_stmt_block":
+		return d.block(obj)
+	case "PLpgSQL_stmt_assign":
+		return &PLpgSQLStmtAssign{
+			PLpgSQLStmtBase: d.base(obj),
+			Varno:           d.integer(obj, "varno"),
+			Expr:            d.expr(obj, "expr"),
+		}
+	case "PLpgSQL_stmt_if":
+		stmt := &PLpgSQLStmtIf{
+			PLpgSQLStmtBase: d.base(obj),
+			Cond:            d.expr(obj, "cond"),
+			ThenBody:        d.stmts(obj, "then_body"),
+			ElseBody:        d.stmts(obj, "else_body"),
+		}
+		for _, raw := range d.list(obj, "elsif_list") {
+			_, elsif := d.tagged(raw)
+			stmt.ElsifList = append(stmt.ElsifList, &PLpgSQLIfElsif{
+				Lineno: d.integer(elsif, "lineno"),
+				Cond:   d.expr(elsif, "cond"),
+				Stmts:  d.stmts(elsif, "stmts"),
+			})
+		}
+		return stmt
+	case "PLpgSQL_stmt_case":
+		stmt := &PLpgSQLStmtCase{
+			PLpgSQLStmtBase: d.base(obj),
+			TExpr:           d.expr(obj, "t_expr"),
+			TVarno:          d.integer(obj, "t_varno"),
+			HaveElse:        d.boolean(obj, "have_else"),
+			ElseStmts:       d.stmts(obj, "else_stmts"),
+		}
+		for _, raw := range d.list(obj, "case_when_list") {
+			_, when := d.tagged(raw)
+			stmt.CaseWhenList = append(stmt.CaseWhenList, &PLpgSQLCaseWhen{
+				Lineno: d.integer(when, "lineno"),
+				Expr:   d.expr(when, "expr"),
+				Stmts:  d.stmts(when, "stmts"),
+			})
+		}
+		return stmt
+	case "PLpgSQL_stmt_loop":
+		return &PLpgSQLStmtLoop{
+			PLpgSQLStmtBase: d.base(obj),
+			Label:           d.str(obj, "label"),
+			Body:            d.stmts(obj, "body"),
+		}
+	case "PLpgSQL_stmt_while":
+		return &PLpgSQLStmtWhile{
+			PLpgSQLStmtBase: d.base(obj),
+			Label:           d.str(obj, "label"),
+			Cond:            d.expr(obj, "cond"),
+			Body:            d.stmts(obj, "body"),
+		}
+	case "PLpgSQL_stmt_fori":
+		stmt := &PLpgSQLStmtForI{
+			PLpgSQLStmtBase: d.base(obj),
+			Label:           d.str(obj, "label"),
+			Lower:           d.expr(obj, "lower"),
+			Upper:           d.expr(obj, "upper"),
+			Step:            d.expr(obj, "step"),
+			Reverse:         d.boolean(obj, "reverse"),
+			Body:            d.stmts(obj, "body"),
+		}
+		if _, v := d.tagged(obj["var"]); v != nil {
+			stmt.Var = d.varDatum(v)
+		}
+		return stmt
+	case "PLpgSQL_stmt_fors":
+		return &PLpgSQLStmtForS{
+			PLpgSQLStmtBase: d.base(obj),
+			Label:           d.str(obj, "label"),
+			Var:             d.variable(obj, "var"),
+			Body:            d.stmts(obj, "body"),
+			Query:           d.expr(obj, "query"),
+		}
+	case "PLpgSQL_stmt_forc":
+		return &PLpgSQLStmtForC{
+			PLpgSQLStmtBase: d.base(obj),
+			Label:           d.str(obj, "label"),
+			Var:             d.variable(obj, "var"),
+			Body:            d.stmts(obj, "body"),
+			Curvar:          d.integer(obj, "curvar"),
+			Argquery:        d.expr(obj, "argquery"),
+		}
+	case "PLpgSQL_stmt_foreach_a":
+		return &PLpgSQLStmtForeachA{
+			PLpgSQLStmtBase: d.base(obj),
+			Label:           d.str(obj, "label"),
+			Varno:           d.integer(obj, "varno"),
+			Slice:           d.integer(obj, "slice"),
+			Expr:            d.expr(obj, "expr"),
+			Body:            d.stmts(obj, "body"),
+		}
+	case "PLpgSQL_stmt_exit":
+		return &PLpgSQLStmtExit{
+			PLpgSQLStmtBase: d.base(obj),
+			IsExit:          d.boolean(obj, "is_exit"),
+			Label:           d.str(obj, "label"),
+			Cond:            d.expr(obj, "cond"),
+		}
+	case "PLpgSQL_stmt_return":
+		return &PLpgSQLStmtReturn{
+			PLpgSQLStmtBase: d.base(obj),
+			Expr:            d.expr(obj, "expr"),
+		}
+	case "PLpgSQL_stmt_return_next":
+		return &PLpgSQLStmtReturnNext{
+			PLpgSQLStmtBase: d.base(obj),
+			Expr:            d.expr(obj, "expr"),
+		}
+	case "PLpgSQL_stmt_return_query":
+		return &PLpgSQLStmtReturnQuery{
+			PLpgSQLStmtBase: d.base(obj),
+			Query:           d.expr(obj, "query"),
+			Dynquery:        d.expr(obj, "dynquery"),
+			Params:          d.exprList(obj, "params"),
+		}
+	case "PLpgSQL_stmt_raise":
+		stmt := &PLpgSQLStmtRaise{
+			PLpgSQLStmtBase: d.base(obj),
+			ElogLevel:       d.integer(obj, "elog_level"),
+			Condname:        d.str(obj, "condname"),
+			Message:         d.str(obj, "message"),
+			Params:          d.exprList(obj, "params"),
+		}
+		for _, raw := range d.list(obj, "options") {
+			_, option := d.tagged(raw)
+			stmt.Options = append(stmt.Options, &PLpgSQLRaiseOption{
+				OptType: PLpgSQLRaiseOptionType(d.integer(option, "opt_type")),
+				Expr:    d.expr(option, "expr"),
+			})
+		}
+		return stmt
+	case "PLpgSQL_stmt_assert":
+		return &PLpgSQLStmtAssert{
+			PLpgSQLStmtBase: d.base(obj),
+			Cond:            d.expr(obj, "cond"),
+			Message:         d.expr(obj, "message"),
+		}
+	case "PLpgSQL_stmt_execsql":
+		return &PLpgSQLStmtExecSQL{
+			PLpgSQLStmtBase: d.base(obj),
+			Sqlstmt:         d.expr(obj, "sqlstmt"),
+			Into:            d.boolean(obj, "into"),
+			Strict:          d.boolean(obj, "strict"),
+			Target:          d.variable(obj, "target"),
+		}
+	case "PLpgSQL_stmt_dynexecute":
+		return &PLpgSQLStmtDynExecute{
+			PLpgSQLStmtBase: d.base(obj),
+			Query:           d.expr(obj, "query"),
+			Into:            d.boolean(obj, "into"),
+			Strict:          d.boolean(obj, "strict"),
+			Target:          d.variable(obj, "target"),
+			Params:          d.exprList(obj, "params"),
+		}
+	case "PLpgSQL_stmt_dynfors":
+		return &PLpgSQLStmtDynForS{
+			PLpgSQLStmtBase: d.base(obj),
+			Label:           d.str(obj, "label"),
+			Var:             d.variable(obj, "var"),
+			Body:            d.stmts(obj, "body"),
+			Query:           d.expr(obj, "query"),
+			Params:          d.exprList(obj, "params"),
+		}
+	case "PLpgSQL_stmt_getdiag":
+		stmt := &PLpgSQLStmtGetDiag{
+			PLpgSQLStmtBase: d.base(obj),
+			IsStacked:       d.boolean(obj, "is_stacked"),
+		}
+		for _, raw := range d.list(obj, "diag_items") {
+			_, item := d.tagged(raw)
+			stmt.DiagItems = append(stmt.DiagItems, &PLpgSQLDiagItem{
+				Kind:   d.str(item, "kind"),
+				Target: d.integer(item, "target"),
+			})
+		}
+		return stmt
+	case "PLpgSQL_stmt_open":
+		return &PLpgSQLStmtOpen{
+			PLpgSQLStmtBase: d.base(obj),
+			Curvar:          d.integer(obj, "curvar"),
+			CursorOptions:   d.integer(obj, "cursor_options"),
+			Argquery:        d.expr(obj, "argquery"),
+			Query:           d.expr(obj, "query"),
+			Dynquery:        d.expr(obj, "dynquery"),
+			Params:          d.exprList(obj, "params"),
+		}
+	case "PLpgSQL_stmt_fetch":
+		return &PLpgSQLStmtFetch{
+			PLpgSQLStmtBase: d.base(obj),
+			Target:          d.variable(obj, "target"),
+			Curvar:          d.integer(obj, "curvar"),
+			// The C enum starts at FETCH_FORWARD, the protobuf one at FETCH_DIRECTION_UNDEFINED
+			Direction:           FetchDirection(d.integer(obj, "direction") + 1),
+			HowMany:             d.long(obj, "how_many"),
+			Expr:                d.expr(obj, "expr"),
+			IsMove:              d.boolean(obj, "is_move"),
+			ReturnsMultipleRows: d.boolean(obj, "returns_multiple_rows"),
+		}
+	case "PLpgSQL_stmt_close":
+		return &PLpgSQLStmtClose{
+			PLpgSQLStmtBase: d.base(obj),
+			Curvar:          d.integer(obj, "curvar"),
+		}
+	case "PLpgSQL_stmt_perform":
+		return &PLpgSQLStmtPerform{
+			PLpgSQLStmtBase: d.base(obj),
+			Expr:            d.expr(obj, "expr"),
+		}
+	case "PLpgSQL_stmt_call":
+		return &PLpgSQLStmtCall{
+			PLpgSQLStmtBase: d.base(obj),
+			Expr:            d.expr(obj, "expr"),
+			IsCall:          d.boolean(obj, "is_call"),
+			Target:          d.variable(obj, "target"),
+		}
+	case "PLpgSQL_stmt_commit":
+		return &PLpgSQLStmtCommit{
+			PLpgSQLStmtBase: d.base(obj),
+			Chain:           d.boolean(obj, "chain"),
+		}
+	case "PLpgSQL_stmt_rollback":
+		return &PLpgSQLStmtRollback{
+			PLpgSQLStmtBase: d.base(obj),
+			Chain:           d.boolean(obj, "chain"),
+		}
+	}
+	if d.err == nil {
+		d.fail(fmt.Errorf("unrecognized PL/pgSQL statement type %q", tag))
+	}
+	return nil
+}
@@ -0,0 +1,1190 @@
+package pg_query
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Catalog provides the table definitions used to resolve column references
+type Catalog interface {
+	// Table returns the definition of a table or view, looking it up in the search path
+	// if schema is empty
+	Table(schema, name string) (*TableDefinition, bool)
+}
+
+// TableDefinition describes the columns of a table or view
+type TableDefinition struct {
+	Schema  string
+	Name    string
+	Columns []TableColumn
+}
+
+// TableColumn is a column of a TableDefinition
+type TableColumn struct {
+	Name string
+	// Type is the name of the column type, e.g. "integer" or "varchar(20)"
+	Type string
+}
+
+// ColumnSourceKind is the kind of FROM-clause item providing a column
+type ColumnSourceKind int
+
+const (
+	// ColumnSourceTable is a table or view
+	ColumnSourceTable ColumnSourceKind = iota
+	// ColumnSourceCTE is a reference to a CTE of a WITH clause
+	ColumnSourceCTE
+	// ColumnSourceSubquery is a subquery or VALUES list in FROM
+	ColumnSourceSubquery
+	// ColumnSourceFunction is a function call or XMLTABLE in FROM
+	ColumnSourceFunction
+)
+
+func (k ColumnSourceKind) String() string {
+	switch k {
+	case ColumnSourceTable:
+		return "table"
+	case ColumnSourceCTE:
+		return "cte"
+	case ColumnSourceSubquery:
+		return "subquery"
+	case ColumnSourceFunction:
+		return "function"
+	}
+	return "unknown"
+}
+
+// ColumnSource is the FROM-clause item a column reference is bound to
+type ColumnSource struct {
+	Kind ColumnSourceKind
+	// Name is the name the item is referenced by, i.e. its alias or its table, CTE or
+	// function name
+	Name string
+	// Schema and Table are the table of a ColumnSourceTable item as written in the query
+	Schema string
+	Table  string
+	// Location is the byte offset of the item in the query, or -1 if it's unknown
+	Location int32
+}
+
+// ColumnOrigin is a table column, e.g. the column read by a resolved column reference
+type ColumnOrigin struct {
+	Schema string
+	Table  string
+	Column string
+}
+
+// ColumnBinding is a column reference bound to the FROM-clause item providing the column
+type ColumnBinding struct {
+	// Ref is the ColumnRef node
+	Ref *Node
+	// Column is the name of the referenced column, or "" for a whole-row reference to a
+	// FROM-clause item (e.g. t in SELECT t FROM t)
+	Column string
+	// Source is the FROM-clause item providing the column, or nil if Err is set or for a
+	// whole-row reference to a join
+	Source *ColumnSource
+	// Origin is the table column read by the reference, traced through the subqueries and
+	// CTEs passing it on unchanged, or nil if the column is computed by an expression
+	Origin *ColumnOrigin
+	// Err is a *ColumnError if the reference can't be resolved
+	Err error
+}
+
+// ColumnErrorKind describes why a column reference can't be resolved
+type ColumnErrorKind int
+
+const (
+	// ColumnErrorUnknown is a column that isn't provided by any FROM-clause item in scope
+	ColumnErrorUnknown ColumnErrorKind = iota
+	// ColumnErrorAmbiguous is a column provided by several FROM-clause items, or by one while
+	// another is a table missing in the catalog
+	ColumnErrorAmbiguous
+	// ColumnErrorMissingTable is a qualified reference to a FROM-clause item that's not in scope
+	ColumnErrorMissingTable
+)
+
+// ColumnError is the error of a column reference that can't be resolved
+type ColumnError struct {
+	Kind ColumnErrorKind
+	// Name is the column reference as written, e.g. "u.email"
+	Name string
+	// Table is the qualifier of the column reference, e.g. "u"
+	Table    string
+	Location int32
+}
+
+func (e *ColumnError) Error() string {
+	switch e.Kind {
+	case ColumnErrorAmbiguous:
+		return fmt.Sprintf("column reference %q is ambiguous", e.Name)
+	case ColumnErrorMissingTable:
+		return fmt.Sprintf("missing FROM-clause entry for table %q", e.Table)
+	}
+	return fmt.Sprintf("column %q does not exist", e.Name)
+}
+
+// ResolveColumns binds the column references of the queries of a parse tree to the
+// FROM-clause items providing them, in the order they appear in the query
+//
+// References are looked up like PostgreSQL does: in the FROM clause of their own query
+// first, then in those of the enclosing queries. Subqueries in FROM only see the items to
+// their left when they are LATERAL, JOIN ... USING columns are merged into one, and ORDER
+// BY and GROUP BY may name output columns, which aren't reported. Star references like
+// "*" and "t.*" are left out too. A reference naming a FROM-clause item rather than a
+// column is a whole-row reference, and the system columns of tables (e.g. ctid and xmin)
+// are found like their other columns.
+//
+// Tables missing in the catalog have unknown columns: a qualified reference to such a
+// table is bound to it, as is an unqualified reference that can only come from a single
+// table. An unqualified reference to a column of a known table is ambiguous if a missing
+// table at the same level may provide it too. Other references that can't be resolved are
+// returned with an error.
+func ResolveColumns(tree *ParseResult, schema Catalog) []ColumnBinding {
+	var bindings []ColumnBinding
+	for _, stmt := range tree.GetStmts() {
+		r := &columnResolver{catalog: schema, resolved: map[*Node]scopeColumn{}}
+		r.stmt(stmt.GetStmt(), nil)
+		sort.SliceStable(r.bindings, func(i, j int) bool {
+			return r.bindings[i].Ref.GetColumnRef().Location < r.bindings[j].Ref.GetColumnRef().Location
+		})
+		bindings = append(bindings, r.bindings...)
+	}
+	return bindings
+}
+
+// scopeColumn is a column provided by a FROM-clause item or a query
+type scopeColumn struct {
+	name   string
+	source *ColumnSource
+	origin *ColumnOrigin
+	// qualifier is the name of the FROM-clause item the column is referenced through when
+	// a star is expanded, or "" if the column is referenced unqualified
+	qualifier string
+	// typ is the type name of the column if it's known, constant is set for constants
+	typ      string
+	constant bool
+}
+
+// rangeItem is a FROM-clause item with the columns it provides
+type rangeItem struct {
+	// name and schema are the names qualified column references use for the item
+	name   string
+	schema string
+	// source is nil for joins, whose columns each keep the source of their input
+	source  *ColumnSource
+	columns []scopeColumn
+	// complete is false if the item may have more columns than listed, e.g. for a table
+	// missing in the catalog, whose name is then held by unknown
+	complete bool
+	unknown  string
+	// hidden are the sources of the item that may have columns besides the listed ones, like
+	// tables missing in the catalog, so unqualified references can't rule them out
+	hidden []*ColumnSource
+	// system are the system columns of a table, or of the tables of an unaliased join,
+	// which are found by name but not expanded by stars
+	system []scopeColumn
+}
+
+// cteDef is a CTE of a WITH clause with its output columns
+type cteDef struct {
+	name     string
+	columns  []scopeColumn
+	complete bool
+}
+
+// nameScope holds the names visible to the column references of a query level
+type nameScope struct {
+	parent *nameScope
+	// items are the FROM-clause items qualified column references can name
+	items []*rangeItem
+	// sets are the top-level FROM-clause items searched by unqualified column references
+	sets []*rangeItem
+	ctes []*cteDef
+}
+
+// cte returns the CTE with the given name visible in the scope
+func (s *nameScope) cte(name string) *cteDef {
+	for ; s != nil; s = s.parent {
+		for i := len(s.ctes) - 1; i >= 0; i-- {
+			if s.ctes[i].name == name {
+				return s.ctes[i]
+			}
+		}
+	}
+	return nil
+}
+
+// lookup finds the column with the given name and qualifier (if any) in the scope
+func (s *nameScope) lookup(qualifier []string, name string) (scopeColumn, *ColumnError) {
+	for ; s != nil; s = s.parent {
+		if len(qualifier) == 0 {
+			var matches []scopeColumn
+			var incomplete []*rangeItem
+			for _, set := range s.sets {
+				matches = append(matches, set.column(name)...)
+				if !set.complete {
+					incomplete = append(incomplete, set)
+				}
+			}
+			switch {
+			case len(matches) == 1 && !hiddenBesides(s.sets, matches[0].source):
+				return matches[0], nil
+			case len(matches) > 0:
+				// A table missing in the catalog may have the column too
+				return scopeColumn{}, &ColumnError{Kind: ColumnErrorAmbiguous}
+			case len(incomplete) == 1 && incomplete[0].source != nil:
+				return incomplete[0].unlisted(name), nil
+			case len(incomplete) > 0:
+				return scopeColumn{}, &ColumnError{Kind: ColumnErrorUnknown}
+			}
+			continue
+		}
+
+		var items []*rangeItem
+		for _, item := range s.items {
+			if item.matches(qualifier) {
+				items = append(items, item)
+			}
+		}
+		if len(items) == 0 {
+			continue
+		}
+		if len(items) > 1 {
+			return scopeColumn{}, &ColumnError{Kind: ColumnErrorAmbiguous}
+		}
+		matches := items[0].column(name)
+		switch {
+		case len(matches) == 1:
+			return matches[0], nil
+		case len(matches) > 1:
+			return scopeColumn{}, &ColumnError{Kind: ColumnErrorAmbiguous}
+		case !items[0].complete && items[0].source != nil:
+			return items[0].unlisted(name), nil
+		}
+		return scopeColumn{}, &ColumnError{Kind: ColumnErrorUnknown}
+	}
+
+	if len(qualifier) > 0 {
+		return scopeColumn{}, &ColumnError{Kind: ColumnErrorMissingTable}
+	}
+	return scopeColumn{}, &ColumnError{Kind: ColumnErrorUnknown}
+}
+
+// hiddenBesides returns whether an item may have unlisted columns from another source than
+// the given one
+func hiddenBesides(items []*rangeItem, source *ColumnSource) bool {
+	for _, item := range items {
+		for _, hidden := range item.hidden {
+			if hidden != source {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// star returns the item whose columns a star reference with the given qualifier expands
+// to, or nil if there is none in scope
+//
+// The item returned for an unqualified star combines the top-level FROM-clause items of the
+// scope's own level.
+func (s *nameScope) star(qualifier []string) *rangeItem {
+	for ; s != nil; s = s.parent {
+		if len(qualifier) == 0 {
+			if len(s.sets) == 0 {
+				return nil
+			}
+			all := &rangeItem{complete: true}
+			for _, set := range s.sets {
+				all.columns = append(all.columns, set.columns...)
+				if !set.complete && all.complete {
+					all.complete, all.unknown = false, set.unknown
+				}
+			}
+			return all
+		}
+		for _, item := range s.items {
+			if item.matches(qualifier) {
+				return item
+			}
+		}
+	}
+	return nil
+}
+
+// matches returns whether a qualifier like "t" or "public.t" names the item
+func (item *rangeItem) matches(qualifier []string) bool {
+	switch len(qualifier) {
+	case 1:
+		return item.name == qualifier[0]
+	case 2, 3:
+		n := len(qualifier)
+		return item.schema != "" && item.schema == qualifier[n-2] && item.name == qualifier[n-1]
+	}
+	return false
+}
+
+// column returns the listed columns of the item with the given name, or the system
+// columns with that name if there are none
+func (item *rangeItem) column(name string) []scopeColumn {
+	var columns []scopeColumn
+	for _, column := range item.columns {
+		if column.name == name {
+			columns = append(columns, column)
+		}
+	}
+	if len(columns) == 0 {
+		for _, column := range item.system {
+			if column.name == name {
+				columns = append(columns, column)
+			}
+		}
+	}
+	return columns
+}
+
+// systemColumnTypes are the system columns of tables with their types
+var systemColumnTypes = []struct{ name, typ string }{
+	{"tableoid", "oid"},
+	{"cmax", "cid"},
+	{"xmax", "xid"},
+	{"cmin", "cid"},
+	{"xmin", "xid"},
+	{"ctid", "tid"},
+}
+
+// systemColumns returns the system columns of a table item
+func systemColumns(source *ColumnSource, def *TableDefinition) []scopeColumn {
+	columns := make([]scopeColumn, len(systemColumnTypes))
+	for i, column := range systemColumnTypes {
+		columns[i] = scopeColumn{
+			name:      column.name,
+			source:    source,
+			origin:    &ColumnOrigin{Schema: def.Schema, Table: def.Name, Column: column.name},
+			qualifier: source.Name,
+			typ:       column.typ,
+		}
+	}
+	return columns
+}
+
+// unlisted returns a column of an incomplete item that isn't listed in its columns
+func (item *rangeItem) unlisted(name string) scopeColumn {
+	column := scopeColumn{name: name, source: item.source, qualifier: item.name}
+	if item.source.Kind == ColumnSourceTable {
+		column.origin = &ColumnOrigin{Schema: item.source.Schema, Table: item.source.Table, Column: name}
+	}
+	return column
+}
+
+// columnResolver implements ResolveColumns
+type columnResolver struct {
+	catalog  Catalog
+	bindings []ColumnBinding
+	// resolved holds the columns the resolved column references are bound to
+	resolved map[*Node]scopeColumn
+	// stars holds the expansions of the star target entries for ExpandStars, if set, and
+	// starLists the target lists containing them
+	stars     map[*Node][]*Node
+	starLists []*[]*Node
+	// rewrite is set by ExpandStars, whose column references must not be ambiguous
+	rewrite bool
+	err     error
+}
+
+// stmt resolves the column references of a statement with the given enclosing scope and
+// returns its output columns, i.e. those of a SELECT or of a RETURNING list
+func (r *columnResolver) stmt(node *Node, outer *nameScope) ([]scopeColumn, bool) {
+	switch n := node.GetNode().(type) {
+	case *Node_SelectStmt:
+		return r.selectStmt(n.SelectStmt, outer)
+	case *Node_InsertStmt:
+		return r.insertStmt(n.InsertStmt, outer)
+	case *Node_UpdateStmt:
+		return r.updateStmt(n.UpdateStmt, outer)
+	case *Node_DeleteStmt:
+		return r.deleteStmt(n.DeleteStmt, outer)
+	case *Node_MergeStmt:
+		r.mergeStmt(n.MergeStmt, outer)
+		return nil, true
+	}
+
+	// Utility statements containing queries, e.g. CREATE VIEW or EXPLAIN
+	_ = Walk(func(child *Node) (bool, error) {
+		if child != node && isQuery(child) {
+			r.stmt(child, outer)
+			return false, nil
+		}
+		return true, nil
+	}, node)
+	return nil, true
+}
+
+func isQuery(node *Node) bool {
+	switch node.GetNode().(type) {
+	case *Node_SelectStmt, *Node_InsertStmt, *Node_UpdateStmt, *Node_DeleteStmt, *Node_MergeStmt:
+		return true
+	}
+	return false
+}
+
+func (r *columnResolver) selectStmt(stmt *SelectStmt, outer *nameScope) ([]scopeColumn, bool) {
+	scope := r.withClause(stmt.WithClause, outer)
+
+	if stmt.Op != SetOperation_SETOP_NONE {
+		columns, complete := r.selectStmt(stmt.Larg, scope)
+		right, _ := r.selectStmt(stmt.Rarg, scope)
+		columns = setOpColumns(columns, right)
+		r.outputRefs(stmt.SortClause, columns, scope)
+		r.expr(stmt.LimitOffset, scope)
+		r.expr(stmt.LimitCount, scope)
+		return columns, complete
+	}
+
+	if len(stmt.ValuesLists) > 0 {
+		var columns []scopeColumn
+		for i, row := range stmt.ValuesLists {
+			items := row.GetList().GetItems()
+			r.exprs(items, scope)
+			for j, item := range items {
+				column := r.exprColumn(item)
+				column.name = fmt.Sprintf("column%d", j+1)
+				if i == 0 {
+					columns = append(columns, column)
+				} else if j < len(columns) {
+					columns[j] = mergeColumns(columns[j], column)
+				}
+			}
+		}
+		r.outputRefs(stmt.SortClause, columns, scope)
+		r.expr(stmt.LimitOffset, scope)
+		r.expr(stmt.LimitCount, scope)
+		return columns, true
+	}
+
+	level := &nameScope{parent: scope}
+	for _, item := range stmt.FromClause {
+		level.sets = append(level.sets, r.fromItem(item, scope, level))
+	}
+	r.exprs(stmt.TargetList, level)
+	r.expr(stmt.WhereClause, level)
+	for _, item := range stmt.GroupClause {
+		if !isOutputName(item, stmt.TargetList, level, false) {
+			r.expr(item, level)
+		}
+	}
+	r.expr(stmt.HavingClause, level)
+	r.exprs(stmt.WindowClause, level)
+	r.exprs(stmt.DistinctClause, level)
+	for _, item := range stmt.SortClause {
+		if !isOutputName(item.GetSortBy().GetNode(), stmt.TargetList, level, true) {
+			r.expr(item, level)
+		}
+	}
+	r.expr(stmt.LimitOffset, level)
+	r.expr(stmt.LimitCount, level)
+	return r.targetColumns(&stmt.TargetList, level)
+}
+
+// setOpColumns returns the output columns of a set operation, named after the left side
+func setOpColumns(left, right []scopeColumn) []scopeColumn {
+	columns := make([]scopeColumn, len(left))
+	for i, column := range left {
+		columns[i] = scopeColumn{name: column.name, typ: column.typ}
+		if i < len(right) {
+			columns[i] = mergeColumns(column, right[i])
+		}
+	}
+	return columns
+}
+
+// mergeColumns returns the column combining the values of two columns, e.g. of both sides
+// of a UNION, which keeps the properties they agree on
+func mergeColumns(a, b scopeColumn) scopeColumn {
+	column := scopeColumn{name: a.name, constant: a.constant && b.constant}
+	if a.typ == b.typ {
+		column.typ = a.typ
+	}
+	if a.origin != nil && b.origin != nil && *a.origin == *b.origin {
+		column.origin = a.origin
+	}
+	return column
+}
+
+// isOutputName returns whether a GROUP BY or ORDER BY item names an output column of the
+// target list rather than an input column
+//
+// Like in PostgreSQL, output columns take precedence in ORDER BY and input columns in GROUP BY.
+func isOutputName(node *Node, targetList []*Node, level *nameScope, preferOutput bool) bool {
+	fields := node.GetColumnRef().GetFields()
+	if len(fields) != 1 || fields[0].GetString_() == nil {
+		return false
+	}
+	name := strVal(fields[0])
+	found := false
+	for _, target := range targetList {
+		if target.GetResTarget().GetName() == name {
+			found = true
+		}
+	}
+	if !found || preferOutput {
+		return found
+	}
+	_, err := level.lookup(nil, name)
+	return err != nil
+}
+
+// outputRefs resolves the ORDER BY items of a set operation or VALUES list, which can
+// name its output columns
+func (r *columnResolver) outputRefs(sortClause []*Node, columns []scopeColumn, scope *nameScope) {
+	for _, item := range sortClause {
+		fields := item.GetSortBy().GetNode().GetColumnRef().GetFields()
+		if len(fields) == 1 {
+			name := strVal(fields[0])
+			found := false
+			for _, column := range columns {
+				found = found || column.name == name
+			}
+			if found {
+				continue
+			}
+		}
+		r.expr(item, scope)
+	}
+}
+
+// withClause resolves the CTEs of a WITH clause and returns the scope they are visible in
+//
+// The queries of a non-recursive WITH clause see the CTEs defined before them, those of a
+// WITH RECURSIVE clause see all of them. The recursive term of a CTE sees the columns of
+// its non-recursive term.
+func (r *columnResolver) withClause(withClause *WithClause, outer *nameScope) *nameScope {
+	if withClause == nil {
+		return outer
+	}
+
+	scope := &nameScope{parent: outer}
+	if withClause.Recursive {
+		for _, item := range withClause.Ctes {
+			scope.ctes = append(scope.ctes, &cteDef{name: item.GetCommonTableExpr().GetCtename()})
+		}
+	}
+	for i, item := range withClause.Ctes {
+		cte := item.GetCommonTableExpr()
+		def := &cteDef{name: cte.Ctename}
+		if withClause.Recursive {
+			def = scope.ctes[i]
+		}
+
+		query := cte.Ctequery.GetSelectStmt()
+		if withClause.Recursive && query != nil && query.Op == SetOperation_SETOP_UNION {
+			inner := r.withClause(query.WithClause, scope)
+			def.columns, def.complete = r.selectStmt(query.Larg, inner)
+			def.columns = renameColumns(def.columns, cte.Aliascolnames)
+			right, _ := r.selectStmt(query.Rarg, inner)
+			def.columns = setOpColumns(def.columns, right)
+			r.outputRefs(query.SortClause, def.columns, inner)
+			continue
+		}
+
+		def.columns, def.complete = r.stmt(cte.Ctequery, scope)
+		def.columns = renameColumns(def.columns, cte.Aliascolnames)
+		if !withClause.Recursive {
+			scope.ctes = append(scope.ctes, def)
+		}
+	}
+	return scope
+}
+
+// fromItem resolves a FROM-clause item, adds the items that can be named by qualified
+// column references to the level and returns the item
+//
+// Subqueries only see the enclosing scope, unless they are LATERAL. Functions are
+// implicitly LATERAL.
+func (r *columnResolver) fromItem(node *Node, outer, level *nameScope) *rangeItem {
+	switch n := node.GetNode().(type) {
+	case *Node_RangeVar:
+		item := r.rangeVar(n.RangeVar, outer)
+		level.items = append(level.items, item)
+		return item
+	case *Node_RangeSubselect:
+		scope := outer
+		if n.RangeSubselect.Lateral {
+			scope = level
+		}
+		columns, complete := r.stmt(n.RangeSubselect.Subquery, scope)
+		name := n.RangeSubselect.GetAlias().GetAliasname()
+		item := newRangeItem(name, &ColumnSource{Kind: ColumnSourceSubquery, Name: name, Location: -1}, columns, complete)
+		item.columns = renameColumns(item.columns, n.RangeSubselect.GetAlias().GetColnames())
+		level.items = append(level.items, item)
+		return item
+	case *Node_RangeFunction:
+		item := r.rangeFunction(n.RangeFunction, level)
+		level.items = append(level.items, item)
+		return item
+	case *Node_RangeTableFunc:
+		f := n.RangeTableFunc
+		r.expr(f.Docexpr, level)
+		r.expr(f.Rowexpr, level)
+		r.exprs(f.Namespaces, level)
+		var columns []scopeColumn
+		for _, column := range f.Columns {
+			c := column.GetRangeTableFuncCol()
+			r.expr(c.Colexpr, level)
+			r.expr(c.Coldefexpr, level)
+			columns = append(columns, scopeColumn{name: c.Colname, typ: typeNameString(c.TypeName)})
+		}
+		name := f.GetAlias().GetAliasname()
+		item := newRangeItem(name, &ColumnSource{Kind: ColumnSourceFunction, Name: name, Location: f.Location}, columns, true)
+		item.columns = renameColumns(item.columns, f.GetAlias().GetColnames())
+		level.items = append(level.items, item)
+		return item
+	case *Node_RangeTableSample:
+		item := r.fromItem(n.RangeTableSample.Relation, outer, level)
+		r.exprs(n.RangeTableSample.Args, level)
+		r.expr(n.RangeTableSample.Repeatable, level)
+		return item
+	case *Node_JoinExpr:
+		return r.joinExpr(n.JoinExpr, outer, level)
+	}
+	return &rangeItem{}
+}
+
+func newRangeItem(name string, source *ColumnSource, columns []scopeColumn, complete bool) *rangeItem {
+	item := &rangeItem{name: name, source: source, complete: complete}
+	if !complete {
+		item.hidden = []*ColumnSource{source}
+		item.unknown = name
+		if name == "" {
+			item.unknown = source.Kind.String()
+		}
+	}
+	for _, column := range columns {
+		item.columns = append(item.columns, scopeColumn{
+			name:      column.name,
+			source:    source,
+			origin:    column.origin,
+			qualifier: name,
+			typ:       column.typ,
+			constant:  column.constant,
+		})
+	}
+	return item
+}
+
+// renameColumns returns the columns with the leading ones renamed by the column aliases
+func renameColumns(columns []scopeColumn, colnames []*Node) []scopeColumn {
+	if len(colnames) == 0 {
+		return columns
+	}
+	renamed := append([]scopeColumn(nil), columns...)
+	for i, name := range colnames {
+		if i < len(renamed) {
+			renamed[i].name = strVal(name)
+		}
+	}
+	return renamed
+}
+
+// qualifyColumns returns the columns with the given qualifier
+func qualifyColumns(columns []scopeColumn, qualifier string) []scopeColumn {
+	qualified := make([]scopeColumn, len(columns))
+	for i, column := range columns {
+		qualified[i] = column
+		qualified[i].qualifier = qualifier
+	}
+	return qualified
+}
+
+// rangeVar returns the item of a table or CTE reference
+func (r *columnResolver) rangeVar(rangeVar *RangeVar, outer *nameScope) *rangeItem {
+	name := rangeVar.Relname
+	if rangeVar.Alias != nil {
+		name = rangeVar.Alias.Aliasname
+	}
+	colnames := rangeVar.GetAlias().GetColnames()
+
+	if rangeVar.Schemaname == "" {
+		if cte := outer.cte(rangeVar.Relname); cte != nil {
+			source := &ColumnSource{Kind: ColumnSourceCTE, Name: name, Location: rangeVar.Location}
+			item := newRangeItem(name, source, cte.columns, cte.complete)
+			item.columns = renameColumns(item.columns, colnames)
+			return item
+		}
+	}
+
+	source := &ColumnSource{
+		Kind:     ColumnSourceTable,
+		Name:     name,
+		Schema:   rangeVar.Schemaname,
+		Table:    rangeVar.Relname,
+		Location: rangeVar.Location,
+	}
+	item := &rangeItem{name: name, source: source}
+	if rangeVar.Alias == nil {
+		item.schema = rangeVar.Schemaname
+	}
+	if r.catalog != nil {
+		if def, ok := r.catalog.Table(rangeVar.Schemaname, rangeVar.Relname); ok {
+			if rangeVar.Alias == nil {
+				item.schema = def.Schema
+			}
+			item.complete = true
+			item.system = systemColumns(source, def)
+			for _, column := range def.Columns {
+				origin := &ColumnOrigin{Schema: def.Schema, Table: def.Name, Column: column.Name}
+				item.columns = append(item.columns, scopeColumn{name: column.Name, source: source, origin: origin, qualifier: name, typ: column.Type})
+			}
+		}
+	}
+	if !item.complete {
+		item.hidden = []*ColumnSource{source}
+		item.unknown = rangeVar.Relname
+		if rangeVar.Schemaname != "" {
+			item.unknown = rangeVar.Schemaname + "." + rangeVar.Relname
+		}
+
+		// The aliases name the leading columns of a table missing in the catalog
+		for _, colname := range colnames {
+			item.columns = append(item.columns, scopeColumn{name: strVal(colname), source: source, qualifier: name})
+		}
+		return item
+	}
+	item.columns = renameColumns(item.columns, colnames)
+	return item
+}
+
+// rangeFunction returns the item of a function call in FROM
+//
+// The columns are known from a column definition list or column aliases, otherwise the
+// function is assumed to return a single column named after the item.
+func (r *columnResolver) rangeFunction(rangeFunction *RangeFunction, level *nameScope) *rangeItem {
+	name := rangeFunction.GetAlias().GetAliasname()
+	var columns []scopeColumn
+	complete := true
+	for _, function := range rangeFunction.Functions {
+		items := function.GetList().GetItems()
+		if len(items) == 0 {
+			continue
+		}
+		r.expr(items[0], level)
+		funcName := ""
+		if names := items[0].GetFuncCall().GetFuncname(); len(names) > 0 {
+			funcName = strVal(names[len(names)-1])
+		}
+		if name == "" {
+			name = funcName
+		}
+
+		coldeflist := rangeFunction.Coldeflist
+		if len(items) > 1 {
+			coldeflist = append(coldeflist, items[1].GetList().GetItems()...)
+		}
+		switch {
+		case len(coldeflist) > 0:
+			for _, column := range coldeflist {
+				def := column.GetColumnDef()
+				columns = append(columns, scopeColumn{name: def.GetColname(), typ: typeNameString(def.GetTypeName())})
+			}
+		case len(rangeFunction.Functions) == 1:
+			columns = append(columns, scopeColumn{name: name})
+			complete = len(rangeFunction.GetAlias().GetColnames()) > 0
+		default:
+			columns = append(columns, scopeColumn{name: funcName})
+			complete = false
+		}
+	}
+	if rangeFunction.Ordinality {
+		columns = append(columns, scopeColumn{name: "ordinality"})
+	}
+
+	item := newRangeItem(name, &ColumnSource{Kind: ColumnSourceFunction, Name: name, Location: -1}, columns, complete)
+	item.columns = renameColumns(item.columns, rangeFunction.GetAlias().GetColnames())
+	if len(rangeFunction.Functions) == 1 {
+		// A single function without column definitions is most likely scalar, with the
+		// single column it's assumed to have
+		item.hidden = nil
+	}
+	return item
+}
+
+// joinExpr resolves a JOIN and returns its item, whose columns are the merged USING (or
+// NATURAL) columns followed by the other columns of both sides
+//
+// The merged columns are bound to the left side, or the right side of a RIGHT JOIN. A join
+// alias hides the names of the items inside the join.
+func (r *columnResolver) joinExpr(join *JoinExpr, outer, level *nameScope) *rangeItem {
+	n := len(level.items)
+	left := r.fromItem(join.Larg, outer, level)
+	right := r.fromItem(join.Rarg, outer, level)
+
+	var using []string
+	for _, name := range join.UsingClause {
+		using = append(using, strVal(name))
+	}
+	if join.IsNatural {
+		for _, column := range left.columns {
+			if len(right.column(column.name)) > 0 {
+				using = append(using, column.name)
+			}
+		}
+	}
+
+	item := &rangeItem{complete: left.complete && right.complete, unknown: left.unknown}
+	item.hidden = append(append([]*ColumnSource(nil), left.hidden...), right.hidden...)
+	if item.unknown == "" {
+		item.unknown = right.unknown
+	}
+	merged := map[string]bool{}
+	var usingColumns []scopeColumn
+	for _, name := range using {
+		l, rr := left.column(name), right.column(name)
+		var column scopeColumn
+		switch {
+		case len(rr) > 0 && (join.Jointype == JoinType_JOIN_RIGHT || len(l) == 0):
+			column = rr[0]
+		case len(l) > 0:
+			column = l[0]
+		case left.source != nil && !left.complete:
+			column = left.unlisted(name)
+		default:
+			column = scopeColumn{name: name}
+		}
+		if join.Jointype == JoinType_JOIN_FULL {
+			// The merged column of a FULL JOIN is the coalesced value of both sides
+			column.qualifier = ""
+		}
+		usingColumns = append(usingColumns, column)
+		merged[name] = true
+	}
+	item.columns = append(item.columns, usingColumns...)
+	for _, side := range []*rangeItem{left, right} {
+		for _, column := range side.columns {
+			if !merged[column.name] {
+				item.columns = append(item.columns, column)
+			}
+		}
+	}
+
+	on := &nameScope{parent: outer, items: level.items[n:], sets: []*rangeItem{left, right}}
+	r.expr(join.Quals, on)
+
+	if join.Alias == nil {
+		item.system = append(append([]scopeColumn(nil), left.system...), right.system...)
+	} else {
+		item.name = join.Alias.Aliasname
+		item.columns = qualifyColumns(renameColumns(item.columns, join.Alias.Colnames), item.name)
+		level.items = append(level.items[:n], item)
+	}
+	if join.JoinUsingAlias != nil {
+		name := join.JoinUsingAlias.Aliasname
+		level.items = append(level.items, &rangeItem{name: name, columns: qualifyColumns(usingColumns, name), complete: true})
+	}
+	return item
+}
+
+func (r *columnResolver) insertStmt(stmt *InsertStmt, outer *nameScope) ([]scopeColumn, bool) {
+	scope := r.withClause(stmt.WithClause, outer)
+	if stmt.SelectStmt != nil {
+		r.stmt(stmt.SelectStmt, scope)
+	}
+
+	target := r.rangeVar(stmt.Relation, nil)
+	level := &nameScope{parent: scope, items: []*rangeItem{target}, sets: []*rangeItem{target}}
+	if onConflict := stmt.OnConflictClause; onConflict != nil {
+		r.exprs(onConflict.GetInfer().GetIndexElems(), level)
+		r.expr(onConflict.GetInfer().GetWhereClause(), level)
+
+		// The row proposed for insertion is available as "excluded"
+		source := *target.source
+		source.Name = "excluded"
+		excluded := newRangeItem("excluded", &source, target.columns, target.complete)
+		conflict := &nameScope{parent: scope, items: []*rangeItem{target, excluded}, sets: []*rangeItem{target, excluded}}
+		r.exprs(onConflict.TargetList, conflict)
+		r.expr(onConflict.WhereClause, conflict)
+	}
+	r.exprs(stmt.ReturningList, level)
+	return r.targetColumns(&stmt.ReturningList, level)
+}
+
+func (r *columnResolver) updateStmt(stmt *UpdateStmt, outer *nameScope) ([]scopeColumn, bool) {
+	scope := r.withClause(stmt.WithClause, outer)
+	target := r.rangeVar(stmt.Relation, nil)
+	level := &nameScope{parent: scope, items: []*rangeItem{target}, sets: []*rangeItem{target}}
+	for _, item := range stmt.FromClause {
+		level.sets = append(level.sets, r.fromItem(item, scope, level))
+	}
+	r.exprs(stmt.TargetList, level)
+	r.expr(stmt.WhereClause, level)
+	r.exprs(stmt.ReturningList, level)
+	return r.targetColumns(&stmt.ReturningList, level)
+}
+
+func (r *columnResolver) deleteStmt(stmt *DeleteStmt, outer *nameScope) ([]scopeColumn, bool) {
+	scope := r.withClause(stmt.WithClause, outer)
+	target := r.rangeVar(stmt.Relation, nil)
+	level := &nameScope{parent: scope, items: []*rangeItem{target}, sets: []*rangeItem{target}}
+	for _, item := range stmt.UsingClause {
+		level.sets = append(level.sets, r.fromItem(item, scope, level))
+	}
+	r.expr(stmt.WhereClause, level)
+	r.exprs(stmt.ReturningList, level)
+	return r.targetColumns(&stmt.ReturningList, level)
+}
+
+func (r *columnResolver) mergeStmt(stmt *MergeStmt, outer *nameScope) {
+	scope := r.withClause(stmt.WithClause, outer)
+	target := r.rangeVar(stmt.Relation, nil)
+	level := &nameScope{parent: scope, items: []*rangeItem{target}, sets: []*rangeItem{target}}
+	level.sets = append(level.sets, r.fromItem(stmt.SourceRelation, scope, level))
+	r.expr(stmt.JoinCondition, level)
+	for _, item := range stmt.MergeWhenClauses {
+		when := item.GetMergeWhenClause()
+		r.expr(when.Condition, level)
+		r.exprs(when.TargetList, level)
+		r.exprs(when.Values, level)
+	}
+}
+
+// targetColumns returns the output columns of a target list, expanding star references
+func (r *columnResolver) targetColumns(targetList *[]*Node, level *nameScope) ([]scopeColumn, bool) {
+	var columns []scopeColumn
+	complete := true
+	for _, node := range *targetList {
+		target := node.GetResTarget()
+		if names, star := columnRefNames(target.GetVal().GetColumnRef()); star {
+			item := level.star(names)
+			if item == nil || !item.complete {
+				complete = false
+			}
+			if r.stars != nil {
+				r.expandStar(targetList, node, names, item)
+			}
+			if item != nil {
+				columns = append(columns, item.columns...)
+			}
+			continue
+		}
+
+		column := r.exprColumn(target.GetVal())
+		column.name = target.GetName()
+		if column.name == "" {
+			column.name = figureColname(target.GetVal())
+		}
+		columns = append(columns, column)
+	}
+	return columns, complete
+}
+
+// exprColumn returns the source, type and constness of the output column of an expression
+// whose column references have been resolved
+func (r *columnResolver) exprColumn(node *Node) scopeColumn {
+	switch n := node.GetNode().(type) {
+	case *Node_ColumnRef:
+		resolved := r.resolved[node]
+		return scopeColumn{source: resolved.source, origin: resolved.origin, typ: resolved.typ, constant: resolved.constant}
+	case *Node_AConst:
+		return scopeColumn{typ: constType(n.AConst), constant: true}
+	case *Node_TypeCast:
+		arg := r.exprColumn(n.TypeCast.Arg)
+		return scopeColumn{typ: typeNameString(n.TypeCast.TypeName), constant: arg.constant}
+	}
+	return scopeColumn{}
+}
+
+// constType returns the type PostgreSQL gives to a constant
+func constType(aConst *A_Const) string {
+	switch {
+	case aConst.Isnull, aConst.GetSval() != nil:
+		return "text"
+	case aConst.GetIval() != nil:
+		return "int"
+	case aConst.GetBoolval() != nil:
+		return "boolean"
+	case aConst.GetBsval() != nil:
+		return "bit"
+	case aConst.GetFval() != nil:
+		// Integers beyond the range of int are bigint, or numeric beyond that of bigint. They
+		// may be written in hexadecimal, octal or binary and contain underscores.
+		digits := strings.ReplaceAll(aConst.GetFval().Fval, "_", "")
+		base := 10
+		if unsigned := strings.TrimLeft(digits, "+-"); len(unsigned) > 1 && unsigned[0] == '0' && !isDigit(unsigned[1]) {
+			base = 0
+		}
+		if _, err := strconv.ParseInt(digits, base, 64); err == nil {
+			return "bigint"
+		}
+		return "numeric"
+	}
+	return ""
+}
+
+// typeNameString returns the deparsed type name, or "" if it can't be deparsed
+func typeNameString(typeName *TypeName) string {
+	if typeName == nil {
+		return ""
+	}
+	name, err := DeparseNode(&Node{Node: &Node_TypeName{TypeName: typeName}})
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+func (r *columnResolver) exprs(nodes []*Node, scope *nameScope) {
+	for _, node := range nodes {
+		r.expr(node, scope)
+	}
+}
+
+// expr resolves the column references of an expression, including those of its sublinks
+func (r *columnResolver) expr(node *Node, scope *nameScope) {
+	_ = Walk(func(node *Node) (bool, error) {
+		switch n := node.GetNode().(type) {
+		case *Node_ColumnRef:
+			r.columnRef(node, scope)
+			return false, nil
+		case *Node_SubLink:
+			r.expr(n.SubLink.Testexpr, scope)
+			r.stmt(n.SubLink.Subselect, scope)
+			return false, nil
+		}
+		if isQuery(node) {
+			r.stmt(node, scope)
+			return false, nil
+		}
+		return true, nil
+	}, node)
+}
+
+func (r *columnResolver) columnRef(node *Node, scope *nameScope) {
+	names, star := columnRefNames(node.GetColumnRef())
+	if star || len(names) == 0 {
+		return
+	}
+
+	qualifier, name := names[:len(names)-1], names[len(names)-1]
+	binding := ColumnBinding{Ref: node, Column: name}
+	column, err := scope.lookup(qualifier, name)
+	if err != nil && err.Kind != ColumnErrorAmbiguous {
+		// A reference that isn't a column may name a FROM-clause item as a whole row, e.g. t in
+		// SELECT t FROM t or (t).id
+		if item := scope.star(names); item != nil {
+			binding.Column = ""
+			binding.Source = item.source
+			r.resolved[node] = scopeColumn{name: item.name, source: item.source, qualifier: item.name}
+			r.bindings = append(r.bindings, binding)
+			return
+		}
+	}
+	if err != nil {
+		err.Name = strings.Join(names, ".")
+		err.Table = strings.Join(qualifier, ".")
+		err.Location = node.GetColumnRef().Location
+		binding.Err = err
+	} else {
+		binding.Source, binding.Origin = column.source, column.origin
+		r.resolved[node] = column
+	}
+	r.bindings = append(r.bindings, binding)
+}
+
+// columnRefNames returns the names of a column reference without its trailing star, if any
+func columnRefNames(columnRef *ColumnRef) (names []string, star bool) {
+	for _, field := range columnRef.GetFields() {
+		if field.GetAStar() != nil {
+			star = true
+			continue
+		}
+		names = append(names, strVal(field))
+	}
+	return
+}
+
+// figureColname returns the name PostgreSQL gives to an output column without alias
+func figureColname(node *Node) string {
+	switch n := node.GetNode().(type) {
+	case *Node_ColumnRef:
+		fields := n.ColumnRef.Fields
+		if len(fields) > 0 && fields[len(fields)-1].GetString_() != nil {
+			return strVal(fields[len(fields)-1])
+		}
+	case *Node_AIndirection:
+		indirection := n.AIndirection.Indirection
+		if len(indirection) > 0 && indirection[len(indirection)-1].GetString_() != nil {
+			return strVal(indirection[len(indirection)-1])
+		}
+		return figureColname(n.AIndirection.Arg)
+	case *Node_FuncCall:
+		if names := n.FuncCall.Funcname; len(names) > 0 {
+			return strVal(names[len(names)-1])
+		}
+	case *Node_AExpr:
+		if n.AExpr.Kind == A_Expr_Kind_AEXPR_NULLIF {
+			return "nullif"
+		}
+	case *Node_TypeCast:
+		if name := figureColname(n.TypeCast.Arg); name != "?column?" {
+			return name
+		}
+		if names := n.TypeCast.GetTypeName().GetNames(); len(names) > 0 {
+			return strVal(names[len(names)-1])
+		}
+	case *Node_CollateClause:
+		return figureColname(n.CollateClause.Arg)
+	case *Node_GroupingFunc:
+		return "grouping"
+	case *Node_SubLink:
+		switch n.SubLink.SubLinkType {
+		case SubLinkType_EXISTS_SUBLINK:
+			return "exists"
+		case SubLinkType_ARRAY_SUBLINK:
+			return "array"
+		case SubLinkType_EXPR_SUBLINK:
+			targets := n.SubLink.Subselect.GetSelectStmt().GetTargetList()
+			if len(targets) > 0 {
+				if name := targets[0].GetResTarget().GetName(); name != "" {
+					return name
+				}
+				return figureColname(targets[0].GetResTarget().GetVal())
+			}
+		}
+	case *Node_CaseExpr:
+		return "case"
+	case *Node_AArrayExpr:
+		return "array"
+	case *Node_RowExpr:
+		return "row"
+	case *Node_CoalesceExpr:
+		return "coalesce"
+	case *Node_MinMaxExpr:
+		if n.MinMaxExpr.Op == MinMaxOp_IS_LEAST {
+			return "least"
+		}
+		return "greatest"
+	case *Node_SqlvalueFunction:
+		return sqlValueFunctionNames[n.SqlvalueFunction.Op]
+	}
+	return "?column?"
+}
+
+var sqlValueFunctionNames = map[SQLValueFunctionOp]string{
+	SQLValueFunctionOp_SVFOP_CURRENT_DATE:        "current_date",
+	SQLValueFunctionOp_SVFOP_CURRENT_TIME:        "current_time",
+	SQLValueFunctionOp_SVFOP_CURRENT_TIME_N:      "current_time",
+	SQLValueFunctionOp_SVFOP_CURRENT_TIMESTAMP:   "current_timestamp",
+	SQLValueFunctionOp_SVFOP_CURRENT_TIMESTAMP_N: "current_timestamp",
+	SQLValueFunctionOp_SVFOP_LOCALTIME:           "localtime",
+	SQLValueFunctionOp_SVFOP_LOCALTIME_N:         "localtime",
+	SQLValueFunctionOp_SVFOP_LOCALTIMESTAMP:      "localtimestamp",
+	SQLValueFunctionOp_SVFOP_LOCALTIMESTAMP_N:    "localtimestamp",
+	SQLValueFunctionOp_SVFOP_CURRENT_ROLE:        "current_role",
+	SQLValueFunctionOp_SVFOP_CURRENT_USER:        "current_user",
+	SQLValueFunctionOp_SVFOP_USER:                "user",
+	SQLValueFunctionOp_SVFOP_SESSION_USER:        "session_user",
+	SQLValueFunctionOp_SVFOP_CURRENT_CATALOG:     "current_catalog",
+	SQLValueFunctionOp_SVFOP_CURRENT_SCHEMA:      "current_schema",
+}
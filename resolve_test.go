@@ -0,0 +1,173 @@
+//go:build cgo
+// +build cgo
+
+package pg_query_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+)
+
+// testCatalog maps qualified table names to their columns
+type testCatalog map[string][]string
+
+func (c testCatalog) Table(schema, name string) (*pg_query.TableDefinition, bool) {
+	if schema == "" {
+		schema = "public"
+	}
+	columns, ok := c[schema+"."+name]
+	if !ok {
+		return nil, false
+	}
+	table := &pg_query.TableDefinition{Schema: schema, Name: name}
+	for _, column := range columns {
+		table.Columns = append(table.Columns, pg_query.TableColumn{Name: column, Type: "text"})
+	}
+	return table, true
+}
+
+var resolveCatalog = testCatalog{
+	"public.users":  {"id", "email", "name"},
+	"public.orders": {"id", "user_id", "total"},
+}
+
+var resolveColumnsTests = []struct {
+	input    string
+	expected []string
+}{
+	{
+		"SELECT id, email FROM users",
+		[]string{"id: table users public.users.id", "email: table users public.users.email"},
+	},
+	{
+		"SELECT u.email, total FROM users u JOIN orders o ON o.user_id = u.id",
+		[]string{
+			"u.email: table u public.users.email",
+			"total: table o public.orders.total",
+			"o.user_id: table o public.orders.user_id",
+			"u.id: table u public.users.id",
+		},
+	},
+	{
+		"SELECT id FROM users, orders",
+		[]string{`id: column reference "id" is ambiguous`},
+	},
+	{
+		"SELECT id, name FROM users JOIN orders USING (id)",
+		[]string{"id: table users public.users.id", "name: table users public.users.name"},
+	},
+	{
+		"SELECT s.mail FROM (SELECT email AS mail, 1 AS one FROM users) s WHERE one = 1",
+		[]string{
+			"s.mail: subquery s public.users.email",
+			"email: table users public.users.email",
+			"one: subquery s",
+		},
+	},
+	{
+		"WITH x AS (SELECT * FROM users) SELECT x.email FROM x",
+		[]string{"x.email: cte x public.users.email"},
+	},
+	{
+		"SELECT e FROM users, LATERAL (SELECT email AS e WHERE id > 1) s",
+		[]string{
+			"e: subquery s public.users.email",
+			"email: table users public.users.email",
+			"id: table users public.users.id",
+		},
+	},
+	{
+		"SELECT 1 FROM users, (SELECT email) s",
+		[]string{`email: column "email" does not exist`},
+	},
+	{
+		"SELECT name FROM users WHERE EXISTS (SELECT 1 FROM orders WHERE user_id = users.id)",
+		[]string{
+			"name: table users public.users.name",
+			"user_id: table orders public.orders.user_id",
+			"users.id: table users public.users.id",
+		},
+	},
+	{
+		"SELECT x.a, public.users.id FROM users",
+		[]string{`x.a: missing FROM-clause entry for table "x"`, "public.users.id: table users public.users.id"},
+	},
+	{
+		"SELECT a, t.b FROM logs t",
+		[]string{"a: table t .logs.a", "t.b: table t .logs.b"},
+	},
+	{
+		"SELECT lower(email) AS m FROM users GROUP BY m ORDER BY m",
+		[]string{"email: table users public.users.email"},
+	},
+	{
+		"INSERT INTO users (id) VALUES (1) ON CONFLICT (id) DO UPDATE SET email = excluded.email RETURNING name",
+		[]string{"excluded.email: table excluded public.users.email", "name: table users public.users.name"},
+	},
+	{
+		"WITH RECURSIVE r(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM r) SELECT n FROM r",
+		[]string{"n: cte r", "n: cte r"},
+	},
+	{
+		"SELECT j.email, u.id FROM (users u JOIN orders o ON true) AS j",
+		[]string{"j.email: table u public.users.email", `u.id: missing FROM-clause entry for table "u"`},
+	},
+	{
+		"SELECT g, x FROM generate_series(1, 3) g, unnest(array[1]) AS u(x)",
+		[]string{"g: function g", "x: function u"},
+	},
+	{
+		"SELECT users, (u).id FROM users, orders u WHERE u.xmin = 1 OR ctid IS NULL",
+		[]string{"users: table users", "u: table u", "u.xmin: table u public.orders.xmin", `ctid: column reference "ctid" is ambiguous`},
+	},
+	{
+		"SELECT id, users.email FROM logs, users",
+		[]string{`id: column reference "id" is ambiguous`, "users.email: table users public.users.email"},
+	},
+	{
+		"SELECT name FROM (SELECT * FROM logs) s JOIN users ON true",
+		[]string{`name: column reference "name" is ambiguous`},
+	},
+	{
+		"SELECT j FROM (users JOIN orders USING (id)) j WHERE id = 1",
+		[]string{"j: join", "id: table users public.users.id"},
+	},
+}
+
+func TestResolveColumns(t *testing.T) {
+	for _, test := range resolveColumnsTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		var actual []string
+		for _, binding := range pg_query.ResolveColumns(tree, resolveCatalog) {
+			ref, err := pg_query.DeparseNode(binding.Ref)
+			if err != nil {
+				t.Fatalf("DeparseNode(%s)\nerror %s\n\n", test.input, err)
+			}
+			if binding.Err != nil {
+				actual = append(actual, fmt.Sprintf("%s: %s", ref, binding.Err))
+				continue
+			}
+			if binding.Source == nil {
+				actual = append(actual, fmt.Sprintf("%s: join", ref))
+				continue
+			}
+			description := fmt.Sprintf("%s: %s %s", ref, binding.Source.Kind, binding.Source.Name)
+			if origin := binding.Origin; origin != nil {
+				description += fmt.Sprintf(" %s.%s.%s", origin.Schema, origin.Table, origin.Column)
+			}
+			actual = append(actual, description)
+		}
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("ResolveColumns(%s)\nexpected %q\nactual %q\n\n", test.input, test.expected, actual)
+		}
+	}
+}
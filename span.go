@@ -0,0 +1,482 @@
+//go:build cgo
+// +build cgo
+
+package pg_query
+
+import (
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Span returns the byte range of the source text of a node that was parsed from source
+//
+// The range starts at the smallest location within the subtree of the node and ends after
+// its last token, which is found in the Scan token stream of source: the range is widened
+// to include qualified names, parentheses and CASE ... END blocks opened or closed inside
+// it, trailing keywords without a location of their own (e.g. IS NULL, DESC NULLS LAST or
+// the alias of a table or target list entry) and the leading keywords of statements.
+// Statements nested in other statements extend up to their closing parenthesis, a RawStmt
+// node spans its statement without the surrounding whitespace and comments.
+//
+// Parentheses that belong to the parent of the node (e.g. around a subquery) are not part
+// of the span. -1, -1 is returned if no location within the node is known, or if source
+// can't be scanned.
+func Span(node *Node, source string) (start, end int) {
+	tokens, err := scanSourceTokens(source)
+	if err != nil {
+		return -1, -1
+	}
+	return tokens.span(node)
+}
+
+// sourceTokens holds a query and its scanned tokens, without comments
+type sourceTokens struct {
+	source string
+	tokens []*ScanToken
+}
+
+func scanSourceTokens(source string) (*sourceTokens, error) {
+	scan, err := Scan(source)
+	if err != nil {
+		return nil, err
+	}
+	t := &sourceTokens{source: source}
+	for _, token := range scan.GetTokens() {
+		if token.Token != Token_SQL_COMMENT && token.Token != Token_C_COMMENT {
+			t.tokens = append(t.tokens, token)
+		}
+	}
+	return t, nil
+}
+
+// at returns the index of the token containing the given offset, or of the first token after it
+func (t *sourceTokens) at(offset int) int {
+	return sort.Search(len(t.tokens), func(i int) bool {
+		return int(t.tokens[i].End) > offset
+	})
+}
+
+// is returns whether the token with index i is one of the given tokens
+func (t *sourceTokens) is(i int, tokens ...Token) bool {
+	if i < 0 || i >= len(t.tokens) {
+		return false
+	}
+	for _, token := range tokens {
+		if t.tokens[i].Token == token {
+			return true
+		}
+	}
+	return false
+}
+
+// isName returns whether the token with index i is the given (unquoted or quoted) identifier
+func (t *sourceTokens) isName(i int, name string) bool {
+	if i < 0 || i >= len(t.tokens) || name == "" {
+		return false
+	}
+	text := t.source[t.tokens[i].Start:t.tokens[i].End]
+	if strings.HasPrefix(text, `"`) {
+		return text == `"`+strings.ReplaceAll(name, `"`, `""`)+`"`
+	}
+	return strings.EqualFold(text, name)
+}
+
+// stmtSpan returns the byte range of a statement, from its first to its last token
+//
+// The range given by StmtLocation and StmtLen also includes whitespace and comments
+// between the previous statement and this one.
+func (t *sourceTokens) stmtSpan(stmt *RawStmt) (start, end int) {
+	stmtStart := int(stmt.StmtLocation)
+	stmtEnd := len(t.source)
+	if stmt.StmtLen > 0 {
+		stmtEnd = stmtStart + int(stmt.StmtLen)
+	}
+
+	first := t.at(stmtStart)
+	if first >= len(t.tokens) || int(t.tokens[first].Start) >= stmtEnd {
+		return -1, -1
+	}
+	last := first
+	for last+1 < len(t.tokens) && int(t.tokens[last+1].Start) < stmtEnd && !t.is(last+1, Token_ASCII_59) {
+		last++
+	}
+	return int(t.tokens[first].Start), int(t.tokens[last].End)
+}
+
+// span implements Span
+func (t *sourceTokens) span(node *Node) (start, end int) {
+	return t.nodeSpan(node, true)
+}
+
+// nodeSpan returns the byte range of a node like Span, but without the alias of a table
+// reference or target list entry unless withAlias is set
+func (t *sourceTokens) nodeSpan(node *Node, withAlias bool) (start, end int) {
+	if rawStmt := node.GetRawStmt(); rawStmt != nil {
+		return t.stmtSpan(rawStmt)
+	}
+
+	l := locator{first: -1, last: -1}
+	l.message(node.ProtoReflect())
+	if l.first < 0 {
+		return -1, -1
+	}
+
+	i, j := t.at(l.first), t.at(l.last)
+	if i >= len(t.tokens) || j >= len(t.tokens) {
+		return -1, -1
+	}
+
+	if l.firstInStmt {
+		i = t.stmtHead(i)
+	}
+	i = t.prefix(node, i)
+	for k := len(l.lastPath) - 1; k >= 0; k-- {
+		var child proto.Message
+		if k+1 < len(l.lastPath) {
+			child = l.lastPath[k+1]
+		}
+		j = t.suffix(l.lastPath[k], child, j)
+	}
+	i, j = t.balance(i, j)
+	if withAlias {
+		j = t.alias(node, j)
+	}
+	if isStmtNode(node) {
+		j = t.stmtTail(node.GetSelectStmt(), j)
+	}
+	return int(t.tokens[i].Start), int(t.tokens[j].End)
+}
+
+// locator finds the smallest and largest location within a parse tree message, including
+// nested messages that aren't nodes (e.g. the TypeName of a TypeCast)
+type locator struct {
+	first, last int
+	// firstInStmt is set if the first location is inside a statement
+	firstInStmt bool
+	// lastPath holds the messages from the root down to the one with the last location
+	lastPath []proto.Message
+	path     []proto.Message
+	stmts    int
+}
+
+func (l *locator) message(msg protoreflect.Message) {
+	if !msg.IsValid() {
+		return
+	}
+	isStmt := strings.HasSuffix(string(msg.Descriptor().Name()), "Stmt") && msg.Descriptor().Name() != "RawStmt"
+	if isStmt {
+		l.stmts++
+	}
+	if msg.Descriptor().Name() != "Node" {
+		l.path = append(l.path, msg.Interface())
+	}
+
+	if field := msg.Descriptor().Fields().ByName("location"); field != nil && field.Kind() == protoreflect.Int32Kind {
+		location := int(msg.Get(field).Int())
+		if location >= 0 && (l.first < 0 || location < l.first) {
+			l.first, l.firstInStmt = location, l.stmts > 0
+		}
+		if location >= 0 && location >= l.last {
+			l.last = location
+			l.lastPath = append(l.lastPath[:0], l.path...)
+		}
+	}
+
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.Kind() != protoreflect.MessageKind:
+		case field.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				l.message(list.Get(i).Message())
+			}
+		default:
+			l.message(value.Message())
+		}
+		return true
+	})
+
+	if msg.Descriptor().Name() != "Node" {
+		l.path = l.path[:len(l.path)-1]
+	}
+	if isStmt {
+		l.stmts--
+	}
+}
+
+// isStmtNode returns whether the node is a statement, e.g. a SelectStmt
+func isStmtNode(node *Node) bool {
+	name := nodeTypeName(node)
+	return strings.HasSuffix(name, "Stmt") && name != "RawStmt"
+}
+
+// stmtHead moves the start of a range from the first located token of a statement back to
+// its leading keywords, e.g. from the first target to SELECT DISTINCT or from the table
+// to INSERT INTO
+func (t *sourceTokens) stmtHead(i int) int {
+	for k := i - 1; k >= 0; k-- {
+		switch {
+		case t.is(k, Token_SELECT, Token_VALUES, Token_TABLE, Token_WITH, Token_INSERT, Token_UPDATE,
+			Token_DELETE_P, Token_MERGE):
+			return k
+		case !t.is(k, Token_DISTINCT, Token_ON, Token_ALL, Token_RECURSIVE, Token_INTO, Token_FROM,
+			Token_ONLY, Token_ASCII_40):
+			return i
+		}
+	}
+	return i
+}
+
+// prefix moves the start of a range back to keywords in front of a table reference
+func (t *sourceTokens) prefix(node *Node, i int) int {
+	switch n := node.GetNode().(type) {
+	case *Node_RangeVar:
+		if !n.RangeVar.Inh && t.is(i-1, Token_ONLY) {
+			return i - 1
+		}
+	case *Node_RangeSubselect:
+		// The parentheses around the subquery are part of the table reference
+		for t.is(i-1, Token_ASCII_40) {
+			i--
+		}
+		if t.is(i-1, Token_LATERAL_P) {
+			return i - 1
+		}
+	case *Node_RangeFunction, *Node_RangeTableFunc:
+		if t.is(i-1, Token_LATERAL_P) {
+			return i - 1
+		}
+	}
+	return i
+}
+
+// suffix moves the end of a range past the tokens of a message that follow its last
+// located token, which is inside child (nil if it's the location of the message itself)
+func (t *sourceTokens) suffix(msg proto.Message, child proto.Message, j int) int {
+	// Qualified names continue after the located token, e.g. schema.table or t.*
+	for j+2 < len(t.tokens) && t.is(j+1, Token_ASCII_46) {
+		j += 2
+	}
+
+	switch n := msg.(type) {
+	case *A_Const:
+		// The location of a negative number is its sign
+		if t.is(j, Token_ASCII_45, Token_ASCII_43) && t.is(j+1, Token_ICONST, Token_FCONST) {
+			j++
+		}
+	case *FuncCall:
+		// Arguments are located, so this is a function without any, e.g. now()
+		if len(n.Args) == 0 && t.is(j+1, Token_ASCII_40) {
+			j++
+		}
+	case *NullTest, *BooleanTest:
+		for t.is(j+1, Token_NOT, Token_NULL_P, Token_TRUE_P, Token_FALSE_P, Token_UNKNOWN) {
+			j++
+		}
+	case *CollateClause:
+		for _, name := range n.Collname {
+			if t.is(j+1, Token_ASCII_46) {
+				j++
+			}
+			if t.isName(j+1, strVal(name)) {
+				j++
+			}
+		}
+	case *TypeName:
+		// The last location is a type modifier, so continue after its closing parenthesis
+		if child != nil {
+			j = t.closing(j)
+		}
+		for {
+			switch {
+			case t.is(j+1, Token_PRECISION, Token_VARYING):
+				j++
+			case t.is(j+1, Token_WITH, Token_WITHOUT) && t.is(j+2, Token_TIME) && t.is(j+3, Token_ZONE):
+				j += 3
+			case t.is(j+1, Token_ASCII_91) && t.is(j+2, Token_ASCII_93):
+				j += 2
+			case t.is(j+1, Token_ASCII_91) && t.is(j+2, Token_ICONST) && t.is(j+3, Token_ASCII_93):
+				j += 3
+			default:
+				return j
+			}
+		}
+	case *SortBy:
+		j = t.sortBySuffix(j)
+	case *A_Indirection:
+		j = t.indirectionSuffix(n, child, j)
+	}
+	return j
+}
+
+// indirectionSuffix moves the end of a range past the field selections and subscripts
+// of an A_Indirection, e.g. from a to the end of (a).b or a[1]
+func (t *sourceTokens) indirectionSuffix(indirection *A_Indirection, child proto.Message, j int) int {
+	items := indirection.Indirection
+	k := j
+	if indices, ok := child.(*A_Indices); ok {
+		// The last location is inside a subscript, so continue after its closing bracket
+		for i, item := range items {
+			if item.GetAIndices() == indices {
+				items = items[i+1:]
+				break
+			}
+		}
+		k = t.closing(k)
+		j = k
+	} else {
+		// Closing parentheses around the argument, e.g. (a).b
+		for t.is(k+1, Token_ASCII_41) {
+			k++
+		}
+	}
+
+	for _, item := range items {
+		switch item.GetNode().(type) {
+		case *Node_AIndices:
+			if !t.is(k+1, Token_ASCII_91) {
+				return j
+			}
+			_, k = t.balance(k+1, k+1)
+		default:
+			if !t.is(k+1, Token_ASCII_46) || k+2 >= len(t.tokens) {
+				return j
+			}
+			k += 2
+		}
+		j = k
+	}
+	return j
+}
+
+// sortBySuffix moves the end of a range past the ordering options of a sort key
+func (t *sourceTokens) sortBySuffix(j int) int {
+	for {
+		switch {
+		case t.is(j+1, Token_ASC, Token_DESC):
+			j++
+		case t.is(j+1, Token_NULLS_P) && t.is(j+2, Token_FIRST_P, Token_LAST_P):
+			j += 2
+		case t.is(j+1, Token_USING) && j+2 < len(t.tokens):
+			j += 2
+		default:
+			return j
+		}
+	}
+}
+
+// balance widens a range until it contains matching pairs of parentheses, brackets and
+// CASE ... END, and includes a window name after a function call
+func (t *sourceTokens) balance(i, j int) (int, int) {
+	// Closers without an opener in the range are matched before it, openers that aren't
+	// closed after it
+	depth, minDepth := 0, 0
+	for k := i; k <= j; k++ {
+		depth += t.depth(k)
+		if depth < minDepth {
+			minDepth = depth
+		}
+	}
+	for unmatched := -minDepth; unmatched > 0 && i > 0; {
+		i--
+		unmatched -= t.depth(i)
+	}
+	for unmatched := depth - minDepth; unmatched > 0 && j+1 < len(t.tokens); {
+		j++
+		unmatched += t.depth(j)
+	}
+
+	if t.is(j, Token_ASCII_41) && t.is(j+1, Token_OVER) && !t.is(j+2, Token_ASCII_40) && j+2 < len(t.tokens) {
+		j += 2
+	}
+	return i, j
+}
+
+// closing returns the index of the token closing the innermost parenthesis, bracket or CASE
+// that is open at the token with index j, or of the last token if there is none
+func (t *sourceTokens) closing(j int) int {
+	for depth := 0; j+1 < len(t.tokens); {
+		j++
+		if depth += t.depth(j); depth < 0 {
+			break
+		}
+	}
+	return j
+}
+
+// depth returns how the token with index i changes the nesting level of the range
+func (t *sourceTokens) depth(i int) int {
+	switch t.tokens[i].Token {
+	case Token_ASCII_40, Token_ASCII_91, Token_CASE:
+		return 1
+	case Token_ASCII_41, Token_ASCII_93, Token_END_P:
+		return -1
+	}
+	return 0
+}
+
+// alias moves the end of a range past the alias of a table reference or target list entry
+func (t *sourceTokens) alias(node *Node, j int) int {
+	var alias *Alias
+	switch n := node.GetNode().(type) {
+	case *Node_RangeVar:
+		alias = n.RangeVar.Alias
+	case *Node_RangeSubselect:
+		alias = n.RangeSubselect.Alias
+	case *Node_RangeFunction:
+		alias = n.RangeFunction.Alias
+	case *Node_RangeTableFunc:
+		alias = n.RangeTableFunc.Alias
+	case *Node_RangeTableSample:
+		alias = n.RangeTableSample.Relation.GetRangeVar().GetAlias()
+	case *Node_JoinExpr:
+		alias = n.JoinExpr.Alias
+	case *Node_ResTarget:
+		if n.ResTarget.Val != nil {
+			alias = &Alias{Aliasname: n.ResTarget.Name}
+		}
+	}
+	if alias == nil {
+		return j
+	}
+
+	k := j
+	if t.is(k+1, Token_AS) {
+		k++
+	}
+	if !t.isName(k+1, alias.Aliasname) {
+		return j
+	}
+	j = k + 1
+	if len(alias.Colnames) > 0 && t.is(j+1, Token_ASCII_40) {
+		_, j = t.balance(j+1, j+1)
+	}
+	return j
+}
+
+// stmtTail moves the end of a range to the end of a nested statement, which is the closing
+// parenthesis around it, the end of the query, a set operation or the remaining clauses of
+// the enclosing statement (e.g. RETURNING or WITH NO DATA). Clauses that belong to
+// an enclosing set operation are not included if the statement has none of them.
+func (t *sourceTokens) stmtTail(selectStmt *SelectStmt, j int) int {
+	ownsClauses := selectStmt == nil || len(selectStmt.SortClause) > 0 || selectStmt.LimitCount != nil ||
+		selectStmt.LimitOffset != nil || len(selectStmt.LockingClause) > 0
+	depth := 0
+	for k := j + 1; k < len(t.tokens); k++ {
+		depth += t.depth(k)
+		switch {
+		case depth < 0,
+			depth == 0 && t.is(k, Token_ASCII_59, Token_UNION, Token_INTERSECT, Token_EXCEPT, Token_RETURNING, Token_WITH),
+			depth == 0 && t.is(k, Token_ON) && t.is(k+1, Token_CONFLICT),
+			depth == 0 && !ownsClauses && t.is(k, Token_ORDER, Token_LIMIT, Token_OFFSET, Token_FETCH, Token_FOR):
+			return j
+		}
+		if depth == 0 {
+			j = k
+		}
+	}
+	return j
+}
@@ -0,0 +1,192 @@
+//go:build cgo
+// +build cgo
+
+package pg_query_test
+
+import (
+	"testing"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+)
+
+var spanTests = []struct {
+	input    string
+	node     func(stmt *pg_query.Node) *pg_query.Node
+	expected string
+}{
+	{
+		"SELECT a + b * 2 FROM t",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().TargetList[0].GetResTarget().Val
+		},
+		"a + b * 2",
+	},
+	{
+		"SELECT (a + 1) * 2, now() AS ts FROM t",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().TargetList[0].GetResTarget().Val.GetAExpr().Lexpr
+		},
+		"a + 1",
+	},
+	{
+		"SELECT (a + 1) * 2, now() AS ts FROM t",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().TargetList[1]
+		},
+		"now() AS ts",
+	},
+	{
+		"SELECT x FROM t WHERE y IS NOT NULL AND z::varchar(10)[] = CASE WHEN w THEN 'a' END",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().WhereClause
+		},
+		"y IS NOT NULL AND z::varchar(10)[] = CASE WHEN w THEN 'a' END",
+	},
+	{
+		"SELECT x FROM t ORDER BY x DESC NULLS LAST",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().SortClause[0]
+		},
+		"x DESC NULLS LAST",
+	},
+	{
+		"SELECT * FROM ONLY public.users AS u(id) JOIN LATERAL (SELECT 1) s ON true",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().FromClause[0].GetJoinExpr().Larg
+		},
+		"ONLY public.users AS u(id)",
+	},
+	{
+		"SELECT * FROM ONLY public.users AS u(id) JOIN LATERAL (SELECT 1) s ON true",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().FromClause[0].GetJoinExpr().Rarg
+		},
+		"LATERAL (SELECT 1) s",
+	},
+	{
+		"SELECT * FROM t WHERE id IN (SELECT DISTINCT user_id FROM orders ORDER BY 1 FOR UPDATE)",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().WhereClause.GetSubLink().Subselect
+		},
+		"SELECT DISTINCT user_id FROM orders ORDER BY 1 FOR UPDATE",
+	},
+	{
+		"WITH c AS (SELECT 1) SELECT * FROM c",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().WithClause.Ctes[0]
+		},
+		"c AS (SELECT 1)",
+	},
+	{
+		"INSERT INTO t (a) SELECT a FROM u RETURNING *",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetInsertStmt().SelectStmt
+		},
+		"SELECT a FROM u",
+	},
+	{
+		"SELECT (a).b, (testfunc.response).\"mycolumn\" FROM t",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().TargetList[0].GetResTarget().Val
+		},
+		"(a).b",
+	},
+	{
+		"SELECT (a).b, (testfunc.response).\"mycolumn\" FROM t",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().TargetList[1].GetResTarget().Val
+		},
+		"(testfunc.response).\"mycolumn\"",
+	},
+	{
+		"SELECT a[i][2:3].x, (f(b)).*, c[1] + 1 FROM t",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().TargetList[0].GetResTarget().Val
+		},
+		"a[i][2:3].x",
+	},
+	{
+		"SELECT a[i][2:3].x, (f(b)).*, c[1] + 1 FROM t",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().TargetList[1].GetResTarget().Val
+		},
+		"(f(b)).*",
+	},
+	{
+		"SELECT a[i][2:3].x, (f(b)).*, c[1] + 1 FROM t",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().TargetList[2].GetResTarget().Val.GetAExpr().Lexpr
+		},
+		"c[1]",
+	},
+	{
+		"SELECT * FROM t WHERE b = -100 AND c = x::varchar(10)[]",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().WhereClause.GetBoolExpr().Args[0].GetAExpr().Rexpr
+		},
+		"-100",
+	},
+	{
+		"SELECT * FROM t WHERE b = -100 AND c = x::varchar(10)[]",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().WhereClause.GetBoolExpr().Args[0]
+		},
+		"b = -100",
+	},
+	{
+		"SELECT * FROM t WHERE b = -100 AND c = x::varchar(10)[]",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().WhereClause.GetBoolExpr().Args[1].GetAExpr().Rexpr
+		},
+		"x::varchar(10)[]",
+	},
+	{
+		"SELECT a::numeric(10, 2)[3], -1.5 FROM t",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt.GetSelectStmt().TargetList[0]
+		},
+		"a::numeric(10, 2)[3]",
+	},
+	{
+		"SELECT 1; /* comment */ UPDATE t SET a = 1 ;",
+		func(stmt *pg_query.Node) *pg_query.Node {
+			return stmt
+		},
+		"SELECT 1",
+	},
+}
+
+func TestSpan(t *testing.T) {
+	for _, test := range spanTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		start, end := pg_query.Span(test.node(tree.Stmts[0].Stmt), test.input)
+		if start < 0 {
+			t.Errorf("Span(%s)\nexpected %s\nactual no span\n\n", test.input, test.expected)
+			continue
+		}
+
+		actual := test.input[start:end]
+		if actual != test.expected {
+			t.Errorf("Span(%s)\nexpected %s\nactual %s\n\n", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestSpanStatement(t *testing.T) {
+	input := "SELECT 1; /* comment */ UPDATE t SET a = 1 ;"
+	tree, err := pg_query.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse(%s)\nerror %s\n\n", input, err)
+	}
+
+	expected := "UPDATE t SET a = 1"
+	start, end := pg_query.Span(&pg_query.Node{Node: &pg_query.Node_RawStmt{RawStmt: tree.Stmts[1]}}, input)
+	if start < 0 || input[start:end] != expected {
+		t.Errorf("Span(%s)\nexpected %s\nactual %d, %d\n\n", input, expected, start, end)
+	}
+}
@@ -0,0 +1,98 @@
+package pg_query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ExpandStars rewrites the star target entries ("*" and "t.*") of the queries of a parse
+// tree into explicit column references, and returns the output columns of each statement
+//
+// A star expands to the columns of the FROM-clause items it covers, in order: the merged
+// columns of a JOIN ... USING come first and only once, and subqueries and CTEs provide
+// their output columns. The column references are qualified with the name of their item,
+// except for the merged columns of a FULL JOIN and the columns of unnamed subqueries.
+// Stars of subqueries, CTEs and RETURNING lists are expanded too, stars inside expressions
+// like count(*) or row(t.*) are left alone.
+//
+// An error is returned, and the tree left unchanged, if a star covers a table missing in
+// the catalog or a function whose columns are unknown, or if the references to its columns
+// would be ambiguous, e.g. for an aliased join or a subquery with duplicate column names.
+func ExpandStars(tree *ParseResult, schema Catalog) ([][]ResultColumn, error) {
+	r := &columnResolver{catalog: schema, resolved: map[*Node]scopeColumn{}, stars: map[*Node][]*Node{}, rewrite: true}
+	var results [][]ResultColumn
+	for _, stmt := range tree.GetStmts() {
+		columns, _ := r.stmt(stmt.GetStmt(), nil)
+		results = append(results, resultColumns(columns))
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	for _, list := range r.starLists {
+		var targets []*Node
+		for _, target := range *list {
+			if expanded, ok := r.stars[target]; ok {
+				targets = append(targets, expanded...)
+			} else {
+				targets = append(targets, target)
+			}
+		}
+		*list = targets
+	}
+	return results, nil
+}
+
+// expandStar records the column references replacing a star target entry of a target list
+func (r *columnResolver) expandStar(targetList *[]*Node, target *Node, qualifier []string, item *rangeItem) {
+	name := strings.Join(append(qualifier, "*"), ".")
+	location := target.GetResTarget().GetLocation()
+	var err error
+	switch {
+	case item == nil && len(qualifier) == 0:
+		err = errors.New("SELECT * with no tables specified is not valid")
+	case item == nil:
+		err = &ColumnError{Kind: ColumnErrorMissingTable, Name: name, Table: strings.Join(qualifier, "."), Location: location}
+	case !item.complete:
+		err = fmt.Errorf("cannot expand %q: the columns of %q are unknown", name, item.unknown)
+	case r.rewrite:
+		if column := ambiguousColumn(item.columns); column != nil {
+			ref := strings.TrimPrefix(column.qualifier+"."+column.name, ".")
+			err = fmt.Errorf("cannot expand %q: column reference %q is ambiguous", name, ref)
+		}
+	}
+	if err != nil {
+		if r.err == nil {
+			r.err = err
+		}
+		return
+	}
+
+	var expanded []*Node
+	for _, column := range item.columns {
+		var fields []*Node
+		if column.qualifier != "" {
+			fields = append(fields, MakeStrNode(column.qualifier))
+		}
+		fields = append(fields, MakeStrNode(column.name))
+		expanded = append(expanded, MakeResTargetNodeWithVal(MakeColumnRefNode(fields, location), location))
+	}
+	if len(r.starLists) == 0 || r.starLists[len(r.starLists)-1] != targetList {
+		r.starLists = append(r.starLists, targetList)
+	}
+	r.stars[target] = expanded
+}
+
+// ambiguousColumn returns the first column whose reference would be ambiguous among the
+// columns of a star, e.g. the columns of a join or subquery sharing a name, or nil
+func ambiguousColumn(columns []scopeColumn) *scopeColumn {
+	for i := range columns {
+		for j := range columns {
+			if i != j && columns[i].name == columns[j].name && (columns[i].qualifier == "" || columns[i].qualifier == columns[j].qualifier) {
+				return &columns[i]
+			}
+		}
+	}
+	return nil
+}
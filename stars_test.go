@@ -0,0 +1,120 @@
+//go:build cgo
+// +build cgo
+
+package pg_query_test
+
+import (
+	"reflect"
+	"testing"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+)
+
+var expandStarsTests = []struct {
+	input    string
+	expected string
+	columns  []string
+}{
+	{
+		"SELECT * FROM users",
+		"SELECT users.id, users.email, users.name FROM users",
+		[]string{"id", "email", "name"},
+	},
+	{
+		"SELECT u.*, o.total FROM users u JOIN orders o ON o.user_id = u.id",
+		"SELECT u.id, u.email, u.name, o.total FROM users u JOIN orders o ON o.user_id = u.id",
+		[]string{"id", "email", "name", "total"},
+	},
+	{
+		"SELECT * FROM users JOIN orders USING (id)",
+		"SELECT users.id, users.email, users.name, orders.user_id, orders.total FROM users JOIN orders USING (id)",
+		[]string{"id", "email", "name", "user_id", "total"},
+	},
+	{
+		"SELECT * FROM users FULL JOIN orders USING (id)",
+		"SELECT id, users.email, users.name, orders.user_id, orders.total FROM users FULL JOIN orders USING (id)",
+		[]string{"id", "email", "name", "user_id", "total"},
+	},
+	{
+		"SELECT * FROM (SELECT *, 1 AS one FROM users u (uid)) s",
+		"SELECT s.uid, s.email, s.name, s.one FROM (SELECT u.uid, u.email, u.name, 1 AS one FROM users u(uid)) s",
+		[]string{"uid", "email", "name", "one"},
+	},
+	{
+		"WITH x AS (SELECT email FROM users) SELECT * FROM x, (VALUES (1)) v",
+		"WITH x AS (SELECT email FROM users) SELECT x.email, v.column1 FROM x, (VALUES (1)) v",
+		[]string{"email", "column1"},
+	},
+	{
+		"DELETE FROM orders WHERE total = 0 RETURNING *",
+		"DELETE FROM orders WHERE total = 0 RETURNING orders.id, orders.user_id, orders.total",
+		[]string{"id", "user_id", "total"},
+	},
+	{
+		"SELECT count(*) FROM users",
+		"SELECT count(*) FROM users",
+		[]string{"count"},
+	},
+}
+
+func TestExpandStars(t *testing.T) {
+	for _, test := range expandStarsTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+		results, err := pg_query.ExpandStars(tree, resolveCatalog)
+		if err != nil {
+			t.Errorf("ExpandStars(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		actual, err := pg_query.Deparse(tree)
+		if err != nil {
+			t.Errorf("Deparse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+		if actual != test.expected {
+			t.Errorf("ExpandStars(%s)\nexpected %s\nactual %s\n\n", test.input, test.expected, actual)
+		}
+
+		var columns []string
+		for _, column := range results[0] {
+			columns = append(columns, column.Name)
+		}
+		if !reflect.DeepEqual(columns, test.columns) {
+			t.Errorf("ExpandStars(%s)\nexpected columns %q\nactual %q\n\n", test.input, test.columns, columns)
+		}
+	}
+}
+
+var expandStarsErrorTests = []struct {
+	input    string
+	expected string
+}{
+	{"SELECT * FROM users, logs", `cannot expand "*": the columns of "logs" are unknown`},
+	{"SELECT x.* FROM users", `missing FROM-clause entry for table "x"`},
+	{"SELECT *", "SELECT * with no tables specified is not valid"},
+	{"SELECT j.* FROM (users JOIN users u USING (id)) j", `cannot expand "j.*": column reference "j.email" is ambiguous`},
+	{"SELECT * FROM (SELECT 1, 2) s", `cannot expand "*": column reference "s.?column?" is ambiguous`},
+	{"SELECT * FROM (SELECT 1 AS x, 2 AS x) s, users", `cannot expand "*": column reference "s.x" is ambiguous`},
+}
+
+func TestExpandStarsErrors(t *testing.T) {
+	for _, test := range expandStarsErrorTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+		original, _ := pg_query.Deparse(tree)
+		_, err = pg_query.ExpandStars(tree, resolveCatalog)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("ExpandStars(%s)\nexpected %s\nactual %v\n\n", test.input, test.expected, err)
+		}
+		if actual, _ := pg_query.Deparse(tree); actual != original {
+			t.Errorf("ExpandStars(%s)\nexpected the tree to be unchanged\nactual %s\n\n", test.input, actual)
+		}
+	}
+}
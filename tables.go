@@ -0,0 +1,267 @@
+package pg_query
+
+import (
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TableAccess describes how a statement accesses a table
+type TableAccess int
+
+const (
+	// TableAccessRead is a table that is only read, e.g. in FROM or a subquery
+	TableAccessRead TableAccess = iota
+	// TableAccessInsert is the target of an INSERT, COPY FROM or MERGE ... THEN INSERT
+	TableAccessInsert
+	// TableAccessUpdate is the target of an UPDATE or MERGE ... THEN UPDATE
+	TableAccessUpdate
+	// TableAccessDelete is the target of a DELETE or MERGE ... THEN DELETE
+	TableAccessDelete
+	// TableAccessDDL is a table that is created, altered or dropped by a utility statement
+	TableAccessDDL
+
+	// tableAccessSkip excludes a field from the traversal of tableExtractor
+	tableAccessSkip TableAccess = -1
+)
+
+func (a TableAccess) String() string {
+	switch a {
+	case TableAccessRead:
+		return "read"
+	case TableAccessInsert:
+		return "insert"
+	case TableAccessUpdate:
+		return "update"
+	case TableAccessDelete:
+		return "delete"
+	case TableAccessDDL:
+		return "ddl"
+	}
+	return "unknown"
+}
+
+// TableRef is a reference to a table (or view) in a parse tree
+type TableRef struct {
+	Schema string
+	Name   string
+	Alias  string
+	Access TableAccess
+	// Location is the byte offset of the reference in the query, or -1 if it's unknown
+	// (e.g. for DROP TABLE)
+	Location int32
+}
+
+// ExtractTables returns the tables referenced by the statements of a parse tree, in the
+// order they appear in the query
+//
+// Every RangeVar is reported, including those in CTEs, subqueries, JOINs, MERGE and
+// utility statements, so a table referenced twice is returned twice. Read references to
+// CTEs of an enclosing WITH clause are not tables and are left out, while the targets of
+// INSERT, UPDATE, DELETE and MERGE always are tables. The target of a MERGE is
+// returned once for every kind of action (insert, update, delete) of its WHEN clauses.
+func ExtractTables(tree *ParseResult) []TableRef {
+	var tables []TableRef
+	for _, stmt := range tree.GetStmts() {
+		e := &tableExtractor{}
+		e.message(stmt.GetStmt().ProtoReflect(), TableAccessRead)
+		sort.SliceStable(e.tables, func(i, j int) bool {
+			return e.tables[i].Location < e.tables[j].Location
+		})
+		tables = append(tables, e.tables...)
+	}
+	return tables
+}
+
+// tableExtractor implements ExtractTables
+type tableExtractor struct {
+	tables []TableRef
+	// ctes holds the names of the CTEs in scope
+	ctes []string
+}
+
+// utilityRelationFields are the fields of utility statements holding the relations they operate on
+var utilityRelationFields = map[string]TableAccess{
+	"relation":  TableAccessDDL,
+	"relations": TableAccessDDL,
+	"rels":      TableAccessDDL,
+	"view":      TableAccessDDL,
+	"table":     TableAccessDDL,
+	"objects":   TableAccessDDL,
+}
+
+func (e *tableExtractor) message(msg protoreflect.Message, access TableAccess) {
+	if !msg.IsValid() {
+		return
+	}
+
+	switch m := msg.Interface().(type) {
+	case *RangeVar:
+		e.rangeVar(m, access)
+	case *SelectStmt:
+		defer e.withClause(m.WithClause)()
+		e.fields(msg, TableAccessRead, map[string]TableAccess{"with_clause": tableAccessSkip})
+	case *InsertStmt:
+		defer e.withClause(m.WithClause)()
+		e.fields(msg, TableAccessRead, map[string]TableAccess{"with_clause": tableAccessSkip, "relation": TableAccessInsert})
+	case *UpdateStmt:
+		defer e.withClause(m.WithClause)()
+		e.fields(msg, TableAccessRead, map[string]TableAccess{"with_clause": tableAccessSkip, "relation": TableAccessUpdate})
+	case *DeleteStmt:
+		defer e.withClause(m.WithClause)()
+		e.fields(msg, TableAccessRead, map[string]TableAccess{"with_clause": tableAccessSkip, "relation": TableAccessDelete})
+	case *MergeStmt:
+		defer e.withClause(m.WithClause)()
+		e.mergeTarget(m)
+		e.fields(msg, TableAccessRead, map[string]TableAccess{"with_clause": tableAccessSkip, "relation": tableAccessSkip})
+	case *CopyStmt:
+		target := TableAccessRead
+		if m.IsFrom {
+			target = TableAccessInsert
+		}
+		e.fields(msg, TableAccessRead, map[string]TableAccess{"relation": target})
+	case *DropStmt:
+		e.dropStmt(m)
+	case *IntoClause:
+		e.fields(msg, TableAccessRead, map[string]TableAccess{"rel": TableAccessDDL})
+	case *LockingClause:
+		// FOR UPDATE OF names the FROM items by their aliases, these aren't further references
+		return
+	default:
+		name := string(msg.Descriptor().Name())
+		if strings.HasSuffix(name, "Stmt") {
+			e.fields(msg, TableAccessRead, utilityRelationFields)
+		} else {
+			e.fields(msg, access, nil)
+		}
+	}
+}
+
+// fields visits the message fields of msg in declaration order, using the access given in
+// fieldAccess by field name or the default access
+func (e *tableExtractor) fields(msg protoreflect.Message, access TableAccess, fieldAccess map[string]TableAccess) {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Kind() != protoreflect.MessageKind || !msg.Has(field) {
+			continue
+		}
+		fa, ok := fieldAccess[string(field.Name())]
+		if !ok {
+			fa = access
+		}
+		if fa == tableAccessSkip {
+			continue
+		}
+
+		if field.IsList() {
+			list := msg.Get(field).List()
+			for j := 0; j < list.Len(); j++ {
+				e.message(list.Get(j).Message(), fa)
+			}
+		} else {
+			e.message(msg.Get(field).Message(), fa)
+		}
+	}
+}
+
+func (e *tableExtractor) rangeVar(rangeVar *RangeVar, access TableAccess) {
+	// Only read references can refer to CTEs, targets of INSERT, UPDATE, DELETE and MERGE are tables
+	if access == TableAccessRead && rangeVar.Schemaname == "" && rangeVar.Catalogname == "" && e.isCTE(rangeVar.Relname) {
+		return
+	}
+	e.addTable(rangeVar, access)
+}
+
+func (e *tableExtractor) addTable(rangeVar *RangeVar, access TableAccess) {
+	e.tables = append(e.tables, TableRef{
+		Schema:   rangeVar.Schemaname,
+		Name:     rangeVar.Relname,
+		Alias:    rangeVar.GetAlias().GetAliasname(),
+		Access:   access,
+		Location: rangeVar.Location,
+	})
+}
+
+func (e *tableExtractor) isCTE(name string) bool {
+	for _, cte := range e.ctes {
+		if cte == name {
+			return true
+		}
+	}
+	return false
+}
+
+// withClause visits the CTEs of a WITH clause and brings their names into scope until the
+// returned function is called
+//
+// The queries of a non-recursive WITH clause only see the CTEs defined before them, those
+// of a WITH RECURSIVE clause see all of them.
+func (e *tableExtractor) withClause(withClause *WithClause) (pop func()) {
+	n := len(e.ctes)
+	pop = func() {
+		e.ctes = e.ctes[:n]
+	}
+	if withClause == nil {
+		return
+	}
+
+	if withClause.Recursive {
+		for _, item := range withClause.Ctes {
+			e.ctes = append(e.ctes, item.GetCommonTableExpr().GetCtename())
+		}
+	}
+	for _, item := range withClause.Ctes {
+		cte := item.GetCommonTableExpr()
+		e.message(cte.GetCtequery().ProtoReflect(), TableAccessRead)
+		if !withClause.Recursive {
+			e.ctes = append(e.ctes, cte.GetCtename())
+		}
+	}
+	return
+}
+
+// mergeTarget adds the target of a MERGE with the kinds of access of its WHEN clauses
+func (e *tableExtractor) mergeTarget(mergeStmt *MergeStmt) {
+	kinds := map[TableAccess]bool{}
+	for _, item := range mergeStmt.MergeWhenClauses {
+		switch item.GetMergeWhenClause().GetCommandType() {
+		case CmdType_CMD_INSERT:
+			kinds[TableAccessInsert] = true
+		case CmdType_CMD_UPDATE:
+			kinds[TableAccessUpdate] = true
+		case CmdType_CMD_DELETE:
+			kinds[TableAccessDelete] = true
+		}
+	}
+	if len(kinds) == 0 {
+		e.addTable(mergeStmt.Relation, TableAccessRead)
+	}
+	for _, access := range []TableAccess{TableAccessInsert, TableAccessUpdate, TableAccessDelete} {
+		if kinds[access] {
+			e.rangeVar(mergeStmt.Relation, access)
+		}
+	}
+}
+
+// dropStmt adds the tables dropped by DROP TABLE, DROP VIEW and similar statements
+func (e *tableExtractor) dropStmt(dropStmt *DropStmt) {
+	switch dropStmt.RemoveType {
+	case ObjectType_OBJECT_TABLE, ObjectType_OBJECT_VIEW, ObjectType_OBJECT_MATVIEW, ObjectType_OBJECT_FOREIGN_TABLE:
+	default:
+		return
+	}
+
+	for _, object := range dropStmt.Objects {
+		names := object.GetList().GetItems()
+		if len(names) == 0 {
+			continue
+		}
+		table := TableRef{Name: strVal(names[len(names)-1]), Access: TableAccessDDL, Location: -1}
+		if len(names) > 1 {
+			table.Schema = strVal(names[len(names)-2])
+		}
+		e.tables = append(e.tables, table)
+	}
+}
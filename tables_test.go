@@ -0,0 +1,105 @@
+//go:build cgo
+// +build cgo
+
+package pg_query_test
+
+import (
+	"reflect"
+	"testing"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+)
+
+var extractTablesTests = []struct {
+	input    string
+	expected []pg_query.TableRef
+}{
+	{
+		"SELECT * FROM public.users u JOIN orders ON orders.user_id = u.id WHERE EXISTS (SELECT 1 FROM bans b)",
+		[]pg_query.TableRef{
+			{Schema: "public", Name: "users", Alias: "u", Access: pg_query.TableAccessRead, Location: 14},
+			{Name: "orders", Access: pg_query.TableAccessRead, Location: 34},
+			{Name: "bans", Alias: "b", Access: pg_query.TableAccessRead, Location: 94},
+		},
+	},
+	{
+		"WITH a AS (SELECT * FROM x), b AS (SELECT * FROM a) SELECT * FROM b, (WITH c AS (SELECT 1) SELECT * FROM c) s, c",
+		[]pg_query.TableRef{
+			{Name: "x", Access: pg_query.TableAccessRead, Location: 25},
+			{Name: "c", Access: pg_query.TableAccessRead, Location: 111},
+		},
+	},
+	{
+		"WITH RECURSIVE r AS (SELECT 1 UNION ALL SELECT n + 1 FROM r) SELECT * FROM r",
+		nil,
+	},
+	{
+		"WITH moved AS (DELETE FROM queue RETURNING *) INSERT INTO done SELECT * FROM moved",
+		[]pg_query.TableRef{
+			{Name: "queue", Access: pg_query.TableAccessDelete, Location: 27},
+			{Name: "done", Access: pg_query.TableAccessInsert, Location: 58},
+		},
+	},
+	{
+		"UPDATE accounts a SET balance = 0 FROM closed c WHERE a.id = c.id",
+		[]pg_query.TableRef{
+			{Name: "accounts", Alias: "a", Access: pg_query.TableAccessUpdate, Location: 7},
+			{Name: "closed", Alias: "c", Access: pg_query.TableAccessRead, Location: 39},
+		},
+	},
+	{
+		"MERGE INTO stock s USING delivery d ON s.item = d.item WHEN MATCHED THEN UPDATE SET qty = s.qty + d.qty WHEN NOT MATCHED THEN INSERT VALUES (d.item, d.qty)",
+		[]pg_query.TableRef{
+			{Name: "stock", Alias: "s", Access: pg_query.TableAccessInsert, Location: 11},
+			{Name: "stock", Alias: "s", Access: pg_query.TableAccessUpdate, Location: 11},
+			{Name: "delivery", Alias: "d", Access: pg_query.TableAccessRead, Location: 25},
+		},
+	},
+	{
+		"CREATE TABLE t (a int REFERENCES p (id)) INHERITS (base)",
+		[]pg_query.TableRef{
+			{Name: "t", Access: pg_query.TableAccessDDL, Location: 13},
+			{Name: "p", Access: pg_query.TableAccessRead, Location: 33},
+			{Name: "base", Access: pg_query.TableAccessRead, Location: 51},
+		},
+	},
+	{
+		"DROP TABLE a, s.b; CREATE VIEW v AS SELECT * FROM t; COPY t FROM STDIN",
+		[]pg_query.TableRef{
+			{Name: "a", Access: pg_query.TableAccessDDL, Location: -1},
+			{Schema: "s", Name: "b", Access: pg_query.TableAccessDDL, Location: -1},
+			{Name: "v", Access: pg_query.TableAccessDDL, Location: 31},
+			{Name: "t", Access: pg_query.TableAccessRead, Location: 50},
+			{Name: "t", Access: pg_query.TableAccessInsert, Location: 58},
+		},
+	},
+	{
+		"WITH t AS (SELECT 1) INSERT INTO t VALUES (1); WITH u AS (SELECT 1) UPDATE u SET a = 1 FROM u AS x",
+		[]pg_query.TableRef{
+			{Name: "t", Access: pg_query.TableAccessInsert, Location: 33},
+			{Name: "u", Access: pg_query.TableAccessUpdate, Location: 75},
+		},
+	},
+	{
+		"SELECT * FROM t a JOIN u ON a.id = u.id FOR UPDATE OF a, u",
+		[]pg_query.TableRef{
+			{Name: "t", Alias: "a", Access: pg_query.TableAccessRead, Location: 14},
+			{Name: "u", Access: pg_query.TableAccessRead, Location: 23},
+		},
+	},
+}
+
+func TestExtractTables(t *testing.T) {
+	for _, test := range extractTablesTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		actual := pg_query.ExtractTables(tree)
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("ExtractTables(%s)\nexpected %+v\nactual %+v\n\n", test.input, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,4914 @@
+// Code generated by internal/walkergen; DO NOT EDIT.
+
+package pg_query
+
+func (n *Node_Alias) WalkSubtree(visit Visit) error {
+	return walkAlias(n.Alias, visitWalker(visit))
+}
+
+func (n *Node_RangeVar) WalkSubtree(visit Visit) error {
+	return walkRangeVar(n.RangeVar, visitWalker(visit))
+}
+
+func (n *Node_TableFunc) WalkSubtree(visit Visit) error {
+	return walkTableFunc(n.TableFunc, visitWalker(visit))
+}
+
+func (n *Node_IntoClause) WalkSubtree(visit Visit) error {
+	return walkIntoClause(n.IntoClause, visitWalker(visit))
+}
+
+func (n *Node_Var) WalkSubtree(visit Visit) error {
+	return walkVar(n.Var, visitWalker(visit))
+}
+
+func (n *Node_Param) WalkSubtree(visit Visit) error {
+	return walkParam(n.Param, visitWalker(visit))
+}
+
+func (n *Node_Aggref) WalkSubtree(visit Visit) error {
+	return walkAggref(n.Aggref, visitWalker(visit))
+}
+
+func (n *Node_GroupingFunc) WalkSubtree(visit Visit) error {
+	return walkGroupingFunc(n.GroupingFunc, visitWalker(visit))
+}
+
+func (n *Node_WindowFunc) WalkSubtree(visit Visit) error {
+	return walkWindowFunc(n.WindowFunc, visitWalker(visit))
+}
+
+func (n *Node_SubscriptingRef) WalkSubtree(visit Visit) error {
+	return walkSubscriptingRef(n.SubscriptingRef, visitWalker(visit))
+}
+
+func (n *Node_FuncExpr) WalkSubtree(visit Visit) error {
+	return walkFuncExpr(n.FuncExpr, visitWalker(visit))
+}
+
+func (n *Node_NamedArgExpr) WalkSubtree(visit Visit) error {
+	return walkNamedArgExpr(n.NamedArgExpr, visitWalker(visit))
+}
+
+func (n *Node_OpExpr) WalkSubtree(visit Visit) error {
+	return walkOpExpr(n.OpExpr, visitWalker(visit))
+}
+
+func (n *Node_DistinctExpr) WalkSubtree(visit Visit) error {
+	return walkDistinctExpr(n.DistinctExpr, visitWalker(visit))
+}
+
+func (n *Node_NullIfExpr) WalkSubtree(visit Visit) error {
+	return walkNullIfExpr(n.NullIfExpr, visitWalker(visit))
+}
+
+func (n *Node_ScalarArrayOpExpr) WalkSubtree(visit Visit) error {
+	return walkScalarArrayOpExpr(n.ScalarArrayOpExpr, visitWalker(visit))
+}
+
+func (n *Node_BoolExpr) WalkSubtree(visit Visit) error {
+	return walkBoolExpr(n.BoolExpr, visitWalker(visit))
+}
+
+func (n *Node_SubLink) WalkSubtree(visit Visit) error {
+	return walkSubLink(n.SubLink, visitWalker(visit))
+}
+
+func (n *Node_SubPlan) WalkSubtree(visit Visit) error {
+	return walkSubPlan(n.SubPlan, visitWalker(visit))
+}
+
+func (n *Node_AlternativeSubPlan) WalkSubtree(visit Visit) error {
+	return walkAlternativeSubPlan(n.AlternativeSubPlan, visitWalker(visit))
+}
+
+func (n *Node_FieldSelect) WalkSubtree(visit Visit) error {
+	return walkFieldSelect(n.FieldSelect, visitWalker(visit))
+}
+
+func (n *Node_FieldStore) WalkSubtree(visit Visit) error {
+	return walkFieldStore(n.FieldStore, visitWalker(visit))
+}
+
+func (n *Node_RelabelType) WalkSubtree(visit Visit) error {
+	return walkRelabelType(n.RelabelType, visitWalker(visit))
+}
+
+func (n *Node_CoerceViaIo) WalkSubtree(visit Visit) error {
+	return walkCoerceViaIO(n.CoerceViaIo, visitWalker(visit))
+}
+
+func (n *Node_ArrayCoerceExpr) WalkSubtree(visit Visit) error {
+	return walkArrayCoerceExpr(n.ArrayCoerceExpr, visitWalker(visit))
+}
+
+func (n *Node_ConvertRowtypeExpr) WalkSubtree(visit Visit) error {
+	return walkConvertRowtypeExpr(n.ConvertRowtypeExpr, visitWalker(visit))
+}
+
+func (n *Node_CollateExpr) WalkSubtree(visit Visit) error {
+	return walkCollateExpr(n.CollateExpr, visitWalker(visit))
+}
+
+func (n *Node_CaseExpr) WalkSubtree(visit Visit) error {
+	return walkCaseExpr(n.CaseExpr, visitWalker(visit))
+}
+
+func (n *Node_CaseWhen) WalkSubtree(visit Visit) error {
+	return walkCaseWhen(n.CaseWhen, visitWalker(visit))
+}
+
+func (n *Node_CaseTestExpr) WalkSubtree(visit Visit) error {
+	return walkCaseTestExpr(n.CaseTestExpr, visitWalker(visit))
+}
+
+func (n *Node_ArrayExpr) WalkSubtree(visit Visit) error {
+	return walkArrayExpr(n.ArrayExpr, visitWalker(visit))
+}
+
+func (n *Node_RowExpr) WalkSubtree(visit Visit) error {
+	return walkRowExpr(n.RowExpr, visitWalker(visit))
+}
+
+func (n *Node_RowCompareExpr) WalkSubtree(visit Visit) error {
+	return walkRowCompareExpr(n.RowCompareExpr, visitWalker(visit))
+}
+
+func (n *Node_CoalesceExpr) WalkSubtree(visit Visit) error {
+	return walkCoalesceExpr(n.CoalesceExpr, visitWalker(visit))
+}
+
+func (n *Node_MinMaxExpr) WalkSubtree(visit Visit) error {
+	return walkMinMaxExpr(n.MinMaxExpr, visitWalker(visit))
+}
+
+func (n *Node_SqlvalueFunction) WalkSubtree(visit Visit) error {
+	return walkSQLValueFunction(n.SqlvalueFunction, visitWalker(visit))
+}
+
+func (n *Node_XmlExpr) WalkSubtree(visit Visit) error {
+	return walkXmlExpr(n.XmlExpr, visitWalker(visit))
+}
+
+func (n *Node_JsonFormat) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_JsonReturning) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_JsonValueExpr) WalkSubtree(visit Visit) error {
+	return walkJsonValueExpr(n.JsonValueExpr, visitWalker(visit))
+}
+
+func (n *Node_JsonConstructorExpr) WalkSubtree(visit Visit) error {
+	return walkJsonConstructorExpr(n.JsonConstructorExpr, visitWalker(visit))
+}
+
+func (n *Node_JsonIsPredicate) WalkSubtree(visit Visit) error {
+	return walkJsonIsPredicate(n.JsonIsPredicate, visitWalker(visit))
+}
+
+func (n *Node_NullTest) WalkSubtree(visit Visit) error {
+	return walkNullTest(n.NullTest, visitWalker(visit))
+}
+
+func (n *Node_BooleanTest) WalkSubtree(visit Visit) error {
+	return walkBooleanTest(n.BooleanTest, visitWalker(visit))
+}
+
+func (n *Node_CoerceToDomain) WalkSubtree(visit Visit) error {
+	return walkCoerceToDomain(n.CoerceToDomain, visitWalker(visit))
+}
+
+func (n *Node_CoerceToDomainValue) WalkSubtree(visit Visit) error {
+	return walkCoerceToDomainValue(n.CoerceToDomainValue, visitWalker(visit))
+}
+
+func (n *Node_SetToDefault) WalkSubtree(visit Visit) error {
+	return walkSetToDefault(n.SetToDefault, visitWalker(visit))
+}
+
+func (n *Node_CurrentOfExpr) WalkSubtree(visit Visit) error {
+	return walkCurrentOfExpr(n.CurrentOfExpr, visitWalker(visit))
+}
+
+func (n *Node_NextValueExpr) WalkSubtree(visit Visit) error {
+	return walkNextValueExpr(n.NextValueExpr, visitWalker(visit))
+}
+
+func (n *Node_InferenceElem) WalkSubtree(visit Visit) error {
+	return walkInferenceElem(n.InferenceElem, visitWalker(visit))
+}
+
+func (n *Node_TargetEntry) WalkSubtree(visit Visit) error {
+	return walkTargetEntry(n.TargetEntry, visitWalker(visit))
+}
+
+func (n *Node_RangeTblRef) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_JoinExpr) WalkSubtree(visit Visit) error {
+	return walkJoinExpr(n.JoinExpr, visitWalker(visit))
+}
+
+func (n *Node_FromExpr) WalkSubtree(visit Visit) error {
+	return walkFromExpr(n.FromExpr, visitWalker(visit))
+}
+
+func (n *Node_OnConflictExpr) WalkSubtree(visit Visit) error {
+	return walkOnConflictExpr(n.OnConflictExpr, visitWalker(visit))
+}
+
+func (n *Node_Query) WalkSubtree(visit Visit) error {
+	return walkQuery(n.Query, visitWalker(visit))
+}
+
+func (n *Node_TypeName) WalkSubtree(visit Visit) error {
+	return walkTypeName(n.TypeName, visitWalker(visit))
+}
+
+func (n *Node_ColumnRef) WalkSubtree(visit Visit) error {
+	return walkColumnRef(n.ColumnRef, visitWalker(visit))
+}
+
+func (n *Node_ParamRef) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_AExpr) WalkSubtree(visit Visit) error {
+	return walkA_Expr(n.AExpr, visitWalker(visit))
+}
+
+func (n *Node_TypeCast) WalkSubtree(visit Visit) error {
+	return walkTypeCast(n.TypeCast, visitWalker(visit))
+}
+
+func (n *Node_CollateClause) WalkSubtree(visit Visit) error {
+	return walkCollateClause(n.CollateClause, visitWalker(visit))
+}
+
+func (n *Node_RoleSpec) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_FuncCall) WalkSubtree(visit Visit) error {
+	return walkFuncCall(n.FuncCall, visitWalker(visit))
+}
+
+func (n *Node_AStar) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_AIndices) WalkSubtree(visit Visit) error {
+	return walkA_Indices(n.AIndices, visitWalker(visit))
+}
+
+func (n *Node_AIndirection) WalkSubtree(visit Visit) error {
+	return walkA_Indirection(n.AIndirection, visitWalker(visit))
+}
+
+func (n *Node_AArrayExpr) WalkSubtree(visit Visit) error {
+	return walkA_ArrayExpr(n.AArrayExpr, visitWalker(visit))
+}
+
+func (n *Node_ResTarget) WalkSubtree(visit Visit) error {
+	return walkResTarget(n.ResTarget, visitWalker(visit))
+}
+
+func (n *Node_MultiAssignRef) WalkSubtree(visit Visit) error {
+	return walkMultiAssignRef(n.MultiAssignRef, visitWalker(visit))
+}
+
+func (n *Node_SortBy) WalkSubtree(visit Visit) error {
+	return walkSortBy(n.SortBy, visitWalker(visit))
+}
+
+func (n *Node_WindowDef) WalkSubtree(visit Visit) error {
+	return walkWindowDef(n.WindowDef, visitWalker(visit))
+}
+
+func (n *Node_RangeSubselect) WalkSubtree(visit Visit) error {
+	return walkRangeSubselect(n.RangeSubselect, visitWalker(visit))
+}
+
+func (n *Node_RangeFunction) WalkSubtree(visit Visit) error {
+	return walkRangeFunction(n.RangeFunction, visitWalker(visit))
+}
+
+func (n *Node_RangeTableFunc) WalkSubtree(visit Visit) error {
+	return walkRangeTableFunc(n.RangeTableFunc, visitWalker(visit))
+}
+
+func (n *Node_RangeTableFuncCol) WalkSubtree(visit Visit) error {
+	return walkRangeTableFuncCol(n.RangeTableFuncCol, visitWalker(visit))
+}
+
+func (n *Node_RangeTableSample) WalkSubtree(visit Visit) error {
+	return walkRangeTableSample(n.RangeTableSample, visitWalker(visit))
+}
+
+func (n *Node_ColumnDef) WalkSubtree(visit Visit) error {
+	return walkColumnDef(n.ColumnDef, visitWalker(visit))
+}
+
+func (n *Node_TableLikeClause) WalkSubtree(visit Visit) error {
+	return walkTableLikeClause(n.TableLikeClause, visitWalker(visit))
+}
+
+func (n *Node_IndexElem) WalkSubtree(visit Visit) error {
+	return walkIndexElem(n.IndexElem, visitWalker(visit))
+}
+
+func (n *Node_DefElem) WalkSubtree(visit Visit) error {
+	return walkDefElem(n.DefElem, visitWalker(visit))
+}
+
+func (n *Node_LockingClause) WalkSubtree(visit Visit) error {
+	return walkLockingClause(n.LockingClause, visitWalker(visit))
+}
+
+func (n *Node_XmlSerialize) WalkSubtree(visit Visit) error {
+	return walkXmlSerialize(n.XmlSerialize, visitWalker(visit))
+}
+
+func (n *Node_PartitionElem) WalkSubtree(visit Visit) error {
+	return walkPartitionElem(n.PartitionElem, visitWalker(visit))
+}
+
+func (n *Node_PartitionSpec) WalkSubtree(visit Visit) error {
+	return walkPartitionSpec(n.PartitionSpec, visitWalker(visit))
+}
+
+func (n *Node_PartitionBoundSpec) WalkSubtree(visit Visit) error {
+	return walkPartitionBoundSpec(n.PartitionBoundSpec, visitWalker(visit))
+}
+
+func (n *Node_PartitionRangeDatum) WalkSubtree(visit Visit) error {
+	return walkPartitionRangeDatum(n.PartitionRangeDatum, visitWalker(visit))
+}
+
+func (n *Node_PartitionCmd) WalkSubtree(visit Visit) error {
+	return walkPartitionCmd(n.PartitionCmd, visitWalker(visit))
+}
+
+func (n *Node_RangeTblEntry) WalkSubtree(visit Visit) error {
+	return walkRangeTblEntry(n.RangeTblEntry, visitWalker(visit))
+}
+
+func (n *Node_RtepermissionInfo) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_RangeTblFunction) WalkSubtree(visit Visit) error {
+	return walkRangeTblFunction(n.RangeTblFunction, visitWalker(visit))
+}
+
+func (n *Node_TableSampleClause) WalkSubtree(visit Visit) error {
+	return walkTableSampleClause(n.TableSampleClause, visitWalker(visit))
+}
+
+func (n *Node_WithCheckOption) WalkSubtree(visit Visit) error {
+	return walkWithCheckOption(n.WithCheckOption, visitWalker(visit))
+}
+
+func (n *Node_SortGroupClause) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_GroupingSet) WalkSubtree(visit Visit) error {
+	return walkGroupingSet(n.GroupingSet, visitWalker(visit))
+}
+
+func (n *Node_WindowClause) WalkSubtree(visit Visit) error {
+	return walkWindowClause(n.WindowClause, visitWalker(visit))
+}
+
+func (n *Node_RowMarkClause) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_WithClause) WalkSubtree(visit Visit) error {
+	return walkWithClause(n.WithClause, visitWalker(visit))
+}
+
+func (n *Node_InferClause) WalkSubtree(visit Visit) error {
+	return walkInferClause(n.InferClause, visitWalker(visit))
+}
+
+func (n *Node_OnConflictClause) WalkSubtree(visit Visit) error {
+	return walkOnConflictClause(n.OnConflictClause, visitWalker(visit))
+}
+
+func (n *Node_CtesearchClause) WalkSubtree(visit Visit) error {
+	return walkCTESearchClause(n.CtesearchClause, visitWalker(visit))
+}
+
+func (n *Node_CtecycleClause) WalkSubtree(visit Visit) error {
+	return walkCTECycleClause(n.CtecycleClause, visitWalker(visit))
+}
+
+func (n *Node_CommonTableExpr) WalkSubtree(visit Visit) error {
+	return walkCommonTableExpr(n.CommonTableExpr, visitWalker(visit))
+}
+
+func (n *Node_MergeWhenClause) WalkSubtree(visit Visit) error {
+	return walkMergeWhenClause(n.MergeWhenClause, visitWalker(visit))
+}
+
+func (n *Node_MergeAction) WalkSubtree(visit Visit) error {
+	return walkMergeAction(n.MergeAction, visitWalker(visit))
+}
+
+func (n *Node_TriggerTransition) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_JsonOutput) WalkSubtree(visit Visit) error {
+	return walkJsonOutput(n.JsonOutput, visitWalker(visit))
+}
+
+func (n *Node_JsonKeyValue) WalkSubtree(visit Visit) error {
+	return walkJsonKeyValue(n.JsonKeyValue, visitWalker(visit))
+}
+
+func (n *Node_JsonObjectConstructor) WalkSubtree(visit Visit) error {
+	return walkJsonObjectConstructor(n.JsonObjectConstructor, visitWalker(visit))
+}
+
+func (n *Node_JsonArrayConstructor) WalkSubtree(visit Visit) error {
+	return walkJsonArrayConstructor(n.JsonArrayConstructor, visitWalker(visit))
+}
+
+func (n *Node_JsonArrayQueryConstructor) WalkSubtree(visit Visit) error {
+	return walkJsonArrayQueryConstructor(n.JsonArrayQueryConstructor, visitWalker(visit))
+}
+
+func (n *Node_JsonAggConstructor) WalkSubtree(visit Visit) error {
+	return walkJsonAggConstructor(n.JsonAggConstructor, visitWalker(visit))
+}
+
+func (n *Node_JsonObjectAgg) WalkSubtree(visit Visit) error {
+	return walkJsonObjectAgg(n.JsonObjectAgg, visitWalker(visit))
+}
+
+func (n *Node_JsonArrayAgg) WalkSubtree(visit Visit) error {
+	return walkJsonArrayAgg(n.JsonArrayAgg, visitWalker(visit))
+}
+
+func (n *Node_RawStmt) WalkSubtree(visit Visit) error {
+	return walkRawStmt(n.RawStmt, visitWalker(visit))
+}
+
+func (n *Node_InsertStmt) WalkSubtree(visit Visit) error {
+	return walkInsertStmt(n.InsertStmt, visitWalker(visit))
+}
+
+func (n *Node_DeleteStmt) WalkSubtree(visit Visit) error {
+	return walkDeleteStmt(n.DeleteStmt, visitWalker(visit))
+}
+
+func (n *Node_UpdateStmt) WalkSubtree(visit Visit) error {
+	return walkUpdateStmt(n.UpdateStmt, visitWalker(visit))
+}
+
+func (n *Node_MergeStmt) WalkSubtree(visit Visit) error {
+	return walkMergeStmt(n.MergeStmt, visitWalker(visit))
+}
+
+func (n *Node_SelectStmt) WalkSubtree(visit Visit) error {
+	return walkSelectStmt(n.SelectStmt, visitWalker(visit))
+}
+
+func (n *Node_SetOperationStmt) WalkSubtree(visit Visit) error {
+	return walkSetOperationStmt(n.SetOperationStmt, visitWalker(visit))
+}
+
+func (n *Node_ReturnStmt) WalkSubtree(visit Visit) error {
+	return walkReturnStmt(n.ReturnStmt, visitWalker(visit))
+}
+
+func (n *Node_PlassignStmt) WalkSubtree(visit Visit) error {
+	return walkPLAssignStmt(n.PlassignStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateSchemaStmt) WalkSubtree(visit Visit) error {
+	return walkCreateSchemaStmt(n.CreateSchemaStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterTableStmt) WalkSubtree(visit Visit) error {
+	return walkAlterTableStmt(n.AlterTableStmt, visitWalker(visit))
+}
+
+func (n *Node_ReplicaIdentityStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_AlterTableCmd) WalkSubtree(visit Visit) error {
+	return walkAlterTableCmd(n.AlterTableCmd, visitWalker(visit))
+}
+
+func (n *Node_AlterCollationStmt) WalkSubtree(visit Visit) error {
+	return walkAlterCollationStmt(n.AlterCollationStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterDomainStmt) WalkSubtree(visit Visit) error {
+	return walkAlterDomainStmt(n.AlterDomainStmt, visitWalker(visit))
+}
+
+func (n *Node_GrantStmt) WalkSubtree(visit Visit) error {
+	return walkGrantStmt(n.GrantStmt, visitWalker(visit))
+}
+
+func (n *Node_ObjectWithArgs) WalkSubtree(visit Visit) error {
+	return walkObjectWithArgs(n.ObjectWithArgs, visitWalker(visit))
+}
+
+func (n *Node_AccessPriv) WalkSubtree(visit Visit) error {
+	return walkAccessPriv(n.AccessPriv, visitWalker(visit))
+}
+
+func (n *Node_GrantRoleStmt) WalkSubtree(visit Visit) error {
+	return walkGrantRoleStmt(n.GrantRoleStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterDefaultPrivilegesStmt) WalkSubtree(visit Visit) error {
+	return walkAlterDefaultPrivilegesStmt(n.AlterDefaultPrivilegesStmt, visitWalker(visit))
+}
+
+func (n *Node_CopyStmt) WalkSubtree(visit Visit) error {
+	return walkCopyStmt(n.CopyStmt, visitWalker(visit))
+}
+
+func (n *Node_VariableSetStmt) WalkSubtree(visit Visit) error {
+	return walkVariableSetStmt(n.VariableSetStmt, visitWalker(visit))
+}
+
+func (n *Node_VariableShowStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_CreateStmt) WalkSubtree(visit Visit) error {
+	return walkCreateStmt(n.CreateStmt, visitWalker(visit))
+}
+
+func (n *Node_Constraint) WalkSubtree(visit Visit) error {
+	return walkConstraint(n.Constraint, visitWalker(visit))
+}
+
+func (n *Node_CreateTableSpaceStmt) WalkSubtree(visit Visit) error {
+	return walkCreateTableSpaceStmt(n.CreateTableSpaceStmt, visitWalker(visit))
+}
+
+func (n *Node_DropTableSpaceStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_AlterTableSpaceOptionsStmt) WalkSubtree(visit Visit) error {
+	return walkAlterTableSpaceOptionsStmt(n.AlterTableSpaceOptionsStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterTableMoveAllStmt) WalkSubtree(visit Visit) error {
+	return walkAlterTableMoveAllStmt(n.AlterTableMoveAllStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateExtensionStmt) WalkSubtree(visit Visit) error {
+	return walkCreateExtensionStmt(n.CreateExtensionStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterExtensionStmt) WalkSubtree(visit Visit) error {
+	return walkAlterExtensionStmt(n.AlterExtensionStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterExtensionContentsStmt) WalkSubtree(visit Visit) error {
+	return walkAlterExtensionContentsStmt(n.AlterExtensionContentsStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateFdwStmt) WalkSubtree(visit Visit) error {
+	return walkCreateFdwStmt(n.CreateFdwStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterFdwStmt) WalkSubtree(visit Visit) error {
+	return walkAlterFdwStmt(n.AlterFdwStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateForeignServerStmt) WalkSubtree(visit Visit) error {
+	return walkCreateForeignServerStmt(n.CreateForeignServerStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterForeignServerStmt) WalkSubtree(visit Visit) error {
+	return walkAlterForeignServerStmt(n.AlterForeignServerStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateForeignTableStmt) WalkSubtree(visit Visit) error {
+	return walkCreateForeignTableStmt(n.CreateForeignTableStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateUserMappingStmt) WalkSubtree(visit Visit) error {
+	return walkCreateUserMappingStmt(n.CreateUserMappingStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterUserMappingStmt) WalkSubtree(visit Visit) error {
+	return walkAlterUserMappingStmt(n.AlterUserMappingStmt, visitWalker(visit))
+}
+
+func (n *Node_DropUserMappingStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_ImportForeignSchemaStmt) WalkSubtree(visit Visit) error {
+	return walkImportForeignSchemaStmt(n.ImportForeignSchemaStmt, visitWalker(visit))
+}
+
+func (n *Node_CreatePolicyStmt) WalkSubtree(visit Visit) error {
+	return walkCreatePolicyStmt(n.CreatePolicyStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterPolicyStmt) WalkSubtree(visit Visit) error {
+	return walkAlterPolicyStmt(n.AlterPolicyStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateAmStmt) WalkSubtree(visit Visit) error {
+	return walkCreateAmStmt(n.CreateAmStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateTrigStmt) WalkSubtree(visit Visit) error {
+	return walkCreateTrigStmt(n.CreateTrigStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateEventTrigStmt) WalkSubtree(visit Visit) error {
+	return walkCreateEventTrigStmt(n.CreateEventTrigStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterEventTrigStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_CreatePlangStmt) WalkSubtree(visit Visit) error {
+	return walkCreatePLangStmt(n.CreatePlangStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateRoleStmt) WalkSubtree(visit Visit) error {
+	return walkCreateRoleStmt(n.CreateRoleStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterRoleStmt) WalkSubtree(visit Visit) error {
+	return walkAlterRoleStmt(n.AlterRoleStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterRoleSetStmt) WalkSubtree(visit Visit) error {
+	return walkAlterRoleSetStmt(n.AlterRoleSetStmt, visitWalker(visit))
+}
+
+func (n *Node_DropRoleStmt) WalkSubtree(visit Visit) error {
+	return walkDropRoleStmt(n.DropRoleStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateSeqStmt) WalkSubtree(visit Visit) error {
+	return walkCreateSeqStmt(n.CreateSeqStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterSeqStmt) WalkSubtree(visit Visit) error {
+	return walkAlterSeqStmt(n.AlterSeqStmt, visitWalker(visit))
+}
+
+func (n *Node_DefineStmt) WalkSubtree(visit Visit) error {
+	return walkDefineStmt(n.DefineStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateDomainStmt) WalkSubtree(visit Visit) error {
+	return walkCreateDomainStmt(n.CreateDomainStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateOpClassStmt) WalkSubtree(visit Visit) error {
+	return walkCreateOpClassStmt(n.CreateOpClassStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateOpClassItem) WalkSubtree(visit Visit) error {
+	return walkCreateOpClassItem(n.CreateOpClassItem, visitWalker(visit))
+}
+
+func (n *Node_CreateOpFamilyStmt) WalkSubtree(visit Visit) error {
+	return walkCreateOpFamilyStmt(n.CreateOpFamilyStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterOpFamilyStmt) WalkSubtree(visit Visit) error {
+	return walkAlterOpFamilyStmt(n.AlterOpFamilyStmt, visitWalker(visit))
+}
+
+func (n *Node_DropStmt) WalkSubtree(visit Visit) error {
+	return walkDropStmt(n.DropStmt, visitWalker(visit))
+}
+
+func (n *Node_TruncateStmt) WalkSubtree(visit Visit) error {
+	return walkTruncateStmt(n.TruncateStmt, visitWalker(visit))
+}
+
+func (n *Node_CommentStmt) WalkSubtree(visit Visit) error {
+	return walkCommentStmt(n.CommentStmt, visitWalker(visit))
+}
+
+func (n *Node_SecLabelStmt) WalkSubtree(visit Visit) error {
+	return walkSecLabelStmt(n.SecLabelStmt, visitWalker(visit))
+}
+
+func (n *Node_DeclareCursorStmt) WalkSubtree(visit Visit) error {
+	return walkDeclareCursorStmt(n.DeclareCursorStmt, visitWalker(visit))
+}
+
+func (n *Node_ClosePortalStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_FetchStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_IndexStmt) WalkSubtree(visit Visit) error {
+	return walkIndexStmt(n.IndexStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateStatsStmt) WalkSubtree(visit Visit) error {
+	return walkCreateStatsStmt(n.CreateStatsStmt, visitWalker(visit))
+}
+
+func (n *Node_StatsElem) WalkSubtree(visit Visit) error {
+	return walkStatsElem(n.StatsElem, visitWalker(visit))
+}
+
+func (n *Node_AlterStatsStmt) WalkSubtree(visit Visit) error {
+	return walkAlterStatsStmt(n.AlterStatsStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateFunctionStmt) WalkSubtree(visit Visit) error {
+	return walkCreateFunctionStmt(n.CreateFunctionStmt, visitWalker(visit))
+}
+
+func (n *Node_FunctionParameter) WalkSubtree(visit Visit) error {
+	return walkFunctionParameter(n.FunctionParameter, visitWalker(visit))
+}
+
+func (n *Node_AlterFunctionStmt) WalkSubtree(visit Visit) error {
+	return walkAlterFunctionStmt(n.AlterFunctionStmt, visitWalker(visit))
+}
+
+func (n *Node_DoStmt) WalkSubtree(visit Visit) error {
+	return walkDoStmt(n.DoStmt, visitWalker(visit))
+}
+
+func (n *Node_InlineCodeBlock) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_CallStmt) WalkSubtree(visit Visit) error {
+	return walkCallStmt(n.CallStmt, visitWalker(visit))
+}
+
+func (n *Node_CallContext) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_RenameStmt) WalkSubtree(visit Visit) error {
+	return walkRenameStmt(n.RenameStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterObjectDependsStmt) WalkSubtree(visit Visit) error {
+	return walkAlterObjectDependsStmt(n.AlterObjectDependsStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterObjectSchemaStmt) WalkSubtree(visit Visit) error {
+	return walkAlterObjectSchemaStmt(n.AlterObjectSchemaStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterOwnerStmt) WalkSubtree(visit Visit) error {
+	return walkAlterOwnerStmt(n.AlterOwnerStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterOperatorStmt) WalkSubtree(visit Visit) error {
+	return walkAlterOperatorStmt(n.AlterOperatorStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterTypeStmt) WalkSubtree(visit Visit) error {
+	return walkAlterTypeStmt(n.AlterTypeStmt, visitWalker(visit))
+}
+
+func (n *Node_RuleStmt) WalkSubtree(visit Visit) error {
+	return walkRuleStmt(n.RuleStmt, visitWalker(visit))
+}
+
+func (n *Node_NotifyStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_ListenStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_UnlistenStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_TransactionStmt) WalkSubtree(visit Visit) error {
+	return walkTransactionStmt(n.TransactionStmt, visitWalker(visit))
+}
+
+func (n *Node_CompositeTypeStmt) WalkSubtree(visit Visit) error {
+	return walkCompositeTypeStmt(n.CompositeTypeStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateEnumStmt) WalkSubtree(visit Visit) error {
+	return walkCreateEnumStmt(n.CreateEnumStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateRangeStmt) WalkSubtree(visit Visit) error {
+	return walkCreateRangeStmt(n.CreateRangeStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterEnumStmt) WalkSubtree(visit Visit) error {
+	return walkAlterEnumStmt(n.AlterEnumStmt, visitWalker(visit))
+}
+
+func (n *Node_ViewStmt) WalkSubtree(visit Visit) error {
+	return walkViewStmt(n.ViewStmt, visitWalker(visit))
+}
+
+func (n *Node_LoadStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_CreatedbStmt) WalkSubtree(visit Visit) error {
+	return walkCreatedbStmt(n.CreatedbStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterDatabaseStmt) WalkSubtree(visit Visit) error {
+	return walkAlterDatabaseStmt(n.AlterDatabaseStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterDatabaseRefreshCollStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_AlterDatabaseSetStmt) WalkSubtree(visit Visit) error {
+	return walkAlterDatabaseSetStmt(n.AlterDatabaseSetStmt, visitWalker(visit))
+}
+
+func (n *Node_DropdbStmt) WalkSubtree(visit Visit) error {
+	return walkDropdbStmt(n.DropdbStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterSystemStmt) WalkSubtree(visit Visit) error {
+	return walkAlterSystemStmt(n.AlterSystemStmt, visitWalker(visit))
+}
+
+func (n *Node_ClusterStmt) WalkSubtree(visit Visit) error {
+	return walkClusterStmt(n.ClusterStmt, visitWalker(visit))
+}
+
+func (n *Node_VacuumStmt) WalkSubtree(visit Visit) error {
+	return walkVacuumStmt(n.VacuumStmt, visitWalker(visit))
+}
+
+func (n *Node_VacuumRelation) WalkSubtree(visit Visit) error {
+	return walkVacuumRelation(n.VacuumRelation, visitWalker(visit))
+}
+
+func (n *Node_ExplainStmt) WalkSubtree(visit Visit) error {
+	return walkExplainStmt(n.ExplainStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateTableAsStmt) WalkSubtree(visit Visit) error {
+	return walkCreateTableAsStmt(n.CreateTableAsStmt, visitWalker(visit))
+}
+
+func (n *Node_RefreshMatViewStmt) WalkSubtree(visit Visit) error {
+	return walkRefreshMatViewStmt(n.RefreshMatViewStmt, visitWalker(visit))
+}
+
+func (n *Node_CheckPointStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_DiscardStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_LockStmt) WalkSubtree(visit Visit) error {
+	return walkLockStmt(n.LockStmt, visitWalker(visit))
+}
+
+func (n *Node_ConstraintsSetStmt) WalkSubtree(visit Visit) error {
+	return walkConstraintsSetStmt(n.ConstraintsSetStmt, visitWalker(visit))
+}
+
+func (n *Node_ReindexStmt) WalkSubtree(visit Visit) error {
+	return walkReindexStmt(n.ReindexStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateConversionStmt) WalkSubtree(visit Visit) error {
+	return walkCreateConversionStmt(n.CreateConversionStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateCastStmt) WalkSubtree(visit Visit) error {
+	return walkCreateCastStmt(n.CreateCastStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateTransformStmt) WalkSubtree(visit Visit) error {
+	return walkCreateTransformStmt(n.CreateTransformStmt, visitWalker(visit))
+}
+
+func (n *Node_PrepareStmt) WalkSubtree(visit Visit) error {
+	return walkPrepareStmt(n.PrepareStmt, visitWalker(visit))
+}
+
+func (n *Node_ExecuteStmt) WalkSubtree(visit Visit) error {
+	return walkExecuteStmt(n.ExecuteStmt, visitWalker(visit))
+}
+
+func (n *Node_DeallocateStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_DropOwnedStmt) WalkSubtree(visit Visit) error {
+	return walkDropOwnedStmt(n.DropOwnedStmt, visitWalker(visit))
+}
+
+func (n *Node_ReassignOwnedStmt) WalkSubtree(visit Visit) error {
+	return walkReassignOwnedStmt(n.ReassignOwnedStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterTsdictionaryStmt) WalkSubtree(visit Visit) error {
+	return walkAlterTSDictionaryStmt(n.AlterTsdictionaryStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterTsconfigurationStmt) WalkSubtree(visit Visit) error {
+	return walkAlterTSConfigurationStmt(n.AlterTsconfigurationStmt, visitWalker(visit))
+}
+
+func (n *Node_PublicationTable) WalkSubtree(visit Visit) error {
+	return walkPublicationTable(n.PublicationTable, visitWalker(visit))
+}
+
+func (n *Node_PublicationObjSpec) WalkSubtree(visit Visit) error {
+	return walkPublicationObjSpec(n.PublicationObjSpec, visitWalker(visit))
+}
+
+func (n *Node_CreatePublicationStmt) WalkSubtree(visit Visit) error {
+	return walkCreatePublicationStmt(n.CreatePublicationStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterPublicationStmt) WalkSubtree(visit Visit) error {
+	return walkAlterPublicationStmt(n.AlterPublicationStmt, visitWalker(visit))
+}
+
+func (n *Node_CreateSubscriptionStmt) WalkSubtree(visit Visit) error {
+	return walkCreateSubscriptionStmt(n.CreateSubscriptionStmt, visitWalker(visit))
+}
+
+func (n *Node_AlterSubscriptionStmt) WalkSubtree(visit Visit) error {
+	return walkAlterSubscriptionStmt(n.AlterSubscriptionStmt, visitWalker(visit))
+}
+
+func (n *Node_DropSubscriptionStmt) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_Integer) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_Float) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_Boolean) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_String_) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_BitString) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+func (n *Node_List) WalkSubtree(visit Visit) error {
+	return walkList(n.List, visitWalker(visit))
+}
+
+func (n *Node_IntList) WalkSubtree(visit Visit) error {
+	return walkIntList(n.IntList, visitWalker(visit))
+}
+
+func (n *Node_OidList) WalkSubtree(visit Visit) error {
+	return walkOidList(n.OidList, visitWalker(visit))
+}
+
+func (n *Node_AConst) WalkSubtree(visit Visit) error {
+	return nil
+}
+
+// walkNode passes all child nodes of the given node to the walker
+func walkNode(node *Node, w treeWalker) error {
+	switch n := node.Node.(type) {
+	case *Node_Alias:
+		return walkAlias(n.Alias, w)
+	case *Node_RangeVar:
+		return walkRangeVar(n.RangeVar, w)
+	case *Node_TableFunc:
+		return walkTableFunc(n.TableFunc, w)
+	case *Node_IntoClause:
+		return walkIntoClause(n.IntoClause, w)
+	case *Node_Var:
+		return walkVar(n.Var, w)
+	case *Node_Param:
+		return walkParam(n.Param, w)
+	case *Node_Aggref:
+		return walkAggref(n.Aggref, w)
+	case *Node_GroupingFunc:
+		return walkGroupingFunc(n.GroupingFunc, w)
+	case *Node_WindowFunc:
+		return walkWindowFunc(n.WindowFunc, w)
+	case *Node_SubscriptingRef:
+		return walkSubscriptingRef(n.SubscriptingRef, w)
+	case *Node_FuncExpr:
+		return walkFuncExpr(n.FuncExpr, w)
+	case *Node_NamedArgExpr:
+		return walkNamedArgExpr(n.NamedArgExpr, w)
+	case *Node_OpExpr:
+		return walkOpExpr(n.OpExpr, w)
+	case *Node_DistinctExpr:
+		return walkDistinctExpr(n.DistinctExpr, w)
+	case *Node_NullIfExpr:
+		return walkNullIfExpr(n.NullIfExpr, w)
+	case *Node_ScalarArrayOpExpr:
+		return walkScalarArrayOpExpr(n.ScalarArrayOpExpr, w)
+	case *Node_BoolExpr:
+		return walkBoolExpr(n.BoolExpr, w)
+	case *Node_SubLink:
+		return walkSubLink(n.SubLink, w)
+	case *Node_SubPlan:
+		return walkSubPlan(n.SubPlan, w)
+	case *Node_AlternativeSubPlan:
+		return walkAlternativeSubPlan(n.AlternativeSubPlan, w)
+	case *Node_FieldSelect:
+		return walkFieldSelect(n.FieldSelect, w)
+	case *Node_FieldStore:
+		return walkFieldStore(n.FieldStore, w)
+	case *Node_RelabelType:
+		return walkRelabelType(n.RelabelType, w)
+	case *Node_CoerceViaIo:
+		return walkCoerceViaIO(n.CoerceViaIo, w)
+	case *Node_ArrayCoerceExpr:
+		return walkArrayCoerceExpr(n.ArrayCoerceExpr, w)
+	case *Node_ConvertRowtypeExpr:
+		return walkConvertRowtypeExpr(n.ConvertRowtypeExpr, w)
+	case *Node_CollateExpr:
+		return walkCollateExpr(n.CollateExpr, w)
+	case *Node_CaseExpr:
+		return walkCaseExpr(n.CaseExpr, w)
+	case *Node_CaseWhen:
+		return walkCaseWhen(n.CaseWhen, w)
+	case *Node_CaseTestExpr:
+		return walkCaseTestExpr(n.CaseTestExpr, w)
+	case *Node_ArrayExpr:
+		return walkArrayExpr(n.ArrayExpr, w)
+	case *Node_RowExpr:
+		return walkRowExpr(n.RowExpr, w)
+	case *Node_RowCompareExpr:
+		return walkRowCompareExpr(n.RowCompareExpr, w)
+	case *Node_CoalesceExpr:
+		return walkCoalesceExpr(n.CoalesceExpr, w)
+	case *Node_MinMaxExpr:
+		return walkMinMaxExpr(n.MinMaxExpr, w)
+	case *Node_SqlvalueFunction:
+		return walkSQLValueFunction(n.SqlvalueFunction, w)
+	case *Node_XmlExpr:
+		return walkXmlExpr(n.XmlExpr, w)
+	case *Node_JsonValueExpr:
+		return walkJsonValueExpr(n.JsonValueExpr, w)
+	case *Node_JsonConstructorExpr:
+		return walkJsonConstructorExpr(n.JsonConstructorExpr, w)
+	case *Node_JsonIsPredicate:
+		return walkJsonIsPredicate(n.JsonIsPredicate, w)
+	case *Node_NullTest:
+		return walkNullTest(n.NullTest, w)
+	case *Node_BooleanTest:
+		return walkBooleanTest(n.BooleanTest, w)
+	case *Node_CoerceToDomain:
+		return walkCoerceToDomain(n.CoerceToDomain, w)
+	case *Node_CoerceToDomainValue:
+		return walkCoerceToDomainValue(n.CoerceToDomainValue, w)
+	case *Node_SetToDefault:
+		return walkSetToDefault(n.SetToDefault, w)
+	case *Node_CurrentOfExpr:
+		return walkCurrentOfExpr(n.CurrentOfExpr, w)
+	case *Node_NextValueExpr:
+		return walkNextValueExpr(n.NextValueExpr, w)
+	case *Node_InferenceElem:
+		return walkInferenceElem(n.InferenceElem, w)
+	case *Node_TargetEntry:
+		return walkTargetEntry(n.TargetEntry, w)
+	case *Node_JoinExpr:
+		return walkJoinExpr(n.JoinExpr, w)
+	case *Node_FromExpr:
+		return walkFromExpr(n.FromExpr, w)
+	case *Node_OnConflictExpr:
+		return walkOnConflictExpr(n.OnConflictExpr, w)
+	case *Node_Query:
+		return walkQuery(n.Query, w)
+	case *Node_TypeName:
+		return walkTypeName(n.TypeName, w)
+	case *Node_ColumnRef:
+		return walkColumnRef(n.ColumnRef, w)
+	case *Node_AExpr:
+		return walkA_Expr(n.AExpr, w)
+	case *Node_TypeCast:
+		return walkTypeCast(n.TypeCast, w)
+	case *Node_CollateClause:
+		return walkCollateClause(n.CollateClause, w)
+	case *Node_FuncCall:
+		return walkFuncCall(n.FuncCall, w)
+	case *Node_AIndices:
+		return walkA_Indices(n.AIndices, w)
+	case *Node_AIndirection:
+		return walkA_Indirection(n.AIndirection, w)
+	case *Node_AArrayExpr:
+		return walkA_ArrayExpr(n.AArrayExpr, w)
+	case *Node_ResTarget:
+		return walkResTarget(n.ResTarget, w)
+	case *Node_MultiAssignRef:
+		return walkMultiAssignRef(n.MultiAssignRef, w)
+	case *Node_SortBy:
+		return walkSortBy(n.SortBy, w)
+	case *Node_WindowDef:
+		return walkWindowDef(n.WindowDef, w)
+	case *Node_RangeSubselect:
+		return walkRangeSubselect(n.RangeSubselect, w)
+	case *Node_RangeFunction:
+		return walkRangeFunction(n.RangeFunction, w)
+	case *Node_RangeTableFunc:
+		return walkRangeTableFunc(n.RangeTableFunc, w)
+	case *Node_RangeTableFuncCol:
+		return walkRangeTableFuncCol(n.RangeTableFuncCol, w)
+	case *Node_RangeTableSample:
+		return walkRangeTableSample(n.RangeTableSample, w)
+	case *Node_ColumnDef:
+		return walkColumnDef(n.ColumnDef, w)
+	case *Node_TableLikeClause:
+		return walkTableLikeClause(n.TableLikeClause, w)
+	case *Node_IndexElem:
+		return walkIndexElem(n.IndexElem, w)
+	case *Node_DefElem:
+		return walkDefElem(n.DefElem, w)
+	case *Node_LockingClause:
+		return walkLockingClause(n.LockingClause, w)
+	case *Node_XmlSerialize:
+		return walkXmlSerialize(n.XmlSerialize, w)
+	case *Node_PartitionElem:
+		return walkPartitionElem(n.PartitionElem, w)
+	case *Node_PartitionSpec:
+		return walkPartitionSpec(n.PartitionSpec, w)
+	case *Node_PartitionBoundSpec:
+		return walkPartitionBoundSpec(n.PartitionBoundSpec, w)
+	case *Node_PartitionRangeDatum:
+		return walkPartitionRangeDatum(n.PartitionRangeDatum, w)
+	case *Node_PartitionCmd:
+		return walkPartitionCmd(n.PartitionCmd, w)
+	case *Node_RangeTblEntry:
+		return walkRangeTblEntry(n.RangeTblEntry, w)
+	case *Node_RangeTblFunction:
+		return walkRangeTblFunction(n.RangeTblFunction, w)
+	case *Node_TableSampleClause:
+		return walkTableSampleClause(n.TableSampleClause, w)
+	case *Node_WithCheckOption:
+		return walkWithCheckOption(n.WithCheckOption, w)
+	case *Node_GroupingSet:
+		return walkGroupingSet(n.GroupingSet, w)
+	case *Node_WindowClause:
+		return walkWindowClause(n.WindowClause, w)
+	case *Node_WithClause:
+		return walkWithClause(n.WithClause, w)
+	case *Node_InferClause:
+		return walkInferClause(n.InferClause, w)
+	case *Node_OnConflictClause:
+		return walkOnConflictClause(n.OnConflictClause, w)
+	case *Node_CtesearchClause:
+		return walkCTESearchClause(n.CtesearchClause, w)
+	case *Node_CtecycleClause:
+		return walkCTECycleClause(n.CtecycleClause, w)
+	case *Node_CommonTableExpr:
+		return walkCommonTableExpr(n.CommonTableExpr, w)
+	case *Node_MergeWhenClause:
+		return walkMergeWhenClause(n.MergeWhenClause, w)
+	case *Node_MergeAction:
+		return walkMergeAction(n.MergeAction, w)
+	case *Node_JsonOutput:
+		return walkJsonOutput(n.JsonOutput, w)
+	case *Node_JsonKeyValue:
+		return walkJsonKeyValue(n.JsonKeyValue, w)
+	case *Node_JsonObjectConstructor:
+		return walkJsonObjectConstructor(n.JsonObjectConstructor, w)
+	case *Node_JsonArrayConstructor:
+		return walkJsonArrayConstructor(n.JsonArrayConstructor, w)
+	case *Node_JsonArrayQueryConstructor:
+		return walkJsonArrayQueryConstructor(n.JsonArrayQueryConstructor, w)
+	case *Node_JsonAggConstructor:
+		return walkJsonAggConstructor(n.JsonAggConstructor, w)
+	case *Node_JsonObjectAgg:
+		return walkJsonObjectAgg(n.JsonObjectAgg, w)
+	case *Node_JsonArrayAgg:
+		return walkJsonArrayAgg(n.JsonArrayAgg, w)
+	case *Node_RawStmt:
+		return walkRawStmt(n.RawStmt, w)
+	case *Node_InsertStmt:
+		return walkInsertStmt(n.InsertStmt, w)
+	case *Node_DeleteStmt:
+		return walkDeleteStmt(n.DeleteStmt, w)
+	case *Node_UpdateStmt:
+		return walkUpdateStmt(n.UpdateStmt, w)
+	case *Node_MergeStmt:
+		return walkMergeStmt(n.MergeStmt, w)
+	case *Node_SelectStmt:
+		return walkSelectStmt(n.SelectStmt, w)
+	case *Node_SetOperationStmt:
+		return walkSetOperationStmt(n.SetOperationStmt, w)
+	case *Node_ReturnStmt:
+		return walkReturnStmt(n.ReturnStmt, w)
+	case *Node_PlassignStmt:
+		return walkPLAssignStmt(n.PlassignStmt, w)
+	case *Node_CreateSchemaStmt:
+		return walkCreateSchemaStmt(n.CreateSchemaStmt, w)
+	case *Node_AlterTableStmt:
+		return walkAlterTableStmt(n.AlterTableStmt, w)
+	case *Node_AlterTableCmd:
+		return walkAlterTableCmd(n.AlterTableCmd, w)
+	case *Node_AlterCollationStmt:
+		return walkAlterCollationStmt(n.AlterCollationStmt, w)
+	case *Node_AlterDomainStmt:
+		return walkAlterDomainStmt(n.AlterDomainStmt, w)
+	case *Node_GrantStmt:
+		return walkGrantStmt(n.GrantStmt, w)
+	case *Node_ObjectWithArgs:
+		return walkObjectWithArgs(n.ObjectWithArgs, w)
+	case *Node_AccessPriv:
+		return walkAccessPriv(n.AccessPriv, w)
+	case *Node_GrantRoleStmt:
+		return walkGrantRoleStmt(n.GrantRoleStmt, w)
+	case *Node_AlterDefaultPrivilegesStmt:
+		return walkAlterDefaultPrivilegesStmt(n.AlterDefaultPrivilegesStmt, w)
+	case *Node_CopyStmt:
+		return walkCopyStmt(n.CopyStmt, w)
+	case *Node_VariableSetStmt:
+		return walkVariableSetStmt(n.VariableSetStmt, w)
+	case *Node_CreateStmt:
+		return walkCreateStmt(n.CreateStmt, w)
+	case *Node_Constraint:
+		return walkConstraint(n.Constraint, w)
+	case *Node_CreateTableSpaceStmt:
+		return walkCreateTableSpaceStmt(n.CreateTableSpaceStmt, w)
+	case *Node_AlterTableSpaceOptionsStmt:
+		return walkAlterTableSpaceOptionsStmt(n.AlterTableSpaceOptionsStmt, w)
+	case *Node_AlterTableMoveAllStmt:
+		return walkAlterTableMoveAllStmt(n.AlterTableMoveAllStmt, w)
+	case *Node_CreateExtensionStmt:
+		return walkCreateExtensionStmt(n.CreateExtensionStmt, w)
+	case *Node_AlterExtensionStmt:
+		return walkAlterExtensionStmt(n.AlterExtensionStmt, w)
+	case *Node_AlterExtensionContentsStmt:
+		return walkAlterExtensionContentsStmt(n.AlterExtensionContentsStmt, w)
+	case *Node_CreateFdwStmt:
+		return walkCreateFdwStmt(n.CreateFdwStmt, w)
+	case *Node_AlterFdwStmt:
+		return walkAlterFdwStmt(n.AlterFdwStmt, w)
+	case *Node_CreateForeignServerStmt:
+		return walkCreateForeignServerStmt(n.CreateForeignServerStmt, w)
+	case *Node_AlterForeignServerStmt:
+		return walkAlterForeignServerStmt(n.AlterForeignServerStmt, w)
+	case *Node_CreateForeignTableStmt:
+		return walkCreateForeignTableStmt(n.CreateForeignTableStmt, w)
+	case *Node_CreateUserMappingStmt:
+		return walkCreateUserMappingStmt(n.CreateUserMappingStmt, w)
+	case *Node_AlterUserMappingStmt:
+		return walkAlterUserMappingStmt(n.AlterUserMappingStmt, w)
+	case *Node_ImportForeignSchemaStmt:
+		return walkImportForeignSchemaStmt(n.ImportForeignSchemaStmt, w)
+	case *Node_CreatePolicyStmt:
+		return walkCreatePolicyStmt(n.CreatePolicyStmt, w)
+	case *Node_AlterPolicyStmt:
+		return walkAlterPolicyStmt(n.AlterPolicyStmt, w)
+	case *Node_CreateAmStmt:
+		return walkCreateAmStmt(n.CreateAmStmt, w)
+	case *Node_CreateTrigStmt:
+		return walkCreateTrigStmt(n.CreateTrigStmt, w)
+	case *Node_CreateEventTrigStmt:
+		return walkCreateEventTrigStmt(n.CreateEventTrigStmt, w)
+	case *Node_CreatePlangStmt:
+		return walkCreatePLangStmt(n.CreatePlangStmt, w)
+	case *Node_CreateRoleStmt:
+		return walkCreateRoleStmt(n.CreateRoleStmt, w)
+	case *Node_AlterRoleStmt:
+		return walkAlterRoleStmt(n.AlterRoleStmt, w)
+	case *Node_AlterRoleSetStmt:
+		return walkAlterRoleSetStmt(n.AlterRoleSetStmt, w)
+	case *Node_DropRoleStmt:
+		return walkDropRoleStmt(n.DropRoleStmt, w)
+	case *Node_CreateSeqStmt:
+		return walkCreateSeqStmt(n.CreateSeqStmt, w)
+	case *Node_AlterSeqStmt:
+		return walkAlterSeqStmt(n.AlterSeqStmt, w)
+	case *Node_DefineStmt:
+		return walkDefineStmt(n.DefineStmt, w)
+	case *Node_CreateDomainStmt:
+		return walkCreateDomainStmt(n.CreateDomainStmt, w)
+	case *Node_CreateOpClassStmt:
+		return walkCreateOpClassStmt(n.CreateOpClassStmt, w)
+	case *Node_CreateOpClassItem:
+		return walkCreateOpClassItem(n.CreateOpClassItem, w)
+	case *Node_CreateOpFamilyStmt:
+		return walkCreateOpFamilyStmt(n.CreateOpFamilyStmt, w)
+	case *Node_AlterOpFamilyStmt:
+		return walkAlterOpFamilyStmt(n.AlterOpFamilyStmt, w)
+	case *Node_DropStmt:
+		return walkDropStmt(n.DropStmt, w)
+	case *Node_TruncateStmt:
+		return walkTruncateStmt(n.TruncateStmt, w)
+	case *Node_CommentStmt:
+		return walkCommentStmt(n.CommentStmt, w)
+	case *Node_SecLabelStmt:
+		return walkSecLabelStmt(n.SecLabelStmt, w)
+	case *Node_DeclareCursorStmt:
+		return walkDeclareCursorStmt(n.DeclareCursorStmt, w)
+	case *Node_IndexStmt:
+		return walkIndexStmt(n.IndexStmt, w)
+	case *Node_CreateStatsStmt:
+		return walkCreateStatsStmt(n.CreateStatsStmt, w)
+	case *Node_StatsElem:
+		return walkStatsElem(n.StatsElem, w)
+	case *Node_AlterStatsStmt:
+		return walkAlterStatsStmt(n.AlterStatsStmt, w)
+	case *Node_CreateFunctionStmt:
+		return walkCreateFunctionStmt(n.CreateFunctionStmt, w)
+	case *Node_FunctionParameter:
+		return walkFunctionParameter(n.FunctionParameter, w)
+	case *Node_AlterFunctionStmt:
+		return walkAlterFunctionStmt(n.AlterFunctionStmt, w)
+	case *Node_DoStmt:
+		return walkDoStmt(n.DoStmt, w)
+	case *Node_CallStmt:
+		return walkCallStmt(n.CallStmt, w)
+	case *Node_RenameStmt:
+		return walkRenameStmt(n.RenameStmt, w)
+	case *Node_AlterObjectDependsStmt:
+		return walkAlterObjectDependsStmt(n.AlterObjectDependsStmt, w)
+	case *Node_AlterObjectSchemaStmt:
+		return walkAlterObjectSchemaStmt(n.AlterObjectSchemaStmt, w)
+	case *Node_AlterOwnerStmt:
+		return walkAlterOwnerStmt(n.AlterOwnerStmt, w)
+	case *Node_AlterOperatorStmt:
+		return walkAlterOperatorStmt(n.AlterOperatorStmt, w)
+	case *Node_AlterTypeStmt:
+		return walkAlterTypeStmt(n.AlterTypeStmt, w)
+	case *Node_RuleStmt:
+		return walkRuleStmt(n.RuleStmt, w)
+	case *Node_TransactionStmt:
+		return walkTransactionStmt(n.TransactionStmt, w)
+	case *Node_CompositeTypeStmt:
+		return walkCompositeTypeStmt(n.CompositeTypeStmt, w)
+	case *Node_CreateEnumStmt:
+		return walkCreateEnumStmt(n.CreateEnumStmt, w)
+	case *Node_CreateRangeStmt:
+		return walkCreateRangeStmt(n.CreateRangeStmt, w)
+	case *Node_AlterEnumStmt:
+		return walkAlterEnumStmt(n.AlterEnumStmt, w)
+	case *Node_ViewStmt:
+		return walkViewStmt(n.ViewStmt, w)
+	case *Node_CreatedbStmt:
+		return walkCreatedbStmt(n.CreatedbStmt, w)
+	case *Node_AlterDatabaseStmt:
+		return walkAlterDatabaseStmt(n.AlterDatabaseStmt, w)
+	case *Node_AlterDatabaseSetStmt:
+		return walkAlterDatabaseSetStmt(n.AlterDatabaseSetStmt, w)
+	case *Node_DropdbStmt:
+		return walkDropdbStmt(n.DropdbStmt, w)
+	case *Node_AlterSystemStmt:
+		return walkAlterSystemStmt(n.AlterSystemStmt, w)
+	case *Node_ClusterStmt:
+		return walkClusterStmt(n.ClusterStmt, w)
+	case *Node_VacuumStmt:
+		return walkVacuumStmt(n.VacuumStmt, w)
+	case *Node_VacuumRelation:
+		return walkVacuumRelation(n.VacuumRelation, w)
+	case *Node_ExplainStmt:
+		return walkExplainStmt(n.ExplainStmt, w)
+	case *Node_CreateTableAsStmt:
+		return walkCreateTableAsStmt(n.CreateTableAsStmt, w)
+	case *Node_RefreshMatViewStmt:
+		return walkRefreshMatViewStmt(n.RefreshMatViewStmt, w)
+	case *Node_LockStmt:
+		return walkLockStmt(n.LockStmt, w)
+	case *Node_ConstraintsSetStmt:
+		return walkConstraintsSetStmt(n.ConstraintsSetStmt, w)
+	case *Node_ReindexStmt:
+		return walkReindexStmt(n.ReindexStmt, w)
+	case *Node_CreateConversionStmt:
+		return walkCreateConversionStmt(n.CreateConversionStmt, w)
+	case *Node_CreateCastStmt:
+		return walkCreateCastStmt(n.CreateCastStmt, w)
+	case *Node_CreateTransformStmt:
+		return walkCreateTransformStmt(n.CreateTransformStmt, w)
+	case *Node_PrepareStmt:
+		return walkPrepareStmt(n.PrepareStmt, w)
+	case *Node_ExecuteStmt:
+		return walkExecuteStmt(n.ExecuteStmt, w)
+	case *Node_DropOwnedStmt:
+		return walkDropOwnedStmt(n.DropOwnedStmt, w)
+	case *Node_ReassignOwnedStmt:
+		return walkReassignOwnedStmt(n.ReassignOwnedStmt, w)
+	case *Node_AlterTsdictionaryStmt:
+		return walkAlterTSDictionaryStmt(n.AlterTsdictionaryStmt, w)
+	case *Node_AlterTsconfigurationStmt:
+		return walkAlterTSConfigurationStmt(n.AlterTsconfigurationStmt, w)
+	case *Node_PublicationTable:
+		return walkPublicationTable(n.PublicationTable, w)
+	case *Node_PublicationObjSpec:
+		return walkPublicationObjSpec(n.PublicationObjSpec, w)
+	case *Node_CreatePublicationStmt:
+		return walkCreatePublicationStmt(n.CreatePublicationStmt, w)
+	case *Node_AlterPublicationStmt:
+		return walkAlterPublicationStmt(n.AlterPublicationStmt, w)
+	case *Node_CreateSubscriptionStmt:
+		return walkCreateSubscriptionStmt(n.CreateSubscriptionStmt, w)
+	case *Node_AlterSubscriptionStmt:
+		return walkAlterSubscriptionStmt(n.AlterSubscriptionStmt, w)
+	case *Node_List:
+		return walkList(n.List, w)
+	case *Node_IntList:
+		return walkIntList(n.IntList, w)
+	case *Node_OidList:
+		return walkOidList(n.OidList, w)
+	}
+	return nil
+}
+
+func walkA_ArrayExpr(n *A_ArrayExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("elements", &n.Elements); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkA_Expr(n *A_Expr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("name", &n.Name); err != nil {
+		return err
+	}
+	if err := w.node("lexpr", &n.Lexpr); err != nil {
+		return err
+	}
+	if err := w.node("rexpr", &n.Rexpr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkA_Indices(n *A_Indices, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("lidx", &n.Lidx); err != nil {
+		return err
+	}
+	if err := w.node("uidx", &n.Uidx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkA_Indirection(n *A_Indirection, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	if err := w.list("indirection", &n.Indirection); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAccessPriv(n *AccessPriv, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("cols", &n.Cols); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAggref(n *Aggref, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("aggargtypes", &n.Aggargtypes); err != nil {
+		return err
+	}
+	if err := w.list("aggdirectargs", &n.Aggdirectargs); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	if err := w.list("aggorder", &n.Aggorder); err != nil {
+		return err
+	}
+	if err := w.list("aggdistinct", &n.Aggdistinct); err != nil {
+		return err
+	}
+	if err := w.node("aggfilter", &n.Aggfilter); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlias(n *Alias, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("colnames", &n.Colnames); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterCollationStmt(n *AlterCollationStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("collname", &n.Collname); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterDatabaseSetStmt(n *AlterDatabaseSetStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Setstmt != nil {
+		if err := walkVariableSetStmt(n.Setstmt, w.message("setstmt")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkAlterDatabaseStmt(n *AlterDatabaseStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterDefaultPrivilegesStmt(n *AlterDefaultPrivilegesStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	if n.Action != nil {
+		if err := walkGrantStmt(n.Action, w.message("action")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkAlterDomainStmt(n *AlterDomainStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("typeName", &n.TypeName); err != nil {
+		return err
+	}
+	if err := w.node("def", &n.Def); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterEnumStmt(n *AlterEnumStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("typeName", &n.TypeName); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterExtensionContentsStmt(n *AlterExtensionContentsStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("object", &n.Object); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterExtensionStmt(n *AlterExtensionStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterFdwStmt(n *AlterFdwStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("func_options", &n.FuncOptions); err != nil {
+		return err
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterForeignServerStmt(n *AlterForeignServerStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterFunctionStmt(n *AlterFunctionStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Func != nil {
+		if err := walkObjectWithArgs(n.Func, w.message("func")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("actions", &n.Actions); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterObjectDependsStmt(n *AlterObjectDependsStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.node("object", &n.Object); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterObjectSchemaStmt(n *AlterObjectSchemaStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.node("object", &n.Object); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterOpFamilyStmt(n *AlterOpFamilyStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("opfamilyname", &n.Opfamilyname); err != nil {
+		return err
+	}
+	if err := w.list("items", &n.Items); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterOperatorStmt(n *AlterOperatorStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Opername != nil {
+		if err := walkObjectWithArgs(n.Opername, w.message("opername")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterOwnerStmt(n *AlterOwnerStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.node("object", &n.Object); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterPolicyStmt(n *AlterPolicyStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Table != nil {
+		if err := walkRangeVar(n.Table, w.message("table")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("roles", &n.Roles); err != nil {
+		return err
+	}
+	if err := w.node("qual", &n.Qual); err != nil {
+		return err
+	}
+	if err := w.node("with_check", &n.WithCheck); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterPublicationStmt(n *AlterPublicationStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	if err := w.list("pubobjects", &n.Pubobjects); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterRoleSetStmt(n *AlterRoleSetStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Setstmt != nil {
+		if err := walkVariableSetStmt(n.Setstmt, w.message("setstmt")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkAlterRoleStmt(n *AlterRoleStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterSeqStmt(n *AlterSeqStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Sequence != nil {
+		if err := walkRangeVar(n.Sequence, w.message("sequence")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterStatsStmt(n *AlterStatsStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("defnames", &n.Defnames); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterSubscriptionStmt(n *AlterSubscriptionStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("publication", &n.Publication); err != nil {
+		return err
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterSystemStmt(n *AlterSystemStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Setstmt != nil {
+		if err := walkVariableSetStmt(n.Setstmt, w.message("setstmt")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkAlterTSConfigurationStmt(n *AlterTSConfigurationStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("cfgname", &n.Cfgname); err != nil {
+		return err
+	}
+	if err := w.list("tokentype", &n.Tokentype); err != nil {
+		return err
+	}
+	if err := w.list("dicts", &n.Dicts); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterTSDictionaryStmt(n *AlterTSDictionaryStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("dictname", &n.Dictname); err != nil {
+		return err
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterTableCmd(n *AlterTableCmd, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("def", &n.Def); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterTableMoveAllStmt(n *AlterTableMoveAllStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("roles", &n.Roles); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterTableSpaceOptionsStmt(n *AlterTableSpaceOptionsStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterTableStmt(n *AlterTableStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("cmds", &n.Cmds); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterTypeStmt(n *AlterTypeStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("typeName", &n.TypeName); err != nil {
+		return err
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlterUserMappingStmt(n *AlterUserMappingStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkAlternativeSubPlan(n *AlternativeSubPlan, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("subplans", &n.Subplans); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkArrayCoerceExpr(n *ArrayCoerceExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	if err := w.node("elemexpr", &n.Elemexpr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkArrayExpr(n *ArrayExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("elements", &n.Elements); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkBoolExpr(n *BoolExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkBooleanTest(n *BooleanTest, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCTECycleClause(n *CTECycleClause, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("cycle_col_list", &n.CycleColList); err != nil {
+		return err
+	}
+	if err := w.node("cycle_mark_value", &n.CycleMarkValue); err != nil {
+		return err
+	}
+	if err := w.node("cycle_mark_default", &n.CycleMarkDefault); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCTESearchClause(n *CTESearchClause, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("search_col_list", &n.SearchColList); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCallStmt(n *CallStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Funccall != nil {
+		if err := walkFuncCall(n.Funccall, w.message("funccall")); err != nil {
+			return err
+		}
+	}
+	if n.Funcexpr != nil {
+		if err := walkFuncExpr(n.Funcexpr, w.message("funcexpr")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("outargs", &n.Outargs); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCaseExpr(n *CaseExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	if err := w.node("defresult", &n.Defresult); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCaseTestExpr(n *CaseTestExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCaseWhen(n *CaseWhen, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("expr", &n.Expr); err != nil {
+		return err
+	}
+	if err := w.node("result", &n.Result); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkClusterStmt(n *ClusterStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("params", &n.Params); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCoalesceExpr(n *CoalesceExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCoerceToDomain(n *CoerceToDomain, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCoerceToDomainValue(n *CoerceToDomainValue, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCoerceViaIO(n *CoerceViaIO, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCollateClause(n *CollateClause, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	if err := w.list("collname", &n.Collname); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCollateExpr(n *CollateExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkColumnDef(n *ColumnDef, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.TypeName != nil {
+		if err := walkTypeName(n.TypeName, w.message("typeName")); err != nil {
+			return err
+		}
+	}
+	if err := w.node("raw_default", &n.RawDefault); err != nil {
+		return err
+	}
+	if err := w.node("cooked_default", &n.CookedDefault); err != nil {
+		return err
+	}
+	if n.IdentitySequence != nil {
+		if err := walkRangeVar(n.IdentitySequence, w.message("identitySequence")); err != nil {
+			return err
+		}
+	}
+	if n.CollClause != nil {
+		if err := walkCollateClause(n.CollClause, w.message("collClause")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("constraints", &n.Constraints); err != nil {
+		return err
+	}
+	if err := w.list("fdwoptions", &n.Fdwoptions); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkColumnRef(n *ColumnRef, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("fields", &n.Fields); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCommentStmt(n *CommentStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("object", &n.Object); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCommonTableExpr(n *CommonTableExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("aliascolnames", &n.Aliascolnames); err != nil {
+		return err
+	}
+	if err := w.node("ctequery", &n.Ctequery); err != nil {
+		return err
+	}
+	if n.SearchClause != nil {
+		if err := walkCTESearchClause(n.SearchClause, w.message("search_clause")); err != nil {
+			return err
+		}
+	}
+	if n.CycleClause != nil {
+		if err := walkCTECycleClause(n.CycleClause, w.message("cycle_clause")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("ctecolnames", &n.Ctecolnames); err != nil {
+		return err
+	}
+	if err := w.list("ctecoltypes", &n.Ctecoltypes); err != nil {
+		return err
+	}
+	if err := w.list("ctecoltypmods", &n.Ctecoltypmods); err != nil {
+		return err
+	}
+	if err := w.list("ctecolcollations", &n.Ctecolcollations); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCompositeTypeStmt(n *CompositeTypeStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Typevar != nil {
+		if err := walkRangeVar(n.Typevar, w.message("typevar")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("coldeflist", &n.Coldeflist); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkConstraint(n *Constraint, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("raw_expr", &n.RawExpr); err != nil {
+		return err
+	}
+	if err := w.list("keys", &n.Keys); err != nil {
+		return err
+	}
+	if err := w.list("including", &n.Including); err != nil {
+		return err
+	}
+	if err := w.list("exclusions", &n.Exclusions); err != nil {
+		return err
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	if err := w.node("where_clause", &n.WhereClause); err != nil {
+		return err
+	}
+	if n.Pktable != nil {
+		if err := walkRangeVar(n.Pktable, w.message("pktable")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("fk_attrs", &n.FkAttrs); err != nil {
+		return err
+	}
+	if err := w.list("pk_attrs", &n.PkAttrs); err != nil {
+		return err
+	}
+	if err := w.list("fk_del_set_cols", &n.FkDelSetCols); err != nil {
+		return err
+	}
+	if err := w.list("old_conpfeqop", &n.OldConpfeqop); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkConstraintsSetStmt(n *ConstraintsSetStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("constraints", &n.Constraints); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkConvertRowtypeExpr(n *ConvertRowtypeExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCopyStmt(n *CopyStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.node("query", &n.Query); err != nil {
+		return err
+	}
+	if err := w.list("attlist", &n.Attlist); err != nil {
+		return err
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	if err := w.node("whereClause", &n.WhereClause); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateAmStmt(n *CreateAmStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("handler_name", &n.HandlerName); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateCastStmt(n *CreateCastStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Sourcetype != nil {
+		if err := walkTypeName(n.Sourcetype, w.message("sourcetype")); err != nil {
+			return err
+		}
+	}
+	if n.Targettype != nil {
+		if err := walkTypeName(n.Targettype, w.message("targettype")); err != nil {
+			return err
+		}
+	}
+	if n.Func != nil {
+		if err := walkObjectWithArgs(n.Func, w.message("func")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkCreateConversionStmt(n *CreateConversionStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("conversion_name", &n.ConversionName); err != nil {
+		return err
+	}
+	if err := w.list("func_name", &n.FuncName); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateDomainStmt(n *CreateDomainStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("domainname", &n.Domainname); err != nil {
+		return err
+	}
+	if n.TypeName != nil {
+		if err := walkTypeName(n.TypeName, w.message("typeName")); err != nil {
+			return err
+		}
+	}
+	if n.CollClause != nil {
+		if err := walkCollateClause(n.CollClause, w.message("collClause")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("constraints", &n.Constraints); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateEnumStmt(n *CreateEnumStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("typeName", &n.TypeName); err != nil {
+		return err
+	}
+	if err := w.list("vals", &n.Vals); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateEventTrigStmt(n *CreateEventTrigStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("whenclause", &n.Whenclause); err != nil {
+		return err
+	}
+	if err := w.list("funcname", &n.Funcname); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateExtensionStmt(n *CreateExtensionStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateFdwStmt(n *CreateFdwStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("func_options", &n.FuncOptions); err != nil {
+		return err
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateForeignServerStmt(n *CreateForeignServerStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateForeignTableStmt(n *CreateForeignTableStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.BaseStmt != nil {
+		if err := walkCreateStmt(n.BaseStmt, w.message("base")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateFunctionStmt(n *CreateFunctionStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("funcname", &n.Funcname); err != nil {
+		return err
+	}
+	if err := w.list("parameters", &n.Parameters); err != nil {
+		return err
+	}
+	if n.ReturnType != nil {
+		if err := walkTypeName(n.ReturnType, w.message("returnType")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	if err := w.node("sql_body", &n.SqlBody); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateOpClassItem(n *CreateOpClassItem, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Name != nil {
+		if err := walkObjectWithArgs(n.Name, w.message("name")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("order_family", &n.OrderFamily); err != nil {
+		return err
+	}
+	if err := w.list("class_args", &n.ClassArgs); err != nil {
+		return err
+	}
+	if n.Storedtype != nil {
+		if err := walkTypeName(n.Storedtype, w.message("storedtype")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkCreateOpClassStmt(n *CreateOpClassStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("opclassname", &n.Opclassname); err != nil {
+		return err
+	}
+	if err := w.list("opfamilyname", &n.Opfamilyname); err != nil {
+		return err
+	}
+	if n.Datatype != nil {
+		if err := walkTypeName(n.Datatype, w.message("datatype")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("items", &n.Items); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateOpFamilyStmt(n *CreateOpFamilyStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("opfamilyname", &n.Opfamilyname); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreatePLangStmt(n *CreatePLangStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("plhandler", &n.Plhandler); err != nil {
+		return err
+	}
+	if err := w.list("plinline", &n.Plinline); err != nil {
+		return err
+	}
+	if err := w.list("plvalidator", &n.Plvalidator); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreatePolicyStmt(n *CreatePolicyStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Table != nil {
+		if err := walkRangeVar(n.Table, w.message("table")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("roles", &n.Roles); err != nil {
+		return err
+	}
+	if err := w.node("qual", &n.Qual); err != nil {
+		return err
+	}
+	if err := w.node("with_check", &n.WithCheck); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreatePublicationStmt(n *CreatePublicationStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	if err := w.list("pubobjects", &n.Pubobjects); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateRangeStmt(n *CreateRangeStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("typeName", &n.TypeName); err != nil {
+		return err
+	}
+	if err := w.list("params", &n.Params); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateRoleStmt(n *CreateRoleStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateSchemaStmt(n *CreateSchemaStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("schemaElts", &n.SchemaElts); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateSeqStmt(n *CreateSeqStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Sequence != nil {
+		if err := walkRangeVar(n.Sequence, w.message("sequence")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateStatsStmt(n *CreateStatsStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("defnames", &n.Defnames); err != nil {
+		return err
+	}
+	if err := w.list("stat_types", &n.StatTypes); err != nil {
+		return err
+	}
+	if err := w.list("exprs", &n.Exprs); err != nil {
+		return err
+	}
+	if err := w.list("relations", &n.Relations); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateStmt(n *CreateStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("tableElts", &n.TableElts); err != nil {
+		return err
+	}
+	if err := w.list("inhRelations", &n.InhRelations); err != nil {
+		return err
+	}
+	if n.Partbound != nil {
+		if err := walkPartitionBoundSpec(n.Partbound, w.message("partbound")); err != nil {
+			return err
+		}
+	}
+	if n.Partspec != nil {
+		if err := walkPartitionSpec(n.Partspec, w.message("partspec")); err != nil {
+			return err
+		}
+	}
+	if n.OfTypename != nil {
+		if err := walkTypeName(n.OfTypename, w.message("ofTypename")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("constraints", &n.Constraints); err != nil {
+		return err
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateSubscriptionStmt(n *CreateSubscriptionStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("publication", &n.Publication); err != nil {
+		return err
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateTableAsStmt(n *CreateTableAsStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("query", &n.Query); err != nil {
+		return err
+	}
+	if n.Into != nil {
+		if err := walkIntoClause(n.Into, w.message("into")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkCreateTableSpaceStmt(n *CreateTableSpaceStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreateTransformStmt(n *CreateTransformStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.TypeName != nil {
+		if err := walkTypeName(n.TypeName, w.message("type_name")); err != nil {
+			return err
+		}
+	}
+	if n.Fromsql != nil {
+		if err := walkObjectWithArgs(n.Fromsql, w.message("fromsql")); err != nil {
+			return err
+		}
+	}
+	if n.Tosql != nil {
+		if err := walkObjectWithArgs(n.Tosql, w.message("tosql")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkCreateTrigStmt(n *CreateTrigStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("funcname", &n.Funcname); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	if err := w.list("columns", &n.Columns); err != nil {
+		return err
+	}
+	if err := w.node("whenClause", &n.WhenClause); err != nil {
+		return err
+	}
+	if err := w.list("transitionRels", &n.TransitionRels); err != nil {
+		return err
+	}
+	if n.Constrrel != nil {
+		if err := walkRangeVar(n.Constrrel, w.message("constrrel")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkCreateUserMappingStmt(n *CreateUserMappingStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCreatedbStmt(n *CreatedbStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkCurrentOfExpr(n *CurrentOfExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkDeclareCursorStmt(n *DeclareCursorStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("query", &n.Query); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkDefElem(n *DefElem, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkDefineStmt(n *DefineStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("defnames", &n.Defnames); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	if err := w.list("definition", &n.Definition); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkDeleteStmt(n *DeleteStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("usingClause", &n.UsingClause); err != nil {
+		return err
+	}
+	if err := w.node("whereClause", &n.WhereClause); err != nil {
+		return err
+	}
+	if err := w.list("returningList", &n.ReturningList); err != nil {
+		return err
+	}
+	if n.WithClause != nil {
+		if err := walkWithClause(n.WithClause, w.message("withClause")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkDistinctExpr(n *DistinctExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkDoStmt(n *DoStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkDropOwnedStmt(n *DropOwnedStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("roles", &n.Roles); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkDropRoleStmt(n *DropRoleStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("roles", &n.Roles); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkDropStmt(n *DropStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("objects", &n.Objects); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkDropdbStmt(n *DropdbStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkExecuteStmt(n *ExecuteStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("params", &n.Params); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkExplainStmt(n *ExplainStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("query", &n.Query); err != nil {
+		return err
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkFieldSelect(n *FieldSelect, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkFieldStore(n *FieldStore, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	if err := w.list("newvals", &n.Newvals); err != nil {
+		return err
+	}
+	if err := w.list("fieldnums", &n.Fieldnums); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkFromExpr(n *FromExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("fromlist", &n.Fromlist); err != nil {
+		return err
+	}
+	if err := w.node("quals", &n.Quals); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkFuncCall(n *FuncCall, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("funcname", &n.Funcname); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	if err := w.list("agg_order", &n.AggOrder); err != nil {
+		return err
+	}
+	if err := w.node("agg_filter", &n.AggFilter); err != nil {
+		return err
+	}
+	if n.Over != nil {
+		if err := walkWindowDef(n.Over, w.message("over")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkFuncExpr(n *FuncExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkFunctionParameter(n *FunctionParameter, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.ArgType != nil {
+		if err := walkTypeName(n.ArgType, w.message("argType")); err != nil {
+			return err
+		}
+	}
+	if err := w.node("defexpr", &n.Defexpr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkGrantRoleStmt(n *GrantRoleStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("granted_roles", &n.GrantedRoles); err != nil {
+		return err
+	}
+	if err := w.list("grantee_roles", &n.GranteeRoles); err != nil {
+		return err
+	}
+	if err := w.list("opt", &n.Opt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkGrantStmt(n *GrantStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("objects", &n.Objects); err != nil {
+		return err
+	}
+	if err := w.list("privileges", &n.Privileges); err != nil {
+		return err
+	}
+	if err := w.list("grantees", &n.Grantees); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkGroupingFunc(n *GroupingFunc, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	if err := w.list("refs", &n.Refs); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkGroupingSet(n *GroupingSet, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("content", &n.Content); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkImportForeignSchemaStmt(n *ImportForeignSchemaStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("table_list", &n.TableList); err != nil {
+		return err
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkIndexElem(n *IndexElem, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("expr", &n.Expr); err != nil {
+		return err
+	}
+	if err := w.list("collation", &n.Collation); err != nil {
+		return err
+	}
+	if err := w.list("opclass", &n.Opclass); err != nil {
+		return err
+	}
+	if err := w.list("opclassopts", &n.Opclassopts); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkIndexStmt(n *IndexStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("indexParams", &n.IndexParams); err != nil {
+		return err
+	}
+	if err := w.list("indexIncludingParams", &n.IndexIncludingParams); err != nil {
+		return err
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	if err := w.node("whereClause", &n.WhereClause); err != nil {
+		return err
+	}
+	if err := w.list("excludeOpNames", &n.ExcludeOpNames); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkInferClause(n *InferClause, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("indexElems", &n.IndexElems); err != nil {
+		return err
+	}
+	if err := w.node("whereClause", &n.WhereClause); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkInferenceElem(n *InferenceElem, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("expr", &n.Expr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkInsertStmt(n *InsertStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("cols", &n.Cols); err != nil {
+		return err
+	}
+	if err := w.node("selectStmt", &n.SelectStmt); err != nil {
+		return err
+	}
+	if n.OnConflictClause != nil {
+		if err := walkOnConflictClause(n.OnConflictClause, w.message("onConflictClause")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("returningList", &n.ReturningList); err != nil {
+		return err
+	}
+	if n.WithClause != nil {
+		if err := walkWithClause(n.WithClause, w.message("withClause")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkIntList(n *IntList, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("items", &n.Items); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkIntoClause(n *IntoClause, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Rel != nil {
+		if err := walkRangeVar(n.Rel, w.message("rel")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("colNames", &n.ColNames); err != nil {
+		return err
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	if err := w.node("viewQuery", &n.ViewQuery); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkJoinExpr(n *JoinExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("larg", &n.Larg); err != nil {
+		return err
+	}
+	if err := w.node("rarg", &n.Rarg); err != nil {
+		return err
+	}
+	if err := w.list("usingClause", &n.UsingClause); err != nil {
+		return err
+	}
+	if n.JoinUsingAlias != nil {
+		if err := walkAlias(n.JoinUsingAlias, w.message("join_using_alias")); err != nil {
+			return err
+		}
+	}
+	if err := w.node("quals", &n.Quals); err != nil {
+		return err
+	}
+	if n.Alias != nil {
+		if err := walkAlias(n.Alias, w.message("alias")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkJsonAggConstructor(n *JsonAggConstructor, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Output != nil {
+		if err := walkJsonOutput(n.Output, w.message("output")); err != nil {
+			return err
+		}
+	}
+	if err := w.node("agg_filter", &n.AggFilter); err != nil {
+		return err
+	}
+	if err := w.list("agg_order", &n.AggOrder); err != nil {
+		return err
+	}
+	if n.Over != nil {
+		if err := walkWindowDef(n.Over, w.message("over")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkJsonArrayAgg(n *JsonArrayAgg, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Constructor != nil {
+		if err := walkJsonAggConstructor(n.Constructor, w.message("constructor")); err != nil {
+			return err
+		}
+	}
+	if n.Arg != nil {
+		if err := walkJsonValueExpr(n.Arg, w.message("arg")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkJsonArrayConstructor(n *JsonArrayConstructor, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("exprs", &n.Exprs); err != nil {
+		return err
+	}
+	if n.Output != nil {
+		if err := walkJsonOutput(n.Output, w.message("output")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkJsonArrayQueryConstructor(n *JsonArrayQueryConstructor, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("query", &n.Query); err != nil {
+		return err
+	}
+	if n.Output != nil {
+		if err := walkJsonOutput(n.Output, w.message("output")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkJsonConstructorExpr(n *JsonConstructorExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	if err := w.node("func", &n.Func); err != nil {
+		return err
+	}
+	if err := w.node("coercion", &n.Coercion); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkJsonIsPredicate(n *JsonIsPredicate, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("expr", &n.Expr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkJsonKeyValue(n *JsonKeyValue, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("key", &n.Key); err != nil {
+		return err
+	}
+	if n.Value != nil {
+		if err := walkJsonValueExpr(n.Value, w.message("value")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkJsonObjectAgg(n *JsonObjectAgg, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Constructor != nil {
+		if err := walkJsonAggConstructor(n.Constructor, w.message("constructor")); err != nil {
+			return err
+		}
+	}
+	if n.Arg != nil {
+		if err := walkJsonKeyValue(n.Arg, w.message("arg")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkJsonObjectConstructor(n *JsonObjectConstructor, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("exprs", &n.Exprs); err != nil {
+		return err
+	}
+	if n.Output != nil {
+		if err := walkJsonOutput(n.Output, w.message("output")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkJsonOutput(n *JsonOutput, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.TypeName != nil {
+		if err := walkTypeName(n.TypeName, w.message("typeName")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkJsonValueExpr(n *JsonValueExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("raw_expr", &n.RawExpr); err != nil {
+		return err
+	}
+	if err := w.node("formatted_expr", &n.FormattedExpr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkList(n *List, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("items", &n.Items); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkLockStmt(n *LockStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("relations", &n.Relations); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkLockingClause(n *LockingClause, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("lockedRels", &n.LockedRels); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkMergeAction(n *MergeAction, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("qual", &n.Qual); err != nil {
+		return err
+	}
+	if err := w.list("targetList", &n.TargetList); err != nil {
+		return err
+	}
+	if err := w.list("updateColnos", &n.UpdateColnos); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkMergeStmt(n *MergeStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.node("sourceRelation", &n.SourceRelation); err != nil {
+		return err
+	}
+	if err := w.node("joinCondition", &n.JoinCondition); err != nil {
+		return err
+	}
+	if err := w.list("mergeWhenClauses", &n.MergeWhenClauses); err != nil {
+		return err
+	}
+	if n.WithClause != nil {
+		if err := walkWithClause(n.WithClause, w.message("withClause")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkMergeWhenClause(n *MergeWhenClause, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("condition", &n.Condition); err != nil {
+		return err
+	}
+	if err := w.list("targetList", &n.TargetList); err != nil {
+		return err
+	}
+	if err := w.list("values", &n.Values); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkMinMaxExpr(n *MinMaxExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkMultiAssignRef(n *MultiAssignRef, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("source", &n.Source); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkNamedArgExpr(n *NamedArgExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkNextValueExpr(n *NextValueExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkNullIfExpr(n *NullIfExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkNullTest(n *NullTest, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkObjectWithArgs(n *ObjectWithArgs, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("objname", &n.Objname); err != nil {
+		return err
+	}
+	if err := w.list("objargs", &n.Objargs); err != nil {
+		return err
+	}
+	if err := w.list("objfuncargs", &n.Objfuncargs); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkOidList(n *OidList, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("items", &n.Items); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkOnConflictClause(n *OnConflictClause, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Infer != nil {
+		if err := walkInferClause(n.Infer, w.message("infer")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("targetList", &n.TargetList); err != nil {
+		return err
+	}
+	if err := w.node("whereClause", &n.WhereClause); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkOnConflictExpr(n *OnConflictExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("arbiterElems", &n.ArbiterElems); err != nil {
+		return err
+	}
+	if err := w.node("arbiterWhere", &n.ArbiterWhere); err != nil {
+		return err
+	}
+	if err := w.list("onConflictSet", &n.OnConflictSet); err != nil {
+		return err
+	}
+	if err := w.node("onConflictWhere", &n.OnConflictWhere); err != nil {
+		return err
+	}
+	if err := w.list("exclRelTlist", &n.ExclRelTlist); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkOpExpr(n *OpExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkPLAssignStmt(n *PLAssignStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("indirection", &n.Indirection); err != nil {
+		return err
+	}
+	if n.Val != nil {
+		if err := walkSelectStmt(n.Val, w.message("val")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkParam(n *Param, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkParseResult(n *ParseResult, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	for _, item := range n.Stmts {
+		if err := walkRawStmt(item, w.message("stmts")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkPartitionBoundSpec(n *PartitionBoundSpec, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("listdatums", &n.Listdatums); err != nil {
+		return err
+	}
+	if err := w.list("lowerdatums", &n.Lowerdatums); err != nil {
+		return err
+	}
+	if err := w.list("upperdatums", &n.Upperdatums); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkPartitionCmd(n *PartitionCmd, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Name != nil {
+		if err := walkRangeVar(n.Name, w.message("name")); err != nil {
+			return err
+		}
+	}
+	if n.Bound != nil {
+		if err := walkPartitionBoundSpec(n.Bound, w.message("bound")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkPartitionElem(n *PartitionElem, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("expr", &n.Expr); err != nil {
+		return err
+	}
+	if err := w.list("collation", &n.Collation); err != nil {
+		return err
+	}
+	if err := w.list("opclass", &n.Opclass); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkPartitionRangeDatum(n *PartitionRangeDatum, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("value", &n.Value); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkPartitionSpec(n *PartitionSpec, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("partParams", &n.PartParams); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkPrepareStmt(n *PrepareStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("argtypes", &n.Argtypes); err != nil {
+		return err
+	}
+	if err := w.node("query", &n.Query); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkPublicationObjSpec(n *PublicationObjSpec, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Pubtable != nil {
+		if err := walkPublicationTable(n.Pubtable, w.message("pubtable")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkPublicationTable(n *PublicationTable, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.node("whereClause", &n.WhereClause); err != nil {
+		return err
+	}
+	if err := w.list("columns", &n.Columns); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkQuery(n *Query, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("utilityStmt", &n.UtilityStmt); err != nil {
+		return err
+	}
+	if err := w.list("cteList", &n.CteList); err != nil {
+		return err
+	}
+	if err := w.list("rtable", &n.Rtable); err != nil {
+		return err
+	}
+	if err := w.list("rteperminfos", &n.Rteperminfos); err != nil {
+		return err
+	}
+	if n.Jointree != nil {
+		if err := walkFromExpr(n.Jointree, w.message("jointree")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("mergeActionList", &n.MergeActionList); err != nil {
+		return err
+	}
+	if err := w.list("targetList", &n.TargetList); err != nil {
+		return err
+	}
+	if n.OnConflict != nil {
+		if err := walkOnConflictExpr(n.OnConflict, w.message("onConflict")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("returningList", &n.ReturningList); err != nil {
+		return err
+	}
+	if err := w.list("groupClause", &n.GroupClause); err != nil {
+		return err
+	}
+	if err := w.list("groupingSets", &n.GroupingSets); err != nil {
+		return err
+	}
+	if err := w.node("havingQual", &n.HavingQual); err != nil {
+		return err
+	}
+	if err := w.list("windowClause", &n.WindowClause); err != nil {
+		return err
+	}
+	if err := w.list("distinctClause", &n.DistinctClause); err != nil {
+		return err
+	}
+	if err := w.list("sortClause", &n.SortClause); err != nil {
+		return err
+	}
+	if err := w.node("limitOffset", &n.LimitOffset); err != nil {
+		return err
+	}
+	if err := w.node("limitCount", &n.LimitCount); err != nil {
+		return err
+	}
+	if err := w.list("rowMarks", &n.RowMarks); err != nil {
+		return err
+	}
+	if err := w.node("setOperations", &n.SetOperations); err != nil {
+		return err
+	}
+	if err := w.list("constraintDeps", &n.ConstraintDeps); err != nil {
+		return err
+	}
+	if err := w.list("withCheckOptions", &n.WithCheckOptions); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkRangeFunction(n *RangeFunction, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("functions", &n.Functions); err != nil {
+		return err
+	}
+	if n.Alias != nil {
+		if err := walkAlias(n.Alias, w.message("alias")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("coldeflist", &n.Coldeflist); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkRangeSubselect(n *RangeSubselect, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("subquery", &n.Subquery); err != nil {
+		return err
+	}
+	if n.Alias != nil {
+		if err := walkAlias(n.Alias, w.message("alias")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkRangeTableFunc(n *RangeTableFunc, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("docexpr", &n.Docexpr); err != nil {
+		return err
+	}
+	if err := w.node("rowexpr", &n.Rowexpr); err != nil {
+		return err
+	}
+	if err := w.list("namespaces", &n.Namespaces); err != nil {
+		return err
+	}
+	if err := w.list("columns", &n.Columns); err != nil {
+		return err
+	}
+	if n.Alias != nil {
+		if err := walkAlias(n.Alias, w.message("alias")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkRangeTableFuncCol(n *RangeTableFuncCol, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.TypeName != nil {
+		if err := walkTypeName(n.TypeName, w.message("typeName")); err != nil {
+			return err
+		}
+	}
+	if err := w.node("colexpr", &n.Colexpr); err != nil {
+		return err
+	}
+	if err := w.node("coldefexpr", &n.Coldefexpr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkRangeTableSample(n *RangeTableSample, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("relation", &n.Relation); err != nil {
+		return err
+	}
+	if err := w.list("method", &n.Method); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	if err := w.node("repeatable", &n.Repeatable); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkRangeTblEntry(n *RangeTblEntry, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Tablesample != nil {
+		if err := walkTableSampleClause(n.Tablesample, w.message("tablesample")); err != nil {
+			return err
+		}
+	}
+	if n.Subquery != nil {
+		if err := walkQuery(n.Subquery, w.message("subquery")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("joinaliasvars", &n.Joinaliasvars); err != nil {
+		return err
+	}
+	if err := w.list("joinleftcols", &n.Joinleftcols); err != nil {
+		return err
+	}
+	if err := w.list("joinrightcols", &n.Joinrightcols); err != nil {
+		return err
+	}
+	if n.JoinUsingAlias != nil {
+		if err := walkAlias(n.JoinUsingAlias, w.message("join_using_alias")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("functions", &n.Functions); err != nil {
+		return err
+	}
+	if n.Tablefunc != nil {
+		if err := walkTableFunc(n.Tablefunc, w.message("tablefunc")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("values_lists", &n.ValuesLists); err != nil {
+		return err
+	}
+	if err := w.list("coltypes", &n.Coltypes); err != nil {
+		return err
+	}
+	if err := w.list("coltypmods", &n.Coltypmods); err != nil {
+		return err
+	}
+	if err := w.list("colcollations", &n.Colcollations); err != nil {
+		return err
+	}
+	if n.Alias != nil {
+		if err := walkAlias(n.Alias, w.message("alias")); err != nil {
+			return err
+		}
+	}
+	if n.Eref != nil {
+		if err := walkAlias(n.Eref, w.message("eref")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("securityQuals", &n.SecurityQuals); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkRangeTblFunction(n *RangeTblFunction, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("funcexpr", &n.Funcexpr); err != nil {
+		return err
+	}
+	if err := w.list("funccolnames", &n.Funccolnames); err != nil {
+		return err
+	}
+	if err := w.list("funccoltypes", &n.Funccoltypes); err != nil {
+		return err
+	}
+	if err := w.list("funccoltypmods", &n.Funccoltypmods); err != nil {
+		return err
+	}
+	if err := w.list("funccolcollations", &n.Funccolcollations); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkRangeVar(n *RangeVar, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Alias != nil {
+		if err := walkAlias(n.Alias, w.message("alias")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkRawStmt(n *RawStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("stmt", &n.Stmt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkReassignOwnedStmt(n *ReassignOwnedStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("roles", &n.Roles); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkRefreshMatViewStmt(n *RefreshMatViewStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkReindexStmt(n *ReindexStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("params", &n.Params); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkRelabelType(n *RelabelType, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkRenameStmt(n *RenameStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.node("object", &n.Object); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkResTarget(n *ResTarget, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("indirection", &n.Indirection); err != nil {
+		return err
+	}
+	if err := w.node("val", &n.Val); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkReturnStmt(n *ReturnStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("returnval", &n.Returnval); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkRowCompareExpr(n *RowCompareExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("opnos", &n.Opnos); err != nil {
+		return err
+	}
+	if err := w.list("opfamilies", &n.Opfamilies); err != nil {
+		return err
+	}
+	if err := w.list("inputcollids", &n.Inputcollids); err != nil {
+		return err
+	}
+	if err := w.list("largs", &n.Largs); err != nil {
+		return err
+	}
+	if err := w.list("rargs", &n.Rargs); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkRowExpr(n *RowExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	if err := w.list("colnames", &n.Colnames); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkRuleStmt(n *RuleStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.node("whereClause", &n.WhereClause); err != nil {
+		return err
+	}
+	if err := w.list("actions", &n.Actions); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkSQLValueFunction(n *SQLValueFunction, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkScalarArrayOpExpr(n *ScalarArrayOpExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkSecLabelStmt(n *SecLabelStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("object", &n.Object); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkSelectStmt(n *SelectStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("distinctClause", &n.DistinctClause); err != nil {
+		return err
+	}
+	if n.IntoClause != nil {
+		if err := walkIntoClause(n.IntoClause, w.message("intoClause")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("targetList", &n.TargetList); err != nil {
+		return err
+	}
+	if err := w.list("fromClause", &n.FromClause); err != nil {
+		return err
+	}
+	if err := w.node("whereClause", &n.WhereClause); err != nil {
+		return err
+	}
+	if err := w.list("groupClause", &n.GroupClause); err != nil {
+		return err
+	}
+	if err := w.node("havingClause", &n.HavingClause); err != nil {
+		return err
+	}
+	if err := w.list("windowClause", &n.WindowClause); err != nil {
+		return err
+	}
+	if err := w.list("valuesLists", &n.ValuesLists); err != nil {
+		return err
+	}
+	if err := w.list("sortClause", &n.SortClause); err != nil {
+		return err
+	}
+	if err := w.node("limitOffset", &n.LimitOffset); err != nil {
+		return err
+	}
+	if err := w.node("limitCount", &n.LimitCount); err != nil {
+		return err
+	}
+	if err := w.list("lockingClause", &n.LockingClause); err != nil {
+		return err
+	}
+	if n.WithClause != nil {
+		if err := walkWithClause(n.WithClause, w.message("withClause")); err != nil {
+			return err
+		}
+	}
+	if n.Larg != nil {
+		if err := walkSelectStmt(n.Larg, w.message("larg")); err != nil {
+			return err
+		}
+	}
+	if n.Rarg != nil {
+		if err := walkSelectStmt(n.Rarg, w.message("rarg")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkSetOperationStmt(n *SetOperationStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("larg", &n.Larg); err != nil {
+		return err
+	}
+	if err := w.node("rarg", &n.Rarg); err != nil {
+		return err
+	}
+	if err := w.list("colTypes", &n.ColTypes); err != nil {
+		return err
+	}
+	if err := w.list("colTypmods", &n.ColTypmods); err != nil {
+		return err
+	}
+	if err := w.list("colCollations", &n.ColCollations); err != nil {
+		return err
+	}
+	if err := w.list("groupClauses", &n.GroupClauses); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkSetToDefault(n *SetToDefault, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkSortBy(n *SortBy, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("node", &n.Node); err != nil {
+		return err
+	}
+	if err := w.list("useOp", &n.UseOp); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkStatsElem(n *StatsElem, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("expr", &n.Expr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkSubLink(n *SubLink, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("testexpr", &n.Testexpr); err != nil {
+		return err
+	}
+	if err := w.list("operName", &n.OperName); err != nil {
+		return err
+	}
+	if err := w.node("subselect", &n.Subselect); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkSubPlan(n *SubPlan, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("testexpr", &n.Testexpr); err != nil {
+		return err
+	}
+	if err := w.list("paramIds", &n.ParamIds); err != nil {
+		return err
+	}
+	if err := w.list("setParam", &n.SetParam); err != nil {
+		return err
+	}
+	if err := w.list("parParam", &n.ParParam); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkSubscriptingRef(n *SubscriptingRef, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("refupperindexpr", &n.Refupperindexpr); err != nil {
+		return err
+	}
+	if err := w.list("reflowerindexpr", &n.Reflowerindexpr); err != nil {
+		return err
+	}
+	if err := w.node("refexpr", &n.Refexpr); err != nil {
+		return err
+	}
+	if err := w.node("refassgnexpr", &n.Refassgnexpr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkTableFunc(n *TableFunc, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("ns_uris", &n.NsUris); err != nil {
+		return err
+	}
+	if err := w.list("ns_names", &n.NsNames); err != nil {
+		return err
+	}
+	if err := w.node("docexpr", &n.Docexpr); err != nil {
+		return err
+	}
+	if err := w.node("rowexpr", &n.Rowexpr); err != nil {
+		return err
+	}
+	if err := w.list("colnames", &n.Colnames); err != nil {
+		return err
+	}
+	if err := w.list("coltypes", &n.Coltypes); err != nil {
+		return err
+	}
+	if err := w.list("coltypmods", &n.Coltypmods); err != nil {
+		return err
+	}
+	if err := w.list("colcollations", &n.Colcollations); err != nil {
+		return err
+	}
+	if err := w.list("colexprs", &n.Colexprs); err != nil {
+		return err
+	}
+	if err := w.list("coldefexprs", &n.Coldefexprs); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkTableLikeClause(n *TableLikeClause, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkTableSampleClause(n *TableSampleClause, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	if err := w.node("repeatable", &n.Repeatable); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkTargetEntry(n *TargetEntry, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.node("expr", &n.Expr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkTransactionStmt(n *TransactionStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkTruncateStmt(n *TruncateStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("relations", &n.Relations); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkTypeCast(n *TypeCast, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("arg", &n.Arg); err != nil {
+		return err
+	}
+	if n.TypeName != nil {
+		if err := walkTypeName(n.TypeName, w.message("typeName")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkTypeName(n *TypeName, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("names", &n.Names); err != nil {
+		return err
+	}
+	if err := w.list("typmods", &n.Typmods); err != nil {
+		return err
+	}
+	if err := w.list("arrayBounds", &n.ArrayBounds); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkUpdateStmt(n *UpdateStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("targetList", &n.TargetList); err != nil {
+		return err
+	}
+	if err := w.node("whereClause", &n.WhereClause); err != nil {
+		return err
+	}
+	if err := w.list("fromClause", &n.FromClause); err != nil {
+		return err
+	}
+	if err := w.list("returningList", &n.ReturningList); err != nil {
+		return err
+	}
+	if n.WithClause != nil {
+		if err := walkWithClause(n.WithClause, w.message("withClause")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkVacuumRelation(n *VacuumRelation, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.Relation != nil {
+		if err := walkRangeVar(n.Relation, w.message("relation")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("va_cols", &n.VaCols); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkVacuumStmt(n *VacuumStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	if err := w.list("rels", &n.Rels); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkVar(n *Var, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkVariableSetStmt(n *VariableSetStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkViewStmt(n *ViewStmt, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if n.View != nil {
+		if err := walkRangeVar(n.View, w.message("view")); err != nil {
+			return err
+		}
+	}
+	if err := w.list("aliases", &n.Aliases); err != nil {
+		return err
+	}
+	if err := w.node("query", &n.Query); err != nil {
+		return err
+	}
+	if err := w.list("options", &n.Options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkWindowClause(n *WindowClause, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("partitionClause", &n.PartitionClause); err != nil {
+		return err
+	}
+	if err := w.list("orderClause", &n.OrderClause); err != nil {
+		return err
+	}
+	if err := w.node("startOffset", &n.StartOffset); err != nil {
+		return err
+	}
+	if err := w.node("endOffset", &n.EndOffset); err != nil {
+		return err
+	}
+	if err := w.list("runCondition", &n.RunCondition); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkWindowDef(n *WindowDef, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("partitionClause", &n.PartitionClause); err != nil {
+		return err
+	}
+	if err := w.list("orderClause", &n.OrderClause); err != nil {
+		return err
+	}
+	if err := w.node("startOffset", &n.StartOffset); err != nil {
+		return err
+	}
+	if err := w.node("endOffset", &n.EndOffset); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkWindowFunc(n *WindowFunc, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	if err := w.node("aggfilter", &n.Aggfilter); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkWithCheckOption(n *WithCheckOption, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("qual", &n.Qual); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkWithClause(n *WithClause, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.list("ctes", &n.Ctes); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkXmlExpr(n *XmlExpr, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("xpr", &n.Xpr); err != nil {
+		return err
+	}
+	if err := w.list("named_args", &n.NamedArgs); err != nil {
+		return err
+	}
+	if err := w.list("arg_names", &n.ArgNames); err != nil {
+		return err
+	}
+	if err := w.list("args", &n.Args); err != nil {
+		return err
+	}
+	return nil
+}
+
+func walkXmlSerialize(n *XmlSerialize, w treeWalker) error {
+	if n == nil {
+		return nil
+	}
+	if err := w.node("expr", &n.Expr); err != nil {
+		return err
+	}
+	if n.TypeName != nil {
+		if err := walkTypeName(n.TypeName, w.message("typeName")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,415 @@
+//go:build cgo
+// +build cgo
+
+package pg_query_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pg_query "github.com/cossacklabs/pg_query_go/v5"
+)
+
+func TestWalkSubtreeCoverage(t *testing.T) {
+	node := &pg_query.Node{}
+	msg := node.ProtoReflect()
+	fields := msg.Descriptor().Oneofs().ByName("node").Fields()
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		msg.Set(field, msg.NewField(field))
+
+		if _, ok := node.Node.(pg_query.Walker); !ok {
+			t.Errorf("%s does not implement Walker\n", reflect.TypeOf(node.Node).Elem().Name())
+		}
+	}
+}
+
+var walkTests = []struct {
+	input    string
+	expected []string
+}{
+	{
+		"SELECT a FROM t WHERE b = 1 ORDER BY c",
+		[]string{"a", "b", "c"},
+	},
+	{
+		"WITH x AS (SELECT a FROM t) SELECT b FROM x UNION SELECT c INTO new_t FROM y",
+		[]string{"a", "b", "c"},
+	},
+	{
+		"WITH x AS (SELECT a FROM t) INSERT INTO t (b) SELECT c FROM x ON CONFLICT (d) DO UPDATE SET b = excluded.e WHERE t.f > 0 RETURNING g",
+		[]string{"c", "e", "f", "g", "a"},
+	},
+	{
+		"SELECT JSON_OBJECT('k' : a, 'l' : b RETURNING jsonb)",
+		[]string{"a", "b"},
+	},
+	{
+		"SELECT JSON_ARRAY(a, b), JSON_ARRAY(SELECT c FROM t)",
+		[]string{"a", "b", "c"},
+	},
+	{
+		"SELECT JSON_OBJECTAGG(k : v) FILTER (WHERE f) OVER (PARTITION BY p), JSON_ARRAYAGG(a ORDER BY o) FROM t",
+		[]string{"f", "p", "k", "v", "o", "a"},
+	},
+	{
+		"SELECT * FROM t WHERE a IS JSON OBJECT",
+		[]string{"a"},
+	},
+}
+
+// collectNodes finds all nodes in the given message independently of the generated walker
+func collectNodes(msg protoreflect.Message, nodes map[*pg_query.Node]bool) {
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if field.Message() == nil {
+			return true
+		}
+		if field.IsList() {
+			for i := 0; i < value.List().Len(); i++ {
+				collectNode(value.List().Get(i).Message(), nodes)
+			}
+		} else {
+			collectNode(value.Message(), nodes)
+		}
+		return true
+	})
+}
+
+func collectNode(msg protoreflect.Message, nodes map[*pg_query.Node]bool) {
+	if node, ok := msg.Interface().(*pg_query.Node); ok {
+		nodes[node] = true
+	}
+	collectNodes(msg, nodes)
+}
+
+func TestWalkVisitsAllNodes(t *testing.T) {
+	var inputs []string
+	for _, test := range parseTests {
+		inputs = append(inputs, test.input)
+	}
+	for _, test := range walkTests {
+		inputs = append(inputs, test.input)
+	}
+
+	for _, input := range inputs {
+		tree, err := pg_query.Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", input, err)
+			continue
+		}
+
+		expected := map[*pg_query.Node]bool{}
+		actual := map[*pg_query.Node]bool{}
+		for _, stmt := range tree.Stmts {
+			collectNode(stmt.Stmt.ProtoReflect(), expected)
+			err = pg_query.Walk(func(node *pg_query.Node) (bool, error) {
+				if actual[node] {
+					t.Errorf("Walk(%s)\nvisited node twice: %v\n\n", input, node)
+				}
+				actual[node] = true
+				return true, nil
+			}, stmt.Stmt)
+			if err != nil {
+				t.Errorf("Walk(%s)\nerror %s\n\n", input, err)
+			}
+		}
+
+		for node := range expected {
+			if !actual[node] {
+				t.Errorf("Walk(%s)\nnode not visited: %v\n\n", input, node)
+			}
+		}
+	}
+}
+
+func TestWalk(t *testing.T) {
+	for _, test := range walkTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		var actual []string
+		err = pg_query.Walk(func(node *pg_query.Node) (bool, error) {
+			if columnRef := node.GetColumnRef(); columnRef != nil {
+				if name := columnRef.GetFields()[len(columnRef.GetFields())-1].GetString_(); name != nil {
+					actual = append(actual, name.GetSval())
+				}
+			}
+			return true, nil
+		}, tree.Stmts[0].Stmt)
+
+		if err != nil {
+			t.Errorf("Walk(%s)\nerror %s\n\n", test.input, err)
+		} else if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Walk(%s)\nexpected %s\nactual %s\n\n", test.input, strings.Join(test.expected, ", "), strings.Join(actual, ", "))
+		}
+	}
+}
+
+var walkWithPathTests = []struct {
+	input    string
+	expected []string
+}{
+	{
+		"SELECT a FROM t WHERE b = 1 ORDER BY c",
+		[]string{
+			"a: SelectStmt.targetList[0]/ResTarget.val",
+			"b: SelectStmt.whereClause/A_Expr.lexpr",
+			"c: SelectStmt.sortClause[0]/SortBy.node",
+		},
+	},
+	{
+		"INSERT INTO t (b) VALUES (1) ON CONFLICT (d) DO UPDATE SET b = excluded.e WHERE t.f > 0",
+		[]string{
+			"e: InsertStmt.onConflictClause.targetList[0]/ResTarget.val",
+			"f: InsertStmt.onConflictClause.whereClause/A_Expr.lexpr",
+		},
+	},
+	{
+		"SELECT * FROM t WHERE a IN (SELECT b FROM u)",
+		[]string{
+			": SelectStmt.targetList[0]/ResTarget.val",
+			"a: SelectStmt.whereClause/SubLink.testexpr",
+			"b: SelectStmt.whereClause/SubLink.subselect/SelectStmt.targetList[0]/ResTarget.val",
+		},
+	},
+}
+
+func TestWalkWithPath(t *testing.T) {
+	for _, test := range walkWithPathTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		var actual []string
+		err = pg_query.WalkWithPath(func(node *pg_query.Node, path pg_query.Path) (bool, error) {
+			if columnRef := node.GetColumnRef(); columnRef != nil {
+				name := columnRef.GetFields()[len(columnRef.GetFields())-1].GetString_().GetSval()
+				actual = append(actual, name+": "+path.String())
+			}
+			return true, nil
+		}, tree.Stmts[0].Stmt)
+
+		if err != nil {
+			t.Errorf("WalkWithPath(%s)\nerror %s\n\n", test.input, err)
+		} else if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("WalkWithPath(%s)\nexpected %s\nactual %s\n\n", test.input, strings.Join(test.expected, ", "), strings.Join(actual, ", "))
+		}
+	}
+}
+
+// traceVisitor records the Enter and Leave calls, skipping the children of nodes of the given type
+type traceVisitor struct {
+	skip  string
+	trace []string
+}
+
+func (v *traceVisitor) Enter(node *pg_query.Node) (bool, error) {
+	name := reflect.TypeOf(node.Node).Elem().Name()
+	v.trace = append(v.trace, "enter "+name)
+	return name != v.skip, nil
+}
+
+func (v *traceVisitor) Leave(node *pg_query.Node) error {
+	v.trace = append(v.trace, "leave "+reflect.TypeOf(node.Node).Elem().Name())
+	return nil
+}
+
+var walkVisitorTests = []struct {
+	input    string
+	skip     string
+	expected []string
+}{
+	{
+		"SELECT a + 1",
+		"",
+		[]string{
+			"enter Node_SelectStmt",
+			"enter Node_ResTarget",
+			"enter Node_AExpr",
+			"enter Node_String_",
+			"leave Node_String_",
+			"enter Node_ColumnRef",
+			"enter Node_String_",
+			"leave Node_String_",
+			"leave Node_ColumnRef",
+			"enter Node_AConst",
+			"leave Node_AConst",
+			"leave Node_AExpr",
+			"leave Node_ResTarget",
+			"leave Node_SelectStmt",
+		},
+	},
+	{
+		"SELECT a + 1",
+		"Node_AExpr",
+		[]string{
+			"enter Node_SelectStmt",
+			"enter Node_ResTarget",
+			"enter Node_AExpr",
+			"leave Node_AExpr",
+			"leave Node_ResTarget",
+			"leave Node_SelectStmt",
+		},
+	},
+}
+
+func TestWalkVisitor(t *testing.T) {
+	for _, test := range walkVisitorTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		visitor := &traceVisitor{skip: test.skip}
+		err = pg_query.WalkVisitor(visitor, tree.Stmts[0].Stmt)
+
+		if err != nil {
+			t.Errorf("WalkVisitor(%s)\nerror %s\n\n", test.input, err)
+		} else if !reflect.DeepEqual(visitor.trace, test.expected) {
+			t.Errorf("WalkVisitor(%s)\nexpected %s\nactual %s\n\n", test.input, strings.Join(test.expected, ", "), strings.Join(visitor.trace, ", "))
+		}
+	}
+}
+
+func columnName(node *pg_query.Node) string {
+	fields := node.GetColumnRef().GetFields()
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1].GetString_().GetSval()
+}
+
+func constToParamRef() pg_query.RewriteFunc {
+	var params int32
+	return func(node *pg_query.Node) (*pg_query.Node, error) {
+		if aConst := node.GetAConst(); aConst != nil {
+			params++
+			return pg_query.MakeParamRefNode(params, aConst.GetLocation()), nil
+		}
+		return node, nil
+	}
+}
+
+var rewriteTests = []struct {
+	input    string
+	rewrite  pg_query.RewriteFunc
+	expected string
+}{
+	{
+		"SELECT email, name FROM users WHERE email = 'x'",
+		func(node *pg_query.Node) (*pg_query.Node, error) {
+			if columnName(node) == "email" {
+				return pg_query.MakeFuncCallNode([]*pg_query.Node{pg_query.MakeStrNode("decrypt")}, []*pg_query.Node{node}, -1), nil
+			}
+			return node, nil
+		},
+		"SELECT decrypt(email), name FROM users WHERE decrypt(email) = 'x'",
+	},
+	{
+		"SELECT * FROM t WHERE a = 1 AND b IN ('x', 'y') LIMIT 10",
+		constToParamRef(),
+		"SELECT * FROM t WHERE a = $1 AND b IN ($2, $3) LIMIT $4",
+	},
+	{
+		"INSERT INTO t (a, b) VALUES (1, 2) ON CONFLICT (a) DO UPDATE SET b = 3 WHERE t.b > 4",
+		constToParamRef(),
+		"INSERT INTO t (a, b) VALUES ($1, $2) ON CONFLICT (a) DO UPDATE SET b = $3 WHERE t.b > $4",
+	},
+	{
+		"SELECT a, b, c FROM t ORDER BY b, c",
+		func(node *pg_query.Node) (*pg_query.Node, error) {
+			if columnName(node.GetResTarget().GetVal()) == "b" || columnName(node.GetSortBy().GetNode()) == "b" {
+				return nil, nil
+			}
+			return node, nil
+		},
+		"SELECT a, c FROM t ORDER BY c",
+	},
+	{
+		"SELECT 1; DELETE FROM t; SELECT 2",
+		func(node *pg_query.Node) (*pg_query.Node, error) {
+			if node.GetDeleteStmt() != nil {
+				return nil, nil
+			}
+			return node, nil
+		},
+		"SELECT 1; SELECT 2",
+	},
+}
+
+func TestRewrite(t *testing.T) {
+	for _, test := range rewriteTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		err = pg_query.Rewrite(tree, test.rewrite)
+		if err != nil {
+			t.Errorf("Rewrite(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		actual, err := pg_query.Deparse(tree)
+		if err != nil {
+			t.Errorf("Deparse(%s)\nerror %s\n\n", test.input, err)
+		} else if actual != test.expected {
+			t.Errorf("Rewrite(%s)\nexpected %s\nactual %s\n\n", test.input, test.expected, actual)
+		}
+	}
+}
+
+var rewriteErrorTests = []struct {
+	input       string
+	rewrite     pg_query.RewriteFunc
+	expectedErr string
+}{
+	{
+		"UPDATE t SET a = (SELECT b FROM u) WHERE id = 1",
+		func(node *pg_query.Node) (*pg_query.Node, error) {
+			if node.GetSelectStmt() != nil {
+				return nil, nil
+			}
+			return node, nil
+		},
+		"rewrite: cannot remove the node of required field SubLink.subselect",
+	},
+	{
+		"SELECT a::text FROM t",
+		func(node *pg_query.Node) (*pg_query.Node, error) {
+			if node.GetColumnRef() != nil {
+				return nil, nil
+			}
+			return node, nil
+		},
+		"rewrite: cannot remove the node of required field TypeCast.arg",
+	},
+}
+
+func TestRewriteError(t *testing.T) {
+	for _, test := range rewriteErrorTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		err = pg_query.Rewrite(tree, test.rewrite)
+		if err == nil {
+			t.Errorf("Rewrite(%s)\nexpected error but none returned\n\n", test.input)
+		} else if err.Error() != test.expectedErr {
+			t.Errorf("Rewrite(%s)\nexpected error %s\nactual error %s\n\n", test.input, test.expectedErr, err)
+		}
+	}
+}